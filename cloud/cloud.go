@@ -33,6 +33,8 @@ type Cloud interface {
 	AttachDisk(vmCID, diskCID string) (interface{}, error)
 	DetachDisk(vmCID, diskCID string) error
 	DeleteDisk(diskCID string) error
+	HasDisk(diskCID string) (bool, error)
+	GetDisks(vmCID string) (diskCIDs []string, err error)
 	Info() (cpiInfo CpiInfo, err error)
 	fmt.Stringer
 }
@@ -396,6 +398,61 @@ func (c cloud) DeleteDisk(diskCID string) error {
 	return nil
 }
 
+func (c cloud) HasDisk(diskCID string) (bool, error) {
+	cpiInfo, err := c.Info()
+	if err != nil {
+		return false, err
+	}
+
+	method := "has_disk"
+	cmdOutput, err := c.cpiCmdRunner.Run(c.context, method, cpiInfo.ApiVersion, diskCID)
+	if err != nil {
+		return false, err
+	}
+
+	if cmdOutput.Error != nil {
+		return false, NewCPIError(method, *cmdOutput.Error)
+	}
+
+	found, ok := cmdOutput.Result.(bool)
+	if !ok {
+		return false, bosherr.Errorf("Unexpected external CPI command result: '%#v'", cmdOutput.Result)
+	}
+	return found, nil
+}
+
+func (c cloud) GetDisks(vmCID string) ([]string, error) {
+	cpiInfo, err := c.Info()
+	if err != nil {
+		return nil, err
+	}
+
+	method := "get_disks"
+	cmdOutput, err := c.cpiCmdRunner.Run(c.context, method, cpiInfo.ApiVersion, vmCID)
+	if err != nil {
+		return nil, err
+	}
+
+	if cmdOutput.Error != nil {
+		return nil, NewCPIError(method, *cmdOutput.Error)
+	}
+
+	result, ok := cmdOutput.Result.([]interface{})
+	if !ok {
+		return nil, bosherr.Errorf("Unexpected external CPI command result: '%#v'", cmdOutput.Result)
+	}
+
+	diskCIDs := []string{}
+	for _, item := range result {
+		diskCID, ok := item.(string)
+		if !ok {
+			return nil, bosherr.Errorf("Unexpected external CPI command result: '%#v'", cmdOutput.Result)
+		}
+		diskCIDs = append(diskCIDs, diskCID)
+	}
+	return diskCIDs, nil
+}
+
 func (c cloud) Info() (cpiInfo CpiInfo, err error) {
 	c.logger.Debug(c.logTag, "Info")
 
@@ -891,4 +891,76 @@ var _ = Describe("Cloud", func() {
 			return cloud.DeleteDisk("fake-disk-cid")
 		})
 	})
+
+	Describe("HasDisk", func() {
+		It("returns true when disk exists", func() {
+			fakeCPICmdRunner.RunCmdOutputs = []CmdOutput{
+				{Result: infoResult},
+				{Result: true},
+			}
+
+			found, err := cloud.HasDisk("fake-disk-cid")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(found).To(BeTrue())
+
+			Expect(fakeCPICmdRunner.CurrentRunInput[1]).To(Equal(fakebicloud.RunInput{
+				Context:    expectedContext,
+				Method:     "has_disk",
+				Arguments:  []interface{}{"fake-disk-cid"},
+				ApiVersion: 1,
+			}))
+		})
+
+		It("returns error when result is not a boolean", func() {
+			fakeCPICmdRunner.RunCmdOutputs = []CmdOutput{
+				{Result: infoResult},
+				{Result: "fake-result"},
+			}
+
+			_, err := cloud.HasDisk("fake-disk-cid")
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("Unexpected external CPI command result"))
+		})
+
+		itHandlesCPIErrors("has_disk", func() error {
+			_, err := cloud.HasDisk("fake-disk-cid")
+			return err
+		})
+	})
+
+	Describe("GetDisks", func() {
+		It("returns CIDs of disks attached to VM", func() {
+			fakeCPICmdRunner.RunCmdOutputs = []CmdOutput{
+				{Result: infoResult},
+				{Result: []interface{}{"fake-disk-cid-1", "fake-disk-cid-2"}},
+			}
+
+			diskCIDs, err := cloud.GetDisks("fake-vm-cid")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(diskCIDs).To(Equal([]string{"fake-disk-cid-1", "fake-disk-cid-2"}))
+
+			Expect(fakeCPICmdRunner.CurrentRunInput[1]).To(Equal(fakebicloud.RunInput{
+				Context:    expectedContext,
+				Method:     "get_disks",
+				Arguments:  []interface{}{"fake-vm-cid"},
+				ApiVersion: 1,
+			}))
+		})
+
+		It("returns error when result is not a list of CIDs", func() {
+			fakeCPICmdRunner.RunCmdOutputs = []CmdOutput{
+				{Result: infoResult},
+				{Result: []interface{}{1}},
+			}
+
+			_, err := cloud.GetDisks("fake-vm-cid")
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("Unexpected external CPI command result"))
+		})
+
+		itHandlesCPIErrors("get_disks", func() error {
+			_, err := cloud.GetDisks("fake-vm-cid")
+			return err
+		})
+	})
 })
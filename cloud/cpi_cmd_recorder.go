@@ -0,0 +1,241 @@
+package cloud
+
+import (
+	"encoding/json"
+	"fmt"
+	"path/filepath"
+	"sort"
+	"sync"
+
+	bosherr "github.com/cloudfoundry/bosh-utils/errors"
+	boshlog "github.com/cloudfoundry/bosh-utils/logger"
+	boshsys "github.com/cloudfoundry/bosh-utils/system"
+)
+
+// CPITranscriptEntry is a single recorded CPI request/response exchange.
+// Entries are stored as '<dir>/NNNN-<method>.json' so that they sort in call order.
+type CPITranscriptEntry struct {
+	Method   string      `json:"method"`
+	Request  interface{} `json:"request"`
+	Response *CmdOutput  `json:"response,omitempty"`
+	Error    string      `json:"error,omitempty"`
+}
+
+type recordingCPICmdRunner struct {
+	runner   CPICmdRunner
+	fs       boshsys.FileSystem
+	dir      string
+	redactor CPIRedactor
+
+	seq   int
+	mutex sync.Mutex
+
+	logger boshlog.Logger
+	logTag string
+}
+
+// NewRecordingCPICmdRunner returns runner that delegates to given runner
+// and writes every exchange (with secrets redacted) into dir.
+// Since entries are numbered from the start, dir must not contain a previous transcript;
+// otherwise stale entries would be served during replay.
+func NewRecordingCPICmdRunner(runner CPICmdRunner, fs boshsys.FileSystem, dir string, redactor CPIRedactor, logger boshlog.Logger) (CPICmdRunner, error) {
+	paths, err := fs.Glob(filepath.Join(dir, "*.json"))
+	if err != nil {
+		return nil, bosherr.WrapErrorf(err, "Listing CPI transcript '%s'", dir)
+	}
+
+	if len(paths) > 0 {
+		return nil, bosherr.Errorf(
+			"Expected CPI transcript directory '%s' to not contain recorded calls (found %d)", dir, len(paths))
+	}
+
+	return &recordingCPICmdRunner{
+		runner:   runner,
+		fs:       fs,
+		dir:      dir,
+		redactor: redactor,
+
+		logger: logger,
+		logTag: "recordingCPICmdRunner",
+	}, nil
+}
+
+func (r *recordingCPICmdRunner) Run(context CmdContext, method string, apiVersion int, args ...interface{}) (CmdOutput, error) {
+	cmdOutput, runErr := r.runner.Run(context, method, apiVersion, args...)
+
+	err := r.record(context, method, apiVersion, args, cmdOutput, runErr)
+	if err != nil {
+		// Failing to record should not affect CPI interaction itself
+		r.logger.Error(r.logTag, "Recording CPI call '%s': %s", method, err)
+	}
+
+	return cmdOutput, runErr
+}
+
+func (r *recordingCPICmdRunner) record(context CmdContext, method string, apiVersion int, args []interface{}, cmdOutput CmdOutput, runErr error) error {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	if len(args) == 0 {
+		args = make([]interface{}, 0)
+	}
+
+	request, err := r.genericValue(CmdInput{
+		Method:     method,
+		Arguments:  args,
+		Context:    context,
+		ApiVersion: apiVersion,
+	})
+	if err != nil {
+		return err
+	}
+
+	entry := CPITranscriptEntry{
+		Method:  method,
+		Request: r.redactor.Redact(request),
+	}
+
+	if runErr != nil {
+		entry.Error = r.redactor.Redact(runErr.Error()).(string)
+	} else {
+		result, err := r.genericValue(cmdOutput.Result)
+		if err != nil {
+			return err
+		}
+
+		entry.Response = &CmdOutput{
+			Result: r.redactor.Redact(result),
+			Error:  cmdOutput.Error,
+			Log:    r.redactor.Redact(cmdOutput.Log).(string),
+		}
+	}
+
+	entryBytes, err := json.MarshalIndent(entry, "", "  ")
+	if err != nil {
+		return bosherr.WrapError(err, "Marshalling CPI transcript entry")
+	}
+
+	err = r.fs.MkdirAll(r.dir, 0700)
+	if err != nil {
+		return bosherr.WrapErrorf(err, "Creating CPI transcript directory '%s'", r.dir)
+	}
+
+	r.seq++
+
+	path := filepath.Join(r.dir, fmt.Sprintf("%04d-%s.json", r.seq, method))
+
+	err = r.fs.WriteFile(path, entryBytes)
+	if err != nil {
+		return bosherr.WrapErrorf(err, "Writing CPI transcript entry '%s'", path)
+	}
+
+	return nil
+}
+
+func (r *recordingCPICmdRunner) genericValue(val interface{}) (interface{}, error) {
+	bytes, err := json.Marshal(val)
+	if err != nil {
+		return nil, bosherr.WrapError(err, "Marshalling CPI transcript value")
+	}
+
+	var result interface{}
+
+	err = json.Unmarshal(bytes, &result)
+	if err != nil {
+		return nil, bosherr.WrapError(err, "Unmarshalling CPI transcript value")
+	}
+
+	return result, nil
+}
+
+type replayCPICmdRunner struct {
+	fs  boshsys.FileSystem
+	dir string
+
+	entries []CPITranscriptEntry
+	loaded  bool
+	next    int
+	mutex   sync.Mutex
+
+	logger boshlog.Logger
+	logTag string
+}
+
+// NewReplayCPICmdRunner returns runner that serves responses previously
+// recorded by recording runner in dir instead of executing the CPI.
+// Calls are expected to happen in the same order as they were recorded.
+func NewReplayCPICmdRunner(fs boshsys.FileSystem, dir string, logger boshlog.Logger) CPICmdRunner {
+	return &replayCPICmdRunner{
+		fs:  fs,
+		dir: dir,
+
+		logger: logger,
+		logTag: "replayCPICmdRunner",
+	}
+}
+
+func (r *replayCPICmdRunner) Run(context CmdContext, method string, apiVersion int, args ...interface{}) (CmdOutput, error) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	if !r.loaded {
+		err := r.load()
+		if err != nil {
+			return CmdOutput{}, err
+		}
+	}
+
+	if r.next >= len(r.entries) {
+		return CmdOutput{}, bosherr.Errorf(
+			"Replaying CPI call '%s': transcript '%s' only contains %d calls", method, r.dir, len(r.entries))
+	}
+
+	entry := r.entries[r.next]
+	r.next++
+
+	if entry.Method != method {
+		return CmdOutput{}, bosherr.Errorf(
+			"Replaying CPI call #%d: expected method '%s' but was '%s'", r.next, entry.Method, method)
+	}
+
+	r.logger.Debug(r.logTag, "Replaying CPI call #%d '%s'", r.next, method)
+
+	if len(entry.Error) > 0 {
+		return CmdOutput{}, bosherr.Error(entry.Error)
+	}
+
+	if entry.Response == nil {
+		return CmdOutput{}, bosherr.Errorf("Replaying CPI call #%d: expected response to be recorded", r.next)
+	}
+
+	return *entry.Response, nil
+}
+
+func (r *replayCPICmdRunner) load() error {
+	paths, err := r.fs.Glob(filepath.Join(r.dir, "*.json"))
+	if err != nil {
+		return bosherr.WrapErrorf(err, "Listing CPI transcript '%s'", r.dir)
+	}
+
+	sort.Strings(paths)
+
+	for _, path := range paths {
+		entryBytes, err := r.fs.ReadFile(path)
+		if err != nil {
+			return bosherr.WrapErrorf(err, "Reading CPI transcript entry '%s'", path)
+		}
+
+		var entry CPITranscriptEntry
+
+		err = json.Unmarshal(entryBytes, &entry)
+		if err != nil {
+			return bosherr.WrapErrorf(err, "Unmarshalling CPI transcript entry '%s'", path)
+		}
+
+		r.entries = append(r.entries, entry)
+	}
+
+	r.loaded = true
+
+	return nil
+}
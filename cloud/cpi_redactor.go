@@ -0,0 +1,111 @@
+package cloud
+
+import (
+	"net/url"
+	"regexp"
+	"strings"
+
+	biproperty "github.com/cloudfoundry/bosh-utils/property"
+
+	biinstallmanifest "github.com/cloudfoundry/bosh-cli/installation/manifest"
+)
+
+const redactedValue = "<redacted>"
+
+// minSecretSubstringLen prevents short secrets from being
+// replaced inside of unrelated longer values.
+const minSecretSubstringLen = 6
+
+var secretKeyPattern = regexp.MustCompile(`(?i)(password|secret|private_key|token|credential|access_key)`)
+
+// CPIRedactor removes secrets from CPI requests and responses
+// before they are written to disk.
+type CPIRedactor struct {
+	secrets []string
+}
+
+// NewCPIRedactor collects secrets from installation manifest: values of
+// properties that look sensitive (e.g. 'password', 'secret_access_key'),
+// mbus password and registry password.
+func NewCPIRedactor(manifest biinstallmanifest.Manifest) CPIRedactor {
+	var secrets []string
+
+	secrets = append(secrets, secretsFromValue("", manifest.Properties)...)
+
+	if mbusURL, err := url.Parse(manifest.Mbus); err == nil && mbusURL.User != nil {
+		if password, set := mbusURL.User.Password(); set {
+			secrets = append(secrets, password)
+		}
+	}
+
+	if len(manifest.Registry.Password) > 0 {
+		secrets = append(secrets, manifest.Registry.Password)
+	}
+
+	return CPIRedactor{secrets: secrets}
+}
+
+// Redact returns copy of JSON-like value with secret values replaced.
+func (r CPIRedactor) Redact(val interface{}) interface{} {
+	return r.redact("", val)
+}
+
+func (r CPIRedactor) redact(key string, val interface{}) interface{} {
+	switch typedVal := val.(type) {
+	case map[string]interface{}:
+		result := map[string]interface{}{}
+		for k, v := range typedVal {
+			result[k] = r.redact(k, v)
+		}
+		return result
+
+	case []interface{}:
+		result := []interface{}{}
+		for _, v := range typedVal {
+			result = append(result, r.redact(key, v))
+		}
+		return result
+
+	case string:
+		if len(typedVal) > 0 && secretKeyPattern.MatchString(key) {
+			return redactedValue
+		}
+
+		for _, secret := range r.secrets {
+			if typedVal == secret {
+				return redactedValue
+			}
+			if len(secret) >= minSecretSubstringLen {
+				typedVal = strings.Replace(typedVal, secret, redactedValue, -1)
+			}
+		}
+
+		return typedVal
+
+	default:
+		return val
+	}
+}
+
+func secretsFromValue(key string, val interface{}) []string {
+	var secrets []string
+
+	switch typedVal := val.(type) {
+	case biproperty.Map:
+		for k, v := range typedVal {
+			secrets = append(secrets, secretsFromValue(k, v)...)
+		}
+
+	case biproperty.List:
+		for _, v := range typedVal {
+			secrets = append(secrets, secretsFromValue(key, v)...)
+		}
+
+	case string:
+		if len(typedVal) > 0 && secretKeyPattern.MatchString(key) {
+			secrets = append(secrets, typedVal)
+		}
+	}
+
+	return secrets
+}
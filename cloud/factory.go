@@ -1,10 +1,14 @@
 package cloud
 
 import (
+	"os"
+
+	"code.cloudfoundry.org/clock"
 	biinstall "github.com/cloudfoundry/bosh-cli/installation"
 	bosherr "github.com/cloudfoundry/bosh-utils/errors"
 	boshlog "github.com/cloudfoundry/bosh-utils/logger"
 	boshsys "github.com/cloudfoundry/bosh-utils/system"
+	boshuuid "github.com/cloudfoundry/bosh-utils/uuid"
 )
 
 type Factory interface {
@@ -38,11 +42,41 @@ func (f *factory) NewCloud(installation biinstall.Installation, directorID strin
 		PackagesDir: target.PackagesPath(),
 	}
 
+	// BOSH_CPI_IN_PROCESS replaces installed CPI with in-process implementation keeping state in given directory
+	if inProcessDir := os.Getenv("BOSH_CPI_IN_PROCESS"); len(inProcessDir) > 0 {
+		// Recording wraps CPI command runner which in-process CPI does not use
+		if len(os.Getenv("BOSH_CPI_RECORD")) > 0 {
+			return nil, bosherr.Error("Expected only one of BOSH_CPI_IN_PROCESS and BOSH_CPI_RECORD to be set")
+		}
+
+		f.logger.Info("cloudFactory", "Using in-process CPI with state in '%s'", inProcessDir)
+		return NewInProcessCloud(f.fs, inProcessDir, boshuuid.NewGenerator(), clock.NewClock(), f.logger), nil
+	}
+
+	// BOSH_CPI_REPLAY serves CPI responses from transcript recorded via BOSH_CPI_RECORD
+	if replayDir := os.Getenv("BOSH_CPI_REPLAY"); len(replayDir) > 0 {
+		f.logger.Info("cloudFactory", "Replaying CPI calls from '%s'", replayDir)
+		cpiCmdRunner := NewReplayCPICmdRunner(f.fs, replayDir, f.logger)
+		return NewCloud(cpiCmdRunner, directorID, stemcellApiVersion, f.logger), nil
+	}
+
 	cmdPath := cpi.ExecutablePath()
 	if !f.fs.FileExists(cmdPath) {
 		return nil, bosherr.Errorf("Installed CPI job '%s' does not contain the required executable '%s'", cpiJob.Name, cmdPath)
 	}
 
 	cpiCmdRunner := NewCPICmdRunner(f.cmdRunner, cpi, f.logger)
+
+	if recordDir := os.Getenv("BOSH_CPI_RECORD"); len(recordDir) > 0 {
+		f.logger.Info("cloudFactory", "Recording CPI calls to '%s'", recordDir)
+		redactor := NewCPIRedactor(installation.Manifest())
+		cpiCmdRunner, err := NewRecordingCPICmdRunner(cpiCmdRunner, f.fs, recordDir, redactor, f.logger)
+		if err != nil {
+			return nil, err
+		}
+
+		return NewCloud(cpiCmdRunner, directorID, stemcellApiVersion, f.logger), nil
+	}
+
 	return NewCloud(cpiCmdRunner, directorID, stemcellApiVersion, f.logger), nil
 }
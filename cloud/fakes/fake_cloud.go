@@ -35,6 +35,14 @@ type FakeCloud struct {
 	DeleteDiskInputs []DeleteDiskInput
 	DeleteDiskErr    error
 
+	HasDiskInputs []HasDiskInput
+	HasDiskFound  map[string]bool
+	HasDiskErr    error
+
+	GetDisksInput GetDisksInput
+	GetDisksCIDs  []string
+	GetDisksErr   error
+
 	DeleteStemcellInputs []DeleteStemcellInput
 	DeleteStemcellErr    error
 
@@ -59,6 +67,14 @@ type HasVMInput struct {
 	VMCID string
 }
 
+type HasDiskInput struct {
+	DiskCID string
+}
+
+type GetDisksInput struct {
+	VMCID string
+}
+
 type CreateVMInput struct {
 	AgentID            string
 	StemcellCID        string
@@ -125,6 +141,20 @@ func (c *FakeCloud) HasVM(vmCID string) (bool, error) {
 	return c.HasVMFound, c.HasVMErr
 }
 
+func (c *FakeCloud) HasDisk(diskCID string) (bool, error) {
+	c.HasDiskInputs = append(c.HasDiskInputs, HasDiskInput{
+		DiskCID: diskCID,
+	})
+	return c.HasDiskFound[diskCID], c.HasDiskErr
+}
+
+func (c *FakeCloud) GetDisks(vmCID string) ([]string, error) {
+	c.GetDisksInput = GetDisksInput{
+		VMCID: vmCID,
+	}
+	return c.GetDisksCIDs, c.GetDisksErr
+}
+
 func (c *FakeCloud) CreateVM(
 	agentID string,
 	stemcellCID string,
@@ -0,0 +1,507 @@
+package cloud
+
+import (
+	"encoding/json"
+	"fmt"
+	"path/filepath"
+	"sort"
+	"sync"
+	"time"
+
+	"code.cloudfoundry.org/clock"
+	bosherr "github.com/cloudfoundry/bosh-utils/errors"
+	boshlog "github.com/cloudfoundry/bosh-utils/logger"
+	biproperty "github.com/cloudfoundry/bosh-utils/property"
+	boshsys "github.com/cloudfoundry/bosh-utils/system"
+	boshuuid "github.com/cloudfoundry/bosh-utils/uuid"
+)
+
+// InProcessCloudConfig is read from '<dir>/config.json' before every call
+// so that failures and delays can be changed while create-env is running.
+type InProcessCloudConfig struct {
+	Failures map[string]InProcessCloudFailure `json:"failures"`
+	Delays   map[string]string                `json:"delays"`
+}
+
+// InProcessCloudFailure makes method respond with given CPI error.
+// Times limits how many calls fail; zero means every call fails.
+type InProcessCloudFailure struct {
+	Type      string `json:"type"`
+	Message   string `json:"message"`
+	OkToRetry bool   `json:"ok_to_retry"`
+	Times     int    `json:"times"`
+}
+
+// InProcessCloudState is kept in '<dir>/state.json'.
+type InProcessCloudState struct {
+	Stemcells map[string]InProcessStemcell `json:"stemcells"`
+	VMs       map[string]InProcessVM       `json:"vms"`
+	Disks     map[string]InProcessDisk     `json:"disks"`
+
+	// InjectedFailures counts failures injected per method
+	InjectedFailures map[string]int `json:"injected_failures"`
+}
+
+type InProcessStemcell struct {
+	ImagePath       string         `json:"image_path"`
+	CloudProperties biproperty.Map `json:"cloud_properties"`
+}
+
+type InProcessVM struct {
+	AgentID         string                    `json:"agent_id"`
+	StemcellCID     string                    `json:"stemcell_cid"`
+	CloudProperties biproperty.Map            `json:"cloud_properties"`
+	Networks        map[string]biproperty.Map `json:"networks"`
+	Env             biproperty.Map            `json:"env"`
+	Metadata        VMMetadata                `json:"metadata"`
+}
+
+type InProcessDisk struct {
+	Size            int            `json:"size"`
+	CloudProperties biproperty.Map `json:"cloud_properties"`
+	VMCID           string         `json:"vm_cid"`
+	Metadata        DiskMetadata   `json:"metadata"`
+}
+
+type InProcessCloud interface {
+	Cloud
+
+	// State returns recorded stemcells, VMs and disks
+	State() (InProcessCloudState, error)
+}
+
+type inProcessCloud struct {
+	fs          boshsys.FileSystem
+	dir         string
+	uuidGen     boshuuid.Generator
+	timeService clock.Clock
+
+	mutex sync.Mutex
+
+	logger boshlog.Logger
+	logTag string
+}
+
+// NewInProcessCloud returns Cloud that does not talk to any IaaS;
+// stemcells, VMs and disks are only recorded in dir. It is meant
+// for exercising create-env and delete-env flows locally.
+func NewInProcessCloud(fs boshsys.FileSystem, dir string, uuidGen boshuuid.Generator, timeService clock.Clock, logger boshlog.Logger) InProcessCloud {
+	return &inProcessCloud{
+		fs:          fs,
+		dir:         dir,
+		uuidGen:     uuidGen,
+		timeService: timeService,
+
+		logger: logger,
+		logTag: "inProcessCloud",
+	}
+}
+
+func (c *inProcessCloud) String() string {
+	return fmt.Sprintf("InProcessCloud{dir: %s}", c.dir)
+}
+
+func (c *inProcessCloud) Info() (CpiInfo, error) {
+	err := c.perform("info", func(state *InProcessCloudState) error { return nil })
+	if err != nil {
+		return CpiInfo{}, err
+	}
+
+	return CpiInfo{StemcellFormats: []string{"in-process"}, ApiVersion: MaxCpiApiVersionSupported}, nil
+}
+
+func (c *inProcessCloud) CreateStemcell(imagePath string, cloudProperties biproperty.Map) (string, error) {
+	var cid string
+
+	err := c.perform("create_stemcell", func(state *InProcessCloudState) error {
+		var err error
+
+		cid, err = c.newCID("stemcell")
+		if err != nil {
+			return err
+		}
+
+		state.Stemcells[cid] = InProcessStemcell{ImagePath: imagePath, CloudProperties: cloudProperties}
+
+		return nil
+	})
+
+	return cid, err
+}
+
+func (c *inProcessCloud) DeleteStemcell(stemcellCID string) error {
+	return c.perform("delete_stemcell", func(state *InProcessCloudState) error {
+		if _, found := state.Stemcells[stemcellCID]; !found {
+			return c.notFound("delete_stemcell", StemcellNotFoundError, "Stemcell", stemcellCID)
+		}
+
+		delete(state.Stemcells, stemcellCID)
+
+		return nil
+	})
+}
+
+func (c *inProcessCloud) HasVM(vmCID string) (bool, error) {
+	var found bool
+
+	err := c.perform("has_vm", func(state *InProcessCloudState) error {
+		_, found = state.VMs[vmCID]
+		return nil
+	})
+
+	return found, err
+}
+
+func (c *inProcessCloud) CreateVM(
+	agentID string,
+	stemcellCID string,
+	cloudProperties biproperty.Map,
+	networksInterfaces map[string]biproperty.Map,
+	env biproperty.Map,
+) (string, error) {
+	var cid string
+
+	err := c.perform("create_vm", func(state *InProcessCloudState) error {
+		if _, found := state.Stemcells[stemcellCID]; !found {
+			return c.notFound("create_vm", StemcellNotFoundError, "Stemcell", stemcellCID)
+		}
+
+		var err error
+
+		cid, err = c.newCID("vm")
+		if err != nil {
+			return err
+		}
+
+		state.VMs[cid] = InProcessVM{
+			AgentID:         agentID,
+			StemcellCID:     stemcellCID,
+			CloudProperties: cloudProperties,
+			Networks:        networksInterfaces,
+			Env:             env,
+		}
+
+		return nil
+	})
+
+	return cid, err
+}
+
+func (c *inProcessCloud) SetVMMetadata(vmCID string, metadata VMMetadata) error {
+	return c.perform("set_vm_metadata", func(state *InProcessCloudState) error {
+		vm, found := state.VMs[vmCID]
+		if !found {
+			return c.notFound("set_vm_metadata", VMNotFoundError, "VM", vmCID)
+		}
+
+		vm.Metadata = metadata
+		state.VMs[vmCID] = vm
+
+		return nil
+	})
+}
+
+func (c *inProcessCloud) SetDiskMetadata(diskCID string, metadata DiskMetadata) error {
+	return c.perform("set_disk_metadata", func(state *InProcessCloudState) error {
+		disk, found := state.Disks[diskCID]
+		if !found {
+			return c.notFound("set_disk_metadata", DiskNotFoundError, "Disk", diskCID)
+		}
+
+		disk.Metadata = metadata
+		state.Disks[diskCID] = disk
+
+		return nil
+	})
+}
+
+func (c *inProcessCloud) DeleteVM(vmCID string) error {
+	return c.perform("delete_vm", func(state *InProcessCloudState) error {
+		if _, found := state.VMs[vmCID]; !found {
+			return c.notFound("delete_vm", VMNotFoundError, "VM", vmCID)
+		}
+
+		for diskCID, disk := range state.Disks {
+			if disk.VMCID == vmCID {
+				disk.VMCID = ""
+				state.Disks[diskCID] = disk
+			}
+		}
+
+		delete(state.VMs, vmCID)
+
+		return nil
+	})
+}
+
+func (c *inProcessCloud) CreateDisk(size int, cloudProperties biproperty.Map, vmCID string) (string, error) {
+	var cid string
+
+	err := c.perform("create_disk", func(state *InProcessCloudState) error {
+		var err error
+
+		cid, err = c.newCID("disk")
+		if err != nil {
+			return err
+		}
+
+		state.Disks[cid] = InProcessDisk{Size: size, CloudProperties: cloudProperties}
+
+		return nil
+	})
+
+	return cid, err
+}
+
+func (c *inProcessCloud) AttachDisk(vmCID, diskCID string) (interface{}, error) {
+	var diskHint interface{}
+
+	err := c.perform("attach_disk", func(state *InProcessCloudState) error {
+		if _, found := state.VMs[vmCID]; !found {
+			return c.notFound("attach_disk", VMNotFoundError, "VM", vmCID)
+		}
+
+		disk, found := state.Disks[diskCID]
+		if !found {
+			return c.notFound("attach_disk", DiskNotFoundError, "Disk", diskCID)
+		}
+
+		if len(disk.VMCID) > 0 && disk.VMCID != vmCID {
+			return NewCPIError("attach_disk", CmdError{
+				Type:    "Bosh::Clouds::CloudError",
+				Message: fmt.Sprintf("Disk '%s' is already attached to VM '%s'", diskCID, disk.VMCID),
+			})
+		}
+
+		disk.VMCID = vmCID
+		state.Disks[diskCID] = disk
+
+		diskHint = map[string]interface{}{"path": filepath.Join("/dev", diskCID)}
+
+		return nil
+	})
+
+	return diskHint, err
+}
+
+func (c *inProcessCloud) DetachDisk(vmCID, diskCID string) error {
+	return c.perform("detach_disk", func(state *InProcessCloudState) error {
+		if _, found := state.VMs[vmCID]; !found {
+			return c.notFound("detach_disk", VMNotFoundError, "VM", vmCID)
+		}
+
+		disk, found := state.Disks[diskCID]
+		if !found || disk.VMCID != vmCID {
+			return c.notFound("detach_disk", DiskNotFoundError, "Disk", diskCID)
+		}
+
+		disk.VMCID = ""
+		state.Disks[diskCID] = disk
+
+		return nil
+	})
+}
+
+func (c *inProcessCloud) DeleteDisk(diskCID string) error {
+	return c.perform("delete_disk", func(state *InProcessCloudState) error {
+		disk, found := state.Disks[diskCID]
+		if !found {
+			return c.notFound("delete_disk", DiskNotFoundError, "Disk", diskCID)
+		}
+
+		if len(disk.VMCID) > 0 {
+			return NewCPIError("delete_disk", CmdError{
+				Type:    "Bosh::Clouds::CloudError",
+				Message: fmt.Sprintf("Disk '%s' is attached to VM '%s'", diskCID, disk.VMCID),
+			})
+		}
+
+		delete(state.Disks, diskCID)
+
+		return nil
+	})
+}
+
+func (c *inProcessCloud) HasDisk(diskCID string) (bool, error) {
+	var found bool
+
+	err := c.perform("has_disk", func(state *InProcessCloudState) error {
+		_, found = state.Disks[diskCID]
+		return nil
+	})
+
+	return found, err
+}
+
+func (c *inProcessCloud) GetDisks(vmCID string) ([]string, error) {
+	diskCIDs := []string{}
+
+	err := c.perform("get_disks", func(state *InProcessCloudState) error {
+		if _, found := state.VMs[vmCID]; !found {
+			return c.notFound("get_disks", VMNotFoundError, "VM", vmCID)
+		}
+
+		for diskCID, disk := range state.Disks {
+			if disk.VMCID == vmCID {
+				diskCIDs = append(diskCIDs, diskCID)
+			}
+		}
+
+		sort.Strings(diskCIDs)
+
+		return nil
+	})
+
+	return diskCIDs, err
+}
+
+func (c *inProcessCloud) State() (InProcessCloudState, error) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	return c.loadState()
+}
+
+// perform applies configured delay and failure for method, then runs fn against
+// loaded state and saves it. State is not saved if fn returns an error.
+func (c *inProcessCloud) perform(method string, fn func(*InProcessCloudState) error) error {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	c.logger.Debug(c.logTag, "Performing '%s'", method)
+
+	config, err := c.loadConfig()
+	if err != nil {
+		return err
+	}
+
+	state, err := c.loadState()
+	if err != nil {
+		return err
+	}
+
+	if delayStr, found := config.Delays[method]; found {
+		delay, err := time.ParseDuration(delayStr)
+		if err != nil {
+			return bosherr.WrapErrorf(err, "Parsing delay for in-process CPI method '%s'", method)
+		}
+
+		c.timeService.Sleep(delay)
+	}
+
+	if failure, found := config.Failures[method]; found {
+		if failure.Times == 0 || state.InjectedFailures[method] < failure.Times {
+			state.InjectedFailures[method]++
+
+			err = c.saveState(state)
+			if err != nil {
+				return err
+			}
+
+			return NewCPIError(method, CmdError{
+				Type:      failure.Type,
+				Message:   failure.Message,
+				OkToRetry: failure.OkToRetry,
+			})
+		}
+	}
+
+	err = fn(&state)
+	if err != nil {
+		return err
+	}
+
+	return c.saveState(state)
+}
+
+func (c *inProcessCloud) notFound(method, errType, kind, cid string) error {
+	return NewCPIError(method, CmdError{
+		Type:    errType,
+		Message: fmt.Sprintf("%s '%s' not found", kind, cid),
+	})
+}
+
+func (c *inProcessCloud) newCID(prefix string) (string, error) {
+	uuid, err := c.uuidGen.Generate()
+	if err != nil {
+		return "", bosherr.WrapErrorf(err, "Generating %s CID", prefix)
+	}
+
+	return fmt.Sprintf("%s-%s", prefix, uuid), nil
+}
+
+func (c *inProcessCloud) loadConfig() (InProcessCloudConfig, error) {
+	var config InProcessCloudConfig
+
+	path := filepath.Join(c.dir, "config.json")
+
+	if !c.fs.FileExists(path) {
+		return config, nil
+	}
+
+	bytes, err := c.fs.ReadFile(path)
+	if err != nil {
+		return config, bosherr.WrapErrorf(err, "Reading in-process CPI config '%s'", path)
+	}
+
+	err = json.Unmarshal(bytes, &config)
+	if err != nil {
+		return config, bosherr.WrapErrorf(err, "Unmarshalling in-process CPI config '%s'", path)
+	}
+
+	return config, nil
+}
+
+func (c *inProcessCloud) loadState() (InProcessCloudState, error) {
+	state := InProcessCloudState{}
+
+	path := filepath.Join(c.dir, "state.json")
+
+	if c.fs.FileExists(path) {
+		bytes, err := c.fs.ReadFile(path)
+		if err != nil {
+			return state, bosherr.WrapErrorf(err, "Reading in-process CPI state '%s'", path)
+		}
+
+		err = json.Unmarshal(bytes, &state)
+		if err != nil {
+			return state, bosherr.WrapErrorf(err, "Unmarshalling in-process CPI state '%s'", path)
+		}
+	}
+
+	if state.Stemcells == nil {
+		state.Stemcells = map[string]InProcessStemcell{}
+	}
+	if state.VMs == nil {
+		state.VMs = map[string]InProcessVM{}
+	}
+	if state.Disks == nil {
+		state.Disks = map[string]InProcessDisk{}
+	}
+	if state.InjectedFailures == nil {
+		state.InjectedFailures = map[string]int{}
+	}
+
+	return state, nil
+}
+
+func (c *inProcessCloud) saveState(state InProcessCloudState) error {
+	path := filepath.Join(c.dir, "state.json")
+
+	bytes, err := json.MarshalIndent(state, "", "  ")
+	if err != nil {
+		return bosherr.WrapError(err, "Marshalling in-process CPI state")
+	}
+
+	err = c.fs.MkdirAll(c.dir, 0700)
+	if err != nil {
+		return bosherr.WrapErrorf(err, "Creating in-process CPI directory '%s'", c.dir)
+	}
+
+	err = c.fs.WriteFile(path, bytes)
+	if err != nil {
+		return bosherr.WrapErrorf(err, "Writing in-process CPI state '%s'", path)
+	}
+
+	return nil
+}
@@ -0,0 +1,192 @@
+package cloud_test
+
+import (
+	"time"
+
+	"code.cloudfoundry.org/clock/fakeclock"
+	boshlog "github.com/cloudfoundry/bosh-utils/logger"
+	biproperty "github.com/cloudfoundry/bosh-utils/property"
+	fakesys "github.com/cloudfoundry/bosh-utils/system/fakes"
+	fakeuuid "github.com/cloudfoundry/bosh-utils/uuid/fakes"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	. "github.com/cloudfoundry/bosh-cli/cloud"
+)
+
+var _ = Describe("InProcessCloud", func() {
+	var (
+		fs        *fakesys.FakeFileSystem
+		uuidGen   *fakeuuid.FakeGenerator
+		fakeClock *fakeclock.FakeClock
+		cloud     InProcessCloud
+	)
+
+	BeforeEach(func() {
+		fs = fakesys.NewFakeFileSystem()
+		uuidGen = fakeuuid.NewFakeGenerator()
+		uuidGen.GeneratedUUID = "uuid"
+		fakeClock = fakeclock.NewFakeClock(time.Now())
+		cloud = NewInProcessCloud(fs, "/cpi", uuidGen, fakeClock, boshlog.NewLogger(boshlog.LevelNone))
+	})
+
+	createVMWithDisk := func() (string, string) {
+		stemcellCID, err := cloud.CreateStemcell("/image", biproperty.Map{})
+		Expect(err).ToNot(HaveOccurred())
+
+		uuidGen.GeneratedUUID = "uuid-vm"
+		vmCID, err := cloud.CreateVM("agent-id", stemcellCID, biproperty.Map{"type": "small"}, nil, biproperty.Map{})
+		Expect(err).ToNot(HaveOccurred())
+
+		uuidGen.GeneratedUUID = "uuid-disk"
+		diskCID, err := cloud.CreateDisk(1024, biproperty.Map{}, vmCID)
+		Expect(err).ToNot(HaveOccurred())
+
+		_, err = cloud.AttachDisk(vmCID, diskCID)
+		Expect(err).ToNot(HaveOccurred())
+
+		return vmCID, diskCID
+	}
+
+	It("reports CPI info", func() {
+		info, err := cloud.Info()
+		Expect(err).ToNot(HaveOccurred())
+		Expect(info.ApiVersion).To(Equal(MaxCpiApiVersionSupported))
+	})
+
+	It("keeps stemcells, VMs and disks in state file", func() {
+		vmCID, diskCID := createVMWithDisk()
+		Expect(vmCID).To(Equal("vm-uuid-vm"))
+		Expect(diskCID).To(Equal("disk-uuid-disk"))
+
+		err := cloud.SetVMMetadata(vmCID, VMMetadata{"director": "bosh-init"})
+		Expect(err).ToNot(HaveOccurred())
+
+		Expect(fs.FileExists("/cpi/state.json")).To(BeTrue())
+
+		reloaded := NewInProcessCloud(fs, "/cpi", uuidGen, fakeClock, boshlog.NewLogger(boshlog.LevelNone))
+
+		state, err := reloaded.State()
+		Expect(err).ToNot(HaveOccurred())
+		Expect(state.Stemcells).To(HaveKey("stemcell-uuid"))
+		Expect(state.VMs[vmCID].AgentID).To(Equal("agent-id"))
+		Expect(state.VMs[vmCID].Metadata).To(Equal(VMMetadata{"director": "bosh-init"}))
+		Expect(state.Disks[diskCID].VMCID).To(Equal(vmCID))
+		Expect(state.Disks[diskCID].Size).To(Equal(1024))
+
+		found, err := reloaded.HasVM(vmCID)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(found).To(BeTrue())
+	})
+
+	It("reports existing disks and disks attached to VM", func() {
+		vmCID, diskCID := createVMWithDisk()
+
+		found, err := cloud.HasDisk(diskCID)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(found).To(BeTrue())
+
+		found, err = cloud.HasDisk("unknown-disk-cid")
+		Expect(err).ToNot(HaveOccurred())
+		Expect(found).To(BeFalse())
+
+		diskCIDs, err := cloud.GetDisks(vmCID)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(diskCIDs).To(Equal([]string{diskCID}))
+
+		Expect(cloud.DetachDisk(vmCID, diskCID)).To(Succeed())
+
+		diskCIDs, err = cloud.GetDisks(vmCID)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(diskCIDs).To(BeEmpty())
+	})
+
+	It("detaches disks and deletes VM", func() {
+		vmCID, diskCID := createVMWithDisk()
+
+		Expect(cloud.DetachDisk(vmCID, diskCID)).To(Succeed())
+		Expect(cloud.DeleteVM(vmCID)).To(Succeed())
+		Expect(cloud.DeleteDisk(diskCID)).To(Succeed())
+
+		state, err := cloud.State()
+		Expect(err).ToNot(HaveOccurred())
+		Expect(state.VMs).To(BeEmpty())
+		Expect(state.Disks).To(BeEmpty())
+	})
+
+	It("does not delete attached disks", func() {
+		_, diskCID := createVMWithDisk()
+
+		err := cloud.DeleteDisk(diskCID)
+		Expect(err).To(HaveOccurred())
+		Expect(err.Error()).To(ContainSubstring("is attached to VM"))
+	})
+
+	It("returns not found errors for missing resources", func() {
+		err := cloud.DeleteVM("missing-vm")
+		Expect(err).To(HaveOccurred())
+		Expect(err.(Error).Type()).To(Equal(VMNotFoundError))
+
+		err = cloud.DeleteDisk("missing-disk")
+		Expect(err).To(HaveOccurred())
+		Expect(err.(Error).Type()).To(Equal(DiskNotFoundError))
+
+		_, err = cloud.CreateVM("agent-id", "missing-stemcell", nil, nil, nil)
+		Expect(err).To(HaveOccurred())
+		Expect(err.(Error).Type()).To(Equal(StemcellNotFoundError))
+	})
+
+	Describe("configured failures and delays", func() {
+		It("fails method configured number of times", func() {
+			fs.WriteFileString("/cpi/config.json", `{
+				"failures": {
+					"create_disk": {"type": "Bosh::Clouds::CloudError", "message": "no capacity", "ok_to_retry": true, "times": 1}
+				}
+			}`)
+
+			_, err := cloud.CreateDisk(1024, nil, "")
+			Expect(err).To(HaveOccurred())
+			Expect(err.(Error).Type()).To(Equal("Bosh::Clouds::CloudError"))
+			Expect(err.(Error).Message()).To(Equal("no capacity"))
+			Expect(err.(Error).OkToRetry()).To(BeTrue())
+
+			_, err = cloud.CreateDisk(1024, nil, "")
+			Expect(err).ToNot(HaveOccurred())
+		})
+
+		It("fails method every time when times is not set", func() {
+			fs.WriteFileString("/cpi/config.json", `{"failures": {"info": {"type": "Bosh::Clouds::CloudError"}}}`)
+
+			_, err := cloud.Info()
+			Expect(err).To(HaveOccurred())
+
+			_, err = cloud.Info()
+			Expect(err).To(HaveOccurred())
+		})
+
+		It("delays method", func() {
+			fs.WriteFileString("/cpi/config.json", `{"delays": {"info": "1m"}}`)
+
+			done := make(chan struct{})
+
+			go func() {
+				defer GinkgoRecover()
+				_, err := cloud.Info()
+				Expect(err).ToNot(HaveOccurred())
+				close(done)
+			}()
+
+			Consistently(done).ShouldNot(BeClosed())
+			fakeClock.WaitForWatcherAndIncrement(time.Minute)
+			Eventually(done).Should(BeClosed())
+		})
+
+		It("returns error if config cannot be parsed", func() {
+			fs.WriteFileString("/cpi/config.json", `not-json`)
+
+			_, err := cloud.Info()
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("Unmarshalling in-process CPI config '/cpi/config.json'"))
+		})
+	})
+})
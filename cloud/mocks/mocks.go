@@ -151,6 +151,36 @@ func (mr *MockCloudMockRecorder) DetachDisk(arg0, arg1 interface{}) *gomock.Call
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DetachDisk", reflect.TypeOf((*MockCloud)(nil).DetachDisk), arg0, arg1)
 }
 
+// GetDisks mocks base method
+func (m *MockCloud) GetDisks(arg0 string) ([]string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetDisks", arg0)
+	ret0, _ := ret[0].([]string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetDisks indicates an expected call of GetDisks
+func (mr *MockCloudMockRecorder) GetDisks(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetDisks", reflect.TypeOf((*MockCloud)(nil).GetDisks), arg0)
+}
+
+// HasDisk mocks base method
+func (m *MockCloud) HasDisk(arg0 string) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "HasDisk", arg0)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// HasDisk indicates an expected call of HasDisk
+func (mr *MockCloudMockRecorder) HasDisk(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "HasDisk", reflect.TypeOf((*MockCloud)(nil).HasDisk), arg0)
+}
+
 // HasVM mocks base method
 func (m *MockCloud) HasVM(arg0 string) (bool, error) {
 	m.ctrl.T.Helper()
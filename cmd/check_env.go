@@ -0,0 +1,79 @@
+package cmd
+
+import (
+	bosherr "github.com/cloudfoundry/bosh-utils/errors"
+	"github.com/cppforlife/go-patch/patch"
+
+	. "github.com/cloudfoundry/bosh-cli/cmd/opts"
+	bidepl "github.com/cloudfoundry/bosh-cli/deployment"
+	boshtpl "github.com/cloudfoundry/bosh-cli/director/template"
+	boshui "github.com/cloudfoundry/bosh-cli/ui"
+	boshtbl "github.com/cloudfoundry/bosh-cli/ui/table"
+)
+
+type CheckEnvCmd struct {
+	ui          boshui.UI
+	envProvider func(string, string, boshtpl.Variables, patch.Op) DeploymentChecker
+}
+
+func NewCheckEnvCmd(ui boshui.UI, envProvider func(string, string, boshtpl.Variables, patch.Op) DeploymentChecker) *CheckEnvCmd {
+	return &CheckEnvCmd{ui: ui, envProvider: envProvider}
+}
+
+func (c *CheckEnvCmd) Run(stage boshui.Stage, opts CheckEnvOpts) error {
+	c.ui.BeginLinef("Deployment manifest: '%s'\n", opts.Args.Manifest.Path)
+
+	depChecker := c.envProvider(
+		opts.Args.Manifest.Path, opts.StatePath, opts.VarFlags.AsVariables(), opts.OpsFlags.AsOp())
+
+	drifts, err := depChecker.CheckDeployment(opts.Repair, stage)
+	if err != nil {
+		return err
+	}
+
+	if len(drifts) == 0 {
+		c.ui.PrintLinef("No drift found between state and environment")
+		return nil
+	}
+
+	table := boshtbl.Table{
+		Content: "drifts",
+		Header: []boshtbl.Header{
+			boshtbl.NewHeader("Kind"),
+			boshtbl.NewHeader("CID"),
+			boshtbl.NewHeader("Description"),
+		},
+	}
+
+	if opts.Repair {
+		table.Header = append(table.Header, boshtbl.NewHeader("Repaired"))
+	}
+
+	var unrepaired int
+
+	for _, drift := range drifts {
+		row := []boshtbl.Value{
+			boshtbl.NewValueString(drift.Kind),
+			boshtbl.NewValueString(drift.CID),
+			boshtbl.NewValueString(drift.Description),
+		}
+
+		if opts.Repair {
+			row = append(row, boshtbl.NewValueBool(drift.Repaired))
+		}
+
+		if !drift.Repaired && drift.Kind != bidepl.DriftOrphanedDisk {
+			unrepaired++
+		}
+
+		table.Rows = append(table.Rows, row)
+	}
+
+	c.ui.PrintTable(table)
+
+	if unrepaired > 0 {
+		return bosherr.Errorf("Found %d drift(s) between state and environment", unrepaired)
+	}
+
+	return nil
+}
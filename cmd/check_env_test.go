@@ -0,0 +1,128 @@
+package cmd_test
+
+import (
+	"github.com/cppforlife/go-patch/patch"
+	"github.com/golang/mock/gomock"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	bosherr "github.com/cloudfoundry/bosh-utils/errors"
+
+	bicmd "github.com/cloudfoundry/bosh-cli/cmd"
+	mock_cmd "github.com/cloudfoundry/bosh-cli/cmd/mocks"
+	. "github.com/cloudfoundry/bosh-cli/cmd/opts"
+	bidepl "github.com/cloudfoundry/bosh-cli/deployment"
+	boshtpl "github.com/cloudfoundry/bosh-cli/director/template"
+	fakeui "github.com/cloudfoundry/bosh-cli/ui/fakes"
+	boshtbl "github.com/cloudfoundry/bosh-cli/ui/table"
+)
+
+var _ = Describe("CheckEnvCmd", func() {
+	var mockCtrl *gomock.Controller
+
+	BeforeEach(func() {
+		mockCtrl = gomock.NewController(GinkgoT())
+	})
+
+	AfterEach(func() {
+		mockCtrl.Finish()
+	})
+
+	Describe("Run", func() {
+		var (
+			mockDeploymentChecker  *mock_cmd.MockDeploymentChecker
+			fakeUI                 *fakeui.FakeUI
+			fakeStage              *fakeui.FakeStage
+			deploymentManifestPath = "/deployment-dir/fake-deployment-manifest.yml"
+			statePath              string
+			opts                   CheckEnvOpts
+		)
+
+		BeforeEach(func() {
+			mockDeploymentChecker = mock_cmd.NewMockDeploymentChecker(mockCtrl)
+			fakeUI = &fakeui.FakeUI{}
+			fakeStage = fakeui.NewFakeStage()
+
+			opts = CheckEnvOpts{
+				Args:      CheckEnvArgs{Manifest: FileBytesWithPathArg{Path: deploymentManifestPath}},
+				StatePath: "/state.json",
+				VarFlags: VarFlags{
+					VarKVs: []boshtpl.VarKV{{Name: "key", Value: "value"}},
+				},
+			}
+		})
+
+		act := func() error {
+			envProvider := func(manifestPath string, statePath_ string, vars boshtpl.Variables, op patch.Op) bicmd.DeploymentChecker {
+				Expect(manifestPath).To(Equal(deploymentManifestPath))
+				Expect(vars).To(Equal(boshtpl.NewMultiVars([]boshtpl.Variables{boshtpl.StaticVariables{"key": "value"}})))
+				statePath = statePath_
+				return mockDeploymentChecker
+			}
+
+			return bicmd.NewCheckEnvCmd(fakeUI, envProvider).Run(fakeStage, opts)
+		}
+
+		It("reports when there is no drift", func() {
+			mockDeploymentChecker.EXPECT().CheckDeployment(false, fakeStage).Return(nil, nil)
+
+			err := act()
+			Expect(err).ToNot(HaveOccurred())
+			Expect(statePath).To(Equal("/state.json"))
+			Expect(fakeUI.Said).To(ContainElement("No drift found between state and environment"))
+		})
+
+		It("prints drifts and returns error", func() {
+			mockDeploymentChecker.EXPECT().CheckDeployment(false, fakeStage).Return([]bidepl.Drift{
+				{Kind: bidepl.DriftMissingVM, CID: "vm-cid", Description: "fake-description"},
+				{Kind: bidepl.DriftOrphanedDisk, CID: "disk-cid", Description: "fake-orphan-description"},
+			}, nil)
+
+			err := act()
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(Equal("Found 1 drift(s) between state and environment"))
+
+			Expect(fakeUI.Tables[0].Rows).To(Equal([][]boshtbl.Value{
+				{
+					boshtbl.NewValueString("missing-vm"),
+					boshtbl.NewValueString("vm-cid"),
+					boshtbl.NewValueString("fake-description"),
+				},
+				{
+					boshtbl.NewValueString("orphaned-disk"),
+					boshtbl.NewValueString("disk-cid"),
+					boshtbl.NewValueString("fake-orphan-description"),
+				},
+			}))
+		})
+
+		It("does not fail on orphaned disks only", func() {
+			mockDeploymentChecker.EXPECT().CheckDeployment(false, fakeStage).Return([]bidepl.Drift{
+				{Kind: bidepl.DriftOrphanedDisk, CID: "disk-cid"},
+			}, nil)
+
+			Expect(act()).To(Succeed())
+		})
+
+		It("shows repaired drifts and succeeds when all drifts are repaired", func() {
+			opts.Repair = true
+
+			mockDeploymentChecker.EXPECT().CheckDeployment(true, fakeStage).Return([]bidepl.Drift{
+				{Kind: bidepl.DriftMissingVM, CID: "vm-cid", Description: "fake-description", Repaired: true},
+			}, nil)
+
+			err := act()
+			Expect(err).ToNot(HaveOccurred())
+			Expect(fakeUI.Tables[0].Header).To(HaveLen(4))
+			Expect(fakeUI.Tables[0].Rows[0][3]).To(Equal(boshtbl.NewValueBool(true)))
+		})
+
+		It("returns error if check fails", func() {
+			mockDeploymentChecker.EXPECT().CheckDeployment(false, fakeStage).Return(nil, bosherr.Error("fake-err"))
+
+			err := act()
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(Equal("fake-err"))
+		})
+	})
+})
@@ -2,24 +2,33 @@ package cmd
 
 import (
 	"fmt"
+	"os"
+	"os/user"
 	"path/filepath"
+	"strconv"
+	"time"
 
 	"github.com/cppforlife/go-patch/patch"
 
 	cmdconf "github.com/cloudfoundry/bosh-cli/cmd/config"
+	biconfig "github.com/cloudfoundry/bosh-cli/config"
 	"github.com/cloudfoundry/bosh-cli/crypto"
 	boshdir "github.com/cloudfoundry/bosh-cli/director"
+	boshlint "github.com/cloudfoundry/bosh-cli/director/lint"
 	boshtpl "github.com/cloudfoundry/bosh-cli/director/template"
 	boshrel "github.com/cloudfoundry/bosh-cli/release"
 	boshreldir "github.com/cloudfoundry/bosh-cli/releasedir"
 	boshssh "github.com/cloudfoundry/bosh-cli/ssh"
 	bistemcell "github.com/cloudfoundry/bosh-cli/stemcell"
+	bitemplate "github.com/cloudfoundry/bosh-cli/templatescompiler"
+	bitemplateerb "github.com/cloudfoundry/bosh-cli/templatescompiler/erbrenderer"
 	boshui "github.com/cloudfoundry/bosh-cli/ui"
 	boshuit "github.com/cloudfoundry/bosh-cli/ui/task"
 
 	. "github.com/cloudfoundry/bosh-cli/cmd/opts"
 	boshtbl "github.com/cloudfoundry/bosh-cli/ui/table"
 	boshcrypto "github.com/cloudfoundry/bosh-utils/crypto"
+	bosherr "github.com/cloudfoundry/bosh-utils/errors"
 	boshfu "github.com/cloudfoundry/bosh-utils/fileutil"
 )
 
@@ -67,36 +76,158 @@ func (c Cmd) Execute() (cmdErr error) {
 		return NewEnvironmentsCmd(c.config(), deps.UI).Run()
 
 	case *CreateEnvOpts:
-		envProvider := func(manifestPath string, statePath string, vars boshtpl.Variables, op patch.Op) DeploymentPreparer {
-			return NewEnvFactory(deps, manifestPath, statePath, vars, op, opts.RecreatePersistentDisks).Preparer()
+		opts.VarsStore.UseEncryptor(opts.EncryptionFlags.AsEncryptor())
+
+		state, err := c.deploymentState(opts.StatePath, opts.EncryptionFlags)
+		if err != nil {
+			return err
 		}
 
-		stage := boshui.NewStage(deps.UI, deps.Time, deps.Logger)
-		return NewCreateEnvCmd(deps.UI, envProvider).Run(stage, *opts)
+		envProvider := func(manifestPath string, _ string, vars boshtpl.Variables, op patch.Op) DeploymentPreparer {
+			return NewEnvFactory(deps, manifestPath, c.deploymentStateService(state, manifestPath), vars, op, opts.RecreatePersistentDisks).Preparer()
+		}
+
+		return c.withEventStage(opts.EventsPath, func(stage boshui.Stage) error {
+			return c.withStateLock(state, func() error {
+				return NewCreateEnvCmd(deps.UI, envProvider).Run(stage, *opts)
+			})
+		})
 
 	case *DeleteEnvOpts:
-		envProvider := func(manifestPath string, statePath string, vars boshtpl.Variables, op patch.Op) DeploymentDeleter {
-			return NewEnvFactory(deps, manifestPath, statePath, vars, op, false).Deleter()
+		opts.VarsStore.UseEncryptor(opts.EncryptionFlags.AsEncryptor())
+
+		state, err := c.deploymentState(opts.StatePath, opts.EncryptionFlags)
+		if err != nil {
+			return err
 		}
 
-		stage := boshui.NewStage(deps.UI, deps.Time, deps.Logger)
-		return NewDeleteEnvCmd(deps.UI, envProvider).Run(stage, *opts)
+		envProvider := func(manifestPath string, _ string, vars boshtpl.Variables, op patch.Op) DeploymentDeleter {
+			return NewEnvFactory(deps, manifestPath, c.deploymentStateService(state, manifestPath), vars, op, false).Deleter()
+		}
+
+		return c.withEventStage(opts.EventsPath, func(stage boshui.Stage) error {
+			return c.withStateLock(state, func() error {
+				return NewDeleteEnvCmd(deps.UI, envProvider).Run(stage, *opts)
+			})
+		})
 
 	case *StopEnvOpts:
-		envProvider := func(manifestPath string, statePath string, vars boshtpl.Variables, op patch.Op) DeploymentStateManager {
-			return NewEnvFactory(deps, manifestPath, statePath, vars, op, false).StateManager()
+		opts.VarsStore.UseEncryptor(opts.EncryptionFlags.AsEncryptor())
+
+		state, err := c.deploymentState(opts.StatePath, opts.EncryptionFlags)
+		if err != nil {
+			return err
+		}
+
+		envProvider := func(manifestPath string, _ string, vars boshtpl.Variables, op patch.Op) DeploymentStateManager {
+			return NewEnvFactory(deps, manifestPath, c.deploymentStateService(state, manifestPath), vars, op, false).StateManager()
 		}
 
 		stage := boshui.NewStage(deps.UI, deps.Time, deps.Logger)
-		return NewStopEnvCmd(deps.UI, envProvider).Run(stage, *opts)
+		return c.withStateLock(state, func() error {
+			return NewStopEnvCmd(deps.UI, envProvider).Run(stage, *opts)
+		})
 
 	case *StartEnvOpts:
-		envProvider := func(manifestPath string, statePath string, vars boshtpl.Variables, op patch.Op) DeploymentStateManager {
-			return NewEnvFactory(deps, manifestPath, statePath, vars, op, false).StateManager()
+		opts.VarsStore.UseEncryptor(opts.EncryptionFlags.AsEncryptor())
+
+		state, err := c.deploymentState(opts.StatePath, opts.EncryptionFlags)
+		if err != nil {
+			return err
+		}
+
+		envProvider := func(manifestPath string, _ string, vars boshtpl.Variables, op patch.Op) DeploymentStateManager {
+			return NewEnvFactory(deps, manifestPath, c.deploymentStateService(state, manifestPath), vars, op, false).StateManager()
 		}
 
 		stage := boshui.NewStage(deps.UI, deps.Time, deps.Logger)
-		return NewStartEnvCmd(deps.UI, envProvider).Run(stage, *opts)
+		return c.withStateLock(state, func() error {
+			return NewStartEnvCmd(deps.UI, envProvider).Run(stage, *opts)
+		})
+
+	case *CheckEnvOpts:
+		opts.VarsStore.UseEncryptor(opts.EncryptionFlags.AsEncryptor())
+
+		state, err := c.deploymentState(opts.StatePath, opts.EncryptionFlags)
+		if err != nil {
+			return err
+		}
+
+		envProvider := func(manifestPath string, _ string, vars boshtpl.Variables, op patch.Op) DeploymentChecker {
+			return NewEnvFactory(deps, manifestPath, c.deploymentStateService(state, manifestPath), vars, op, false).Checker()
+		}
+
+		stage := boshui.NewStage(deps.UI, deps.Time, deps.Logger)
+		return c.withStateLock(state, func() error {
+			return NewCheckEnvCmd(deps.UI, envProvider).Run(stage, *opts)
+		})
+
+	case *EncryptVarsStoreOpts:
+		return NewEncryptVarsStoreCmd(deps.FS, deps.UI).Run(*opts)
+
+	case *DecryptVarsStoreOpts:
+		return NewDecryptVarsStoreCmd(deps.FS, deps.UI).Run(*opts)
+
+	case *RotateVarsOpts:
+		opts.VarsStore.UseEncryptor(opts.EncryptionFlags.AsEncryptor())
+
+		return NewRotateVarsCmd(deps.UI, deps.Time).Run(*opts)
+
+	case *StateShowOpts:
+		stateCmd, _, err := c.stateCmd(opts.StateFlags)
+		if err != nil {
+			return err
+		}
+
+		return stateCmd.Show(*opts)
+
+	case *StateSetVMCIDOpts:
+		stateCmd, state, err := c.stateCmd(opts.StateFlags)
+		if err != nil {
+			return err
+		}
+
+		return c.withStateLock(state, func() error {
+			return stateCmd.SetVMCID(*opts)
+		})
+
+	case *StateForgetDiskOpts:
+		stateCmd, state, err := c.stateCmd(opts.StateFlags)
+		if err != nil {
+			return err
+		}
+
+		return c.withStateLock(state, func() error {
+			return stateCmd.ForgetDisk(*opts)
+		})
+
+	case *StateImportDiskOpts:
+		stateCmd, state, err := c.stateCmd(opts.StateFlags)
+		if err != nil {
+			return err
+		}
+
+		return c.withStateLock(state, func() error {
+			return stateCmd.ImportDisk(*opts)
+		})
+
+	case *StateForgetStemcellOpts:
+		stateCmd, state, err := c.stateCmd(opts.StateFlags)
+		if err != nil {
+			return err
+		}
+
+		return c.withStateLock(state, func() error {
+			return stateCmd.ForgetStemcell(*opts)
+		})
+
+	case *StateValidateOpts:
+		stateCmd, _, err := c.stateCmd(opts.StateFlags)
+		if err != nil {
+			return err
+		}
+
+		return stateCmd.Validate(*opts)
 
 	case *AliasEnvOpts:
 		sessionFactory := func(config cmdconf.Config) Session {
@@ -132,12 +263,20 @@ func (c Cmd) Execute() (cmdErr error) {
 		return NewLogOutCmd(sess.Environment(), config, deps.UI).Run()
 
 	case *TaskOpts:
-		eventsTaskReporter := boshuit.NewReporter(deps.UI, true)
+		eventsTaskReporter := NewTaskReporterFromOpts(c.BoshOpts, deps.UI, deps.UI.EventWriter())
 		plainTaskReporter := boshuit.NewReporter(deps.UI, false)
 		return NewTaskCmd(eventsTaskReporter, plainTaskReporter, c.director()).Run(*opts)
 
 	case *TasksOpts:
-		return NewTasksCmd(deps.UI, c.director()).Run(*opts)
+		var taskReporter boshuit.Reporter
+		if opts.Follow {
+			if c.BoshOpts.EventJSONOpt {
+				taskReporter = NewTaskReporterFromOpts(c.BoshOpts, deps.UI, deps.UI.EventWriter())
+			} else {
+				taskReporter = boshuit.NewCompactReporter(deps.UI)
+			}
+		}
+		return NewTasksCmd(deps.UI, c.director(), taskReporter).Run(*opts)
 
 	case *CancelTaskOpts:
 		return NewCancelTaskCmd(c.director()).Run(*opts)
@@ -261,6 +400,12 @@ func (c Cmd) Execute() (cmdErr error) {
 	case *InterpolateOpts:
 		return NewInterpolateCmd(deps.UI).Run(*opts)
 
+	case *OpsTestOpts:
+		return NewOpsTestCmd(deps.UI).Run(*opts)
+
+	case *OpsGenerateOpts:
+		return NewOpsGenerateCmd(deps.UI).Run(*opts)
+
 	case *ConfigOpts:
 		return NewConfigCmd(deps.UI, c.director()).Run(*opts)
 
@@ -311,10 +456,14 @@ func (c Cmd) Execute() (cmdErr error) {
 	case *InspectLocalReleaseOpts:
 		relProv, _ := c.releaseProviders()
 
-		return NewInspectLocalReleaseCmd(
-			relProv.NewArchiveReader(),
-			deps.UI,
-		).Run(*opts)
+		reader := relProv.NewArchiveReader()
+
+		// Job specs are only available once jobs are extracted
+		if opts.Links || opts.Properties {
+			reader = relProv.NewExtractingArchiveReader()
+		}
+
+		return NewInspectLocalReleaseCmd(reader, deps.UI).Run(*opts)
 
 	case *VMsOpts:
 		return NewVMsCmd(deps.UI, c.director(), c.BoshOpts.Parallel).Run(*opts)
@@ -337,7 +486,43 @@ func (c Cmd) Execute() (cmdErr error) {
 	case *DeployOpts:
 		director, deployment := c.directorAndDeployment()
 		releaseManager := c.releaseManager(director)
-		return NewDeployCmd(deps.UI, deployment, releaseManager).Run(*opts)
+		relProv, _ := c.releaseProviders()
+		validator := NewReleasePropertiesValidator(director, relProv.NewExtractingArchiveReader())
+		return NewDeployCmd(deps.UI, deployment, releaseManager, validator).Run(*opts)
+
+	case *DiffDeploymentOpts:
+		return NewDiffDeploymentCmd(deps.UI, c.deployment()).Run(*opts)
+
+	case *DiffManifestsOpts:
+		return NewDiffManifestsCmd(deps.UI).Run(*opts, c.getDeployment)
+
+	case *RenderJobOpts:
+		relProv, _ := c.releaseProviders()
+		erbRenderer := bitemplateerb.NewERBRenderer(deps.FS, deps.CmdRunner, deps.Logger)
+		jobRenderer := bitemplate.NewInstanceJobRenderer(erbRenderer, deps.FS, deps.UUIDGen, deps.Logger)
+		return NewRenderJobCmd(jobRenderer, relProv.NewExtractingArchiveReader(), deps.FS, deps.UI).Run(*opts)
+
+	case *JobDocsOpts:
+		relProv, _ := c.releaseProviders()
+
+		var reader boshrel.Reader = relProv.NewDirReader(opts.ReleaseDir.Path)
+
+		// Job specs are only available once jobs are extracted
+		if len(opts.Release.ExpandedPath) > 0 {
+			reader = relProv.NewExtractingArchiveReader()
+		}
+
+		return NewJobDocsCmd(reader, deps.FS, deps.UI).Run(*opts)
+
+	case *LintManifestOpts:
+		relProv, _ := c.releaseProviders()
+		// Job specs (and hence job properties) are only available once jobs are extracted
+		return NewLintManifestCmd(deps.UI, boshlint.NewLinter(relProv.NewExtractingArchiveReader())).Run(*opts)
+
+	case *ValidatePropertiesOpts:
+		relProv, _ := c.releaseProviders()
+		validator := NewReleasePropertiesValidator(c.director(), relProv.NewExtractingArchiveReader())
+		return NewValidatePropertiesCmd(deps.UI, validator).Run(*opts)
 
 	case *StartOpts:
 		return NewStartCmd(deps.UI, c.deployment()).Run(*opts)
@@ -405,7 +590,18 @@ func (c Cmd) Execute() (cmdErr error) {
 		return NewFinalizeReleaseCmd(releaseReader, releaseDir, deps.UI).Run(*opts)
 
 	case *CreateReleaseOpts:
-		relProv, relDirProv := c.releaseProviders()
+		compressor := c.deps.Compressor
+
+		if opts.Reproducible {
+			modTime, err := c.sourceDateEpoch(opts.Directory)
+			if err != nil {
+				return err
+			}
+
+			compressor = boshrel.NewReproducibleCompressor(c.deps.Compressor, c.deps.FS, modTime)
+		}
+
+		relProv, relDirProv := c.releaseProvidersWithCompressor(compressor)
 
 		releaseDirFactory := func(dir DirOrCWDArg) (boshrel.Reader, boshreldir.ReleaseDir) {
 			releaseReader := relDirProv.NewReleaseReader(dir.Path, c.BoshOpts.Parallel)
@@ -421,6 +617,10 @@ func (c Cmd) Execute() (cmdErr error) {
 		).Run(*opts)
 		return err
 
+	case *VerifyReleaseReproducibleOpts:
+		verifier := boshrel.NewReproducibleCompressor(c.deps.Compressor, c.deps.FS, time.Time{})
+		return NewVerifyReleaseReproducibleCmd(verifier, deps.UI).Run(*opts)
+
 	case *Sha1ifyReleaseOpts:
 		relProv, _ := c.releaseProviders()
 
@@ -485,6 +685,11 @@ func (c Cmd) configureUI() {
 		c.deps.UI.EnableJSON()
 	}
 
+	if c.streamsEvents() {
+		c.deps.UI.EnableEventStream()
+	}
+
+	// Applied after event stream so that confirmations are still skipped
 	if c.BoshOpts.NonInteractiveOpt {
 		c.deps.UI.EnableNonInteractive()
 	}
@@ -499,6 +704,22 @@ func (c Cmd) configureUI() {
 	}
 }
 
+// streamsEvents returns true when stdout is reserved for machine readable events.
+func (c Cmd) streamsEvents() bool {
+	if c.BoshOpts.EventJSONOpt {
+		return true
+	}
+
+	switch opts := c.Opts.(type) {
+	case *CreateEnvOpts:
+		return opts.EventsPath == "-"
+	case *DeleteEnvOpts:
+		return opts.EventsPath == "-"
+	}
+
+	return false
+}
+
 func (c Cmd) configureFS() {
 	tmpDirPath, err := c.deps.FS.ExpandPath(filepath.Join("~", ".bosh", "tmp"))
 	c.panicIfErr(err)
@@ -507,6 +728,114 @@ func (c Cmd) configureFS() {
 	c.panicIfErr(err)
 }
 
+// deploymentState describes where create-env state is kept. Backend and lock
+// are only set for state URLs (e.g. 's3://bucket/state.json') and are shared
+// by the state service and withStateLock.
+type deploymentState struct {
+	statePath string
+	encryptor crypto.Encryptor
+
+	backend biconfig.StateBackend
+	name    string
+	lock    biconfig.StateLock
+}
+
+func (c Cmd) deploymentState(statePath string, encryptionFlags EncryptionFlags) (deploymentState, error) {
+	state := deploymentState{statePath: statePath, encryptor: encryptionFlags.AsEncryptor()}
+
+	if !biconfig.IsRemoteDeploymentStatePath(statePath) {
+		return state, nil
+	}
+
+	backend, name, err := biconfig.NewStateBackendFromURL(statePath, c.deps.FS, c.deps.Logger)
+	if err != nil {
+		return state, err
+	}
+
+	id, err := c.deps.UUIDGen.Generate()
+	if err != nil {
+		return state, bosherr.WrapError(err, "Generating lock ID")
+	}
+
+	state.backend = backend
+	state.name = name
+	state.lock = biconfig.NewStateLock(
+		backend, name, c.stateLockHolder(), id, biconfig.DefaultStateLockTTL, c.deps.Time, c.deps.Logger)
+
+	return state, nil
+}
+
+// deploymentStateService returns remote state service for state URLs
+// and file based one otherwise.
+func (c Cmd) deploymentStateService(state deploymentState, manifestPath string) biconfig.DeploymentStateService {
+	if state.backend != nil {
+		return biconfig.NewRemoteDeploymentStateService(
+			state.backend, state.name, c.deps.UUIDGen, state.encryptor, state.lock, c.deps.Logger)
+	}
+
+	return biconfig.NewEncryptedFileSystemDeploymentStateService(
+		c.deps.FS, c.deps.UUIDGen, state.encryptor, c.deps.Logger,
+		biconfig.DeploymentStatePath(manifestPath, state.statePath))
+}
+
+func (c Cmd) stateCmd(flags StateFlags) (StateCmd, deploymentState, error) {
+	state, err := c.deploymentState(flags.StatePath, flags.EncryptionFlags)
+	if err != nil {
+		return StateCmd{}, state, err
+	}
+
+	return NewStateCmd(c.deps.UI, c.deploymentStateService(state, ""), c.deps.UUIDGen, c.deps.Time), state, nil
+}
+
+// withStateLock holds lock on remote deployment state while fn is running.
+// Local state files are not locked.
+func (c Cmd) withStateLock(state deploymentState, fn func() error) error {
+	if state.lock == nil {
+		return fn()
+	}
+
+	return state.lock.Hold(fn)
+}
+
+// withEventStage runs fn with stage that additionally streams stage events
+// as newline-delimited JSON to eventsPath when it's set. Events are written
+// to stdout for '-' and other output is suppressed (see streamsEvents).
+func (c Cmd) withEventStage(eventsPath string, fn func(boshui.Stage) error) error {
+	stage := boshui.NewStage(c.deps.UI, c.deps.Time, c.deps.Logger)
+
+	if len(eventsPath) == 0 {
+		return fn(stage)
+	}
+
+	if eventsPath == "-" {
+		return fn(boshui.NewEventStage(stage, c.deps.UI.EventWriter(), c.deps.Time))
+	}
+
+	file, err := c.deps.FS.OpenFile(eventsPath, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, 0644)
+	if err != nil {
+		return bosherr.WrapErrorf(err, "Opening stage events file '%s'", eventsPath)
+	}
+
+	defer file.Close()
+
+	return fn(boshui.NewEventStage(stage, file, c.deps.Time))
+}
+
+func (c Cmd) stateLockHolder() string {
+	username := "unknown"
+
+	if currentUser, err := user.Current(); err == nil {
+		username = currentUser.Username
+	}
+
+	hostname, err := os.Hostname()
+	if err != nil {
+		hostname = "unknown"
+	}
+
+	return fmt.Sprintf("%s@%s", username, hostname)
+}
+
 func (c Cmd) config() cmdconf.Config {
 	config, err := cmdconf.NewFSConfigFromPath(c.BoshOpts.ConfigPathOpt, c.deps.FS)
 	c.panicIfErr(err)
@@ -545,12 +874,16 @@ func (c Cmd) directorAndDeployment() (boshdir.Director, boshdir.Deployment) {
 }
 
 func (c Cmd) releaseProviders() (boshrel.Provider, boshreldir.Provider) {
+	return c.releaseProvidersWithCompressor(c.deps.Compressor)
+}
+
+func (c Cmd) releaseProvidersWithCompressor(compressor boshfu.Compressor) (boshrel.Provider, boshreldir.Provider) {
 	indexReporter := boshui.NewIndexReporter(c.deps.UI)
 	blobsReporter := boshui.NewBlobsReporter(c.deps.UI)
 	releaseIndexReporter := boshui.NewReleaseIndexReporter(c.deps.UI)
 
 	releaseProvider := boshrel.NewProvider(
-		c.deps.CmdRunner, c.deps.Compressor, c.deps.DigestCalculator, c.deps.FS, c.deps.Logger)
+		c.deps.CmdRunner, compressor, c.deps.DigestCalculator, c.deps.FS, c.deps.Logger)
 
 	releaseDirProvider := boshreldir.NewProvider(
 		indexReporter, releaseIndexReporter, blobsReporter, releaseProvider,
@@ -559,6 +892,21 @@ func (c Cmd) releaseProviders() (boshrel.Provider, boshreldir.Provider) {
 	return releaseProvider, releaseDirProvider
 }
 
+// sourceDateEpoch picks modification time for reproducible archives:
+// SOURCE_DATE_EPOCH environment variable if set, otherwise time of last commit.
+func (c Cmd) sourceDateEpoch(dir DirOrCWDArg) (time.Time, error) {
+	if epoch := os.Getenv("SOURCE_DATE_EPOCH"); len(epoch) > 0 {
+		secs, err := strconv.ParseInt(epoch, 10, 64)
+		if err != nil {
+			return time.Time{}, bosherr.WrapErrorf(err, "Parsing SOURCE_DATE_EPOCH '%s'", epoch)
+		}
+
+		return time.Unix(secs, 0).UTC(), nil
+	}
+
+	return boshreldir.NewFSGitRepo(dir.Path, c.deps.CmdRunner, c.deps.FS).LastCommitTime()
+}
+
 func (c Cmd) releaseManager(director boshdir.Director) ReleaseManager {
 	relProv, relDirProv := c.releaseProviders()
 
@@ -1,12 +1,15 @@
 package cmd_test
 
 import (
+	"encoding/pem"
 	"errors"
+	"net/http"
 
 	boshlog "github.com/cloudfoundry/bosh-utils/logger"
 	fakesys "github.com/cloudfoundry/bosh-utils/system/fakes"
 	. "github.com/onsi/ginkgo"
 	. "github.com/onsi/gomega"
+	"github.com/onsi/gomega/ghttp"
 
 	. "github.com/cloudfoundry/bosh-cli/cmd"
 	. "github.com/cloudfoundry/bosh-cli/cmd/opts"
@@ -97,6 +100,69 @@ var _ = Describe("Cmd", func() {
 			})
 		})
 
+		Context("when streaming director task events", func() {
+			var server *ghttp.Server
+
+			BeforeEach(func() {
+				server = ghttp.NewTLSServer()
+
+				caCert := pem.EncodeToMemory(&pem.Block{
+					Type:  "CERTIFICATE",
+					Bytes: server.HTTPTestServer.Certificate().Raw,
+				})
+
+				taskHeader := http.Header{}
+				taskHeader.Add("Location", "/tasks/123")
+
+				server.RouteToHandler("GET", "/info", ghttp.RespondWith(http.StatusOK, `{"user_authentication":{"type":"basic"}}`))
+				server.RouteToHandler("POST", "/deployments/dep/diff", ghttp.RespondWith(http.StatusOK, `{"context":{},"diff":[]}`))
+				server.RouteToHandler("POST", "/deployments", ghttp.RespondWith(http.StatusFound, nil, taskHeader))
+				server.RouteToHandler("GET", "/tasks/123", ghttp.RespondWith(http.StatusOK, `{"id":123,"state":"done"}`))
+				server.RouteToHandler("GET", "/tasks/123/output", ghttp.RespondWith(http.StatusOK, ``))
+
+				cmd.BoshOpts = BoshOpts{
+					EnvironmentOpt:  server.URL(),
+					CACertOpt:       CACertArg{Content: string(caCert)},
+					ClientOpt:       "client",
+					ClientSecretOpt: "client-secret",
+					DeploymentOpt:   "dep",
+					EventJSONOpt:    true,
+				}
+			})
+
+			AfterEach(func() {
+				server.Close()
+			})
+
+			It("deploys without asking for confirmation when non-interactive", func() {
+				cmd.BoshOpts.NonInteractiveOpt = true
+				cmd.Opts = &DeployOpts{
+					Args: DeployArgs{Manifest: FileBytesArg{Bytes: []byte("name: dep")}},
+				}
+
+				err := cmd.Execute()
+				Expect(err).ToNot(HaveOccurred())
+
+				var updated bool
+				for _, req := range server.ReceivedRequests() {
+					if req.Method == "POST" && req.URL.Path == "/deployments" {
+						updated = true
+					}
+				}
+				Expect(updated).To(BeTrue())
+			})
+
+			It("requires non-interactive mode to confirm deploy", func() {
+				cmd.Opts = &DeployOpts{
+					Args: DeployArgs{Manifest: FileBytesArg{Bytes: []byte("name: dep")}},
+				}
+
+				err := cmd.Execute()
+				Expect(err).To(HaveOccurred())
+				Expect(err.Error()).To(ContainSubstring("Cannot ask for confirmation while streaming events"))
+			})
+		})
+
 		It("returns error if changing tmp root fails", func() {
 			fs.ChangeTempRootErr = errors.New("fake-err")
 
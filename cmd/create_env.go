@@ -4,8 +4,10 @@ import (
 	"github.com/cppforlife/go-patch/patch"
 
 	. "github.com/cloudfoundry/bosh-cli/cmd/opts"
+	bidepl "github.com/cloudfoundry/bosh-cli/deployment"
 	boshtpl "github.com/cloudfoundry/bosh-cli/director/template"
 	boshui "github.com/cloudfoundry/bosh-cli/ui"
+	boshtbl "github.com/cloudfoundry/bosh-cli/ui/table"
 )
 
 type CreateEnvCmd struct {
@@ -24,5 +26,44 @@ func (c *CreateEnvCmd) Run(stage boshui.Stage, opts CreateEnvOpts) error {
 
 	depPreparer := c.envProvider(opts.Args.Manifest.Path, opts.StatePath, opts.VarFlags.AsVariables(), opts.OpsFlags.AsOp())
 
+	if opts.DryRun {
+		plan, err := depPreparer.PlanDeployment(stage, opts.Recreate, opts.RecreatePersistentDisks)
+		if err != nil {
+			return err
+		}
+
+		c.printPlan(plan)
+
+		return nil
+	}
+
 	return depPreparer.PrepareDeployment(stage, opts.Recreate, opts.RecreatePersistentDisks, opts.SkipDrain)
 }
+
+func (c *CreateEnvCmd) printPlan(plan bidepl.Plan) {
+	table := boshtbl.Table{
+		Content: "plan",
+
+		Header: []boshtbl.Header{
+			boshtbl.NewHeader("Action"),
+			boshtbl.NewHeader("Subject"),
+			boshtbl.NewHeader("Reason"),
+		},
+
+		Notes: []string{"Dry run: no changes were made"},
+	}
+
+	if !plan.HasChanges() {
+		table.Notes = append(table.Notes, "No deployment, stemcell or release changes")
+	}
+
+	for _, step := range plan.Steps {
+		table.Rows = append(table.Rows, []boshtbl.Value{
+			boshtbl.NewValueString(step.Action),
+			boshtbl.NewValueString(step.Subject),
+			boshtbl.NewValueStrings(step.Reasons),
+		})
+	}
+
+	c.ui.PrintTable(table)
+}
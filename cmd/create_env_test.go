@@ -445,13 +445,15 @@ var _ = Describe("CreateEnvCmd", func() {
 			expectDeploy = mockDeployer.EXPECT().Deploy(
 				mockCloud,
 				boshDeploymentManifest,
+				gomock.Any(),
 				cloudStemcell,
 				expectedRegistryConfig,
 				fakeVMManager,
 				mockBlobstore,
 				expectedSkipDrain,
 				gomock.Any(),
-			).Do(func(_, _, _, _, _, _, _ interface{}, stage biui.Stage) {
+				gomock.Any(),
+			).Do(func(_, _, _, _, _, _, _, _, _ interface{}, stage biui.Stage) {
 				Expect(fakeStage.SubStages).To(ContainElement(stage))
 			}).Return(nil, expectedDeployError).AnyTimes()
 
@@ -780,7 +782,9 @@ var _ = Describe("CreateEnvCmd", func() {
 			})
 
 			It("deploys if `recreate` flag is specified", func() {
-				expectDeploy.Times(1)
+				expectDeploy.Times(1).Do(func(_, _, _, _, _, _, _, _, recreate, _ interface{}) {
+					Expect(recreate).To(BeTrue())
+				})
 
 				defaultCreateEnvOpts.Recreate = true
 
@@ -798,6 +802,64 @@ var _ = Describe("CreateEnvCmd", func() {
 			})
 		})
 
+		Context("when dry run is requested", func() {
+			BeforeEach(func() {
+				defaultCreateEnvOpts.DryRun = true
+			})
+
+			It("prints plan without installing CPI or deploying", func() {
+				expectInstall.Times(0)
+				expectNewCloud.Times(0)
+				expectStemcellUpload.Times(0)
+				expectDeploy.Times(0)
+
+				err := command.Run(fakeStage, defaultCreateEnvOpts)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(stdOut).To(gbytes.Say("install-cpi"))
+				Expect(stdOut).To(gbytes.Say("upload-stemcell"))
+				Expect(stdOut).To(gbytes.Say("create-vm"))
+				Expect(stdOut).To(gbytes.Say("compile-release"))
+				Expect(stdOut).To(gbytes.Say("render-jobs"))
+				Expect(stdOut).To(gbytes.Say("Dry run: no changes were made"))
+			})
+
+			It("does not leave deployment state behind", func() {
+				err := command.Run(fakeStage, defaultCreateEnvOpts)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(fs.FileExists(deploymentStatePath)).To(BeFalse())
+			})
+
+			Context("when deployment has not changed", func() {
+				JustBeforeEach(func() {
+					err := setupDeploymentStateService.Save(biconfig.DeploymentState{
+						DirectorID:         directorID,
+						CurrentVMCID:       "fake-vm-cid",
+						Releases:           []biconfig.ReleaseRecord{{ID: "my-release-id-1", Name: cpiRelease.Name(), Version: cpiRelease.Version()}},
+						CurrentStemcellID:  "my-stemcellRecordID",
+						Stemcells:          []biconfig.StemcellRecord{{ID: "my-stemcellRecordID", Name: cloudStemcell.Name(), Version: cloudStemcell.Version()}},
+						CurrentManifestSHA: manifestSHA,
+					})
+					Expect(err).ToNot(HaveOccurred())
+				})
+
+				It("prints empty plan", func() {
+					err := command.Run(fakeStage, defaultCreateEnvOpts)
+					Expect(err).NotTo(HaveOccurred())
+					Expect(stdOut).To(gbytes.Say("No deployment, stemcell or release changes"))
+					Expect(fs.FileExists(deploymentStatePath)).To(BeTrue())
+				})
+
+				It("plans VM recreate if `recreate` flag is specified", func() {
+					defaultCreateEnvOpts.Recreate = true
+
+					err := command.Run(fakeStage, defaultCreateEnvOpts)
+					Expect(err).NotTo(HaveOccurred())
+					Expect(stdOut).To(gbytes.Say("recreate-vm"))
+					Expect(stdOut).To(gbytes.Say("recreate requested"))
+				})
+			})
+		})
+
 		Context("when parsing the cpi deployment manifest fails", func() {
 			JustBeforeEach(func() {
 				manifest := bideplmanifest.Manifest{}
@@ -1137,11 +1199,13 @@ var _ = Describe("CreateEnvCmd", func() {
 				mockDeployer.EXPECT().Deploy(
 					mockCloud,
 					boshDeploymentManifest,
+					gomock.Any(),
 					cloudStemcell,
 					installationManifest.Registry,
 					fakeVMManager,
 					mockBlobstore,
 					expectedSkipDrain,
+					false,
 					gomock.Any(),
 				).Return(nil, expectedDeployError).AnyTimes()
 
@@ -0,0 +1,50 @@
+package cmd
+
+import (
+	bosherr "github.com/cloudfoundry/bosh-utils/errors"
+	boshsys "github.com/cloudfoundry/bosh-utils/system"
+
+	. "github.com/cloudfoundry/bosh-cli/cmd/opts"
+	bicrypto "github.com/cloudfoundry/bosh-cli/crypto"
+	boshui "github.com/cloudfoundry/bosh-cli/ui"
+)
+
+type DecryptVarsStoreCmd struct {
+	fs boshsys.FileSystem
+	ui boshui.UI
+}
+
+func NewDecryptVarsStoreCmd(fs boshsys.FileSystem, ui boshui.UI) DecryptVarsStoreCmd {
+	return DecryptVarsStoreCmd{fs: fs, ui: ui}
+}
+
+func (c DecryptVarsStoreCmd) Run(opts DecryptVarsStoreOpts) error {
+	if !opts.IsSet() {
+		return bosherr.Error("Expected encryption key to be provided via '--encryption-key-file' or BOSH_ENCRYPTION_KEY")
+	}
+
+	path := opts.Args.Path.ExpandedPath
+
+	contents, err := c.fs.ReadFile(path)
+	if err != nil {
+		return bosherr.WrapErrorf(err, "Reading file '%s'", path)
+	}
+
+	if !bicrypto.IsEncrypted(contents) {
+		return bosherr.Errorf("File '%s' is not encrypted", path)
+	}
+
+	decrypted, err := opts.AsEncryptor().Decrypt(contents)
+	if err != nil {
+		return bosherr.WrapErrorf(err, "Decrypting file '%s'", path)
+	}
+
+	err = c.fs.WriteFile(path, decrypted)
+	if err != nil {
+		return bosherr.WrapErrorf(err, "Writing file '%s'", path)
+	}
+
+	c.ui.PrintLinef("Decrypted '%s'", path)
+
+	return nil
+}
@@ -0,0 +1,74 @@
+package cmd_test
+
+import (
+	fakesys "github.com/cloudfoundry/bosh-utils/system/fakes"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	. "github.com/cloudfoundry/bosh-cli/cmd"
+	. "github.com/cloudfoundry/bosh-cli/cmd/opts"
+	bicrypto "github.com/cloudfoundry/bosh-cli/crypto"
+	fakeui "github.com/cloudfoundry/bosh-cli/ui/fakes"
+)
+
+var _ = Describe("DecryptVarsStoreCmd", func() {
+	var (
+		fs      *fakesys.FakeFileSystem
+		ui      *fakeui.FakeUI
+		command DecryptVarsStoreCmd
+	)
+
+	BeforeEach(func() {
+		fs = fakesys.NewFakeFileSystem()
+		ui = &fakeui.FakeUI{}
+		command = NewDecryptVarsStoreCmd(fs, ui)
+	})
+
+	Describe("Run", func() {
+		var (
+			opts DecryptVarsStoreOpts
+		)
+
+		BeforeEach(func() {
+			encrypted, err := bicrypto.NewEncryptor([]byte("key")).Encrypt([]byte("password: secret\n"))
+			Expect(err).ToNot(HaveOccurred())
+
+			fs.WriteFile("/creds.yml", encrypted)
+
+			opts = DecryptVarsStoreOpts{
+				Args:            EncryptVarsStoreArgs{Path: FileArg{ExpandedPath: "/creds.yml"}},
+				EncryptionFlags: EncryptionFlags{EncryptionKeyFile: FileBytesArg{Bytes: []byte("key")}},
+			}
+		})
+
+		act := func() error { return command.Run(opts) }
+
+		It("decrypts file in place", func() {
+			err := act()
+			Expect(err).ToNot(HaveOccurred())
+
+			Expect(fs.ReadFileString("/creds.yml")).To(Equal("password: secret\n"))
+			Expect(ui.Said).To(Equal([]string{"Decrypted '/creds.yml'"}))
+		})
+
+		It("returns error if key is wrong and leaves file untouched", func() {
+			opts.EncryptionFlags = EncryptionFlags{EncryptionKeyFile: FileBytesArg{Bytes: []byte("other-key")}}
+
+			err := act()
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("Decrypting file '/creds.yml'"))
+
+			contents, err := fs.ReadFile("/creds.yml")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(bicrypto.IsEncrypted(contents)).To(BeTrue())
+		})
+
+		It("returns error if file is not encrypted", func() {
+			fs.WriteFileString("/creds.yml", "password: secret\n")
+
+			err := act()
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(Equal("File '/creds.yml' is not encrypted"))
+		})
+	})
+})
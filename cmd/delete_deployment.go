@@ -21,5 +21,16 @@ func (c DeleteDeploymentCmd) Run(opts DeleteDeploymentOpts) error {
 		return err
 	}
 
+	if opts.NoTrack {
+		task, err := c.deployment.DeleteAsync(opts.Force)
+		if err != nil {
+			return err
+		}
+
+		StartedTaskTable{Task: task, UI: c.ui}.Print()
+
+		return nil
+	}
+
 	return c.deployment.Delete(opts.Force)
 }
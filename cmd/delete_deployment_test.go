@@ -71,5 +71,32 @@ var _ = Describe("DeleteDeploymentCmd", func() {
 			Expect(err).To(HaveOccurred())
 			Expect(err.Error()).To(ContainSubstring("fake-err"))
 		})
+
+		Context("when no-track is set", func() {
+			BeforeEach(func() {
+				opts.NoTrack = true
+			})
+
+			It("starts task and prints it without waiting for it to finish", func() {
+				task := &fakedir.FakeTask{}
+				task.IDReturns(123)
+				deployment.DeleteAsyncReturns(task, nil)
+
+				err := act()
+				Expect(err).ToNot(HaveOccurred())
+
+				Expect(deployment.DeleteAsyncCallCount()).To(Equal(1))
+				Expect(deployment.DeleteCallCount()).To(Equal(0))
+				Expect(ui.Table.Content).To(Equal("tasks"))
+			})
+
+			It("returns error if starting task failed", func() {
+				deployment.DeleteAsyncReturns(nil, errors.New("fake-err"))
+
+				err := act()
+				Expect(err).To(HaveOccurred())
+				Expect(err.Error()).To(ContainSubstring("fake-err"))
+			})
+		})
 	})
 })
@@ -13,6 +13,7 @@ type DeployCmd struct {
 	ui              boshui.UI
 	deployment      boshdir.Deployment
 	releaseUploader ReleaseUploader
+	validator       PropertiesValidator
 }
 
 type ReleaseUploader interface {
@@ -24,8 +25,9 @@ func NewDeployCmd(
 	ui boshui.UI,
 	deployment boshdir.Deployment,
 	releaseUploader ReleaseUploader,
+	validator PropertiesValidator,
 ) DeployCmd {
-	return DeployCmd{ui, deployment, releaseUploader}
+	return DeployCmd{ui, deployment, releaseUploader, validator}
 }
 
 func (c DeployCmd) Run(opts DeployOpts) error {
@@ -36,11 +38,23 @@ func (c DeployCmd) Run(opts DeployOpts) error {
 		return bosherr.WrapErrorf(err, "Evaluating manifest")
 	}
 
-	err = c.checkDeploymentName(bytes)
+	err = checkDeploymentName(bytes, c.deployment)
 	if err != nil {
 		return err
 	}
 
+	// Validate before uploading releases so that problems are reported early
+	if opts.ValidateProperties {
+		problems, err := c.validator.Validate(bytes)
+		if err != nil {
+			return bosherr.WrapErrorf(err, "Validating job properties")
+		}
+
+		if len(problems) > 0 {
+			return PropertyProblemsTable{Problems: problems, UI: c.ui}.Print()
+		}
+	}
+
 	if opts.FixReleases {
 		bytes, err = c.releaseUploader.UploadReleasesWithFix(bytes)
 	} else {
@@ -56,7 +70,11 @@ func (c DeployCmd) Run(opts DeployOpts) error {
 	}
 
 	diff := NewDiff(deploymentDiff.Diff)
-	diff.Print(c.ui)
+
+	err = diff.PrintFormat(c.ui, opts.DiffFormat)
+	if err != nil {
+		return err
+	}
 
 	err = c.ui.AskForConfirmation()
 	if err != nil {
@@ -74,18 +92,29 @@ func (c DeployCmd) Run(opts DeployOpts) error {
 		Diff:                    deploymentDiff,
 	}
 
+	if opts.NoTrack {
+		task, err := c.deployment.UpdateAsync(bytes, updateOpts)
+		if err != nil {
+			return err
+		}
+
+		StartedTaskTable{Task: task, UI: c.ui}.Print()
+
+		return nil
+	}
+
 	return c.deployment.Update(bytes, updateOpts)
 }
 
-func (c DeployCmd) checkDeploymentName(bytes []byte) error {
+func checkDeploymentName(bytes []byte, deployment boshdir.Deployment) error {
 	manifest, err := boshdir.NewManifestFromBytes(bytes)
 	if err != nil {
 		return bosherr.WrapErrorf(err, "Parsing manifest")
 	}
 
-	if manifest.Name != c.deployment.Name() {
+	if manifest.Name != deployment.Name() {
 		errMsg := "Expected manifest to specify deployment name '%s' but was '%s'"
-		return bosherr.Errorf(errMsg, c.deployment.Name(), manifest.Name)
+		return bosherr.Errorf(errMsg, deployment.Name(), manifest.Name)
 	}
 
 	return nil
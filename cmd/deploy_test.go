@@ -21,6 +21,7 @@ var _ = Describe("DeployCmd", func() {
 		ui              *fakeui.FakeUI
 		deployment      *fakedir.FakeDeployment
 		releaseUploader *fakecmd.FakeReleaseUploader
+		validator       *fakecmd.FakePropertiesValidator
 		command         DeployCmd
 	)
 
@@ -34,7 +35,9 @@ var _ = Describe("DeployCmd", func() {
 			UploadReleasesStub: func(bytes []byte) ([]byte, error) { return bytes, nil },
 		}
 
-		command = NewDeployCmd(ui, deployment, releaseUploader)
+		validator = &fakecmd.FakePropertiesValidator{}
+
+		command = NewDeployCmd(ui, deployment, releaseUploader, validator)
 	})
 
 	Describe("Run", func() {
@@ -258,6 +261,31 @@ releases:
 			Expect(ui.Said).To(ContainElement("- some line that was removed\n"))
 		})
 
+		It("prints the diff in requested format", func() {
+			diff := [][]interface{}{
+				[]interface{}{"some line that was removed", "removed"},
+				[]interface{}{"some line that was added", "added"},
+			}
+
+			deployment.DiffReturns(boshdir.NewDeploymentDiff(diff, nil), nil)
+
+			opts.DiffFormat = "side-by-side"
+
+			err := act()
+			Expect(err).ToNot(HaveOccurred())
+			Expect(ui.Said).To(ContainElement("- some line that was removed | + some line that was added\n"))
+		})
+
+		It("returns an error and does not deploy if diff format is unknown", func() {
+			opts.DiffFormat = "unknown"
+
+			err := act()
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("Unknown diff format 'unknown'"))
+
+			Expect(deployment.UpdateCallCount()).To(Equal(0))
+		})
+
 		It("deploys manifest with diff context", func() {
 			context := map[string]interface{}{
 				"cloud_config_id":   2,
@@ -281,5 +309,83 @@ releases:
 			Expect(err).To(HaveOccurred())
 			Expect(err.Error()).To(ContainSubstring("fake-err"))
 		})
+
+		It("does not validate job properties by default", func() {
+			err := act()
+			Expect(err).ToNot(HaveOccurred())
+
+			Expect(validator.ValidateCallCount()).To(Equal(0))
+		})
+
+		Context("when validate-properties is set", func() {
+			BeforeEach(func() {
+				opts.ValidateProperties = true
+				releaseUploader.UploadReleasesReturns([]byte("name: dep\nuploaded: true\n"), nil)
+			})
+
+			It("validates job properties of manifest before uploading releases and deploying", func() {
+				err := act()
+				Expect(err).ToNot(HaveOccurred())
+
+				Expect(validator.ValidateCallCount()).To(Equal(1))
+				Expect(validator.ValidateArgsForCall(0)).To(Equal([]byte("name: dep\n")))
+
+				Expect(releaseUploader.UploadReleasesCallCount()).To(Equal(1))
+				Expect(deployment.UpdateCallCount()).To(Equal(1))
+			})
+
+			It("prints problems and does not deploy if job properties are invalid", func() {
+				validator.ValidateReturns([]PropertyProblem{
+					{InstanceGroup: "web", Job: "nginx", Property: "prot", Problem: "Unknown property (did you mean 'port'?)"},
+				}, nil)
+
+				err := act()
+				Expect(err).To(HaveOccurred())
+				Expect(err.Error()).To(Equal("Found 1 problem(s) in job properties"))
+
+				Expect(ui.Table.Rows).To(HaveLen(1))
+				Expect(releaseUploader.UploadReleasesCallCount()).To(Equal(0))
+				Expect(deployment.DiffCallCount()).To(Equal(0))
+				Expect(deployment.UpdateCallCount()).To(Equal(0))
+			})
+
+			It("returns error and does not deploy if validating fails", func() {
+				validator.ValidateReturns(nil, errors.New("fake-err"))
+
+				err := act()
+				Expect(err).To(HaveOccurred())
+				Expect(err.Error()).To(ContainSubstring("fake-err"))
+
+				Expect(releaseUploader.UploadReleasesCallCount()).To(Equal(0))
+				Expect(deployment.UpdateCallCount()).To(Equal(0))
+			})
+		})
+
+		Context("when no-track is set", func() {
+			BeforeEach(func() {
+				opts.NoTrack = true
+			})
+
+			It("starts task and prints it without waiting for it to finish", func() {
+				task := &fakedir.FakeTask{}
+				task.IDReturns(123)
+				deployment.UpdateAsyncReturns(task, nil)
+
+				err := act()
+				Expect(err).ToNot(HaveOccurred())
+
+				Expect(deployment.UpdateAsyncCallCount()).To(Equal(1))
+				Expect(deployment.UpdateCallCount()).To(Equal(0))
+				Expect(ui.Table.Content).To(Equal("tasks"))
+			})
+
+			It("returns error if starting task failed", func() {
+				deployment.UpdateAsyncReturns(nil, errors.New("fake-err"))
+
+				err := act()
+				Expect(err).To(HaveOccurred())
+				Expect(err.Error()).To(ContainSubstring("fake-err"))
+			})
+		})
 	})
 })
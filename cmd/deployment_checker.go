@@ -0,0 +1,218 @@
+package cmd
+
+import (
+	bihttpagent "github.com/cloudfoundry/bosh-agent/agentclient/http"
+	bosherr "github.com/cloudfoundry/bosh-utils/errors"
+	boshlog "github.com/cloudfoundry/bosh-utils/logger"
+	"github.com/cppforlife/go-patch/patch"
+
+	bicloud "github.com/cloudfoundry/bosh-cli/cloud"
+	biconfig "github.com/cloudfoundry/bosh-cli/config"
+	bicpirel "github.com/cloudfoundry/bosh-cli/cpi/release"
+	bidepl "github.com/cloudfoundry/bosh-cli/deployment"
+	bidisk "github.com/cloudfoundry/bosh-cli/deployment/disk"
+	bivm "github.com/cloudfoundry/bosh-cli/deployment/vm"
+	boshtpl "github.com/cloudfoundry/bosh-cli/director/template"
+	biinstall "github.com/cloudfoundry/bosh-cli/installation"
+	biinstallmanifest "github.com/cloudfoundry/bosh-cli/installation/manifest"
+	birelsetmanifest "github.com/cloudfoundry/bosh-cli/release/set/manifest"
+	biui "github.com/cloudfoundry/bosh-cli/ui"
+)
+
+type DeploymentChecker interface {
+	CheckDeployment(repair bool, stage biui.Stage) ([]bidepl.Drift, error)
+}
+
+func NewDeploymentChecker(
+	ui biui.UI,
+	logTag string,
+	logger boshlog.Logger,
+	deploymentStateService biconfig.DeploymentStateService,
+	releaseManager biinstall.ReleaseManager,
+	cloudFactory bicloud.Factory,
+	agentClientFactory bihttpagent.AgentClientFactory,
+	vmManagerFactory bivm.ManagerFactory,
+	diskManagerFactory bidisk.ManagerFactory,
+	deploymentRepo biconfig.DeploymentRepo,
+	vmRepo biconfig.VMRepo,
+	diskRepo biconfig.DiskRepo,
+	deploymentManifestPath string,
+	deploymentVars boshtpl.Variables,
+	deploymentOp patch.Op,
+	cpiInstaller bicpirel.CpiInstaller,
+	releaseFetcher biinstall.ReleaseFetcher,
+	releaseSetAndInstallationManifestParser ReleaseSetAndInstallationManifestParser,
+	tempRootConfigurator TempRootConfigurator,
+	targetProvider biinstall.TargetProvider,
+) DeploymentChecker {
+	return &deploymentChecker{
+		ui:                                      ui,
+		logTag:                                  logTag,
+		logger:                                  logger,
+		deploymentStateService:                  deploymentStateService,
+		releaseManager:                          releaseManager,
+		cloudFactory:                            cloudFactory,
+		agentClientFactory:                      agentClientFactory,
+		vmManagerFactory:                        vmManagerFactory,
+		diskManagerFactory:                      diskManagerFactory,
+		deploymentRepo:                          deploymentRepo,
+		vmRepo:                                  vmRepo,
+		diskRepo:                                diskRepo,
+		deploymentManifestPath:                  deploymentManifestPath,
+		deploymentVars:                          deploymentVars,
+		deploymentOp:                            deploymentOp,
+		cpiInstaller:                            cpiInstaller,
+		releaseFetcher:                          releaseFetcher,
+		releaseSetAndInstallationManifestParser: releaseSetAndInstallationManifestParser,
+		tempRootConfigurator:                    tempRootConfigurator,
+		targetProvider:                          targetProvider,
+	}
+}
+
+type deploymentChecker struct {
+	ui                                      biui.UI
+	logTag                                  string
+	logger                                  boshlog.Logger
+	deploymentStateService                  biconfig.DeploymentStateService
+	releaseManager                          biinstall.ReleaseManager
+	cloudFactory                            bicloud.Factory
+	agentClientFactory                      bihttpagent.AgentClientFactory
+	vmManagerFactory                        bivm.ManagerFactory
+	diskManagerFactory                      bidisk.ManagerFactory
+	deploymentRepo                          biconfig.DeploymentRepo
+	vmRepo                                  biconfig.VMRepo
+	diskRepo                                biconfig.DiskRepo
+	deploymentManifestPath                  string
+	deploymentVars                          boshtpl.Variables
+	deploymentOp                            patch.Op
+	cpiInstaller                            bicpirel.CpiInstaller
+	releaseFetcher                          biinstall.ReleaseFetcher
+	releaseSetAndInstallationManifestParser ReleaseSetAndInstallationManifestParser
+	tempRootConfigurator                    TempRootConfigurator
+	targetProvider                          biinstall.TargetProvider
+}
+
+func (c *deploymentChecker) CheckDeployment(repair bool, stage biui.Stage) ([]bidepl.Drift, error) {
+	c.ui.BeginLinef("Deployment state: '%s'\n", c.deploymentStateService.Path())
+
+	if !c.deploymentStateService.Exists() {
+		return nil, bosherr.Errorf("Expected deployment state '%s' to exist", c.deploymentStateService.Path())
+	}
+
+	deploymentState, err := c.deploymentStateService.Load()
+	if err != nil {
+		return nil, bosherr.WrapError(err, "Loading deployment state")
+	}
+
+	target, err := c.targetProvider.NewTarget()
+	if err != nil {
+		return nil, bosherr.WrapError(err, "Determining installation target")
+	}
+
+	err = c.tempRootConfigurator.PrepareAndSetTempRoot(target.TmpPath(), c.logger)
+	if err != nil {
+		return nil, bosherr.WrapError(err, "Setting temp root")
+	}
+
+	defer func() {
+		err := c.releaseManager.DeleteAll()
+		if err != nil {
+			c.logger.Warn(c.logTag, "Deleting all extracted releases: %s", err.Error())
+		}
+	}()
+
+	var installationManifest biinstallmanifest.Manifest
+
+	err = stage.PerformComplex("validating", func(stage biui.Stage) error {
+		var releaseSetManifest birelsetmanifest.Manifest
+		releaseSetManifest, installationManifest, err = c.releaseSetAndInstallationManifestParser.ReleaseSetAndInstallationManifest(c.deploymentManifestPath, c.deploymentVars, c.deploymentOp)
+		if err != nil {
+			return err
+		}
+
+		cpiReleaseName := installationManifest.Template.Release
+		cpiReleaseRef, found := releaseSetManifest.FindByName(cpiReleaseName)
+		if !found {
+			return bosherr.Errorf("installation release '%s' must refer to a release in releases", cpiReleaseName)
+		}
+
+		err = c.releaseFetcher.DownloadAndExtract(cpiReleaseRef, stage)
+		if err != nil {
+			return err
+		}
+
+		return c.cpiInstaller.ValidateCpiRelease(installationManifest, stage)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	var drifts []bidepl.Drift
+
+	err = c.cpiInstaller.WithInstalledCpiRelease(installationManifest, target, stage, func(localCpiInstallation biinstall.Installation) error {
+		return localCpiInstallation.WithRunningRegistry(c.logger, stage, func() error {
+			driftChecker, err := c.driftChecker(localCpiInstallation, deploymentState, installationManifest)
+			if err != nil {
+				return err
+			}
+
+			err = stage.Perform("Checking environment against state", func() error {
+				drifts, err = driftChecker.Check()
+				return err
+			})
+			if err != nil {
+				return err
+			}
+
+			if !repair || len(drifts) == 0 {
+				return nil
+			}
+
+			return stage.PerformComplex("repairing environment", func(repairStage biui.Stage) error {
+				drifts, err = driftChecker.Repair(drifts, repairStage)
+				return err
+			})
+		})
+	})
+
+	return drifts, err
+}
+
+func (c *deploymentChecker) driftChecker(
+	installation biinstall.Installation,
+	deploymentState biconfig.DeploymentState,
+	installationManifest biinstallmanifest.Manifest,
+) (bidepl.DriftChecker, error) {
+	stemcellApiVersion := 1
+	for _, s := range deploymentState.Stemcells {
+		if deploymentState.CurrentStemcellID == s.ID {
+			stemcellApiVersion = s.ApiVersion
+			break
+		}
+	}
+
+	c.logger.Debug(c.logTag, "Creating cloud client...")
+
+	cloud, err := c.cloudFactory.NewCloud(installation, deploymentState.DirectorID, stemcellApiVersion)
+	if err != nil {
+		return nil, bosherr.WrapError(err, "Creating CPI client from CPI installation")
+	}
+
+	c.logger.Debug(c.logTag, "Creating agent client...")
+
+	agentClient, err := c.agentClientFactory.NewAgentClient(
+		deploymentState.DirectorID, installationManifest.Mbus, installationManifest.Cert.CA)
+	if err != nil {
+		return nil, bosherr.WrapError(err, "Creating agent client")
+	}
+
+	return bidepl.NewDriftChecker(
+		cloud,
+		c.vmManagerFactory.NewManager(cloud, agentClient),
+		c.diskManagerFactory.NewManager(cloud),
+		c.deploymentRepo,
+		c.vmRepo,
+		c.diskRepo,
+		c.logger,
+	), nil
+}
@@ -136,48 +136,11 @@ func (c *DeploymentPreparer) PrepareDeployment(stage biui.Stage, recreate bool,
 		}
 	}()
 
-	var (
-		extractedStemcell    bistemcell.ExtractedStemcell
-		deploymentManifest   bideplmanifest.Manifest
-		installationManifest biinstallmanifest.Manifest
-		manifestSHA          string
-	)
-	err = stage.PerformComplex("validating", func(stage biui.Stage) error {
-		var releaseSetManifest birelsetmanifest.Manifest
-		releaseSetManifest, installationManifest, err = c.releaseSetAndInstallationManifestParser.ReleaseSetAndInstallationManifest(c.deploymentManifestPath, c.deploymentVars, c.deploymentOp)
-		if err != nil {
-			return err
-		}
-
-		for _, releaseRef := range releaseSetManifest.Releases {
-			err = c.releaseFetcher.DownloadAndExtract(releaseRef, stage)
-			if err != nil {
-				return err
-			}
-		}
-
-		err := c.cpiInstaller.ValidateCpiRelease(installationManifest, stage)
-		if err != nil {
-			return err
-		}
-
-		deploymentManifest, manifestSHA, err = c.deploymentManifestParser.GetDeploymentManifest(c.deploymentManifestPath, c.deploymentVars, c.deploymentOp, releaseSetManifest, stage)
-		if err != nil {
-			return err
-		}
-
-		extractedStemcell, err = c.stemcellFetcher.GetStemcell(deploymentManifest, stage)
-		return err
-	})
+	installationManifest, deploymentManifest, manifestSHA, extractedStemcell, err := c.validate(stage)
 	if err != nil {
 		return err
 	}
-	defer func() {
-		deleteErr := extractedStemcell.Cleanup()
-		if deleteErr != nil {
-			c.logger.Warn(c.logTag, "Failed to delete extracted stemcell: %s", deleteErr.Error())
-		}
-	}()
+	defer c.cleanupStemcell(extractedStemcell)
 
 	isDeployed, err := c.deploymentRecord.IsDeployed(manifestSHA, c.releaseManager.List(), extractedStemcell)
 	if err != nil {
@@ -206,6 +169,7 @@ func (c *DeploymentPreparer) PrepareDeployment(stage biui.Stage, recreate bool,
 				deploymentManifest,
 				manifestSHA,
 				skipDrain,
+				recreate || recreatePersistentDisks,
 				stage,
 				cloud,
 				usesRegistry)
@@ -228,6 +192,108 @@ func (c *DeploymentPreparer) PrepareDeployment(stage biui.Stage, recreate bool,
 	return err
 }
 
+// PlanDeployment validates manifests and compares them with deployment state
+// to determine which steps PrepareDeployment would perform. CPI is not used.
+func (c *DeploymentPreparer) PlanDeployment(stage biui.Stage, recreate bool, recreatePersistentDisks bool) (bidepl.Plan, error) {
+	c.ui.BeginLinef("Deployment state: '%s'\n", c.deploymentStateService.Path())
+
+	if !c.deploymentStateService.Exists() {
+		// Loading state initializes and saves new state; dry run should leave no trace
+		defer func() {
+			err := c.deploymentStateService.Cleanup()
+			if err != nil {
+				c.logger.Warn(c.logTag, "Deleting deployment state initialized for plan: %s", err.Error())
+			}
+		}()
+	}
+
+	deploymentState, err := c.deploymentStateService.Load()
+	if err != nil {
+		return bidepl.Plan{}, bosherr.WrapError(err, "Loading deployment state")
+	}
+
+	target, err := c.targetProvider.NewTarget()
+	if err != nil {
+		return bidepl.Plan{}, bosherr.WrapError(err, "Determining installation target")
+	}
+
+	err = c.tempRootConfigurator.PrepareAndSetTempRoot(target.TmpPath(), c.logger)
+	if err != nil {
+		return bidepl.Plan{}, bosherr.WrapError(err, "Setting temp root")
+	}
+
+	defer func() {
+		err := c.releaseManager.DeleteAll()
+		if err != nil {
+			c.logger.Warn(c.logTag, "Deleting all extracted releases: %s", err.Error())
+		}
+	}()
+
+	installationManifest, deploymentManifest, manifestSHA, extractedStemcell, err := c.validate(stage)
+	if err != nil {
+		return bidepl.Plan{}, err
+	}
+	defer c.cleanupStemcell(extractedStemcell)
+
+	return bidepl.NewPlan(bidepl.PlanInput{
+		State:       deploymentState,
+		Manifest:    deploymentManifest,
+		ManifestSHA: manifestSHA,
+		Releases:    c.releaseManager.List(),
+		Stemcell:    extractedStemcell,
+
+		InstallationManifest: installationManifest,
+
+		Recreate:                recreate,
+		RecreatePersistentDisks: recreatePersistentDisks,
+	})
+}
+
+func (c *DeploymentPreparer) validate(stage biui.Stage) (
+	installationManifest biinstallmanifest.Manifest,
+	deploymentManifest bideplmanifest.Manifest,
+	manifestSHA string,
+	extractedStemcell bistemcell.ExtractedStemcell,
+	err error,
+) {
+	err = stage.PerformComplex("validating", func(stage biui.Stage) error {
+		var releaseSetManifest birelsetmanifest.Manifest
+		releaseSetManifest, installationManifest, err = c.releaseSetAndInstallationManifestParser.ReleaseSetAndInstallationManifest(c.deploymentManifestPath, c.deploymentVars, c.deploymentOp)
+		if err != nil {
+			return err
+		}
+
+		for _, releaseRef := range releaseSetManifest.Releases {
+			err = c.releaseFetcher.DownloadAndExtract(releaseRef, stage)
+			if err != nil {
+				return err
+			}
+		}
+
+		err := c.cpiInstaller.ValidateCpiRelease(installationManifest, stage)
+		if err != nil {
+			return err
+		}
+
+		deploymentManifest, manifestSHA, err = c.deploymentManifestParser.GetDeploymentManifest(c.deploymentManifestPath, c.deploymentVars, c.deploymentOp, releaseSetManifest, stage)
+		if err != nil {
+			return err
+		}
+
+		extractedStemcell, err = c.stemcellFetcher.GetStemcell(deploymentManifest, stage)
+		return err
+	})
+
+	return
+}
+
+func (c *DeploymentPreparer) cleanupStemcell(extractedStemcell bistemcell.ExtractedStemcell) {
+	deleteErr := extractedStemcell.Cleanup()
+	if deleteErr != nil {
+		c.logger.Warn(c.logTag, "Failed to delete extracted stemcell: %s", deleteErr.Error())
+	}
+}
+
 func (c *DeploymentPreparer) deploy(
 	installation biinstall.Installation,
 	deploymentState biconfig.DeploymentState,
@@ -236,6 +302,7 @@ func (c *DeploymentPreparer) deploy(
 	deploymentManifest bideplmanifest.Manifest,
 	manifestSHA string,
 	skipDrain bool,
+	recreate bool,
 	stage biui.Stage,
 	cloud bicloud.Cloud,
 	usesRegistry bool,
@@ -272,11 +339,13 @@ func (c *DeploymentPreparer) deploy(
 		_, err = c.deployer.Deploy(
 			cloud,
 			deploymentManifest,
+			manifestSHA,
 			cloudStemcell,
 			registrySettings,
 			vmManager,
 			blobstore,
 			skipDrain,
+			recreate,
 			deployStage,
 		)
 		if err != nil {
@@ -0,0 +1,40 @@
+package cmd
+
+import (
+	bosherr "github.com/cloudfoundry/bosh-utils/errors"
+
+	. "github.com/cloudfoundry/bosh-cli/cmd/opts"
+	boshdir "github.com/cloudfoundry/bosh-cli/director"
+	boshtpl "github.com/cloudfoundry/bosh-cli/director/template"
+	boshui "github.com/cloudfoundry/bosh-cli/ui"
+)
+
+type DiffDeploymentCmd struct {
+	ui         boshui.UI
+	deployment boshdir.Deployment
+}
+
+func NewDiffDeploymentCmd(ui boshui.UI, deployment boshdir.Deployment) DiffDeploymentCmd {
+	return DiffDeploymentCmd{ui: ui, deployment: deployment}
+}
+
+func (c DiffDeploymentCmd) Run(opts DiffDeploymentOpts) error {
+	tpl := boshtpl.NewTemplate(opts.Args.Manifest.Bytes)
+
+	bytes, err := tpl.Evaluate(opts.VarFlags.AsVariables(), opts.OpsFlags.AsOp(), boshtpl.EvaluateOpts{})
+	if err != nil {
+		return bosherr.WrapErrorf(err, "Evaluating manifest")
+	}
+
+	err = checkDeploymentName(bytes, c.deployment)
+	if err != nil {
+		return err
+	}
+
+	deploymentDiff, err := c.deployment.Diff(bytes, opts.NoRedact)
+	if err != nil {
+		return err
+	}
+
+	return NewDiff(deploymentDiff.Diff).PrintFormat(c.ui, opts.DiffFormat)
+}
@@ -0,0 +1,126 @@
+package cmd_test
+
+import (
+	"errors"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	. "github.com/cloudfoundry/bosh-cli/cmd"
+	. "github.com/cloudfoundry/bosh-cli/cmd/opts"
+	boshdir "github.com/cloudfoundry/bosh-cli/director"
+	fakedir "github.com/cloudfoundry/bosh-cli/director/directorfakes"
+	fakeui "github.com/cloudfoundry/bosh-cli/ui/fakes"
+)
+
+var _ = Describe("DiffDeploymentCmd", func() {
+	var (
+		ui         *fakeui.FakeUI
+		deployment *fakedir.FakeDeployment
+		command    DiffDeploymentCmd
+	)
+
+	BeforeEach(func() {
+		ui = &fakeui.FakeUI{}
+		deployment = &fakedir.FakeDeployment{
+			NameStub: func() string { return "dep" },
+		}
+		command = NewDiffDeploymentCmd(ui, deployment)
+	})
+
+	Describe("Run", func() {
+		var (
+			opts DiffDeploymentOpts
+		)
+
+		BeforeEach(func() {
+			opts = DiffDeploymentOpts{
+				Args: DeployArgs{
+					Manifest: FileBytesArg{Bytes: []byte("name: dep")},
+				},
+			}
+
+			diff := [][]interface{}{
+				[]interface{}{"instance_groups:", ""},
+				[]interface{}{"- name: web", ""},
+				[]interface{}{"  instances: 1", "removed"},
+				[]interface{}{"  instances: 2", "added"},
+			}
+
+			deployment.DiffReturns(boshdir.NewDeploymentDiff(diff, nil), nil)
+		})
+
+		act := func() error { return command.Run(opts) }
+
+		It("prints unified diff without deploying", func() {
+			opts.NoRedact = true
+
+			err := act()
+			Expect(err).ToNot(HaveOccurred())
+
+			Expect(deployment.DiffCallCount()).To(Equal(1))
+
+			bytes, noRedact := deployment.DiffArgsForCall(0)
+			Expect(bytes).To(Equal([]byte("name: dep\n")))
+			Expect(noRedact).To(BeTrue())
+
+			Expect(ui.Said).To(Equal([]string{
+				"  instance_groups:\n",
+				"  - name: web\n",
+				"-   instances: 1\n",
+				"+   instances: 2\n",
+			}))
+
+			Expect(deployment.UpdateCallCount()).To(Equal(0))
+		})
+
+		It("prints diff changes as JSON", func() {
+			opts.DiffFormat = "json"
+
+			err := act()
+			Expect(err).ToNot(HaveOccurred())
+
+			Expect(ui.Blocks).To(HaveLen(1))
+			Expect(ui.Blocks[0]).To(MatchJSON(`{
+				"changes": [{
+					"type": "changed",
+					"path": ["instance_groups", "web", "instances"],
+					"instance_group": "web",
+					"old": ["  instances: 1"],
+					"new": ["  instances: 2"]
+				}]
+			}`))
+		})
+
+		It("prints side-by-side diff", func() {
+			opts.DiffFormat = "side-by-side"
+
+			err := act()
+			Expect(err).ToNot(HaveOccurred())
+
+			Expect(ui.Said).To(Equal([]string{
+				"  instance_groups: |   instance_groups:\n",
+				"  - name: web      |   - name: web\n",
+				"-   instances: 1   | +   instances: 2\n",
+			}))
+		})
+
+		It("returns an error if manifest name does not match deployment", func() {
+			opts.Args.Manifest = FileBytesArg{Bytes: []byte("name: other-dep")}
+
+			err := act()
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("Expected manifest to specify deployment name 'dep' but was 'other-dep'"))
+
+			Expect(deployment.DiffCallCount()).To(Equal(0))
+		})
+
+		It("returns an error if diffing failed", func() {
+			deployment.DiffReturns(boshdir.DeploymentDiff{}, errors.New("fake-err"))
+
+			err := act()
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("fake-err"))
+		})
+	})
+})
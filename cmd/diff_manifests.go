@@ -0,0 +1,83 @@
+package cmd
+
+import (
+	bosherr "github.com/cloudfoundry/bosh-utils/errors"
+
+	. "github.com/cloudfoundry/bosh-cli/cmd/opts"
+	boshdir "github.com/cloudfoundry/bosh-cli/director"
+	boshtpl "github.com/cloudfoundry/bosh-cli/director/template"
+	boshui "github.com/cloudfoundry/bosh-cli/ui"
+)
+
+type DiffManifestsCmd struct {
+	ui boshui.UI
+}
+
+func NewDiffManifestsCmd(ui boshui.UI) DiffManifestsCmd {
+	return DiffManifestsCmd{ui: ui}
+}
+
+func (c DiffManifestsCmd) Run(opts DiffManifestsOpts, deploymentFetcher func() (boshdir.Deployment, error)) error {
+	var from, to boshdir.ManifestDiffInput
+
+	if opts.Args.To.Bytes != nil {
+		var err error
+
+		from, err = c.evaluate(opts.Args.From.Bytes, opts)
+		if err != nil {
+			return bosherr.WrapErrorf(err, "Evaluating first manifest")
+		}
+
+		to, err = c.evaluate(opts.Args.To.Bytes, opts)
+		if err != nil {
+			return bosherr.WrapErrorf(err, "Evaluating second manifest")
+		}
+	} else {
+		deployment, err := deploymentFetcher()
+		if err != nil {
+			return err
+		}
+
+		manifest, err := deployment.Manifest()
+		if err != nil {
+			return err
+		}
+
+		from = boshdir.ManifestDiffInput{Manifest: []byte(manifest)}
+
+		to, err = c.evaluate(opts.Args.From.Bytes, opts)
+		if err != nil {
+			return bosherr.WrapErrorf(err, "Evaluating manifest")
+		}
+
+		// Director keeps manifests with variables hence
+		// local manifest is compared before interpolation
+		to.Manifest = to.Uninterpolated
+	}
+
+	diff, err := boshdir.DiffManifests(from, to, !opts.NoRedact)
+	if err != nil {
+		return err
+	}
+
+	return NewDiff(diff.Diff).PrintFormat(c.ui, opts.DiffFormat)
+}
+
+// evaluate interpolates manifest with and without variables
+// so that values coming from variables can be redacted.
+// Missing variables are not generated since diffing must not change vars store.
+func (c DiffManifestsCmd) evaluate(bytes []byte, opts DiffManifestsOpts) (boshdir.ManifestDiffInput, error) {
+	tpl := boshtpl.NewTemplate(bytes)
+
+	manifest, err := tpl.Evaluate(opts.VarFlags.AsNonGeneratingVariables(), opts.OpsFlags.AsOp(), boshtpl.EvaluateOpts{})
+	if err != nil {
+		return boshdir.ManifestDiffInput{}, err
+	}
+
+	uninterpolated, err := tpl.Evaluate(boshtpl.StaticVariables{}, opts.OpsFlags.AsOp(), boshtpl.EvaluateOpts{})
+	if err != nil {
+		return boshdir.ManifestDiffInput{}, err
+	}
+
+	return boshdir.ManifestDiffInput{Manifest: manifest, Uninterpolated: uninterpolated}, nil
+}
@@ -0,0 +1,169 @@
+package cmd_test
+
+import (
+	"errors"
+
+	fakesys "github.com/cloudfoundry/bosh-utils/system/fakes"
+	"github.com/cppforlife/go-patch/patch"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	. "github.com/cloudfoundry/bosh-cli/cmd"
+	. "github.com/cloudfoundry/bosh-cli/cmd/opts"
+	boshdir "github.com/cloudfoundry/bosh-cli/director"
+	fakedir "github.com/cloudfoundry/bosh-cli/director/directorfakes"
+	boshtpl "github.com/cloudfoundry/bosh-cli/director/template"
+	fakeui "github.com/cloudfoundry/bosh-cli/ui/fakes"
+)
+
+var _ = Describe("DiffManifestsCmd", func() {
+	var (
+		ui         *fakeui.FakeUI
+		deployment *fakedir.FakeDeployment
+		command    DiffManifestsCmd
+	)
+
+	BeforeEach(func() {
+		ui = &fakeui.FakeUI{}
+		deployment = &fakedir.FakeDeployment{}
+		command = NewDiffManifestsCmd(ui)
+	})
+
+	Describe("Run", func() {
+		var (
+			opts            DiffManifestsOpts
+			deploymentCalls int
+		)
+
+		BeforeEach(func() {
+			deploymentCalls = 0
+
+			opts = DiffManifestsOpts{
+				Args: DiffManifestsArgs{
+					From: FileBytesArg{Bytes: []byte("name: dep\npassword: ((password))\nport: 80\n")},
+					To:   FileBytesArg{Bytes: []byte("name: dep\npassword: ((password))\nport: 8080\n")},
+				},
+				VarFlags: VarFlags{
+					VarKVs: []boshtpl.VarKV{{Name: "password", Value: "secret"}},
+				},
+			}
+		})
+
+		act := func() error {
+			return command.Run(opts, func() (boshdir.Deployment, error) {
+				deploymentCalls++
+				return deployment, nil
+			})
+		}
+
+		It("prints diff between two interpolated manifests without fetching deployment", func() {
+			err := act()
+			Expect(err).ToNot(HaveOccurred())
+
+			Expect(ui.Said).To(Equal([]string{
+				"- port: 80\n",
+				"+ port: 8080\n",
+			}))
+
+			Expect(deploymentCalls).To(Equal(0))
+		})
+
+		It("shows values coming from variables if redaction is disabled", func() {
+			opts.Args.To.Bytes = []byte("name: dep\npassword: other\nport: 80\n")
+			opts.NoRedact = true
+
+			err := act()
+			Expect(err).ToNot(HaveOccurred())
+
+			Expect(ui.Said).To(Equal([]string{
+				"- password: secret\n",
+				"+ password: other\n",
+			}))
+		})
+
+		It("redacts changed values coming from variables", func() {
+			opts.Args.To.Bytes = []byte("name: dep\npassword: other\nport: 80\n")
+
+			err := act()
+			Expect(err).ToNot(HaveOccurred())
+
+			Expect(ui.Said).To(Equal([]string{
+				"- password: <redacted>\n",
+				"+ password: other\n",
+			}))
+		})
+
+		It("prints diff in requested format", func() {
+			opts.DiffFormat = "json"
+
+			err := act()
+			Expect(err).ToNot(HaveOccurred())
+
+			Expect(ui.Blocks).To(HaveLen(1))
+			Expect(ui.Blocks[0]).To(MatchJSON(`{
+				"changes": [{
+					"type": "changed",
+					"path": ["port"],
+					"old": ["port: 80"],
+					"new": ["port: 8080"]
+				}]
+			}`))
+		})
+
+		It("does not generate missing variables in vars store", func() {
+			fs := fakesys.NewFakeFileSystem()
+
+			opts.VarsStore = VarsStore{FS: fs}
+			err := opts.VarsStore.UnmarshalFlag("/creds.yml")
+			Expect(err).ToNot(HaveOccurred())
+
+			opts.Args.To.Bytes = []byte("name: dep\npassword: ((other))\nvariables:\n- name: other\n  type: password\n")
+
+			err = act()
+			Expect(err).ToNot(HaveOccurred())
+
+			Expect(fs.FileExists("/creds.yml")).To(BeFalse())
+		})
+
+		It("returns an error if manifest cannot be evaluated", func() {
+			opts.OpsFlags = OpsFlags{
+				OpsFiles: []OpsFileArg{{
+					Ops: patch.Ops{patch.ReplaceOp{Path: patch.MustNewPointerFromString("/missing/key"), Value: "x"}},
+				}},
+			}
+
+			err := act()
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("Evaluating first manifest"))
+		})
+
+		Context("when second manifest is not given", func() {
+			BeforeEach(func() {
+				opts.Args.To = FileBytesArg{}
+
+				deployment.ManifestReturns("name: dep\npassword: ((password))\nport: 8080\n", nil)
+			})
+
+			It("compares current deployment manifest to given manifest", func() {
+				err := act()
+				Expect(err).ToNot(HaveOccurred())
+
+				Expect(deploymentCalls).To(Equal(1))
+				Expect(deployment.ManifestCallCount()).To(Equal(1))
+
+				Expect(ui.Said).To(Equal([]string{
+					"- port: 8080\n",
+					"+ port: 80\n",
+				}))
+			})
+
+			It("returns an error if fetching manifest fails", func() {
+				deployment.ManifestReturns("", errors.New("fake-err"))
+
+				err := act()
+				Expect(err).To(HaveOccurred())
+				Expect(err.Error()).To(ContainSubstring("fake-err"))
+			})
+		})
+	})
+})
@@ -0,0 +1,50 @@
+package cmd
+
+import (
+	bosherr "github.com/cloudfoundry/bosh-utils/errors"
+	boshsys "github.com/cloudfoundry/bosh-utils/system"
+
+	. "github.com/cloudfoundry/bosh-cli/cmd/opts"
+	bicrypto "github.com/cloudfoundry/bosh-cli/crypto"
+	boshui "github.com/cloudfoundry/bosh-cli/ui"
+)
+
+type EncryptVarsStoreCmd struct {
+	fs boshsys.FileSystem
+	ui boshui.UI
+}
+
+func NewEncryptVarsStoreCmd(fs boshsys.FileSystem, ui boshui.UI) EncryptVarsStoreCmd {
+	return EncryptVarsStoreCmd{fs: fs, ui: ui}
+}
+
+func (c EncryptVarsStoreCmd) Run(opts EncryptVarsStoreOpts) error {
+	if !opts.IsSet() {
+		return bosherr.Error("Expected encryption key to be provided via '--encryption-key-file' or BOSH_ENCRYPTION_KEY")
+	}
+
+	path := opts.Args.Path.ExpandedPath
+
+	contents, err := c.fs.ReadFile(path)
+	if err != nil {
+		return bosherr.WrapErrorf(err, "Reading file '%s'", path)
+	}
+
+	if bicrypto.IsEncrypted(contents) {
+		return bosherr.Errorf("File '%s' is already encrypted", path)
+	}
+
+	encrypted, err := opts.AsEncryptor().Encrypt(contents)
+	if err != nil {
+		return bosherr.WrapErrorf(err, "Encrypting file '%s'", path)
+	}
+
+	err = c.fs.WriteFile(path, encrypted)
+	if err != nil {
+		return bosherr.WrapErrorf(err, "Writing file '%s'", path)
+	}
+
+	c.ui.PrintLinef("Encrypted '%s'", path)
+
+	return nil
+}
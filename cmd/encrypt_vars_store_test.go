@@ -0,0 +1,97 @@
+package cmd_test
+
+import (
+	"errors"
+
+	fakesys "github.com/cloudfoundry/bosh-utils/system/fakes"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	. "github.com/cloudfoundry/bosh-cli/cmd"
+	. "github.com/cloudfoundry/bosh-cli/cmd/opts"
+	bicrypto "github.com/cloudfoundry/bosh-cli/crypto"
+	fakeui "github.com/cloudfoundry/bosh-cli/ui/fakes"
+)
+
+var _ = Describe("EncryptVarsStoreCmd", func() {
+	var (
+		fs      *fakesys.FakeFileSystem
+		ui      *fakeui.FakeUI
+		command EncryptVarsStoreCmd
+	)
+
+	BeforeEach(func() {
+		fs = fakesys.NewFakeFileSystem()
+		ui = &fakeui.FakeUI{}
+		command = NewEncryptVarsStoreCmd(fs, ui)
+	})
+
+	Describe("Run", func() {
+		var (
+			opts EncryptVarsStoreOpts
+		)
+
+		BeforeEach(func() {
+			fs.WriteFileString("/creds.yml", "password: secret\n")
+
+			opts = EncryptVarsStoreOpts{
+				Args:            EncryptVarsStoreArgs{Path: FileArg{ExpandedPath: "/creds.yml"}},
+				EncryptionFlags: EncryptionFlags{EncryptionKeyFile: FileBytesArg{Bytes: []byte("key")}},
+			}
+		})
+
+		act := func() error { return command.Run(opts) }
+
+		It("encrypts file in place", func() {
+			err := act()
+			Expect(err).ToNot(HaveOccurred())
+
+			contents, err := fs.ReadFile("/creds.yml")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(bicrypto.IsEncrypted(contents)).To(BeTrue())
+
+			decrypted, err := bicrypto.NewEncryptor([]byte("key")).Decrypt(contents)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(string(decrypted)).To(Equal("password: secret\n"))
+
+			Expect(ui.Said).To(Equal([]string{"Encrypted '/creds.yml'"}))
+		})
+
+		It("uses key from key file", func() {
+			opts.EncryptionFlags = EncryptionFlags{EncryptionKeyFile: FileBytesArg{Bytes: []byte("file-key\n")}}
+
+			err := act()
+			Expect(err).ToNot(HaveOccurred())
+
+			contents, err := fs.ReadFile("/creds.yml")
+			Expect(err).ToNot(HaveOccurred())
+
+			_, err = bicrypto.NewEncryptor([]byte("file-key")).Decrypt(contents)
+			Expect(err).ToNot(HaveOccurred())
+		})
+
+		It("returns error if encryption key is not provided", func() {
+			opts.EncryptionFlags = EncryptionFlags{}
+
+			err := act()
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("Expected encryption key"))
+		})
+
+		It("returns error if file is already encrypted", func() {
+			Expect(act()).ToNot(HaveOccurred())
+
+			err := act()
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(Equal("File '/creds.yml' is already encrypted"))
+		})
+
+		It("returns error if writing file fails", func() {
+			fs.WriteFileError = errors.New("fake-err")
+
+			err := act()
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("fake-err"))
+		})
+	})
+})
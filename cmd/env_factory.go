@@ -64,12 +64,13 @@ type envFactory struct {
 	blobstoreFactory   biblobstore.Factory
 	deploymentFactory  bidepl.Factory
 	deploymentRecord   bidepl.Record
+	checkpointRepo     biconfig.CheckpointRepo
 }
 
 func NewEnvFactory(
 	deps BasicDeps,
 	manifestPath string,
-	statePath string,
+	deploymentStateService biconfig.DeploymentStateService,
 	manifestVars boshtpl.Variables,
 	manifestOp patch.Op,
 	recreatePersistentDisks bool,
@@ -112,8 +113,7 @@ func NewEnvFactory(
 		}
 	}
 
-	f.deploymentStateService = biconfig.NewFileSystemDeploymentStateService(
-		deps.FS, deps.UUIDGen, deps.Logger, biconfig.DeploymentStatePath(manifestPath, statePath))
+	f.deploymentStateService = deploymentStateService
 
 	{
 		registryServer := biregistry.NewServerManager(deps.Logger)
@@ -135,9 +135,10 @@ func NewEnvFactory(
 		diskRepo := biconfig.NewDiskRepo(f.deploymentStateService, deps.UUIDGen)
 		stemcellRepo := biconfig.NewStemcellRepo(f.deploymentStateService, deps.UUIDGen)
 		vmRepo := biconfig.NewVMRepo(f.deploymentStateService)
+		f.checkpointRepo = biconfig.NewCheckpointRepo(f.deploymentStateService)
 
 		f.diskManagerFactory = bidisk.NewManagerFactory(diskRepo, deps.Logger)
-		diskDeployer := bivm.NewDiskDeployer(f.diskManagerFactory, diskRepo, deps.Logger, recreatePersistentDisks)
+		diskDeployer := bivm.NewDiskDeployer(f.diskManagerFactory, diskRepo, f.checkpointRepo, deps.Logger, recreatePersistentDisks)
 
 		f.stemcellManagerFactory = bistemcell.NewManagerFactory(stemcellRepo)
 		f.vmManagerFactory = bivm.NewManagerFactory(
@@ -213,6 +214,7 @@ func (f *envFactory) Preparer() DeploymentPreparer {
 			f.vmManagerFactory,
 			f.instanceManagerFactory,
 			f.deploymentFactory,
+			f.checkpointRepo,
 			f.deps.Logger,
 		),
 		f.manifestPath,
@@ -288,3 +290,28 @@ func (f *envFactory) StateManager() DeploymentStateManager {
 		),
 	)
 }
+
+func (f *envFactory) Checker() DeploymentChecker {
+	return NewDeploymentChecker(
+		f.deps.UI,
+		"DeploymentChecker",
+		f.deps.Logger,
+		f.deploymentStateService,
+		f.releaseManager,
+		f.cloudFactory,
+		f.agentClientFactory,
+		f.vmManagerFactory,
+		f.diskManagerFactory,
+		biconfig.NewDeploymentRepo(f.deploymentStateService),
+		biconfig.NewVMRepo(f.deploymentStateService),
+		biconfig.NewDiskRepo(f.deploymentStateService, f.deps.UUIDGen),
+		f.manifestPath,
+		f.manifestVars,
+		f.manifestOp,
+		f.cpiInstaller,
+		f.releaseFetcher,
+		f.installationManifestParser,
+		NewTempRootConfigurator(f.deps.FS),
+		f.targetProvider,
+	)
+}
@@ -197,6 +197,29 @@ var _ = Describe("Factory", func() {
 		})
 	})
 
+	Describe("state commands", func() {
+		It("parses nested state subcommands", func() {
+			cmd, err := factory.New([]string{"state", "set-vm-cid", "vm-cid", "--state", "/state.json"})
+			Expect(err).ToNot(HaveOccurred())
+
+			opts := cmd.Opts.(*StateSetVMCIDOpts)
+			Expect(opts.Args.CID).To(Equal("vm-cid"))
+			Expect(opts.StatePath).To(Equal("/state.json"))
+		})
+
+		It("requires state path", func() {
+			_, err := factory.New([]string{"state", "show"})
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("the required flag `--state' was not specified"))
+		})
+
+		It("requires subcommand", func() {
+			_, err := factory.New([]string{"state"})
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("Please specify one command of: forget-disk"))
+		})
+	})
+
 	Describe("deploy command", func() {
 		It("parses multiple skip-drain flags", func() {
 			cmd, err := factory.New([]string{"deploy", "--skip-drain=job1", "--skip-drain=job2", tmpFile})
@@ -372,6 +395,7 @@ var _ = Describe("Factory", func() {
 			boshOpts.RunErrand = RunErrandOpts{}
 			boshOpts.Logs = LogsOpts{}
 			boshOpts.Interpolate = InterpolateOpts{}
+			boshOpts.OpsTest = OpsTestOpts{}
 			boshOpts.InitRelease = InitReleaseOpts{}
 			boshOpts.ResetRelease = ResetReleaseOpts{}
 			boshOpts.GenerateJob = GenerateJobOpts{}
@@ -387,6 +411,11 @@ var _ = Describe("Factory", func() {
 			boshOpts.SSH = SSHOpts{}
 			boshOpts.SCP = SCPOpts{}
 			boshOpts.Deploy = DeployOpts{}
+			boshOpts.DiffDeployment = DiffDeploymentOpts{}
+			boshOpts.DiffManifests = DiffManifestsOpts{}
+			boshOpts.LintManifest = LintManifestOpts{}
+			boshOpts.RenderJob = RenderJobOpts{}
+			boshOpts.JobDocs = JobDocsOpts{}
 			boshOpts.UpdateRuntimeConfig = UpdateRuntimeConfigOpts{}
 			boshOpts.VMs = VMsOpts{}
 			boshOpts.Instances = InstancesOpts{}
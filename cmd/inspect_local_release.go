@@ -29,7 +29,21 @@ func (cmd InspectLocalReleaseCmd) Run(opts InspectLocalReleaseOpts) error {
 	}
 	defer release.CleanUp()
 
-	ReleaseTables{Release: release, ArchivePath: opts.Args.PathToRelease}.Print(cmd.ui)
+	tables := ReleaseTables{Release: release, ArchivePath: opts.Args.PathToRelease}
+
+	if opts.Links || opts.Properties {
+		if opts.Links {
+			tables.PrintLinks(cmd.ui)
+		}
+
+		if opts.Properties {
+			tables.PrintProperties(cmd.ui)
+		}
+
+		return nil
+	}
+
+	tables.Print(cmd.ui)
 
 	return nil
 }
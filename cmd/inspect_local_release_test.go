@@ -42,6 +42,11 @@ var _ = Describe("InspectLocalReleaseCmd", func() {
 				"job-digest",
 			))
 			job.PackageNames = []string{"pkg-1-name", "pkg-2-name"}
+			job.Consumes = []boshjob.LinkDefinition{{Name: "db", Type: "database", Optional: true}}
+			job.Provides = []boshjob.LinkDefinition{{Name: "api", Type: "http", Properties: []string{"port"}}}
+			job.Properties = map[string]boshjob.PropertyDefinition{
+				"port": {Description: "Port", Type: "integer", Default: 8080, Example: 443, Env: "PORT"},
+			}
 
 			pkg1 := boshpkg.NewPackage(NewResourceWithBuiltArchive(
 				"pkg-1-name",
@@ -167,6 +172,75 @@ var _ = Describe("InspectLocalReleaseCmd", func() {
 			}))
 		})
 
+		It("prints only links and properties tables when requested", func() {
+			opts.Links = true
+			opts.Properties = true
+
+			err := command.Run(opts)
+			Expect(err).ToNot(HaveOccurred())
+
+			Expect(ui.Tables).To(Equal([]boshtbl.Table{
+				{
+					Content: "links",
+					Header: []boshtbl.Header{
+						boshtbl.NewHeader("Job"),
+						boshtbl.NewHeader("Direction"),
+						boshtbl.NewHeader("Name"),
+						boshtbl.NewHeader("Type"),
+						boshtbl.NewHeader("Optional"),
+						boshtbl.NewHeader("Properties"),
+					},
+					SortBy: []boshtbl.ColumnSort{{Column: 0, Asc: true}, {Column: 1, Asc: true}, {Column: 2, Asc: true}},
+
+					Rows: [][]boshtbl.Value{
+						{
+							boshtbl.NewValueString("job-name"),
+							boshtbl.NewValueString("consumes"),
+							boshtbl.NewValueString("db"),
+							boshtbl.NewValueString("database"),
+							boshtbl.NewValueBool(true),
+							boshtbl.NewValueStrings(nil),
+						},
+						{
+							boshtbl.NewValueString("job-name"),
+							boshtbl.NewValueString("provides"),
+							boshtbl.NewValueString("api"),
+							boshtbl.NewValueString("http"),
+							boshtbl.NewValueBool(false),
+							boshtbl.NewValueStrings([]string{"port"}),
+						},
+					},
+				},
+				{
+					Content: "properties",
+					Header: []boshtbl.Header{
+						boshtbl.NewHeader("Job"),
+						boshtbl.NewHeader("Property"),
+						boshtbl.NewHeader("Type"),
+						boshtbl.NewHeader("Default"),
+						boshtbl.NewHeader("Example"),
+						boshtbl.NewHeader("Env"),
+						boshtbl.NewHeader("Description"),
+					},
+					SortBy: []boshtbl.ColumnSort{{Column: 0, Asc: true}, {Column: 1, Asc: true}},
+
+					Rows: [][]boshtbl.Value{
+						{
+							boshtbl.NewValueString("job-name"),
+							boshtbl.NewValueString("port"),
+							boshtbl.NewValueString("integer"),
+							boshtbl.NewValueInterface(8080),
+							boshtbl.NewValueInterface(443),
+							boshtbl.NewValueString("PORT"),
+							boshtbl.NewValueString("Port"),
+						},
+					},
+				},
+			}))
+
+			Expect(fakeRelease.CleanUpCallCount()).To(Equal(1))
+		})
+
 		It("returns error if reading the release manifest fails", func() {
 			releaseReader.ReadReturns(nil, errors.New("fake-err"))
 
@@ -24,6 +24,10 @@ func (c InspectReleaseCmd) Run(opts InspectReleaseOpts) error {
 		return err
 	}
 
+	if opts.Links || opts.Properties {
+		return c.printJobDetails(release, opts)
+	}
+
 	jobsTable := boshtbl.Table{
 		Content: "jobs",
 		Header: []boshtbl.Header{
@@ -98,3 +102,82 @@ func (c InspectReleaseCmd) Run(opts InspectReleaseOpts) error {
 
 	return nil
 }
+
+func (c InspectReleaseCmd) printJobDetails(release boshdir.Release, opts InspectReleaseOpts) error {
+	jobs, err := release.Jobs()
+	if err != nil {
+		return err
+	}
+
+	if opts.Links {
+		linksTable := boshtbl.Table{
+			Content: "links",
+			Header: []boshtbl.Header{
+				boshtbl.NewHeader("Job"),
+				boshtbl.NewHeader("Direction"),
+				boshtbl.NewHeader("Name"),
+				boshtbl.NewHeader("Type"),
+				boshtbl.NewHeader("Optional"),
+				boshtbl.NewHeader("Properties"),
+			},
+			SortBy: []boshtbl.ColumnSort{{Column: 0, Asc: true}, {Column: 1, Asc: true}, {Column: 2, Asc: true}},
+		}
+
+		for _, j := range jobs {
+			for _, link := range j.LinksConsumed {
+				linksTable.Rows = append(linksTable.Rows, c.linkRow(j, "consumes", link))
+			}
+
+			for _, link := range j.LinksProvided {
+				linksTable.Rows = append(linksTable.Rows, c.linkRow(j, "provides", link))
+			}
+		}
+
+		c.ui.PrintTable(linksTable)
+	}
+
+	if opts.Properties {
+		propsTable := boshtbl.Table{
+			Content: "properties",
+			Header: []boshtbl.Header{
+				boshtbl.NewHeader("Job"),
+				boshtbl.NewHeader("Property"),
+				boshtbl.NewHeader("Type"),
+				boshtbl.NewHeader("Default"),
+				boshtbl.NewHeader("Example"),
+				boshtbl.NewHeader("Env"),
+				boshtbl.NewHeader("Description"),
+			},
+			SortBy: []boshtbl.ColumnSort{{Column: 0, Asc: true}, {Column: 1, Asc: true}},
+		}
+
+		for _, j := range jobs {
+			for name, prop := range j.Properties {
+				propsTable.Rows = append(propsTable.Rows, []boshtbl.Value{
+					boshtbl.NewValueString(j.Name),
+					boshtbl.NewValueString(name),
+					boshtbl.NewValueString(prop.Type),
+					boshtbl.NewValueInterface(prop.Default),
+					boshtbl.NewValueInterface(prop.Example),
+					boshtbl.NewValueString(prop.Env),
+					boshtbl.NewValueString(prop.Description),
+				})
+			}
+		}
+
+		c.ui.PrintTable(propsTable)
+	}
+
+	return nil
+}
+
+func (c InspectReleaseCmd) linkRow(j boshdir.Job, direction string, link boshdir.Link) []boshtbl.Value {
+	return []boshtbl.Value{
+		boshtbl.NewValueString(j.Name),
+		boshtbl.NewValueString(direction),
+		boshtbl.NewValueString(link.Name),
+		boshtbl.NewValueString(link.Type),
+		boshtbl.NewValueBool(link.Optional),
+		boshtbl.NewValueStrings(link.Properties),
+	}
+}
@@ -175,6 +175,61 @@ var _ = Describe("InspectReleaseCmd", func() {
 			}))
 		})
 
+		It("shows only links and properties of jobs when requested", func() {
+			opts.Links = true
+			opts.Properties = true
+
+			release.JobsReturns([]boshdir.Job{
+				{
+					Name:          "some-job-name",
+					LinksConsumed: []boshdir.Link{{Name: "db", Type: "database", Optional: true}},
+					LinksProvided: []boshdir.Link{{Name: "api", Type: "http", Properties: []string{"port"}}},
+					Properties: map[string]boshdir.JobProperty{
+						"port": {Description: "Port", Type: "integer", Default: 8080, Env: "PORT"},
+					},
+				},
+			}, nil)
+
+			err := act()
+			Expect(err).ToNot(HaveOccurred())
+
+			Expect(release.PackagesCallCount()).To(Equal(0))
+			Expect(ui.Tables).To(HaveLen(2))
+
+			Expect(ui.Tables[0].Content).To(Equal("links"))
+			Expect(ui.Tables[0].Rows).To(Equal([][]boshtbl.Value{
+				{
+					boshtbl.NewValueString("some-job-name"),
+					boshtbl.NewValueString("consumes"),
+					boshtbl.NewValueString("db"),
+					boshtbl.NewValueString("database"),
+					boshtbl.NewValueBool(true),
+					boshtbl.NewValueStrings(nil),
+				},
+				{
+					boshtbl.NewValueString("some-job-name"),
+					boshtbl.NewValueString("provides"),
+					boshtbl.NewValueString("api"),
+					boshtbl.NewValueString("http"),
+					boshtbl.NewValueBool(false),
+					boshtbl.NewValueStrings([]string{"port"}),
+				},
+			}))
+
+			Expect(ui.Tables[1].Content).To(Equal("properties"))
+			Expect(ui.Tables[1].Rows).To(Equal([][]boshtbl.Value{
+				{
+					boshtbl.NewValueString("some-job-name"),
+					boshtbl.NewValueString("port"),
+					boshtbl.NewValueString("integer"),
+					boshtbl.NewValueInterface(8080),
+					boshtbl.NewValueInterface(nil),
+					boshtbl.NewValueString("PORT"),
+					boshtbl.NewValueString("Port"),
+				},
+			}))
+		})
+
 		It("returns error if jobs cannot be retrieved", func() {
 			release.JobsReturns(nil, errors.New("fake-err"))
 
@@ -6,6 +6,7 @@ import (
 	. "github.com/cloudfoundry/bosh-cli/cmd/opts"
 	boshtpl "github.com/cloudfoundry/bosh-cli/director/template"
 	boshui "github.com/cloudfoundry/bosh-cli/ui"
+	boshtbl "github.com/cloudfoundry/bosh-cli/ui/table"
 )
 
 type InterpolateCmd struct {
@@ -19,6 +20,10 @@ func NewInterpolateCmd(ui boshui.UI) InterpolateCmd {
 func (c InterpolateCmd) Run(opts InterpolateOpts) error {
 	tpl := boshtpl.NewTemplate(opts.Args.Manifest.Bytes)
 
+	if opts.VarsReport {
+		return c.printVarsReport(tpl, opts)
+	}
+
 	vars := opts.VarFlags.AsVariables()
 	op := opts.OpsFlags.AsOp()
 	evalOpts := boshtpl.EvaluateOpts{
@@ -42,3 +47,67 @@ func (c InterpolateCmd) Run(opts InterpolateOpts) error {
 
 	return nil
 }
+
+func (c InterpolateCmd) printVarsReport(tpl boshtpl.Template, opts InterpolateOpts) error {
+	report, err := tpl.VarsReport(opts.VarFlags.AsVarsSources(), opts.OpsFlags.AsOp())
+	if err != nil {
+		return err
+	}
+
+	refsTable := boshtbl.Table{
+		Content: "variable references",
+		Header: []boshtbl.Header{
+			boshtbl.NewHeader("Name"),
+			boshtbl.NewHeader("Path"),
+			boshtbl.NewHeader("Found"),
+			boshtbl.NewHeader("Source"),
+		},
+	}
+
+	for _, ref := range report.References {
+		refsTable.Rows = append(refsTable.Rows, []boshtbl.Value{
+			boshtbl.NewValueString(ref.Name),
+			boshtbl.NewValueString(ref.Path),
+			boshtbl.NewValueFmt(boshtbl.NewValueBool(ref.Found), !ref.Found),
+			boshtbl.NewValueString(ref.Source),
+		})
+	}
+
+	defsTable := boshtbl.Table{
+		Content: "variable definitions",
+		Header: []boshtbl.Header{
+			boshtbl.NewHeader("Name"),
+			boshtbl.NewHeader("Type"),
+			boshtbl.NewHeader("CA"),
+		},
+	}
+
+	for _, def := range report.Definitions {
+		defsTable.Rows = append(defsTable.Rows, []boshtbl.Value{
+			boshtbl.NewValueString(def.Name),
+			boshtbl.NewValueString(def.Type),
+			boshtbl.NewValueString(def.CA),
+		})
+	}
+
+	unusedTable := boshtbl.Table{
+		Content: "unused variables",
+		Header: []boshtbl.Header{
+			boshtbl.NewHeader("Name"),
+			boshtbl.NewHeader("Source"),
+		},
+	}
+
+	for _, unused := range report.Unused {
+		unusedTable.Rows = append(unusedTable.Rows, []boshtbl.Value{
+			boshtbl.NewValueString(unused.Name),
+			boshtbl.NewValueString(unused.Source),
+		})
+	}
+
+	c.ui.PrintTable(refsTable)
+	c.ui.PrintTable(defsTable)
+	c.ui.PrintTable(unusedTable)
+
+	return nil
+}
@@ -9,6 +9,7 @@ import (
 	. "github.com/cloudfoundry/bosh-cli/cmd/opts"
 	boshtpl "github.com/cloudfoundry/bosh-cli/director/template"
 	fakeui "github.com/cloudfoundry/bosh-cli/ui/fakes"
+	boshtbl "github.com/cloudfoundry/bosh-cli/ui/table"
 )
 
 var _ = Describe("InterpolateCmd", func() {
@@ -149,5 +150,94 @@ var _ = Describe("InterpolateCmd", func() {
 			Expect(err).To(HaveOccurred())
 			Expect(err.Error()).To(ContainSubstring("Expected to use variables: name3"))
 		})
+
+		Context("when vars-report flag is set", func() {
+			BeforeEach(func() {
+				opts.Args.Manifest = FileBytesArg{
+					Bytes: []byte("name1: ((name1))\nname2: ((name2))\nvariables:\n- name: cert\n  type: certificate\n  options: {ca: ca}\n"),
+				}
+
+				opts.VarKVs = []boshtpl.VarKV{{Name: "name1", Value: "val1-from-kv"}}
+
+				opts.VarsFiles = []boshtpl.VarsFileArg{
+					{Vars: boshtpl.StaticVariables(map[string]interface{}{"name3": "val3-from-file"})},
+				}
+
+				opts.VarsReport = true
+			})
+
+			It("shows variable references, definitions and unused variables instead of templated manifest", func() {
+				err := act()
+				Expect(err).ToNot(HaveOccurred())
+
+				Expect(ui.Blocks).To(BeEmpty())
+				Expect(ui.Tables).To(HaveLen(3))
+
+				Expect(ui.Tables[0]).To(Equal(boshtbl.Table{
+					Content: "variable references",
+					Header: []boshtbl.Header{
+						boshtbl.NewHeader("Name"),
+						boshtbl.NewHeader("Path"),
+						boshtbl.NewHeader("Found"),
+						boshtbl.NewHeader("Source"),
+					},
+					Rows: [][]boshtbl.Value{
+						{
+							boshtbl.NewValueString("name1"),
+							boshtbl.NewValueString("/name1"),
+							boshtbl.NewValueFmt(boshtbl.NewValueBool(true), false),
+							boshtbl.NewValueString("--var"),
+						},
+						{
+							boshtbl.NewValueString("name2"),
+							boshtbl.NewValueString("/name2"),
+							boshtbl.NewValueFmt(boshtbl.NewValueBool(false), true),
+							boshtbl.NewValueString(""),
+						},
+					},
+				}))
+
+				Expect(ui.Tables[1]).To(Equal(boshtbl.Table{
+					Content: "variable definitions",
+					Header: []boshtbl.Header{
+						boshtbl.NewHeader("Name"),
+						boshtbl.NewHeader("Type"),
+						boshtbl.NewHeader("CA"),
+					},
+					Rows: [][]boshtbl.Value{
+						{
+							boshtbl.NewValueString("cert"),
+							boshtbl.NewValueString("certificate"),
+							boshtbl.NewValueString("ca"),
+						},
+					},
+				}))
+
+				Expect(ui.Tables[2]).To(Equal(boshtbl.Table{
+					Content: "unused variables",
+					Header: []boshtbl.Header{
+						boshtbl.NewHeader("Name"),
+						boshtbl.NewHeader("Source"),
+					},
+					Rows: [][]boshtbl.Value{
+						{
+							boshtbl.NewValueString("name3"),
+							boshtbl.NewValueString("--vars-file"),
+						},
+					},
+				}))
+			})
+
+			It("returns error if operations cannot be applied", func() {
+				opts.OpsFiles = []OpsFileArg{
+					{Ops: patch.Ops([]patch.Op{
+						patch.ReplaceOp{Path: patch.MustNewPointerFromString("/missing/key"), Value: "val"},
+					})},
+				}
+
+				err := act()
+				Expect(err).To(HaveOccurred())
+			})
+		})
 	})
 })
@@ -0,0 +1,333 @@
+package cmd
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	bosherr "github.com/cloudfoundry/bosh-utils/errors"
+	boshsys "github.com/cloudfoundry/bosh-utils/system"
+
+	. "github.com/cloudfoundry/bosh-cli/cmd/opts"
+	boshrel "github.com/cloudfoundry/bosh-cli/release"
+	boshjob "github.com/cloudfoundry/bosh-cli/release/job"
+	boshui "github.com/cloudfoundry/bosh-cli/ui"
+)
+
+const (
+	JobDocsFormatMarkdown = "markdown"
+	JobDocsFormatJSON     = "json"
+)
+
+type JobDocsCmd struct {
+	releaseReader boshrel.Reader
+	fs            boshsys.FileSystem
+	ui            boshui.UI
+}
+
+type JobDoc struct {
+	Name       string            `json:"name"`
+	Properties []JobPropertyDoc  `json:"properties"`
+	Consumes   []JobLinkDoc      `json:"consumes"`
+	Provides   []JobLinkDoc      `json:"provides"`
+	Templates  map[string]string `json:"templates"`
+	Packages   []string          `json:"packages"`
+}
+
+type JobPropertyDoc struct {
+	Name        string      `json:"name"`
+	Type        string      `json:"type,omitempty"`
+	Description string      `json:"description,omitempty"`
+	Default     interface{} `json:"default,omitempty"`
+	Example     interface{} `json:"example,omitempty"`
+	Env         string      `json:"env,omitempty"`
+}
+
+type JobLinkDoc struct {
+	Name       string   `json:"name"`
+	Type       string   `json:"type"`
+	Optional   bool     `json:"optional,omitempty"`
+	Properties []string `json:"properties,omitempty"`
+}
+
+func NewJobDocsCmd(releaseReader boshrel.Reader, fs boshsys.FileSystem, ui boshui.UI) JobDocsCmd {
+	return JobDocsCmd{releaseReader: releaseReader, fs: fs, ui: ui}
+}
+
+func (c JobDocsCmd) Run(opts JobDocsOpts) error {
+	var ext string
+
+	switch opts.Format {
+	case JobDocsFormatMarkdown:
+		ext = ".md"
+	case JobDocsFormatJSON:
+		ext = ".json"
+	default:
+		return bosherr.Errorf("Unknown format '%s' (supported: markdown, json)", opts.Format)
+	}
+
+	releasePath := opts.ReleaseDir.Path
+
+	if len(opts.Release.ExpandedPath) > 0 {
+		releasePath = opts.Release.ExpandedPath
+	}
+
+	release, err := c.releaseReader.Read(releasePath)
+	if err != nil {
+		return bosherr.WrapErrorf(err, "Reading release '%s'", releasePath)
+	}
+
+	defer release.CleanUp()
+
+	jobs, err := c.selectJobs(release.Jobs(), opts.Jobs, releasePath)
+	if err != nil {
+		return err
+	}
+
+	var docs []JobDoc
+
+	for _, job := range jobs {
+		docs = append(docs, NewJobDoc(*job))
+	}
+
+	if len(opts.OutputDir.Path) == 0 {
+		return c.print(docs, opts.Format)
+	}
+
+	err = c.fs.MkdirAll(opts.OutputDir.Path, os.ModePerm)
+	if err != nil {
+		return bosherr.WrapErrorf(err, "Creating output directory '%s'", opts.OutputDir.Path)
+	}
+
+	for _, doc := range docs {
+		docBytes, err := c.render(doc, opts.Format)
+		if err != nil {
+			return err
+		}
+
+		docPath := filepath.Join(opts.OutputDir.Path, doc.Name+ext)
+
+		err = c.fs.WriteFile(docPath, docBytes)
+		if err != nil {
+			return bosherr.WrapErrorf(err, "Writing documentation for job '%s'", doc.Name)
+		}
+
+		c.ui.PrintLinef("Wrote documentation for job '%s' to '%s'", doc.Name, docPath)
+	}
+
+	return nil
+}
+
+func (c JobDocsCmd) selectJobs(jobs []*boshjob.Job, names []string, releasePath string) ([]*boshjob.Job, error) {
+	if len(names) == 0 {
+		return jobs, nil
+	}
+
+	var selected []*boshjob.Job
+
+	for _, name := range names {
+		var found bool
+
+		for _, job := range jobs {
+			if job.Name() == name {
+				selected = append(selected, job)
+				found = true
+				break
+			}
+		}
+
+		if !found {
+			return nil, bosherr.Errorf("Expected to find job '%s' in release '%s'", name, releasePath)
+		}
+	}
+
+	return selected, nil
+}
+
+func (c JobDocsCmd) print(docs []JobDoc, format string) error {
+	if format == JobDocsFormatJSON {
+		docsBytes, err := json.MarshalIndent(map[string][]JobDoc{"jobs": docs}, "", "  ")
+		if err != nil {
+			return bosherr.WrapErrorf(err, "Marshaling job documentation")
+		}
+
+		c.ui.PrintBlock(append(docsBytes, '\n'))
+
+		return nil
+	}
+
+	for i, doc := range docs {
+		docBytes, err := c.render(doc, format)
+		if err != nil {
+			return err
+		}
+
+		if i > 0 {
+			c.ui.PrintBlock([]byte("\n"))
+		}
+
+		c.ui.PrintBlock(docBytes)
+	}
+
+	return nil
+}
+
+func (c JobDocsCmd) render(doc JobDoc, format string) ([]byte, error) {
+	if format == JobDocsFormatJSON {
+		docBytes, err := json.MarshalIndent(doc, "", "  ")
+		if err != nil {
+			return nil, bosherr.WrapErrorf(err, "Marshaling documentation for job '%s'", doc.Name)
+		}
+
+		return append(docBytes, '\n'), nil
+	}
+
+	return doc.Markdown(), nil
+}
+
+func NewJobDoc(job boshjob.Job) JobDoc {
+	doc := JobDoc{
+		Name:       job.Name(),
+		Properties: []JobPropertyDoc{},
+		Consumes:   newJobLinkDocs(job.Consumes),
+		Provides:   newJobLinkDocs(job.Provides),
+		Templates:  job.Templates,
+		Packages:   job.PackageNames,
+	}
+
+	if doc.Templates == nil {
+		doc.Templates = map[string]string{}
+	}
+
+	if doc.Packages == nil {
+		doc.Packages = []string{}
+	}
+
+	for name, prop := range job.Properties {
+		doc.Properties = append(doc.Properties, JobPropertyDoc{
+			Name:        name,
+			Type:        prop.Type,
+			Description: prop.Description,
+			Default:     prop.Default,
+			Example:     prop.Example,
+			Env:         prop.Env,
+		})
+	}
+
+	sort.Slice(doc.Properties, func(i, j int) bool {
+		return doc.Properties[i].Name < doc.Properties[j].Name
+	})
+
+	return doc
+}
+
+func newJobLinkDocs(links []boshjob.LinkDefinition) []JobLinkDoc {
+	docs := []JobLinkDoc{}
+
+	for _, link := range links {
+		docs = append(docs, JobLinkDoc{
+			Name:       link.Name,
+			Type:       link.Type,
+			Optional:   link.Optional,
+			Properties: link.Properties,
+		})
+	}
+
+	return docs
+}
+
+// Markdown renders job reference documentation; empty sections are omitted.
+func (d JobDoc) Markdown() []byte {
+	buf := &bytes.Buffer{}
+
+	fmt.Fprintf(buf, "# %s\n", d.Name)
+
+	if len(d.Properties) > 0 {
+		fmt.Fprintf(buf, "\n## Properties\n\n")
+		fmt.Fprintf(buf, "| Name | Type | Default | Example | Env | Description |\n")
+		fmt.Fprintf(buf, "| --- | --- | --- | --- | --- | --- |\n")
+
+		for _, prop := range d.Properties {
+			fmt.Fprintf(buf, "| `%s` | %s | %s | %s | %s | %s |\n",
+				prop.Name,
+				markdownCell(prop.Type),
+				markdownCode(prop.Default),
+				markdownCode(prop.Example),
+				markdownCell(prop.Env),
+				markdownCell(prop.Description),
+			)
+		}
+	}
+
+	if len(d.Consumes) > 0 {
+		fmt.Fprintf(buf, "\n## Consumed Links\n\n")
+		fmt.Fprintf(buf, "| Name | Type | Optional |\n")
+		fmt.Fprintf(buf, "| --- | --- | --- |\n")
+
+		for _, link := range d.Consumes {
+			fmt.Fprintf(buf, "| `%s` | %s | %t |\n", link.Name, markdownCell(link.Type), link.Optional)
+		}
+	}
+
+	if len(d.Provides) > 0 {
+		fmt.Fprintf(buf, "\n## Provided Links\n\n")
+		fmt.Fprintf(buf, "| Name | Type | Properties |\n")
+		fmt.Fprintf(buf, "| --- | --- | --- |\n")
+
+		for _, link := range d.Provides {
+			var props []string
+			for _, prop := range link.Properties {
+				props = append(props, "`"+prop+"`")
+			}
+			fmt.Fprintf(buf, "| `%s` | %s | %s |\n", link.Name, markdownCell(link.Type), strings.Join(props, ", "))
+		}
+	}
+
+	if len(d.Templates) > 0 {
+		var srcs []string
+		for src := range d.Templates {
+			srcs = append(srcs, src)
+		}
+		sort.Strings(srcs)
+
+		fmt.Fprintf(buf, "\n## Templates\n\n")
+		fmt.Fprintf(buf, "| Source | Destination |\n")
+		fmt.Fprintf(buf, "| --- | --- |\n")
+
+		for _, src := range srcs {
+			fmt.Fprintf(buf, "| `%s` | `%s` |\n", src, d.Templates[src])
+		}
+	}
+
+	if len(d.Packages) > 0 {
+		fmt.Fprintf(buf, "\n## Packages\n\n")
+
+		for _, pkg := range d.Packages {
+			fmt.Fprintf(buf, "- `%s`\n", pkg)
+		}
+	}
+
+	return buf.Bytes()
+}
+
+func markdownCell(s string) string {
+	s = strings.Replace(strings.TrimSpace(s), "|", "\\|", -1)
+	return strings.Replace(s, "\n", "<br>", -1)
+}
+
+func markdownCode(val interface{}) string {
+	if val == nil {
+		return ""
+	}
+
+	valBytes, err := json.Marshal(val)
+	if err != nil {
+		return markdownCell(fmt.Sprintf("%v", val))
+	}
+
+	return "`" + strings.Replace(string(valBytes), "|", "\\|", -1) + "`"
+}
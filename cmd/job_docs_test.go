@@ -0,0 +1,185 @@
+package cmd_test
+
+import (
+	"errors"
+
+	fakesys "github.com/cloudfoundry/bosh-utils/system/fakes"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	. "github.com/cloudfoundry/bosh-cli/cmd"
+	. "github.com/cloudfoundry/bosh-cli/cmd/opts"
+	boshjob "github.com/cloudfoundry/bosh-cli/release/job"
+	fakerel "github.com/cloudfoundry/bosh-cli/release/releasefakes"
+	. "github.com/cloudfoundry/bosh-cli/release/resource"
+	fakeui "github.com/cloudfoundry/bosh-cli/ui/fakes"
+)
+
+var _ = Describe("JobDocsCmd", func() {
+	var (
+		releaseReader *fakerel.FakeReader
+		release       *fakerel.FakeRelease
+		fs            *fakesys.FakeFileSystem
+		ui            *fakeui.FakeUI
+		command       JobDocsCmd
+		opts          JobDocsOpts
+	)
+
+	BeforeEach(func() {
+		releaseReader = &fakerel.FakeReader{}
+		fs = fakesys.NewFakeFileSystem()
+		ui = &fakeui.FakeUI{}
+		command = NewJobDocsCmd(releaseReader, fs, ui)
+
+		job := boshjob.NewJob(NewResource("web", "fp", nil))
+		job.Templates = map[string]string{"config.yml.erb": "config/config.yml", "ctl.erb": "bin/ctl"}
+		job.PackageNames = []string{"nginx"}
+		job.Consumes = []boshjob.LinkDefinition{{Name: "db", Type: "database", Optional: true}}
+		job.Provides = []boshjob.LinkDefinition{{Name: "web", Type: "http", Properties: []string{"port"}}}
+		job.Properties = map[string]boshjob.PropertyDefinition{
+			"port":        {Description: "Port to listen on", Type: "integer", Default: 8080, Example: 443, Env: "PORT"},
+			"tls.enabled": {Description: "Enable TLS | HTTPS\nfor all requests"},
+		}
+
+		otherJob := boshjob.NewJob(NewResource("worker", "fp", nil))
+
+		release = &fakerel.FakeRelease{}
+		release.JobsReturns([]*boshjob.Job{job, otherJob})
+		releaseReader.ReadReturns(release, nil)
+
+		opts = JobDocsOpts{
+			ReleaseDir: DirOrCWDArg{Path: "/release"},
+			Format:     "markdown",
+		}
+	})
+
+	act := func() error { return command.Run(opts) }
+
+	It("prints markdown documentation for all jobs in release directory", func() {
+		err := act()
+		Expect(err).ToNot(HaveOccurred())
+
+		Expect(releaseReader.ReadArgsForCall(0)).To(Equal("/release"))
+		Expect(release.CleanUpCallCount()).To(Equal(1))
+
+		Expect(ui.Blocks).To(Equal([]string{
+			`# web
+
+## Properties
+
+| Name | Type | Default | Example | Env | Description |
+| --- | --- | --- | --- | --- | --- |
+| ` + "`port`" + ` | integer | ` + "`8080`" + ` | ` + "`443`" + ` | PORT | Port to listen on |
+| ` + "`tls.enabled`" + ` |  |  |  |  | Enable TLS \| HTTPS<br>for all requests |
+
+## Consumed Links
+
+| Name | Type | Optional |
+| --- | --- | --- |
+| ` + "`db`" + ` | database | true |
+
+## Provided Links
+
+| Name | Type | Properties |
+| --- | --- | --- |
+| ` + "`web`" + ` | http | ` + "`port`" + ` |
+
+## Templates
+
+| Source | Destination |
+| --- | --- |
+| ` + "`config.yml.erb`" + ` | ` + "`config/config.yml`" + ` |
+| ` + "`ctl.erb`" + ` | ` + "`bin/ctl`" + ` |
+
+## Packages
+
+- ` + "`nginx`" + `
+`,
+			"\n",
+			"# worker\n",
+		}))
+	})
+
+	It("prints json documentation for selected jobs", func() {
+		opts.Format = "json"
+		opts.Jobs = []string{"worker"}
+
+		err := act()
+		Expect(err).ToNot(HaveOccurred())
+
+		Expect(ui.Blocks).To(HaveLen(1))
+		Expect(ui.Blocks[0]).To(MatchJSON(`{
+			"jobs": [{
+				"name": "worker",
+				"properties": [],
+				"consumes": [],
+				"provides": [],
+				"templates": {},
+				"packages": []
+			}]
+		}`))
+	})
+
+	It("reads release tarball instead of release directory if it's given", func() {
+		opts.Release = FileArg{ExpandedPath: "/release.tgz"}
+
+		err := act()
+		Expect(err).ToNot(HaveOccurred())
+
+		Expect(releaseReader.ReadArgsForCall(0)).To(Equal("/release.tgz"))
+	})
+
+	It("writes documentation for each job into output directory", func() {
+		opts.Format = "json"
+		opts.OutputDir = DirOrCWDArg{Path: "/docs"}
+
+		err := act()
+		Expect(err).ToNot(HaveOccurred())
+
+		Expect(fs.ReadFileString("/docs/web.json")).To(MatchJSON(`{
+			"name": "web",
+			"properties": [
+				{"name": "port", "type": "integer", "description": "Port to listen on", "default": 8080, "example": 443, "env": "PORT"},
+				{"name": "tls.enabled", "description": "Enable TLS | HTTPS\nfor all requests"}
+			],
+			"consumes": [{"name": "db", "type": "database", "optional": true}],
+			"provides": [{"name": "web", "type": "http", "properties": ["port"]}],
+			"templates": {"config.yml.erb": "config/config.yml", "ctl.erb": "bin/ctl"},
+			"packages": ["nginx"]
+		}`))
+
+		Expect(fs.FileExists("/docs/worker.json")).To(BeTrue())
+
+		Expect(ui.Blocks).To(BeEmpty())
+		Expect(ui.Said).To(Equal([]string{
+			"Wrote documentation for job 'web' to '/docs/web.json'",
+			"Wrote documentation for job 'worker' to '/docs/worker.json'",
+		}))
+	})
+
+	It("returns an error if format is unknown", func() {
+		opts.Format = "html"
+
+		err := act()
+		Expect(err).To(HaveOccurred())
+		Expect(err.Error()).To(Equal("Unknown format 'html' (supported: markdown, json)"))
+		Expect(releaseReader.ReadCallCount()).To(Equal(0))
+	})
+
+	It("returns an error if job is not found", func() {
+		opts.Jobs = []string{"missing"}
+
+		err := act()
+		Expect(err).To(HaveOccurred())
+		Expect(err.Error()).To(Equal("Expected to find job 'missing' in release '/release'"))
+	})
+
+	It("returns an error if release cannot be read", func() {
+		releaseReader.ReadReturns(nil, errors.New("fake-err"))
+
+		err := act()
+		Expect(err).To(HaveOccurred())
+		Expect(err.Error()).To(ContainSubstring("Reading release '/release'"))
+		Expect(err.Error()).To(ContainSubstring("fake-err"))
+	})
+})
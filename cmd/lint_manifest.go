@@ -0,0 +1,58 @@
+package cmd
+
+import (
+	bosherr "github.com/cloudfoundry/bosh-utils/errors"
+
+	. "github.com/cloudfoundry/bosh-cli/cmd/opts"
+	boshlint "github.com/cloudfoundry/bosh-cli/director/lint"
+	boshtpl "github.com/cloudfoundry/bosh-cli/director/template"
+	boshui "github.com/cloudfoundry/bosh-cli/ui"
+	boshtbl "github.com/cloudfoundry/bosh-cli/ui/table"
+)
+
+type LintManifestCmd struct {
+	ui     boshui.UI
+	linter boshlint.Linter
+}
+
+func NewLintManifestCmd(ui boshui.UI, linter boshlint.Linter) LintManifestCmd {
+	return LintManifestCmd{ui: ui, linter: linter}
+}
+
+func (c LintManifestCmd) Run(opts LintManifestOpts) error {
+	tpl := boshtpl.NewTemplate(opts.Args.Manifest.Bytes)
+
+	bytes, err := tpl.Evaluate(opts.VarFlags.AsVariables(), opts.OpsFlags.AsOp(), boshtpl.EvaluateOpts{})
+	if err != nil {
+		return bosherr.WrapErrorf(err, "Evaluating manifest")
+	}
+
+	problems, err := c.linter.Lint(bytes, opts.CloudConfig.Bytes, opts.Releases)
+	if err != nil {
+		return err
+	}
+
+	if len(problems) == 0 {
+		c.ui.PrintLinef("No problems found in manifest")
+		return nil
+	}
+
+	table := boshtbl.Table{
+		Content: "problems",
+		Header: []boshtbl.Header{
+			boshtbl.NewHeader("Path"),
+			boshtbl.NewHeader("Problem"),
+		},
+	}
+
+	for _, problem := range problems {
+		table.Rows = append(table.Rows, []boshtbl.Value{
+			boshtbl.NewValueString(problem.Path),
+			boshtbl.NewValueString(problem.Message),
+		})
+	}
+
+	c.ui.PrintTable(table)
+
+	return bosherr.Errorf("Found %d problem(s) in manifest", len(problems))
+}
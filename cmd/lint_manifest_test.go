@@ -0,0 +1,86 @@
+package cmd_test
+
+import (
+	"errors"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	. "github.com/cloudfoundry/bosh-cli/cmd"
+	. "github.com/cloudfoundry/bosh-cli/cmd/opts"
+	boshlint "github.com/cloudfoundry/bosh-cli/director/lint"
+	boshtpl "github.com/cloudfoundry/bosh-cli/director/template"
+	fakerel "github.com/cloudfoundry/bosh-cli/release/releasefakes"
+	fakeui "github.com/cloudfoundry/bosh-cli/ui/fakes"
+	boshtbl "github.com/cloudfoundry/bosh-cli/ui/table"
+)
+
+var _ = Describe("LintManifestCmd", func() {
+	var (
+		ui            *fakeui.FakeUI
+		releaseReader *fakerel.FakeReader
+		command       LintManifestCmd
+	)
+
+	BeforeEach(func() {
+		ui = &fakeui.FakeUI{}
+		releaseReader = &fakerel.FakeReader{}
+		command = NewLintManifestCmd(ui, boshlint.NewLinter(releaseReader))
+	})
+
+	Describe("Run", func() {
+		var (
+			opts LintManifestOpts
+		)
+
+		BeforeEach(func() {
+			opts = LintManifestOpts{
+				Args: DeployArgs{
+					Manifest: FileBytesArg{Bytes: []byte("name: dep\nreleases: [{name: ((rel))}]\ninstance_groups: [{name: web, vm_type: small, jobs: [{name: nginx, release: rel}]}]\n")},
+				},
+				VarFlags: VarFlags{
+					VarKVs: []boshtpl.VarKV{{Name: "rel", Value: "rel"}},
+				},
+			}
+		})
+
+		act := func() error { return command.Run(opts) }
+
+		It("reports that no problems were found", func() {
+			err := act()
+			Expect(err).ToNot(HaveOccurred())
+			Expect(ui.Said).To(Equal([]string{"No problems found in manifest"}))
+		})
+
+		It("prints found problems and returns an error", func() {
+			opts.CloudConfig = FileBytesArg{Bytes: []byte("vm_types: [{name: default}]")}
+
+			err := act()
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(Equal("Found 1 problem(s) in manifest"))
+
+			Expect(ui.Table).To(Equal(boshtbl.Table{
+				Content: "problems",
+				Header: []boshtbl.Header{
+					boshtbl.NewHeader("Path"),
+					boshtbl.NewHeader("Problem"),
+				},
+				Rows: [][]boshtbl.Value{
+					{
+						boshtbl.NewValueString("/instance_groups/name=web/vm_type"),
+						boshtbl.NewValueString("VM type 'small' is not found in cloud config"),
+					},
+				},
+			}))
+		})
+
+		It("returns an error if linting fails", func() {
+			opts.Releases = []string{"/rel.tgz"}
+			releaseReader.ReadReturns(nil, errors.New("fake-err"))
+
+			err := act()
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("fake-err"))
+		})
+	})
+})
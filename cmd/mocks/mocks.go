@@ -1,15 +1,54 @@
 // Code generated by MockGen. DO NOT EDIT.
-// Source: github.com/cloudfoundry/bosh-cli/cmd (interfaces: DeploymentDeleter,DeploymentStateManager)
+// Source: github.com/cloudfoundry/bosh-cli/cmd (interfaces: DeploymentChecker,DeploymentDeleter,DeploymentStateManager)
 
 // Package mocks is a generated GoMock package.
 package mocks
 
 import (
+	deployment "github.com/cloudfoundry/bosh-cli/deployment"
 	ui "github.com/cloudfoundry/bosh-cli/ui"
 	gomock "github.com/golang/mock/gomock"
 	reflect "reflect"
 )
 
+// MockDeploymentChecker is a mock of DeploymentChecker interface
+type MockDeploymentChecker struct {
+	ctrl     *gomock.Controller
+	recorder *MockDeploymentCheckerMockRecorder
+}
+
+// MockDeploymentCheckerMockRecorder is the mock recorder for MockDeploymentChecker
+type MockDeploymentCheckerMockRecorder struct {
+	mock *MockDeploymentChecker
+}
+
+// NewMockDeploymentChecker creates a new mock instance
+func NewMockDeploymentChecker(ctrl *gomock.Controller) *MockDeploymentChecker {
+	mock := &MockDeploymentChecker{ctrl: ctrl}
+	mock.recorder = &MockDeploymentCheckerMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use
+func (m *MockDeploymentChecker) EXPECT() *MockDeploymentCheckerMockRecorder {
+	return m.recorder
+}
+
+// CheckDeployment mocks base method
+func (m *MockDeploymentChecker) CheckDeployment(arg0 bool, arg1 ui.Stage) ([]deployment.Drift, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CheckDeployment", arg0, arg1)
+	ret0, _ := ret[0].([]deployment.Drift)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CheckDeployment indicates an expected call of CheckDeployment
+func (mr *MockDeploymentCheckerMockRecorder) CheckDeployment(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CheckDeployment", reflect.TypeOf((*MockDeploymentChecker)(nil).CheckDeployment), arg0, arg1)
+}
+
 // MockDeploymentDeleter is a mock of DeploymentDeleter interface
 type MockDeploymentDeleter struct {
 	ctrl     *gomock.Controller
@@ -0,0 +1,26 @@
+package cmd
+
+import (
+	. "github.com/cloudfoundry/bosh-cli/cmd/opts"
+	boshopsfile "github.com/cloudfoundry/bosh-cli/director/opsfile"
+	boshui "github.com/cloudfoundry/bosh-cli/ui"
+)
+
+type OpsGenerateCmd struct {
+	ui boshui.UI
+}
+
+func NewOpsGenerateCmd(ui boshui.UI) OpsGenerateCmd {
+	return OpsGenerateCmd{ui: ui}
+}
+
+func (c OpsGenerateCmd) Run(opts OpsGenerateOpts) error {
+	bytes, err := boshopsfile.Generate(opts.Args.From.Bytes, opts.Args.To.Bytes)
+	if err != nil {
+		return err
+	}
+
+	c.ui.PrintBlock(bytes)
+
+	return nil
+}
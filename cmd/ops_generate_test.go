@@ -0,0 +1,44 @@
+package cmd_test
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	. "github.com/cloudfoundry/bosh-cli/cmd"
+	. "github.com/cloudfoundry/bosh-cli/cmd/opts"
+	fakeui "github.com/cloudfoundry/bosh-cli/ui/fakes"
+)
+
+var _ = Describe("OpsGenerateCmd", func() {
+	var (
+		ui      *fakeui.FakeUI
+		command OpsGenerateCmd
+	)
+
+	BeforeEach(func() {
+		ui = &fakeui.FakeUI{}
+		command = NewOpsGenerateCmd(ui)
+	})
+
+	Describe("Run", func() {
+		It("prints operations turning first manifest into second one", func() {
+			err := command.Run(OpsGenerateOpts{
+				Args: OpsGenerateArgs{
+					From: FileBytesArg{Bytes: []byte("name: dep\ninstances: 1\n")},
+					To:   FileBytesArg{Bytes: []byte("name: dep\ninstances: 2\n")},
+				},
+			})
+			Expect(err).ToNot(HaveOccurred())
+			Expect(ui.Blocks).To(Equal([]string{"- type: replace\n  path: /instances\n  value: 2\n"}))
+		})
+
+		It("returns an error if manifest cannot be parsed", func() {
+			err := command.Run(OpsGenerateOpts{
+				Args: OpsGenerateArgs{
+					From: FileBytesArg{Bytes: []byte("-a: b\n- c")},
+				},
+			})
+			Expect(err).To(HaveOccurred())
+		})
+	})
+})
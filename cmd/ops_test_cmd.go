@@ -0,0 +1,77 @@
+package cmd
+
+import (
+	bosherr "github.com/cloudfoundry/bosh-utils/errors"
+	"gopkg.in/yaml.v2"
+
+	. "github.com/cloudfoundry/bosh-cli/cmd/opts"
+	boshopsfile "github.com/cloudfoundry/bosh-cli/director/opsfile"
+	boshtpl "github.com/cloudfoundry/bosh-cli/director/template"
+	boshui "github.com/cloudfoundry/bosh-cli/ui"
+	boshtbl "github.com/cloudfoundry/bosh-cli/ui/table"
+)
+
+type OpsTestCmd struct {
+	ui boshui.UI
+}
+
+func NewOpsTestCmd(ui boshui.UI) OpsTestCmd {
+	return OpsTestCmd{ui: ui}
+}
+
+func (c OpsTestCmd) Run(opts OpsTestOpts) error {
+	expectations, err := boshopsfile.NewExpectationsFromBytes(opts.Args.Expectations.Bytes)
+	if err != nil {
+		return err
+	}
+
+	tpl := boshtpl.NewTemplate(opts.Args.Manifest.Bytes)
+
+	bytes, err := tpl.Evaluate(opts.VarFlags.AsVariables(), opts.OpsFlags.AsOp(), boshtpl.EvaluateOpts{})
+	if err != nil {
+		return bosherr.WrapErrorf(err, "Evaluating manifest")
+	}
+
+	var doc interface{}
+
+	err = yaml.Unmarshal(bytes, &doc)
+	if err != nil {
+		return bosherr.WrapErrorf(err, "Unmarshalling manifest")
+	}
+
+	table := boshtbl.Table{
+		Content: "expectations",
+		Header: []boshtbl.Header{
+			boshtbl.NewHeader("Path"),
+			boshtbl.NewHeader("Result"),
+			boshtbl.NewHeader("Error"),
+		},
+	}
+
+	var failed int
+
+	for _, result := range boshopsfile.Check(doc, expectations) {
+		status := "passed"
+		var errMsg string
+
+		if !result.Passed() {
+			status = "failed"
+			errMsg = result.Error.Error()
+			failed++
+		}
+
+		table.Rows = append(table.Rows, []boshtbl.Value{
+			boshtbl.NewValueString(result.Expectation.Path.String()),
+			boshtbl.NewValueString(status),
+			boshtbl.NewValueString(errMsg),
+		})
+	}
+
+	c.ui.PrintTable(table)
+
+	if failed > 0 {
+		return bosherr.Errorf("Failed %d of %d expectation(s)", failed, len(expectations))
+	}
+
+	return nil
+}
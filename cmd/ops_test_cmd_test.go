@@ -0,0 +1,94 @@
+package cmd_test
+
+import (
+	"github.com/cppforlife/go-patch/patch"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	. "github.com/cloudfoundry/bosh-cli/cmd"
+	. "github.com/cloudfoundry/bosh-cli/cmd/opts"
+	fakeui "github.com/cloudfoundry/bosh-cli/ui/fakes"
+	boshtbl "github.com/cloudfoundry/bosh-cli/ui/table"
+)
+
+var _ = Describe("OpsTestCmd", func() {
+	var (
+		ui      *fakeui.FakeUI
+		command OpsTestCmd
+	)
+
+	BeforeEach(func() {
+		ui = &fakeui.FakeUI{}
+		command = NewOpsTestCmd(ui)
+	})
+
+	Describe("Run", func() {
+		var (
+			opts OpsTestOpts
+		)
+
+		BeforeEach(func() {
+			opts = OpsTestOpts{
+				Args: OpsTestArgs{
+					Manifest:     FileBytesArg{Bytes: []byte("name: dep\ninstances: 1\n")},
+					Expectations: FileBytesArg{Bytes: []byte("- {path: /name, value: dep}\n- {path: /instances, value: 2}\n")},
+				},
+				OpsFlags: OpsFlags{
+					OpsFiles: []OpsFileArg{{
+						Ops: patch.Ops{patch.ReplaceOp{Path: patch.MustNewPointerFromString("/instances"), Value: 2}},
+					}},
+				},
+			}
+		})
+
+		act := func() error { return command.Run(opts) }
+
+		It("applies operations and checks expectations", func() {
+			err := act()
+			Expect(err).ToNot(HaveOccurred())
+
+			Expect(ui.Table).To(Equal(boshtbl.Table{
+				Content: "expectations",
+				Header: []boshtbl.Header{
+					boshtbl.NewHeader("Path"),
+					boshtbl.NewHeader("Result"),
+					boshtbl.NewHeader("Error"),
+				},
+				Rows: [][]boshtbl.Value{
+					{boshtbl.NewValueString("/name"), boshtbl.NewValueString("passed"), boshtbl.NewValueString("")},
+					{boshtbl.NewValueString("/instances"), boshtbl.NewValueString("passed"), boshtbl.NewValueString("")},
+				},
+			}))
+		})
+
+		It("returns an error if some expectations failed", func() {
+			opts.OpsFlags = OpsFlags{}
+
+			err := act()
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(Equal("Failed 1 of 2 expectation(s)"))
+
+			Expect(ui.Table.Rows[1]).To(Equal([]boshtbl.Value{
+				boshtbl.NewValueString("/instances"),
+				boshtbl.NewValueString("failed"),
+				boshtbl.NewValueString("Expected value '2' but found '1'"),
+			}))
+		})
+
+		It("returns an error if operations cannot be applied", func() {
+			opts.OpsFlags.OpsFiles[0].Ops = patch.Ops{patch.ReplaceOp{Path: patch.MustNewPointerFromString("/missing/key"), Value: 2}}
+
+			err := act()
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("Evaluating manifest"))
+		})
+
+		It("returns an error if expectations cannot be parsed", func() {
+			opts.Args.Expectations = FileBytesArg{Bytes: []byte("- path: instances")}
+
+			err := act()
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("Parsing path"))
+		})
+	})
+})
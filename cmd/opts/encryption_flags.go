@@ -0,0 +1,38 @@
+package opts
+
+import (
+	"os"
+
+	bicrypto "github.com/cloudfoundry/bosh-cli/crypto"
+)
+
+// encryptionKeyEnv allows to provide key material without exposing it in process arguments.
+const encryptionKeyEnv = "BOSH_ENCRYPTION_KEY"
+
+// Shared by commands that read or write vars store and state files
+type EncryptionFlags struct {
+	EncryptionKeyFile FileBytesArg `long:"encryption-key-file" value-name:"PATH" description:"Encrypt/decrypt vars store and state files with key from a file (or set BOSH_ENCRYPTION_KEY)" env:"BOSH_ENCRYPTION_KEY_FILE"`
+}
+
+func (f EncryptionFlags) IsSet() bool {
+	return len(f.keyMaterial()) > 0
+}
+
+// AsEncryptor returns nil when encryption is not configured.
+func (f EncryptionFlags) AsEncryptor() bicrypto.Encryptor {
+	keyMaterial := f.keyMaterial()
+
+	if len(keyMaterial) > 0 {
+		return bicrypto.NewEncryptor(keyMaterial)
+	}
+
+	return nil
+}
+
+func (f EncryptionFlags) keyMaterial() []byte {
+	if len(f.EncryptionKeyFile.Bytes) > 0 {
+		return f.EncryptionKeyFile.Bytes
+	}
+
+	return []byte(os.Getenv(encryptionKeyEnv))
+}
@@ -29,6 +29,7 @@ type BoshOpts struct {
 	// Output formatting
 	ColumnOpt         []ColumnOpt `long:"column"                    description:"Filter to show only given column(s)"`
 	JSONOpt           bool        `long:"json"                      description:"Output as JSON"`
+	EventJSONOpt      bool        `long:"event-json"                description:"Output director task events as newline-delimited JSON"`
 	TTYOpt            bool        `long:"tty"                       description:"Force TTY-like output"`
 	NoColorOpt        bool        `long:"no-color"                  description:"Toggle colorized output"`
 	NonInteractiveOpt bool        `long:"non-interactive" short:"n" description:"Don't ask for user input" env:"BOSH_NON_INTERACTIVE"`
@@ -44,9 +45,16 @@ type BoshOpts struct {
 	DeleteEnv    DeleteEnvOpts    `command:"delete-env"                description:"Delete BOSH environment"`
 	StopEnv      StopEnvOpts      `command:"stop-env"                  description:"Stop BOSH environment"`
 	StartEnv     StartEnvOpts     `command:"start-env"                 description:"Start BOSH environment"`
+	CheckEnv     CheckEnvOpts     `command:"check-env"                 description:"Check BOSH environment for drift from its state"`
 	AliasEnv     AliasEnvOpts     `command:"alias-env"                 description:"Alias environment to save URL and CA certificate"`
 	UnaliasEnv   UnaliasEnvOpts   `command:"unalias-env"               description:"Remove an aliased environment"`
 
+	EncryptVarsStore EncryptVarsStoreOpts `command:"encrypt-vars-store" description:"Encrypt vars store or state file"`
+	DecryptVarsStore DecryptVarsStoreOpts `command:"decrypt-vars-store" description:"Decrypt vars store or state file"`
+	RotateVars       RotateVarsOpts       `command:"rotate-vars"        description:"Rotate certificates in vars store"`
+
+	State StateOpts `command:"state" description:"Inspect and edit create-env state"`
+
 	// Authentication
 	LogIn  LogInOpts  `command:"log-in"  alias:"l" alias:"login"  description:"Log in"`
 	LogOut LogOutOpts `command:"log-out"           alias:"logout" description:"Log out"`
@@ -86,10 +94,16 @@ type BoshOpts struct {
 	Deployments      DeploymentsOpts      `command:"deployments"       alias:"ds" alias:"deps" description:"List deployments"`
 	DeleteDeployment DeleteDeploymentOpts `command:"delete-deployment" alias:"deld"            description:"Delete deployment"`
 
-	Deploy   DeployOpts   `command:"deploy"   alias:"d"   description:"Update deployment"`
-	Manifest ManifestOpts `command:"manifest" alias:"man" description:"Show deployment manifest"`
+	Deploy             DeployOpts             `command:"deploy"   alias:"d"   description:"Update deployment"`
+	DiffDeployment     DiffDeploymentOpts     `command:"diff-deployment" description:"Show manifest diff against deployment without deploying"`
+	DiffManifests      DiffManifestsOpts      `command:"diff-manifests" description:"Show diff between two manifests or between deployment and a manifest"`
+	LintManifest       LintManifestOpts       `command:"lint-manifest" description:"Validate deployment manifest without contacting the Director"`
+	ValidateProperties ValidatePropertiesOpts `command:"validate-properties" description:"Validate job properties in deployment manifest against release job specs"`
+	Manifest           ManifestOpts           `command:"manifest" alias:"man" description:"Show deployment manifest"`
 
 	Interpolate InterpolateOpts `command:"interpolate" alias:"int" description:"Interpolates variables into a manifest"`
+	OpsTest     OpsTestOpts     `command:"ops-test"                description:"Apply operations to a manifest and check expected paths and values"`
+	OpsGenerate OpsGenerateOpts `command:"ops-generate"            description:"Generate operations file from the difference between two manifests"`
 
 	// Events
 	Events EventsOpts `command:"events" description:"List events"`
@@ -166,6 +180,11 @@ type BoshOpts struct {
 
 	FinalizeRelease FinalizeReleaseOpts `command:"finalize-release"               description:"Create final release from dev release tarball"`
 
+	VerifyReleaseReproducible VerifyReleaseReproducibleOpts `command:"verify-release-reproducible" description:"Check that release tarball and its job and package archives were created reproducibly"`
+
+	RenderJob RenderJobOpts `command:"render-job" description:"Render job templates from a release directory or tarball"`
+	JobDocs   JobDocsOpts   `command:"job-docs"   description:"Generate reference documentation for jobs from a release directory or tarball"`
+
 	// Blob management
 	Blobs       BlobsOpts       `command:"blobs"        description:"List blobs"`
 	AddBlob     AddBlobOpts     `command:"add-blob"     description:"Add blob"`
@@ -186,10 +205,13 @@ type CreateEnvOpts struct {
 	Args CreateEnvArgs `positional-args:"true" required:"true"`
 	VarFlags
 	OpsFlags
+	EncryptionFlags
 	SkipDrain               bool   `long:"skip-drain" description:"Skip running drain and pre-stop scripts"`
-	StatePath               string `long:"state" value-name:"PATH" description:"State file path"`
+	StatePath               string `long:"state" value-name:"PATH|URL" description:"State file path or remote state URL (dav+https://HOST/PATH, s3://BUCKET/PATH)"`
 	Recreate                bool   `long:"recreate" description:"Recreate VM in deployment"`
 	RecreatePersistentDisks bool   `long:"recreate-persistent-disks" description:"Recreate persistent disks in the deployment"`
+	DryRun                  bool   `long:"dry-run" description:"Show planned changes without making them"`
+	EventsPath              string `long:"events" value-name:"PATH" description:"Stream stage events as newline-delimited JSON to a file ('-' for stdout, suppresses other output)"`
 	cmd
 }
 
@@ -201,8 +223,10 @@ type DeleteEnvOpts struct {
 	Args DeleteEnvArgs `positional-args:"true" required:"true"`
 	VarFlags
 	OpsFlags
-	SkipDrain bool   `long:"skip-drain" description:"Skip running drain and pre-stop scripts"`
-	StatePath string `long:"state" value-name:"PATH" description:"State file path"`
+	EncryptionFlags
+	SkipDrain  bool   `long:"skip-drain" description:"Skip running drain and pre-stop scripts"`
+	StatePath  string `long:"state" value-name:"PATH|URL" description:"State file path or remote state URL (dav+https://HOST/PATH, s3://BUCKET/PATH)"`
+	EventsPath string `long:"events" value-name:"PATH" description:"Stream stage events as newline-delimited JSON to a file ('-' for stdout, suppresses other output)"`
 	cmd
 }
 
@@ -210,8 +234,9 @@ type StopEnvOpts struct {
 	Args StartStopEnvArgs `positional-args:"true" required:"true"`
 	VarFlags
 	OpsFlags
+	EncryptionFlags
 	SkipDrain bool   `long:"skip-drain" description:"Skip running drain and pre-stop scripts"`
-	StatePath string `long:"state" value-name:"PATH" description:"State file path"`
+	StatePath string `long:"state" value-name:"PATH|URL" description:"State file path or remote state URL (dav+https://HOST/PATH, s3://BUCKET/PATH)"`
 	cmd
 }
 
@@ -219,7 +244,118 @@ type StartEnvOpts struct {
 	Args StartStopEnvArgs `positional-args:"true" required:"true"`
 	VarFlags
 	OpsFlags
-	StatePath string `long:"state" value-name:"PATH" description:"State file path"`
+	EncryptionFlags
+	StatePath string `long:"state" value-name:"PATH|URL" description:"State file path or remote state URL (dav+https://HOST/PATH, s3://BUCKET/PATH)"`
+	cmd
+}
+
+type CheckEnvOpts struct {
+	Args CheckEnvArgs `positional-args:"true" required:"true"`
+	VarFlags
+	OpsFlags
+	EncryptionFlags
+	StatePath string `long:"state" value-name:"PATH|URL" description:"State file path or remote state URL (dav+https://HOST/PATH, s3://BUCKET/PATH)"`
+	Repair    bool   `long:"repair" description:"Update state or reattach disks to repair found drift"`
+	cmd
+}
+
+type CheckEnvArgs struct {
+	Manifest FileBytesWithPathArg `positional-arg-name:"PATH" description:"Path to a manifest file"`
+}
+
+type EncryptVarsStoreOpts struct {
+	Args EncryptVarsStoreArgs `positional-args:"true" required:"true"`
+	EncryptionFlags
+	cmd
+}
+
+type DecryptVarsStoreOpts struct {
+	Args EncryptVarsStoreArgs `positional-args:"true" required:"true"`
+	EncryptionFlags
+	cmd
+}
+
+type EncryptVarsStoreArgs struct {
+	Path FileArg `positional-arg-name:"PATH" description:"Path to a vars store or state file"`
+}
+
+type RotateVarsOpts struct {
+	Args RotateVarsArgs `positional-args:"true" required:"true"`
+
+	VarFlags
+	OpsFlags
+	EncryptionFlags
+
+	Names   []string `long:"name"     value-name:"NAME"  description:"Rotate only given certificate variable (multiple allowed)"`
+	CAPhase string   `long:"ca-phase" value-name:"PHASE" description:"Rotate CAs: 'transition' adds new CAs to trust bundles, 'switch' signs certificates with new CAs"`
+	DryRun  bool     `long:"dry-run"                     description:"Only show certificate expiry dates"`
+
+	cmd
+}
+
+type RotateVarsArgs struct {
+	Manifest FileBytesArg `positional-arg-name:"PATH" description:"Path to a manifest file"`
+}
+
+type StateOpts struct {
+	Show           StateShowOpts           `command:"show"            description:"Show create-env state"`
+	SetVMCID       StateSetVMCIDOpts       `command:"set-vm-cid"      description:"Set current VM CID in create-env state"`
+	ForgetDisk     StateForgetDiskOpts     `command:"forget-disk"     description:"Remove disk from create-env state"`
+	ImportDisk     StateImportDiskOpts     `command:"import-disk"     description:"Add existing disk to create-env state as current disk"`
+	ForgetStemcell StateForgetStemcellOpts `command:"forget-stemcell" description:"Remove stemcell from create-env state"`
+	Validate       StateValidateOpts       `command:"validate"        description:"Check create-env state for inconsistencies"`
+}
+
+type StateFlags struct {
+	StatePath string `long:"state" value-name:"PATH|URL" description:"State file path or remote state URL (dav+https://HOST/PATH, s3://BUCKET/PATH)" required:"true"`
+	EncryptionFlags
+}
+
+type StateShowOpts struct {
+	StateFlags
+	cmd
+}
+
+type StateSetVMCIDOpts struct {
+	Args StateSetVMCIDArgs `positional-args:"true" required:"true"`
+	StateFlags
+	cmd
+}
+
+type StateSetVMCIDArgs struct {
+	CID string `positional-arg-name:"CID" description:"VM CID (empty to clear)"`
+}
+
+type StateForgetDiskOpts struct {
+	Args StateDiskArgs `positional-args:"true" required:"true"`
+	StateFlags
+	cmd
+}
+
+type StateImportDiskOpts struct {
+	Args            StateDiskArgs `positional-args:"true" required:"true"`
+	Size            int           `long:"size"             value-name:"MB"   description:"Disk size in MB" required:"true"`
+	CloudProperties string        `long:"cloud-properties" value-name:"YAML" description:"Disk cloud properties"`
+	StateFlags
+	cmd
+}
+
+type StateDiskArgs struct {
+	CID string `positional-arg-name:"CID" description:"Disk CID"`
+}
+
+type StateForgetStemcellOpts struct {
+	Args StateStemcellArgs `positional-args:"true" required:"true"`
+	StateFlags
+	cmd
+}
+
+type StateStemcellArgs struct {
+	CID string `positional-arg-name:"CID" description:"Stemcell CID"`
+}
+
+type StateValidateOpts struct {
+	StateFlags
 	cmd
 }
 
@@ -294,8 +430,11 @@ type TaskArgs struct {
 }
 
 type TasksOpts struct {
-	Recent     *int `long:"recent" short:"r" description:"Show 30 recent tasks. Use '=' to specify the number of tasks to show" optional:"true" optional-value:"30"`
-	All        bool `long:"all" short:"a" description:"Include all task types (ssh, logs, vms, etc)"`
+	Recent     *int   `long:"recent" short:"r" description:"Show 30 recent tasks. Use '=' to specify the number of tasks to show" optional:"true" optional-value:"30"`
+	All        bool   `long:"all" short:"a" description:"Include all task types (ssh, logs, vms, etc)"`
+	Follow     bool   `long:"follow" short:"f" description:"Follow output of running tasks until all of them finish"`
+	User       string `long:"user" description:"Show only tasks started by user"`
+	ContextID  string `long:"context-id" description:"Show only tasks with context ID"`
 	Deployment string
 
 	cmd
@@ -350,6 +489,7 @@ type InterpolateOpts struct {
 	Path            patch.Pointer `long:"path" value-name:"OP-PATH" description:"Extract value out of template (e.g.: /private_key)"`
 	VarErrors       bool          `long:"var-errs"                  description:"Expect all variables to be found, otherwise error"`
 	VarErrorsUnused bool          `long:"var-errs-unused"           description:"Expect all variables to be used, otherwise error"`
+	VarsReport      bool          `long:"vars-report"               description:"Show variable references, their sources and certificate CAs instead of interpolating"`
 
 	cmd
 }
@@ -358,6 +498,30 @@ type InterpolateArgs struct {
 	Manifest FileBytesArg `positional-arg-name:"PATH" description:"Path to a template that will be interpolated"`
 }
 
+type OpsTestOpts struct {
+	Args OpsTestArgs `positional-args:"true" required:"true"`
+
+	VarFlags
+	OpsFlags
+
+	cmd
+}
+
+type OpsTestArgs struct {
+	Manifest     FileBytesArg `positional-arg-name:"PATH"         description:"Path to a manifest that operations are applied to"`
+	Expectations FileBytesArg `positional-arg-name:"EXPECTATIONS" description:"Path to a YAML file with expected paths and values"`
+}
+
+type OpsGenerateOpts struct {
+	Args OpsGenerateArgs `positional-args:"true" required:"true"`
+	cmd
+}
+
+type OpsGenerateArgs struct {
+	From FileBytesArg `positional-arg-name:"FROM" description:"Path to an original manifest"`
+	To   FileBytesArg `positional-arg-name:"TO"   description:"Path to a manifest that operations should produce"`
+}
+
 // Config
 
 type ConfigOpts struct {
@@ -490,6 +654,8 @@ type DeployOpts struct {
 
 	NoRedact bool `long:"no-redact" description:"Show non-redacted manifest diff"`
 
+	DiffFormatFlags
+
 	Recreate                bool                `long:"recreate"                                description:"Recreate all VMs in deployment"`
 	RecreatePersistentDisks bool                `long:"recreate-persistent-disks"               description:"Recreate all persistent disks in deployment"`
 	Fix                     bool                `long:"fix"                                     description:"Recreate an instance with an unresponsive agent instead of erroring"`
@@ -501,6 +667,10 @@ type DeployOpts struct {
 
 	DryRun bool `long:"dry-run" description:"Renders job templates without altering deployment"`
 
+	ValidateProperties bool `long:"validate-properties" description:"Validate job properties against release job specs before deploying"`
+
+	NoTrackFlags
+
 	cmd
 }
 
@@ -508,12 +678,73 @@ type DeployArgs struct {
 	Manifest FileBytesArg `positional-arg-name:"PATH" description:"Path to a manifest file"`
 }
 
+type DiffDeploymentOpts struct {
+	Args DeployArgs `positional-args:"true" required:"true"`
+
+	VarFlags
+	OpsFlags
+
+	NoRedact bool `long:"no-redact" description:"Show non-redacted manifest diff"`
+
+	DiffFormatFlags
+
+	cmd
+}
+
+type DiffManifestsOpts struct {
+	Args DiffManifestsArgs `positional-args:"true"`
+
+	VarFlags
+	OpsFlags
+
+	NoRedact bool `long:"no-redact" description:"Show values interpolated from variables"`
+
+	DiffFormatFlags
+
+	cmd
+}
+
+type DiffManifestsArgs struct {
+	From FileBytesArg `positional-arg-name:"FROM" description:"Path to a manifest file" required:"true"`
+	To   FileBytesArg `positional-arg-name:"TO"   description:"Path to a second manifest file. If omitted, current deployment manifest is compared to FROM"`
+}
+
+type LintManifestOpts struct {
+	Args DeployArgs `positional-args:"true" required:"true"`
+
+	VarFlags
+	OpsFlags
+
+	CloudConfig FileBytesArg `long:"cloud-config" value-name:"PATH" description:"Path to a cloud config file to check azs, vm types, disk types and networks against"`
+	Releases    []string     `long:"release"      value-name:"PATH" description:"Path to a release tarball to check jobs and properties against. Can be used multiple times"`
+
+	cmd
+}
+
+type ValidatePropertiesOpts struct {
+	Args DeployArgs `positional-args:"true" required:"true"`
+
+	VarFlags
+	OpsFlags
+
+	cmd
+}
+
+type DiffFormatFlags struct {
+	DiffFormat string `long:"diff-format" value-name:"FORMAT" description:"Manifest diff format: unified, json or side-by-side" default:"unified" choice:"unified" choice:"json" choice:"side-by-side"`
+}
+
+type NoTrackFlags struct {
+	NoTrack bool `long:"no-track" description:"Print started task ID and exit without waiting for the task to finish"`
+}
+
 type ManifestOpts struct {
 	cmd
 }
 
 type DeleteDeploymentOpts struct {
 	Force bool `long:"force" description:"Ignore errors"`
+	NoTrackFlags
 	cmd
 }
 
@@ -663,6 +894,10 @@ type ExportReleaseArgs struct {
 
 type InspectReleaseOpts struct {
 	Args InspectReleaseArgs `positional-args:"true" required:"true"`
+
+	Links      bool `long:"links"      description:"Show links consumed and provided by jobs"`
+	Properties bool `long:"properties" description:"Show properties defined by jobs"`
+
 	cmd
 }
 
@@ -672,6 +907,10 @@ type InspectReleaseArgs struct {
 
 type InspectLocalReleaseOpts struct {
 	Args InspectLocalReleaseArgs `positional-args:"true" required:"true"`
+
+	Links      bool `long:"links"      description:"Show links consumed and provided by jobs"`
+	Properties bool `long:"properties" description:"Show properties defined by jobs"`
+
 	cmd
 }
 
@@ -700,6 +939,8 @@ type RunErrandOpts struct {
 	DownloadLogs  bool        `long:"download-logs" description:"Download logs"`
 	LogsDirectory DirOrCWDArg `long:"logs-dir" description:"Destination directory for logs" default:"."`
 
+	NoTrackFlags
+
 	cmd
 }
 
@@ -863,6 +1104,8 @@ type StartOpts struct {
 	Converge    bool   `long:"converge" description:"Converge the deployment state before running action (default)"`
 	NoConverge  bool   `long:"no-converge" description:"Act only on specified instance"`
 
+	NoTrackFlags
+
 	cmd
 }
 
@@ -880,6 +1123,8 @@ type StopOpts struct {
 	Converge   bool `long:"converge" description:"Converge the deployment state before running action (default)"`
 	NoConverge bool `long:"no-converge" description:"Act only on specified instance"`
 
+	NoTrackFlags
+
 	cmd
 }
 
@@ -894,6 +1139,8 @@ type RestartOpts struct {
 	Converge   bool `long:"converge" description:"Converge the deployment state before running action (default)"`
 	NoConverge bool `long:"no-converge" description:"Act only on specified instance"`
 
+	NoTrackFlags
+
 	cmd
 }
 
@@ -911,6 +1158,8 @@ type RecreateOpts struct {
 	Converge   bool `long:"converge" description:"Converge the deployment state before running action (default)"`
 	NoConverge bool `long:"no-converge" description:"Act only on specified instance"`
 
+	NoTrackFlags
+
 	cmd
 }
 
@@ -1054,6 +1303,8 @@ type CreateReleaseOpts struct {
 	Tarball FileArg `long:"tarball" description:"Create release tarball at path (e.g. /tmp/release.tgz)"`
 	Force   bool    `long:"force"   description:"Ignore Git dirty state check"`
 
+	Reproducible bool `long:"reproducible" description:"Create archives with normalized file metadata and modification time from SOURCE_DATE_EPOCH or last commit"`
+
 	cmd
 }
 
@@ -1061,6 +1312,31 @@ type CreateReleaseArgs struct {
 	Manifest FileBytesWithPathArg `positional-arg-name:"PATH"`
 }
 
+type RenderJobOpts struct {
+	ReleaseDir DirOrCWDArg `long:"release-dir" value-name:"DIR"  description:"Release directory path if not current working directory" default:"."`
+	Release    FileArg     `long:"release"     value-name:"PATH" description:"Path to a release tarball (used instead of release directory)"`
+	Job        string      `long:"job"         value-name:"NAME" description:"Name of the job to render" required:"true"`
+
+	Properties FileBytesArg `long:"properties" value-name:"PATH" description:"Path to a YAML file with job properties"`
+	Links      FileBytesArg `long:"links"      value-name:"PATH" description:"Path to a YAML file with links consumed by the job"`
+	Spec       FileBytesArg `long:"spec"       value-name:"PATH" description:"Path to a YAML file with instance spec (e.g. id, index, az, bootstrap, networks)"`
+
+	OutputDir DirOrCWDArg `long:"output-dir" value-name:"DIR" description:"Destination directory for rendered templates" default:"."`
+
+	cmd
+}
+
+type JobDocsOpts struct {
+	ReleaseDir DirOrCWDArg `long:"release-dir" value-name:"DIR"  description:"Release directory path if not current working directory" default:"."`
+	Release    FileArg     `long:"release"     value-name:"PATH" description:"Path to a release tarball (used instead of release directory)"`
+	Jobs       []string    `long:"job"         value-name:"NAME" description:"Name of the job to document (all jobs by default)"`
+
+	Format    string      `long:"format"     value-name:"FORMAT" description:"Documentation format (markdown, json)" default:"markdown"`
+	OutputDir DirOrCWDArg `long:"output-dir" value-name:"DIR"    description:"Write documentation for each job into a file in this directory instead of printing it"`
+
+	cmd
+}
+
 type FinalizeReleaseOpts struct {
 	Args FinalizeReleaseArgs `positional-args:"true" required:"true"`
 
@@ -1078,6 +1354,16 @@ type FinalizeReleaseArgs struct {
 	Path string `positional-arg-name:"PATH"`
 }
 
+type VerifyReleaseReproducibleOpts struct {
+	Args VerifyReleaseReproducibleArgs `positional-args:"true" required:"true"`
+
+	cmd
+}
+
+type VerifyReleaseReproducibleArgs struct {
+	Path FileArg `positional-arg-name:"PATH"`
+}
+
 // Blobs
 
 type BlobsOpts struct {
@@ -176,6 +176,14 @@ var _ = Describe("Opts", func() {
 			})
 		})
 
+		Describe("EventJSONOpt", func() {
+			It("contains desired values", func() {
+				Expect(getStructTagForName("EventJSONOpt", opts)).To(Equal(
+					`long:"event-json" description:"Output director task events as newline-delimited JSON"`,
+				))
+			})
+		})
+
 		Describe("TTYOpt", func() {
 			It("contains desired values", func() {
 				Expect(getStructTagForName("TTYOpt", opts)).To(Equal(
@@ -216,6 +224,14 @@ var _ = Describe("Opts", func() {
 			})
 		})
 
+		Describe("CheckEnv", func() {
+			It("contains desired values", func() {
+				Expect(getStructTagForName("CheckEnv", opts)).To(Equal(
+					`command:"check-env" description:"Check BOSH environment for drift from its state"`,
+				))
+			})
+		})
+
 		Describe("StopEnv", func() {
 			It("contains desired values", func() {
 				Expect(getStructTagForName("StopEnv", opts)).To(Equal(
@@ -232,6 +248,54 @@ var _ = Describe("Opts", func() {
 			})
 		})
 
+		Describe("EncryptVarsStore", func() {
+			It("contains desired values", func() {
+				Expect(getStructTagForName("EncryptVarsStore", opts)).To(Equal(
+					`command:"encrypt-vars-store" description:"Encrypt vars store or state file"`,
+				))
+			})
+		})
+
+		Describe("DecryptVarsStore", func() {
+			It("contains desired values", func() {
+				Expect(getStructTagForName("DecryptVarsStore", opts)).To(Equal(
+					`command:"decrypt-vars-store" description:"Decrypt vars store or state file"`,
+				))
+			})
+		})
+
+		Describe("RenderJob", func() {
+			It("contains desired values", func() {
+				Expect(getStructTagForName("RenderJob", opts)).To(Equal(
+					`command:"render-job" description:"Render job templates from a release directory or tarball"`,
+				))
+			})
+		})
+
+		Describe("JobDocs", func() {
+			It("contains desired values", func() {
+				Expect(getStructTagForName("JobDocs", opts)).To(Equal(
+					`command:"job-docs" description:"Generate reference documentation for jobs from a release directory or tarball"`,
+				))
+			})
+		})
+
+		Describe("RotateVars", func() {
+			It("contains desired values", func() {
+				Expect(getStructTagForName("RotateVars", opts)).To(Equal(
+					`command:"rotate-vars" description:"Rotate certificates in vars store"`,
+				))
+			})
+		})
+
+		Describe("State", func() {
+			It("contains desired values", func() {
+				Expect(getStructTagForName("State", opts)).To(Equal(
+					`command:"state" description:"Inspect and edit create-env state"`,
+				))
+			})
+		})
+
 		Describe("Environment", func() {
 			It("contains desired values", func() {
 				Expect(getStructTagForName("Environment", opts)).To(Equal(
@@ -320,6 +384,22 @@ var _ = Describe("Opts", func() {
 			})
 		})
 
+		Describe("OpsTest", func() {
+			It("contains desired values", func() {
+				Expect(getStructTagForName("OpsTest", opts)).To(Equal(
+					`command:"ops-test" description:"Apply operations to a manifest and check expected paths and values"`,
+				))
+			})
+		})
+
+		Describe("OpsGenerate", func() {
+			It("contains desired values", func() {
+				Expect(getStructTagForName("OpsGenerate", opts)).To(Equal(
+					`command:"ops-generate" description:"Generate operations file from the difference between two manifests"`,
+				))
+			})
+		})
+
 		Describe("Config", func() {
 			It("contains desired values", func() {
 				Expect(getStructTagForName("Config", opts)).To(Equal(
@@ -440,6 +520,38 @@ var _ = Describe("Opts", func() {
 			})
 		})
 
+		Describe("DiffDeployment", func() {
+			It("contains desired values", func() {
+				Expect(getStructTagForName("DiffDeployment", opts)).To(Equal(
+					`command:"diff-deployment" description:"Show manifest diff against deployment without deploying"`,
+				))
+			})
+		})
+
+		Describe("DiffManifests", func() {
+			It("contains desired values", func() {
+				Expect(getStructTagForName("DiffManifests", opts)).To(Equal(
+					`command:"diff-manifests" description:"Show diff between two manifests or between deployment and a manifest"`,
+				))
+			})
+		})
+
+		Describe("LintManifest", func() {
+			It("contains desired values", func() {
+				Expect(getStructTagForName("LintManifest", opts)).To(Equal(
+					`command:"lint-manifest" description:"Validate deployment manifest without contacting the Director"`,
+				))
+			})
+		})
+
+		Describe("ValidateProperties", func() {
+			It("contains desired values", func() {
+				Expect(getStructTagForName("ValidateProperties", opts)).To(Equal(
+					`command:"validate-properties" description:"Validate job properties in deployment manifest against release job specs"`,
+				))
+			})
+		})
+
 		Describe("Manifest", func() {
 			It("contains desired values", func() {
 				Expect(getStructTagForName("Manifest", opts)).To(Equal(
@@ -768,6 +880,14 @@ var _ = Describe("Opts", func() {
 			})
 		})
 
+		Describe("VerifyReleaseReproducible", func() {
+			It("contains desired values", func() {
+				Expect(getStructTagForName("VerifyReleaseReproducible", opts)).To(Equal(
+					`command:"verify-release-reproducible" description:"Check that release tarball and its job and package archives were created reproducibly"`,
+				))
+			})
+		})
+
 		Describe("Blobs", func() {
 			It("contains desired values", func() {
 				Expect(getStructTagForName("Blobs", opts)).To(Equal(
@@ -840,7 +960,7 @@ var _ = Describe("Opts", func() {
 
 		It("has --state", func() {
 			Expect(getStructTagForName("StatePath", opts)).To(Equal(
-				`long:"state" value-name:"PATH" description:"State file path"`,
+				`long:"state" value-name:"PATH|URL" description:"State file path or remote state URL (dav+https://HOST/PATH, s3://BUCKET/PATH)"`,
 			))
 		})
 
@@ -861,6 +981,18 @@ var _ = Describe("Opts", func() {
 				`long:"skip-drain" description:"Skip running drain and pre-stop scripts"`,
 			))
 		})
+
+		It("has --dry-run", func() {
+			Expect(getStructTagForName("DryRun", opts)).To(Equal(
+				`long:"dry-run" description:"Show planned changes without making them"`,
+			))
+		})
+
+		It("has --events", func() {
+			Expect(getStructTagForName("EventsPath", opts)).To(Equal(
+				`long:"events" value-name:"PATH" description:"Stream stage events as newline-delimited JSON to a file ('-' for stdout, suppresses other output)"`,
+			))
+		})
 	})
 
 	Describe("CreateEnvArgs", func() {
@@ -894,7 +1026,7 @@ var _ = Describe("Opts", func() {
 
 		It("has --state", func() {
 			Expect(getStructTagForName("StatePath", opts)).To(Equal(
-				`long:"state" value-name:"PATH" description:"State file path"`,
+				`long:"state" value-name:"PATH|URL" description:"State file path or remote state URL (dav+https://HOST/PATH, s3://BUCKET/PATH)"`,
 			))
 		})
 
@@ -903,84 +1035,510 @@ var _ = Describe("Opts", func() {
 				`long:"skip-drain" description:"Skip running drain and pre-stop scripts"`,
 			))
 		})
+
+		It("has --events", func() {
+			Expect(getStructTagForName("EventsPath", opts)).To(Equal(
+				`long:"events" value-name:"PATH" description:"Stream stage events as newline-delimited JSON to a file ('-' for stdout, suppresses other output)"`,
+			))
+		})
+	})
+
+	Describe("DeleteEnvArgs", func() {
+		var args *DeleteEnvArgs
+
+		BeforeEach(func() {
+			args = &DeleteEnvArgs{}
+		})
+
+		Describe("Manifest", func() {
+			It("contains desired values", func() {
+				Expect(getStructTagForName("Manifest", args)).To(Equal(
+					`positional-arg-name:"PATH" description:"Path to a manifest file"`,
+				))
+			})
+		})
+	})
+
+	Describe("CheckEnvOpts", func() {
+		var opts *CheckEnvOpts
+
+		BeforeEach(func() {
+			opts = &CheckEnvOpts{}
+		})
+
+		Describe("Args", func() {
+			It("contains desired values", func() {
+				Expect(getStructTagForName("Args", opts)).To(Equal(`positional-args:"true" required:"true"`))
+			})
+		})
+
+		Describe("StatePath", func() {
+			It("contains desired values", func() {
+				Expect(getStructTagForName("StatePath", opts)).To(Equal(
+					`long:"state" value-name:"PATH|URL" description:"State file path or remote state URL (dav+https://HOST/PATH, s3://BUCKET/PATH)"`,
+				))
+			})
+		})
+
+		Describe("Repair", func() {
+			It("contains desired values", func() {
+				Expect(getStructTagForName("Repair", opts)).To(Equal(
+					`long:"repair" description:"Update state or reattach disks to repair found drift"`,
+				))
+			})
+		})
+	})
+
+	Describe("CheckEnvArgs", func() {
+		var args *CheckEnvArgs
+
+		BeforeEach(func() {
+			args = &CheckEnvArgs{}
+		})
+
+		Describe("Manifest", func() {
+			It("contains desired values", func() {
+				Expect(getStructTagForName("Manifest", args)).To(Equal(
+					`positional-arg-name:"PATH" description:"Path to a manifest file"`,
+				))
+			})
+		})
+	})
+
+	Describe("StopEnvOpts", func() {
+		var opts *StopEnvOpts
+
+		BeforeEach(func() {
+			opts = &StopEnvOpts{}
+		})
+
+		Describe("Args", func() {
+			It("contains desired values", func() {
+				Expect(getStructTagForName("Args", opts)).To(Equal(`positional-args:"true" required:"true"`))
+			})
+		})
+
+		It("has --state", func() {
+			Expect(getStructTagForName("StatePath", opts)).To(Equal(
+				`long:"state" value-name:"PATH|URL" description:"State file path or remote state URL (dav+https://HOST/PATH, s3://BUCKET/PATH)"`,
+			))
+		})
+
+		It("has --skip-drain", func() {
+			Expect(getStructTagForName("SkipDrain", opts)).To(Equal(
+				`long:"skip-drain" description:"Skip running drain and pre-stop scripts"`,
+			))
+		})
+	})
+
+	Describe("StartEnvOpts", func() {
+		var opts *StartEnvOpts
+
+		BeforeEach(func() {
+			opts = &StartEnvOpts{}
+		})
+
+		Describe("Args", func() {
+			It("contains desired values", func() {
+				Expect(getStructTagForName("Args", opts)).To(Equal(`positional-args:"true" required:"true"`))
+			})
+		})
+
+		It("has --state", func() {
+			Expect(getStructTagForName("StatePath", opts)).To(Equal(
+				`long:"state" value-name:"PATH|URL" description:"State file path or remote state URL (dav+https://HOST/PATH, s3://BUCKET/PATH)"`,
+			))
+		})
+
+	})
+
+	Describe("SartStopEnvArgs", func() {
+		var args *StartStopEnvArgs
+
+		BeforeEach(func() {
+			args = &StartStopEnvArgs{}
+		})
+
+		Describe("Manifest", func() {
+			It("contains desired values", func() {
+				Expect(getStructTagForName("Manifest", args)).To(Equal(
+					`positional-arg-name:"PATH" description:"Path to a manifest file"`,
+				))
+			})
+		})
+	})
+
+	Describe("EncryptVarsStoreOpts", func() {
+		var opts *EncryptVarsStoreOpts
+
+		BeforeEach(func() {
+			opts = &EncryptVarsStoreOpts{}
+		})
+
+		Describe("Args", func() {
+			It("contains desired values", func() {
+				Expect(getStructTagForName("Args", opts)).To(Equal(`positional-args:"true" required:"true"`))
+			})
+		})
+	})
+
+	Describe("DecryptVarsStoreOpts", func() {
+		var opts *DecryptVarsStoreOpts
+
+		BeforeEach(func() {
+			opts = &DecryptVarsStoreOpts{}
+		})
+
+		Describe("Args", func() {
+			It("contains desired values", func() {
+				Expect(getStructTagForName("Args", opts)).To(Equal(`positional-args:"true" required:"true"`))
+			})
+		})
+	})
+
+	Describe("RenderJobOpts", func() {
+		var opts *RenderJobOpts
+
+		BeforeEach(func() {
+			opts = &RenderJobOpts{}
+		})
+
+		Describe("ReleaseDir", func() {
+			It("contains desired values", func() {
+				Expect(getStructTagForName("ReleaseDir", opts)).To(Equal(
+					`long:"release-dir" value-name:"DIR" description:"Release directory path if not current working directory" default:"."`,
+				))
+			})
+		})
+
+		Describe("Release", func() {
+			It("contains desired values", func() {
+				Expect(getStructTagForName("Release", opts)).To(Equal(
+					`long:"release" value-name:"PATH" description:"Path to a release tarball (used instead of release directory)"`,
+				))
+			})
+		})
+
+		Describe("Job", func() {
+			It("contains desired values", func() {
+				Expect(getStructTagForName("Job", opts)).To(Equal(
+					`long:"job" value-name:"NAME" description:"Name of the job to render" required:"true"`,
+				))
+			})
+		})
+
+		Describe("Properties", func() {
+			It("contains desired values", func() {
+				Expect(getStructTagForName("Properties", opts)).To(Equal(
+					`long:"properties" value-name:"PATH" description:"Path to a YAML file with job properties"`,
+				))
+			})
+		})
+
+		Describe("Links", func() {
+			It("contains desired values", func() {
+				Expect(getStructTagForName("Links", opts)).To(Equal(
+					`long:"links" value-name:"PATH" description:"Path to a YAML file with links consumed by the job"`,
+				))
+			})
+		})
+
+		Describe("Spec", func() {
+			It("contains desired values", func() {
+				Expect(getStructTagForName("Spec", opts)).To(Equal(
+					`long:"spec" value-name:"PATH" description:"Path to a YAML file with instance spec (e.g. id, index, az, bootstrap, networks)"`,
+				))
+			})
+		})
+
+		Describe("OutputDir", func() {
+			It("contains desired values", func() {
+				Expect(getStructTagForName("OutputDir", opts)).To(Equal(
+					`long:"output-dir" value-name:"DIR" description:"Destination directory for rendered templates" default:"."`,
+				))
+			})
+		})
+	})
+
+	Describe("JobDocsOpts", func() {
+		var opts *JobDocsOpts
+
+		BeforeEach(func() {
+			opts = &JobDocsOpts{}
+		})
+
+		Describe("ReleaseDir", func() {
+			It("contains desired values", func() {
+				Expect(getStructTagForName("ReleaseDir", opts)).To(Equal(
+					`long:"release-dir" value-name:"DIR" description:"Release directory path if not current working directory" default:"."`,
+				))
+			})
+		})
+
+		Describe("Release", func() {
+			It("contains desired values", func() {
+				Expect(getStructTagForName("Release", opts)).To(Equal(
+					`long:"release" value-name:"PATH" description:"Path to a release tarball (used instead of release directory)"`,
+				))
+			})
+		})
+
+		Describe("Jobs", func() {
+			It("contains desired values", func() {
+				Expect(getStructTagForName("Jobs", opts)).To(Equal(
+					`long:"job" value-name:"NAME" description:"Name of the job to document (all jobs by default)"`,
+				))
+			})
+		})
+
+		Describe("Format", func() {
+			It("contains desired values", func() {
+				Expect(getStructTagForName("Format", opts)).To(Equal(
+					`long:"format" value-name:"FORMAT" description:"Documentation format (markdown, json)" default:"markdown"`,
+				))
+			})
+		})
+
+		Describe("OutputDir", func() {
+			It("contains desired values", func() {
+				Expect(getStructTagForName("OutputDir", opts)).To(Equal(
+					`long:"output-dir" value-name:"DIR" description:"Write documentation for each job into a file in this directory instead of printing it"`,
+				))
+			})
+		})
+	})
+
+	Describe("RotateVarsOpts", func() {
+		var opts *RotateVarsOpts
+
+		BeforeEach(func() {
+			opts = &RotateVarsOpts{}
+		})
+
+		Describe("Args", func() {
+			It("contains desired values", func() {
+				Expect(getStructTagForName("Args", opts)).To(Equal(`positional-args:"true" required:"true"`))
+			})
+		})
+
+		Describe("Names", func() {
+			It("contains desired values", func() {
+				Expect(getStructTagForName("Names", opts)).To(Equal(
+					`long:"name" value-name:"NAME" description:"Rotate only given certificate variable (multiple allowed)"`,
+				))
+			})
+		})
+
+		Describe("CAPhase", func() {
+			It("contains desired values", func() {
+				Expect(getStructTagForName("CAPhase", opts)).To(Equal(
+					`long:"ca-phase" value-name:"PHASE" description:"Rotate CAs: 'transition' adds new CAs to trust bundles, 'switch' signs certificates with new CAs"`,
+				))
+			})
+		})
+
+		Describe("DryRun", func() {
+			It("contains desired values", func() {
+				Expect(getStructTagForName("DryRun", opts)).To(Equal(
+					`long:"dry-run" description:"Only show certificate expiry dates"`,
+				))
+			})
+		})
+	})
+
+	Describe("RotateVarsArgs", func() {
+		var opts *RotateVarsArgs
+
+		BeforeEach(func() {
+			opts = &RotateVarsArgs{}
+		})
+
+		Describe("Manifest", func() {
+			It("contains desired values", func() {
+				Expect(getStructTagForName("Manifest", opts)).To(Equal(
+					`positional-arg-name:"PATH" description:"Path to a manifest file"`,
+				))
+			})
+		})
+	})
+
+	Describe("StateOpts", func() {
+		var opts *StateOpts
+
+		BeforeEach(func() {
+			opts = &StateOpts{}
+		})
+
+		Describe("Show", func() {
+			It("contains desired values", func() {
+				Expect(getStructTagForName("Show", opts)).To(Equal(
+					`command:"show" description:"Show create-env state"`,
+				))
+			})
+		})
+
+		Describe("SetVMCID", func() {
+			It("contains desired values", func() {
+				Expect(getStructTagForName("SetVMCID", opts)).To(Equal(
+					`command:"set-vm-cid" description:"Set current VM CID in create-env state"`,
+				))
+			})
+		})
+
+		Describe("ForgetDisk", func() {
+			It("contains desired values", func() {
+				Expect(getStructTagForName("ForgetDisk", opts)).To(Equal(
+					`command:"forget-disk" description:"Remove disk from create-env state"`,
+				))
+			})
+		})
+
+		Describe("ImportDisk", func() {
+			It("contains desired values", func() {
+				Expect(getStructTagForName("ImportDisk", opts)).To(Equal(
+					`command:"import-disk" description:"Add existing disk to create-env state as current disk"`,
+				))
+			})
+		})
+
+		Describe("ForgetStemcell", func() {
+			It("contains desired values", func() {
+				Expect(getStructTagForName("ForgetStemcell", opts)).To(Equal(
+					`command:"forget-stemcell" description:"Remove stemcell from create-env state"`,
+				))
+			})
+		})
+
+		Describe("Validate", func() {
+			It("contains desired values", func() {
+				Expect(getStructTagForName("Validate", opts)).To(Equal(
+					`command:"validate" description:"Check create-env state for inconsistencies"`,
+				))
+			})
+		})
+	})
+
+	Describe("StateFlags", func() {
+		var opts *StateFlags
+
+		BeforeEach(func() {
+			opts = &StateFlags{}
+		})
+
+		Describe("StatePath", func() {
+			It("contains desired values", func() {
+				Expect(getStructTagForName("StatePath", opts)).To(Equal(
+					`long:"state" value-name:"PATH|URL" description:"State file path or remote state URL (dav+https://HOST/PATH, s3://BUCKET/PATH)" required:"true"`,
+				))
+			})
+		})
+	})
+
+	Describe("StateImportDiskOpts", func() {
+		var opts *StateImportDiskOpts
+
+		BeforeEach(func() {
+			opts = &StateImportDiskOpts{}
+		})
+
+		Describe("Args", func() {
+			It("contains desired values", func() {
+				Expect(getStructTagForName("Args", opts)).To(Equal(`positional-args:"true" required:"true"`))
+			})
+		})
+
+		Describe("Size", func() {
+			It("contains desired values", func() {
+				Expect(getStructTagForName("Size", opts)).To(Equal(
+					`long:"size" value-name:"MB" description:"Disk size in MB" required:"true"`,
+				))
+			})
+		})
+
+		Describe("CloudProperties", func() {
+			It("contains desired values", func() {
+				Expect(getStructTagForName("CloudProperties", opts)).To(Equal(
+					`long:"cloud-properties" value-name:"YAML" description:"Disk cloud properties"`,
+				))
+			})
+		})
 	})
 
-	Describe("DeleteEnvArgs", func() {
-		var args *DeleteEnvArgs
+	Describe("StateSetVMCIDArgs", func() {
+		var args *StateSetVMCIDArgs
 
 		BeforeEach(func() {
-			args = &DeleteEnvArgs{}
+			args = &StateSetVMCIDArgs{}
 		})
 
-		Describe("Manifest", func() {
+		Describe("CID", func() {
 			It("contains desired values", func() {
-				Expect(getStructTagForName("Manifest", args)).To(Equal(
-					`positional-arg-name:"PATH" description:"Path to a manifest file"`,
+				Expect(getStructTagForName("CID", args)).To(Equal(
+					`positional-arg-name:"CID" description:"VM CID (empty to clear)"`,
 				))
 			})
 		})
 	})
 
-	Describe("StopEnvOpts", func() {
-		var opts *StopEnvOpts
+	Describe("StateDiskArgs", func() {
+		var args *StateDiskArgs
 
 		BeforeEach(func() {
-			opts = &StopEnvOpts{}
+			args = &StateDiskArgs{}
 		})
 
-		Describe("Args", func() {
+		Describe("CID", func() {
 			It("contains desired values", func() {
-				Expect(getStructTagForName("Args", opts)).To(Equal(`positional-args:"true" required:"true"`))
+				Expect(getStructTagForName("CID", args)).To(Equal(
+					`positional-arg-name:"CID" description:"Disk CID"`,
+				))
 			})
 		})
+	})
 
-		It("has --state", func() {
-			Expect(getStructTagForName("StatePath", opts)).To(Equal(
-				`long:"state" value-name:"PATH" description:"State file path"`,
-			))
+	Describe("StateStemcellArgs", func() {
+		var args *StateStemcellArgs
+
+		BeforeEach(func() {
+			args = &StateStemcellArgs{}
 		})
 
-		It("has --skip-drain", func() {
-			Expect(getStructTagForName("SkipDrain", opts)).To(Equal(
-				`long:"skip-drain" description:"Skip running drain and pre-stop scripts"`,
-			))
+		Describe("CID", func() {
+			It("contains desired values", func() {
+				Expect(getStructTagForName("CID", args)).To(Equal(
+					`positional-arg-name:"CID" description:"Stemcell CID"`,
+				))
+			})
 		})
 	})
 
-	Describe("StartEnvOpts", func() {
-		var opts *StartEnvOpts
+	Describe("EncryptVarsStoreArgs", func() {
+		var args *EncryptVarsStoreArgs
 
 		BeforeEach(func() {
-			opts = &StartEnvOpts{}
+			args = &EncryptVarsStoreArgs{}
 		})
 
-		Describe("Args", func() {
+		Describe("Path", func() {
 			It("contains desired values", func() {
-				Expect(getStructTagForName("Args", opts)).To(Equal(`positional-args:"true" required:"true"`))
+				Expect(getStructTagForName("Path", args)).To(Equal(
+					`positional-arg-name:"PATH" description:"Path to a vars store or state file"`,
+				))
 			})
 		})
-
-		It("has --state", func() {
-			Expect(getStructTagForName("StatePath", opts)).To(Equal(
-				`long:"state" value-name:"PATH" description:"State file path"`,
-			))
-		})
-
 	})
 
-	Describe("SartStopEnvArgs", func() {
-		var args *StartStopEnvArgs
+	Describe("EncryptionFlags", func() {
+		var flags *EncryptionFlags
 
 		BeforeEach(func() {
-			args = &StartStopEnvArgs{}
+			flags = &EncryptionFlags{}
 		})
 
-		Describe("Manifest", func() {
-			It("contains desired values", func() {
-				Expect(getStructTagForName("Manifest", args)).To(Equal(
-					`positional-arg-name:"PATH" description:"Path to a manifest file"`,
-				))
-			})
+		It("has --encryption-key-file", func() {
+			Expect(getStructTagForName("EncryptionKeyFile", flags)).To(Equal(
+				`long:"encryption-key-file" value-name:"PATH" description:"Encrypt/decrypt vars store and state files with key from a file (or set BOSH_ENCRYPTION_KEY)" env:"BOSH_ENCRYPTION_KEY_FILE"`,
+			))
 		})
 	})
 
@@ -1309,6 +1867,30 @@ var _ = Describe("Opts", func() {
 				))
 			})
 		})
+
+		Describe("Follow", func() {
+			It("contains desired values", func() {
+				Expect(getStructTagForName("Follow", opts)).To(Equal(
+					`long:"follow" short:"f" description:"Follow output of running tasks until all of them finish"`,
+				))
+			})
+		})
+
+		Describe("User", func() {
+			It("contains desired values", func() {
+				Expect(getStructTagForName("User", opts)).To(Equal(
+					`long:"user" description:"Show only tasks started by user"`,
+				))
+			})
+		})
+
+		Describe("ContextID", func() {
+			It("contains desired values", func() {
+				Expect(getStructTagForName("ContextID", opts)).To(Equal(
+					`long:"context-id" description:"Show only tasks with context ID"`,
+				))
+			})
+		})
 	})
 
 	Describe("CancelTaskOpts", func() {
@@ -1430,6 +2012,12 @@ var _ = Describe("Opts", func() {
 				`long:"var-errs-unused" description:"Expect all variables to be used, otherwise error"`,
 			))
 		})
+
+		It("has VarsReport", func() {
+			Expect(getStructTagForName("VarsReport", &opts)).To(Equal(
+				`long:"vars-report" description:"Show variable references, their sources and certificate CAs instead of interpolating"`,
+			))
+		})
 	})
 
 	Describe("InterpolateArgs", func() {
@@ -1448,6 +2036,54 @@ var _ = Describe("Opts", func() {
 		})
 	})
 
+	Describe("OpsTestArgs", func() {
+		var opts *OpsTestArgs
+
+		BeforeEach(func() {
+			opts = &OpsTestArgs{}
+		})
+
+		Describe("Manifest", func() {
+			It("contains desired values", func() {
+				Expect(getStructTagForName("Manifest", opts)).To(Equal(
+					`positional-arg-name:"PATH" description:"Path to a manifest that operations are applied to"`,
+				))
+			})
+		})
+
+		Describe("Expectations", func() {
+			It("contains desired values", func() {
+				Expect(getStructTagForName("Expectations", opts)).To(Equal(
+					`positional-arg-name:"EXPECTATIONS" description:"Path to a YAML file with expected paths and values"`,
+				))
+			})
+		})
+	})
+
+	Describe("OpsGenerateArgs", func() {
+		var opts *OpsGenerateArgs
+
+		BeforeEach(func() {
+			opts = &OpsGenerateArgs{}
+		})
+
+		Describe("From", func() {
+			It("contains desired values", func() {
+				Expect(getStructTagForName("From", opts)).To(Equal(
+					`positional-arg-name:"FROM" description:"Path to an original manifest"`,
+				))
+			})
+		})
+
+		Describe("To", func() {
+			It("contains desired values", func() {
+				Expect(getStructTagForName("To", opts)).To(Equal(
+					`positional-arg-name:"TO" description:"Path to a manifest that operations should produce"`,
+				))
+			})
+		})
+	})
+
 	Describe("UpdateCloudConfigOpts", func() {
 		var opts *UpdateCloudConfigOpts
 
@@ -1609,6 +2245,14 @@ var _ = Describe("Opts", func() {
 			})
 		})
 
+		Describe("ValidateProperties", func() {
+			It("contains desired values", func() {
+				Expect(getStructTagForName("ValidateProperties", opts)).To(Equal(
+					`long:"validate-properties" description:"Validate job properties against release job specs before deploying"`,
+				))
+			})
+		})
+
 		Describe("FixReleases", func() {
 			It("contains desired values", func() {
 				Expect(getStructTagForName("FixReleases", opts)).To(Equal(
@@ -1634,6 +2278,118 @@ var _ = Describe("Opts", func() {
 		})
 	})
 
+	Describe("DiffDeploymentOpts", func() {
+		var opts *DiffDeploymentOpts
+
+		BeforeEach(func() {
+			opts = &DiffDeploymentOpts{}
+		})
+
+		Describe("Args", func() {
+			It("contains desired values", func() {
+				Expect(getStructTagForName("Args", opts)).To(Equal(`positional-args:"true" required:"true"`))
+			})
+		})
+
+		Describe("NoRedact", func() {
+			It("contains desired values", func() {
+				Expect(getStructTagForName("NoRedact", opts)).To(Equal(
+					`long:"no-redact" description:"Show non-redacted manifest diff"`,
+				))
+			})
+		})
+	})
+
+	Describe("DiffManifestsOpts", func() {
+		var opts *DiffManifestsOpts
+
+		BeforeEach(func() {
+			opts = &DiffManifestsOpts{}
+		})
+
+		Describe("Args", func() {
+			It("contains desired values", func() {
+				Expect(getStructTagForName("Args", opts)).To(Equal(`positional-args:"true"`))
+			})
+		})
+
+		Describe("NoRedact", func() {
+			It("contains desired values", func() {
+				Expect(getStructTagForName("NoRedact", opts)).To(Equal(
+					`long:"no-redact" description:"Show values interpolated from variables"`,
+				))
+			})
+		})
+	})
+
+	Describe("DiffManifestsArgs", func() {
+		var opts *DiffManifestsArgs
+
+		BeforeEach(func() {
+			opts = &DiffManifestsArgs{}
+		})
+
+		Describe("From", func() {
+			It("contains desired values", func() {
+				Expect(getStructTagForName("From", opts)).To(Equal(
+					`positional-arg-name:"FROM" description:"Path to a manifest file" required:"true"`,
+				))
+			})
+		})
+
+		Describe("To", func() {
+			It("contains desired values", func() {
+				Expect(getStructTagForName("To", opts)).To(Equal(
+					`positional-arg-name:"TO" description:"Path to a second manifest file. If omitted, current deployment manifest is compared to FROM"`,
+				))
+			})
+		})
+	})
+
+	Describe("LintManifestOpts", func() {
+		var opts *LintManifestOpts
+
+		BeforeEach(func() {
+			opts = &LintManifestOpts{}
+		})
+
+		Describe("Args", func() {
+			It("contains desired values", func() {
+				Expect(getStructTagForName("Args", opts)).To(Equal(`positional-args:"true" required:"true"`))
+			})
+		})
+
+		Describe("CloudConfig", func() {
+			It("contains desired values", func() {
+				Expect(getStructTagForName("CloudConfig", opts)).To(Equal(
+					`long:"cloud-config" value-name:"PATH" description:"Path to a cloud config file to check azs, vm types, disk types and networks against"`,
+				))
+			})
+		})
+
+		Describe("Releases", func() {
+			It("contains desired values", func() {
+				Expect(getStructTagForName("Releases", opts)).To(Equal(
+					`long:"release" value-name:"PATH" description:"Path to a release tarball to check jobs and properties against. Can be used multiple times"`,
+				))
+			})
+		})
+	})
+
+	Describe("ValidatePropertiesOpts", func() {
+		var opts *ValidatePropertiesOpts
+
+		BeforeEach(func() {
+			opts = &ValidatePropertiesOpts{}
+		})
+
+		Describe("Args", func() {
+			It("contains desired values", func() {
+				Expect(getStructTagForName("Args", opts)).To(Equal(`positional-args:"true" required:"true"`))
+			})
+		})
+	})
+
 	Describe("DeleteDeploymentOpts", func() {
 		var opts *DeleteDeploymentOpts
 
@@ -2015,6 +2771,22 @@ var _ = Describe("Opts", func() {
 				Expect(getStructTagForName("Args", opts)).To(Equal(`positional-args:"true" required:"true"`))
 			})
 		})
+
+		Describe("Links", func() {
+			It("contains desired values", func() {
+				Expect(getStructTagForName("Links", opts)).To(Equal(
+					`long:"links" description:"Show links consumed and provided by jobs"`,
+				))
+			})
+		})
+
+		Describe("Properties", func() {
+			It("contains desired values", func() {
+				Expect(getStructTagForName("Properties", opts)).To(Equal(
+					`long:"properties" description:"Show properties defined by jobs"`,
+				))
+			})
+		})
 	})
 
 	Describe("InspectReleaseArgs", func() {
@@ -2045,6 +2817,22 @@ var _ = Describe("Opts", func() {
 				Expect(getStructTagForName("Args", opts)).To(Equal(`positional-args:"true" required:"true"`))
 			})
 		})
+
+		Describe("Links", func() {
+			It("contains desired values", func() {
+				Expect(getStructTagForName("Links", opts)).To(Equal(
+					`long:"links" description:"Show links consumed and provided by jobs"`,
+				))
+			})
+		})
+
+		Describe("Properties", func() {
+			It("contains desired values", func() {
+				Expect(getStructTagForName("Properties", opts)).To(Equal(
+					`long:"properties" description:"Show properties defined by jobs"`,
+				))
+			})
+		})
 	})
 
 	Describe("InspectLocalReleaseArgs", func() {
@@ -2063,6 +2851,38 @@ var _ = Describe("Opts", func() {
 		})
 	})
 
+	Describe("DiffFormatFlags", func() {
+		var opts *DiffFormatFlags
+
+		BeforeEach(func() {
+			opts = &DiffFormatFlags{}
+		})
+
+		Describe("DiffFormat", func() {
+			It("contains desired values", func() {
+				Expect(getStructTagForName("DiffFormat", opts)).To(Equal(
+					`long:"diff-format" value-name:"FORMAT" description:"Manifest diff format: unified, json or side-by-side" default:"unified" choice:"unified" choice:"json" choice:"side-by-side"`,
+				))
+			})
+		})
+	})
+
+	Describe("NoTrackFlags", func() {
+		var opts *NoTrackFlags
+
+		BeforeEach(func() {
+			opts = &NoTrackFlags{}
+		})
+
+		Describe("NoTrack", func() {
+			It("contains desired values", func() {
+				Expect(getStructTagForName("NoTrack", opts)).To(Equal(
+					`long:"no-track" description:"Print started task ID and exit without waiting for the task to finish"`,
+				))
+			})
+		})
+	})
+
 	Describe("InstanceGroupOrInstanceSlugFlags", func() {
 		var opts *InstanceGroupOrInstanceSlugFlags
 
@@ -3085,8 +3905,29 @@ var _ = Describe("Opts", func() {
 				))
 			})
 		})
+
+		Describe("Reproducible", func() {
+			It("contains desired values", func() {
+				Expect(getStructTagForName("Reproducible", opts)).To(Equal(
+					`long:"reproducible" description:"Create archives with normalized file metadata and modification time from SOURCE_DATE_EPOCH or last commit"`,
+				))
+			})
+		})
 	})
 
+	Describe("VerifyReleaseReproducibleOpts", func() {
+		var opts *VerifyReleaseReproducibleOpts
+
+		BeforeEach(func() {
+			opts = &VerifyReleaseReproducibleOpts{}
+		})
+
+		Describe("Args", func() {
+			It("contains desired values", func() {
+				Expect(getStructTagForName("Args", opts)).To(Equal(`positional-args:"true" required:"true"`))
+			})
+		})
+	})
 	Describe("Sha2ifyReleaseOpts", func() {
 		var opts *Sha2ifyReleaseOpts
 
@@ -8,14 +8,24 @@ import (
 
 // Shared
 type VarFlags struct {
-	VarKVs      []boshtpl.VarKV       `long:"var"        short:"v" value-name:"VAR=VALUE" description:"Set variable"`
-	VarFiles    []boshtpl.VarFileArg  `long:"var-file"             value-name:"VAR=PATH"  description:"Set variable to file contents"`
-	VarsFiles   []boshtpl.VarsFileArg `long:"vars-file"  short:"l" value-name:"PATH"      description:"Load variables from a YAML file"`
-	VarsEnvs    []boshtpl.VarsEnvArg  `long:"vars-env"             value-name:"PREFIX"    description:"Load variables from environment variables (e.g.: 'MY' to load MY_var=value)"`
-	VarsFSStore VarsFSStore           `long:"vars-store"           value-name:"PATH"      description:"Load/save variables from/to a YAML file"`
+	VarKVs    []boshtpl.VarKV       `long:"var"        short:"v" value-name:"VAR=VALUE" description:"Set variable"`
+	VarFiles  []boshtpl.VarFileArg  `long:"var-file"             value-name:"VAR=PATH"  description:"Set variable to file contents"`
+	VarsFiles []boshtpl.VarsFileArg `long:"vars-file"  short:"l" value-name:"PATH"      description:"Load variables from a YAML file"`
+	VarsEnvs  []boshtpl.VarsEnvArg  `long:"vars-env"             value-name:"PREFIX"    description:"Load variables from environment variables (e.g.: 'MY' to load MY_var=value)"`
+	VarsStore VarsStore             `long:"vars-store"           value-name:"PATH|URL"  description:"Load/save variables from/to a YAML file or a variables store URL (file://PATH, exec://COMMAND)"`
 }
 
 func (f VarFlags) AsVariables() boshtpl.Variables {
+	return f.asVariables(true)
+}
+
+// AsNonGeneratingVariables returns the same variables as AsVariables
+// except that missing variables are never generated and saved to the vars store.
+func (f VarFlags) AsNonGeneratingVariables() boshtpl.Variables {
+	return f.asVariables(false)
+}
+
+func (f VarFlags) asVariables(generating bool) boshtpl.Variables {
 	var firstToUse []boshtpl.Variables
 
 	staticVars := boshtpl.StaticVariables{}
@@ -44,17 +54,70 @@ func (f VarFlags) AsVariables() boshtpl.Variables {
 
 	firstToUse = append(firstToUse, staticVars)
 
-	store := &f.VarsFSStore
+	store := &f.VarsStore
 
-	if f.VarsFSStore.IsSet() {
-		firstToUse = append(firstToUse, store)
+	if f.VarsStore.IsSet() {
+		if generating {
+			firstToUse = append(firstToUse, store)
+		} else {
+			firstToUse = append(firstToUse, store.NonGenerating())
+		}
 	}
 
 	vars := boshtpl.NewMultiVars(firstToUse)
 
-	if f.VarsFSStore.IsSet() {
+	if f.VarsStore.IsSet() && generating {
 		store.ValueGeneratorFactory = cfgtypes.NewValueGeneratorConcrete(NewVarsCertLoader(vars))
 	}
 
 	return vars
 }
+
+// AsVarsSources returns variables grouped by flag in the order of precedence
+// used by AsVariables; vars store is the only source able to generate values.
+func (f VarFlags) AsVarsSources() []boshtpl.VarsSource {
+	varKVs := boshtpl.StaticVariables{}
+
+	for _, kv := range f.VarKVs {
+		varKVs[kv.Name] = kv.Value
+	}
+
+	varFiles := boshtpl.StaticVariables{}
+
+	for i, _ := range f.VarFiles {
+		for k, v := range f.VarFiles[i].Vars {
+			varFiles[k] = v
+		}
+	}
+
+	varsFiles := boshtpl.StaticVariables{}
+
+	for i, _ := range f.VarsFiles {
+		for k, v := range f.VarsFiles[i].Vars {
+			varsFiles[k] = v
+		}
+	}
+
+	varsEnvs := boshtpl.StaticVariables{}
+
+	for i, _ := range f.VarsEnvs {
+		for k, v := range f.VarsEnvs[i].Vars {
+			varsEnvs[k] = v
+		}
+	}
+
+	sources := []boshtpl.VarsSource{
+		{Name: "--var", Variables: varKVs},
+		{Name: "--var-file", Variables: varFiles},
+		{Name: "--vars-file", Variables: varsFiles},
+		{Name: "--vars-env", Variables: varsEnvs},
+	}
+
+	if f.VarsStore.IsSet() {
+		store := &f.VarsStore
+
+		sources = append(sources, boshtpl.VarsSource{Name: "vars-store", Variables: store, Generating: true})
+	}
+
+	return sources
+}
@@ -9,6 +9,7 @@ import (
 	"gopkg.in/yaml.v2"
 
 	. "github.com/cloudfoundry/bosh-cli/cmd/opts"
+	bicrypto "github.com/cloudfoundry/bosh-cli/crypto"
 	. "github.com/cloudfoundry/bosh-cli/director/template"
 )
 
@@ -92,7 +93,7 @@ var _ = Describe("VarFlags", func() {
 		})
 
 		It("adds vars store as last resort if configured", func() {
-			varsStore := &VarsFSStore{FS: fakesys.NewFakeFileSystem()}
+			varsStore := &VarsStore{FS: fakesys.NewFakeFileSystem()}
 
 			err := varsStore.UnmarshalFlag("/file")
 			Expect(err).ToNot(HaveOccurred())
@@ -119,7 +120,7 @@ env: store
 				VarsEnvs: []VarsEnvArg{
 					{Vars: StaticVariables{"env": "env"}},
 				},
-				VarsFSStore: *varsStore,
+				VarsStore: *varsStore,
 			}
 
 			vars := flags.AsVariables()
@@ -140,8 +141,27 @@ env: store
 			}
 		})
 
+		It("keeps vars store encryption configured before variables are built", func() {
+			varsStore := &VarsStore{FS: fakesys.NewFakeFileSystem()}
+
+			err := varsStore.UnmarshalFlag("/file")
+			Expect(err).ToNot(HaveOccurred())
+
+			varsStore.UseEncryptor(bicrypto.NewEncryptor([]byte("key")))
+
+			flags := VarFlags{VarsStore: *varsStore}
+
+			_, found, err := flags.AsVariables().Get(VariableDefinition{Name: "password", Type: "password"})
+			Expect(err).ToNot(HaveOccurred())
+			Expect(found).To(BeTrue())
+
+			contents, err := varsStore.FS.ReadFile("/file")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(bicrypto.IsEncrypted(contents)).To(BeTrue())
+		})
+
 		It("configures vars store to have ability to look up all variables for value generation", func() {
-			varsStore := &VarsFSStore{FS: fakesys.NewFakeFileSystem()}
+			varsStore := &VarsStore{FS: fakesys.NewFakeFileSystem()}
 			varsStore.UnmarshalFlag("/file")
 
 			// https://github.com/cloudfoundry/bosh-lite/blob/master/ca/certs as an example
@@ -155,7 +175,7 @@ env: store
 						"private_key": caPrivKey,
 					}},
 				},
-				VarsFSStore: *varsStore,
+				VarsStore: *varsStore,
 			}
 
 			vars := flags.AsVariables()
@@ -178,4 +198,53 @@ env: store
 			Expect(valRaw["ca"].(string)).To(Equal(caCert))
 		})
 	})
+
+	Describe("AsVarsSources", func() {
+		It("returns each kind of variables as a separate source in order of precedence", func() {
+			flags := VarFlags{
+				VarKVs: []VarKV{
+					{Name: "kv", Value: "kv"},
+				},
+				VarFiles: []VarFileArg{
+					{Vars: StaticVariables{"var_file": "var_file"}},
+				},
+				VarsFiles: []VarsFileArg{
+					{Vars: StaticVariables{"file": "file1"}},
+					{Vars: StaticVariables{"file": "file2"}},
+				},
+				VarsEnvs: []VarsEnvArg{
+					{Vars: StaticVariables{"env": "env"}},
+				},
+			}
+
+			Expect(flags.AsVarsSources()).To(Equal([]VarsSource{
+				{Name: "--var", Variables: StaticVariables{"kv": "kv"}},
+				{Name: "--var-file", Variables: StaticVariables{"var_file": "var_file"}},
+				{Name: "--vars-file", Variables: StaticVariables{"file": "file2"}},
+				{Name: "--vars-env", Variables: StaticVariables{"env": "env"}},
+			}))
+		})
+
+		It("adds vars store as last generating source if configured", func() {
+			varsStore := &VarsStore{FS: fakesys.NewFakeFileSystem()}
+
+			err := varsStore.UnmarshalFlag("/file")
+			Expect(err).ToNot(HaveOccurred())
+
+			err = varsStore.FS.WriteFileString("/file", "store: store\n")
+			Expect(err).ToNot(HaveOccurred())
+
+			sources := VarFlags{VarsStore: *varsStore}.AsVarsSources()
+			Expect(sources).To(HaveLen(5))
+
+			storeSource := sources[4]
+			Expect(storeSource.Name).To(Equal("vars-store"))
+			Expect(storeSource.Generating).To(BeTrue())
+
+			val, found, err := storeSource.Variables.Get(VariableDefinition{Name: "store"})
+			Expect(err).ToNot(HaveOccurred())
+			Expect(found).To(BeTrue())
+			Expect(val).To(Equal("store"))
+		})
+	})
 })
@@ -0,0 +1,160 @@
+package opts
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+
+	bosherr "github.com/cloudfoundry/bosh-utils/errors"
+	boshsys "github.com/cloudfoundry/bosh-utils/system"
+	"gopkg.in/yaml.v2"
+
+	boshtpl "github.com/cloudfoundry/bosh-cli/director/template"
+)
+
+// VarsExecStore delegates variable storage to an external command.
+// Each operation runs the command once with a JSON request on stdin
+// and expects a JSON response on stdout:
+//
+//	{"operation":"get","name":"x"}           -> {"found":true,"value":...}
+//	{"operation":"set","name":"x","value":.} -> {}
+//	{"operation":"list"}                     -> {"names":["x"]}
+//
+// Any response may include "error" to report a failure.
+type VarsExecStore struct {
+	CmdRunner boshsys.CmdRunner
+	Command   string
+}
+
+var _ VarsStoreBackend = VarsExecStore{}
+
+type varsExecRequest struct {
+	Operation string      `json:"operation"`
+	Name      string      `json:"name,omitempty"`
+	Value     interface{} `json:"value,omitempty"`
+}
+
+type varsExecResponse struct {
+	Found bool            `json:"found"`
+	Value json.RawMessage `json:"value"`
+	Names []string        `json:"names"`
+	Error string          `json:"error"`
+}
+
+func (s VarsExecStore) Find(name string) (interface{}, bool, error) {
+	resp, err := s.run(varsExecRequest{Operation: "get", Name: name})
+	if err != nil {
+		return nil, false, err
+	}
+
+	if !resp.Found {
+		return nil, false, nil
+	}
+
+	var val interface{}
+
+	// YAML is a superset of JSON; decoding as YAML keeps values
+	// in the same shape as values loaded from a vars file
+	err = yaml.Unmarshal(resp.Value, &val)
+	if err != nil {
+		return nil, false, bosherr.WrapErrorf(err, "Deserializing variable '%s' from variables store command", name)
+	}
+
+	return val, true, nil
+}
+
+func (s VarsExecStore) Put(name string, val interface{}) error {
+	jsonVal, err := s.jsonCompatible(val)
+	if err != nil {
+		return bosherr.WrapErrorf(err, "Serializing variable '%s'", name)
+	}
+
+	_, err = s.run(varsExecRequest{Operation: "set", Name: name, Value: jsonVal})
+
+	return err
+}
+
+func (s VarsExecStore) List() ([]boshtpl.VariableDefinition, error) {
+	resp, err := s.run(varsExecRequest{Operation: "list"})
+	if err != nil {
+		return nil, err
+	}
+
+	var defs []boshtpl.VariableDefinition
+
+	for _, name := range resp.Names {
+		defs = append(defs, boshtpl.VariableDefinition{Name: name})
+	}
+
+	return defs, nil
+}
+
+func (s VarsExecStore) run(req varsExecRequest) (varsExecResponse, error) {
+	var resp varsExecResponse
+
+	reqBytes, err := json.Marshal(req)
+	if err != nil {
+		return resp, bosherr.WrapErrorf(err, "Serializing variables store request")
+	}
+
+	cmd := boshsys.Command{
+		Name:  s.Command,
+		Stdin: bytes.NewReader(reqBytes),
+		Quiet: true,
+	}
+
+	stdout, _, _, err := s.CmdRunner.RunComplexCommand(cmd)
+	if err != nil {
+		return resp, bosherr.WrapErrorf(err, "Running variables store command '%s' (%s)", s.Command, req.Operation)
+	}
+
+	err = json.Unmarshal([]byte(stdout), &resp)
+	if err != nil {
+		return resp, bosherr.WrapErrorf(err, "Deserializing variables store command '%s' response", s.Command)
+	}
+
+	if len(resp.Error) > 0 {
+		return resp, bosherr.Errorf("Variables store command '%s' (%s) failed: %s", s.Command, req.Operation, resp.Error)
+	}
+
+	return resp, nil
+}
+
+// jsonCompatible converts generated values (structs, maps with interface keys)
+// into values that encoding/json can serialize.
+func (s VarsExecStore) jsonCompatible(val interface{}) (interface{}, error) {
+	bytes, err := yaml.Marshal(val)
+	if err != nil {
+		return nil, err
+	}
+
+	var generic interface{}
+
+	err = yaml.Unmarshal(bytes, &generic)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.stringKeys(generic), nil
+}
+
+func (s VarsExecStore) stringKeys(val interface{}) interface{} {
+	switch typedVal := val.(type) {
+	case map[interface{}]interface{}:
+		result := map[string]interface{}{}
+		for k, v := range typedVal {
+			result[fmt.Sprintf("%v", k)] = s.stringKeys(v)
+		}
+		return result
+
+	case []interface{}:
+		result := []interface{}{}
+		for _, v := range typedVal {
+			result = append(result, s.stringKeys(v))
+		}
+		return result
+
+	default:
+		return val
+	}
+}
@@ -0,0 +1,114 @@
+package opts_test
+
+import (
+	"errors"
+	"io/ioutil"
+
+	fakesys "github.com/cloudfoundry/bosh-utils/system/fakes"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	. "github.com/cloudfoundry/bosh-cli/cmd/opts"
+	boshtpl "github.com/cloudfoundry/bosh-cli/director/template"
+)
+
+var _ = Describe("VarsExecStore", func() {
+	var (
+		cmdRunner *fakesys.FakeCmdRunner
+		store     VarsExecStore
+	)
+
+	BeforeEach(func() {
+		cmdRunner = fakesys.NewFakeCmdRunner()
+		store = VarsExecStore{CmdRunner: cmdRunner, Command: "/store"}
+	})
+
+	stdinForCall := func(i int) string {
+		bytes, err := ioutil.ReadAll(cmdRunner.RunComplexCommands[i].Stdin)
+		Expect(err).ToNot(HaveOccurred())
+		return string(bytes)
+	}
+
+	Describe("Find", func() {
+		It("sends get request and returns found value", func() {
+			cmdRunner.AddCmdResult("/store", fakesys.FakeCmdResult{
+				Stdout: `{"found":true,"value":{"certificate":"cert","private_key":"key"}}`,
+			})
+
+			val, found, err := store.Find("key")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(found).To(BeTrue())
+			Expect(val).To(Equal(map[interface{}]interface{}{
+				"certificate": "cert",
+				"private_key": "key",
+			}))
+
+			Expect(stdinForCall(0)).To(MatchJSON(`{"operation":"get","name":"key"}`))
+		})
+
+		It("returns not found if command does not find variable", func() {
+			cmdRunner.AddCmdResult("/store", fakesys.FakeCmdResult{Stdout: `{"found":false}`})
+
+			val, found, err := store.Find("key")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(found).To(BeFalse())
+			Expect(val).To(BeNil())
+		})
+
+		It("returns error if command fails", func() {
+			cmdRunner.AddCmdResult("/store", fakesys.FakeCmdResult{Error: errors.New("fake-err")})
+
+			_, _, err := store.Find("key")
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("Running variables store command '/store' (get)"))
+			Expect(err.Error()).To(ContainSubstring("fake-err"))
+		})
+
+		It("returns error if command reports an error", func() {
+			cmdRunner.AddCmdResult("/store", fakesys.FakeCmdResult{Stdout: `{"error":"access denied"}`})
+
+			_, _, err := store.Find("key")
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(Equal("Variables store command '/store' (get) failed: access denied"))
+		})
+
+		It("returns error if response cannot be parsed", func() {
+			cmdRunner.AddCmdResult("/store", fakesys.FakeCmdResult{Stdout: `not-json`})
+
+			_, _, err := store.Find("key")
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("Deserializing variables store command '/store' response"))
+		})
+	})
+
+	Describe("Put", func() {
+		It("sends set request with JSON compatible value", func() {
+			cmdRunner.AddCmdResult("/store", fakesys.FakeCmdResult{Stdout: `{}`})
+
+			err := store.Put("key", map[interface{}]interface{}{"ca": "ca-val"})
+			Expect(err).ToNot(HaveOccurred())
+
+			Expect(stdinForCall(0)).To(MatchJSON(`{"operation":"set","name":"key","value":{"ca":"ca-val"}}`))
+		})
+
+		It("returns error if command reports an error", func() {
+			cmdRunner.AddCmdResult("/store", fakesys.FakeCmdResult{Stdout: `{"error":"read-only"}`})
+
+			err := store.Put("key", "val")
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(Equal("Variables store command '/store' (set) failed: read-only"))
+		})
+	})
+
+	Describe("List", func() {
+		It("sends list request and returns names", func() {
+			cmdRunner.AddCmdResult("/store", fakesys.FakeCmdResult{Stdout: `{"names":["key1","key2"]}`})
+
+			defs, err := store.List()
+			Expect(err).ToNot(HaveOccurred())
+			Expect(defs).To(Equal([]boshtpl.VariableDefinition{{Name: "key1"}, {Name: "key2"}}))
+
+			Expect(stdinForCall(0)).To(MatchJSON(`{"operation":"list"}`))
+		})
+	})
+})
@@ -4,47 +4,26 @@ import (
 	bosherr "github.com/cloudfoundry/bosh-utils/errors"
 	boshlog "github.com/cloudfoundry/bosh-utils/logger"
 	boshsys "github.com/cloudfoundry/bosh-utils/system"
-	cfgtypes "github.com/cloudfoundry/config-server/types"
 	"gopkg.in/yaml.v2"
 
+	bicrypto "github.com/cloudfoundry/bosh-cli/crypto"
 	boshtpl "github.com/cloudfoundry/bosh-cli/director/template"
 )
 
+// VarsFSStore keeps variables in a YAML file on behalf of VarsStore.
 type VarsFSStore struct {
 	FS boshsys.FileSystem
 
-	ValueGeneratorFactory cfgtypes.ValueGeneratorFactory
+	// Encryptor is optional; when set variables are encrypted on save
+	Encryptor bicrypto.Encryptor
 
 	path string
 }
 
-var _ boshtpl.Variables = VarsFSStore{}
+var _ VarsStoreBackend = VarsFSStore{}
 
 func (s VarsFSStore) IsSet() bool { return len(s.path) > 0 }
 
-func (s VarsFSStore) Get(varDef boshtpl.VariableDefinition) (interface{}, bool, error) {
-	vars, err := s.load()
-	if err != nil {
-		return nil, false, err
-	}
-
-	val, found := vars[varDef.Name]
-	if found {
-		return val, true, nil
-	}
-
-	if len(varDef.Type) == 0 {
-		return nil, false, nil
-	}
-
-	val, err = s.generateAndSet(varDef)
-	if err != nil {
-		return nil, false, bosherr.WrapErrorf(err, "Generating variable '%s'", varDef.Name)
-	}
-
-	return val, true, nil
-}
-
 func (s VarsFSStore) List() ([]boshtpl.VariableDefinition, error) {
 	vars, err := s.load()
 	if err != nil {
@@ -54,23 +33,19 @@ func (s VarsFSStore) List() ([]boshtpl.VariableDefinition, error) {
 	return vars.List()
 }
 
-func (s VarsFSStore) generateAndSet(varDef boshtpl.VariableDefinition) (interface{}, error) {
-	generator, err := s.ValueGeneratorFactory.GetGenerator(varDef.Type)
+func (s VarsFSStore) Find(name string) (interface{}, bool, error) {
+	vars, err := s.load()
 	if err != nil {
-		return nil, err
+		return nil, false, err
 	}
 
-	val, err := generator.Generate(varDef.Options)
-	if err != nil {
-		return nil, err
-	}
+	val, found := vars[name]
 
-	err = s.set(varDef.Name, val)
-	if err != nil {
-		return nil, err
-	}
+	return val, found, nil
+}
 
-	return val, nil
+func (s VarsFSStore) Put(name string, val interface{}) error {
+	return s.set(name, val)
 }
 
 func (s VarsFSStore) set(key string, val interface{}) error {
@@ -97,6 +72,17 @@ func (s VarsFSStore) load() (boshtpl.StaticVariables, error) {
 			return vars, err
 		}
 
+		if bicrypto.IsEncrypted(bytes) {
+			if s.Encryptor == nil {
+				return vars, bosherr.Errorf("Variables file store '%s' is encrypted but no encryption key was provided", s.path)
+			}
+
+			bytes, err = s.Encryptor.Decrypt(bytes)
+			if err != nil {
+				return vars, bosherr.WrapErrorf(err, "Decrypting variables file store '%s'", s.path)
+			}
+		}
+
 		err = yaml.Unmarshal(bytes, &vars)
 		if err != nil {
 			return vars, bosherr.WrapErrorf(err, "Deserializing variables file store '%s'", s.path)
@@ -119,6 +105,13 @@ func (s VarsFSStore) save(vars boshtpl.StaticVariables) error {
 		return bosherr.WrapErrorf(err, "Serializing variables")
 	}
 
+	if s.Encryptor != nil {
+		bytes, err = s.Encryptor.Encrypt(bytes)
+		if err != nil {
+			return bosherr.WrapErrorf(err, "Encrypting variables file store '%s'", s.path)
+		}
+	}
+
 	err = s.FS.WriteFile(s.path, bytes)
 	if err != nil {
 		return bosherr.WrapErrorf(err, "Writing variables to file store '%s'", s.path)
@@ -142,7 +135,6 @@ func (s *VarsFSStore) UnmarshalFlag(data string) error {
 	}
 
 	(*s).path = absPath
-	(*s).ValueGeneratorFactory = cfgtypes.NewValueGeneratorConcrete(nil)
 
 	return nil
 }
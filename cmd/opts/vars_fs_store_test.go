@@ -2,14 +2,13 @@ package opts_test
 
 import (
 	"errors"
-	"fmt"
 
 	fakesys "github.com/cloudfoundry/bosh-utils/system/fakes"
-	fakecfgtypes "github.com/cloudfoundry/config-server/types/typesfakes"
 	. "github.com/onsi/ginkgo"
 	. "github.com/onsi/gomega"
 
 	. "github.com/cloudfoundry/bosh-cli/cmd/opts"
+	bicrypto "github.com/cloudfoundry/bosh-cli/crypto"
 	boshtpl "github.com/cloudfoundry/bosh-cli/director/template"
 )
 
@@ -24,7 +23,7 @@ var _ = Describe("VarsFSStore", func() {
 		store = VarsFSStore{FS: fs}
 	})
 
-	Describe("Get", func() {
+	Describe("Find", func() {
 		BeforeEach(func() {
 			err := (&store).UnmarshalFlag("/file")
 			Expect(err).ToNot(HaveOccurred())
@@ -33,104 +32,143 @@ var _ = Describe("VarsFSStore", func() {
 		It("returns value and found if store finds variable", func() {
 			fs.WriteFileString("/file", "key: val")
 
-			val, found, err := store.Get(boshtpl.VariableDefinition{Name: "key"})
+			val, found, err := store.Find("key")
 			Expect(val).To(Equal("val"))
 			Expect(found).To(BeTrue())
 			Expect(err).ToNot(HaveOccurred())
 		})
 
-		Context("when store does not find variable", func() {
-			BeforeEach(func() {
-				fs.WriteFileString("/file", "key: val")
-			})
+		It("returns nil and not found if store does not find variable", func() {
+			fs.WriteFileString("/file", "key: val")
+
+			val, found, err := store.Find("key2")
+			Expect(val).To(BeNil())
+			Expect(found).To(BeFalse())
+			Expect(err).ToNot(HaveOccurred())
+		})
 
-			It("returns nil and not found if variable type is not available", func() {
-				val, found, err := store.Get(boshtpl.VariableDefinition{Name: "key2"})
-				Expect(val).To(BeNil())
-				Expect(found).To(BeFalse())
-				Expect(err).ToNot(HaveOccurred())
-			})
+		It("returns nil and not found if backing file does not exist", func() {
+			val, found, err := store.Find("key")
+			Expect(val).To(BeNil())
+			Expect(found).To(BeFalse())
+			Expect(err).ToNot(HaveOccurred())
+		})
+
+		It("returns error if reading file fails", func() {
+			fs.WriteFileString("/file", "contents")
+			fs.ReadFileError = errors.New("fake-err")
 
-			It("tries to generate value and save it if variable type is available", func() {
-				val, found, err := store.Get(boshtpl.VariableDefinition{Name: "key2", Type: "password"})
-				Expect(len(val.(string))).To(BeNumerically(">", 10))
-				Expect(found).To(BeTrue())
-				Expect(err).ToNot(HaveOccurred())
+			_, _, err := store.Find("key")
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("fake-err"))
+		})
 
-				Expect(fs.ReadFileString("/file")).To(Equal(fmt.Sprintf("key: val\nkey2: %s\n", val.(string))))
-			})
+		It("returns an error if parsing file fails", func() {
+			fs.WriteFileString("/file", "content")
 
-			It("returns error if variable type is not known", func() {
-				val, found, err := store.Get(boshtpl.VariableDefinition{Name: "key2", Type: "unknown"})
-				Expect(val).To(BeNil())
-				Expect(found).To(BeFalse())
-				Expect(err).To(HaveOccurred())
-				Expect(err.Error()).To(Equal("Generating variable 'key2': Unsupported value type: unknown"))
-			})
+			_, _, err := store.Find("key")
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("Deserializing variables file store '/file'"))
+		})
+	})
 
-			It("returns error if generating variable fails", func() {
-				generator := &fakecfgtypes.FakeValueGenerator{}
-				generator.GenerateReturns(nil, errors.New("fake-err"))
+	Describe("Put", func() {
+		BeforeEach(func() {
+			err := (&store).UnmarshalFlag("/file")
+			Expect(err).ToNot(HaveOccurred())
+		})
 
-				factory := &fakecfgtypes.FakeValueGeneratorFactory{}
-				factory.GetGeneratorReturns(generator, nil)
+		It("adds variable to existing variables", func() {
+			fs.WriteFileString("/file", "key: val")
 
-				store.ValueGeneratorFactory = factory
+			err := store.Put("key2", "val2")
+			Expect(err).ToNot(HaveOccurred())
 
-				val, found, err := store.Get(boshtpl.VariableDefinition{Name: "key2", Type: "type"})
-				Expect(val).To(BeNil())
-				Expect(found).To(BeFalse())
-				Expect(err).To(HaveOccurred())
-				Expect(err.Error()).To(Equal("Generating variable 'key2': fake-err"))
-			})
+			Expect(fs.ReadFileString("/file")).To(Equal("key: val\nkey2: val2\n"))
+		})
 
-			It("returns error if writing file fails", func() {
-				fs.WriteFileError = errors.New("fake-err")
+		It("creates backing file if it does not exist", func() {
+			err := store.Put("key", "val")
+			Expect(err).ToNot(HaveOccurred())
 
-				val, found, err := store.Get(boshtpl.VariableDefinition{Name: "key2", Type: "password"})
-				Expect(val).To(BeNil())
-				Expect(found).To(BeFalse())
-				Expect(err).To(HaveOccurred())
-				Expect(err.Error()).To(ContainSubstring("fake-err"))
-			})
+			Expect(fs.ReadFileString("/file")).To(Equal("key: val\n"))
 		})
 
-		Context("when store does not find backing file", func() {
-			It("tries to generate value and save it if variable type is available", func() {
-				val, found, err := store.Get(boshtpl.VariableDefinition{Name: "key2", Type: "password"})
-				Expect(len(val.(string))).To(BeNumerically(">", 10))
-				Expect(found).To(BeTrue())
-				Expect(err).ToNot(HaveOccurred())
-			})
+		It("adds variable if file contains only the yaml header", func() {
+			fs.WriteFileString("/file", "---")
+
+			err := store.Put("key", "val")
+			Expect(err).ToNot(HaveOccurred())
+
+			Expect(fs.ReadFileString("/file")).To(MatchYAML("---\nkey: val\n"))
 		})
 
 		It("returns error if reading file fails", func() {
 			fs.WriteFileString("/file", "contents")
 			fs.ReadFileError = errors.New("fake-err")
 
-			_, _, err := store.Get(boshtpl.VariableDefinition{})
+			err := store.Put("key", "val")
 			Expect(err).To(HaveOccurred())
 			Expect(err.Error()).To(ContainSubstring("fake-err"))
 		})
 
-		It("returns an error if parsing file fails", func() {
-			fs.WriteFileString("/file", "content")
+		It("returns error if writing file fails", func() {
+			fs.WriteFileError = errors.New("fake-err")
 
-			_, _, err := store.Get(boshtpl.VariableDefinition{})
+			err := store.Put("key", "val")
 			Expect(err).To(HaveOccurred())
-			Expect(err.Error()).To(ContainSubstring("Deserializing variables file store '/file'"))
+			Expect(err.Error()).To(ContainSubstring("fake-err"))
+		})
+	})
+
+	Describe("encryption", func() {
+		BeforeEach(func() {
+			err := (&store).UnmarshalFlag("/file")
+			Expect(err).ToNot(HaveOccurred())
+
+			store.Encryptor = bicrypto.NewEncryptor([]byte("key"))
 		})
 
-		Context("when file contains only the yaml header", func() {
-			It("tries to generate value and save it if variable type is available", func() {
-				fs.WriteFileString("/file", "---")
+		It("saves values encrypted and reads them back", func() {
+			err := store.Put("key", "secret-val")
+			Expect(err).ToNot(HaveOccurred())
+
+			contents, err := fs.ReadFile("/file")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(bicrypto.IsEncrypted(contents)).To(BeTrue())
+			Expect(string(contents)).ToNot(ContainSubstring("secret-val"))
+
+			val, found, err := store.Find("key")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(found).To(BeTrue())
+			Expect(val).To(Equal("secret-val"))
+		})
 
-				val, found, err := store.Get(boshtpl.VariableDefinition{Name: "key", Type: "password"})
-				Expect(len(val.(string))).To(BeNumerically(">", 10))
-				Expect(found).To(BeTrue())
-				Expect(err).ToNot(HaveOccurred())
-				Expect(fs.ReadFileString("/file")).To(MatchYAML(fmt.Sprintf("---\nkey:  %s\n", val.(string))))
-			})
+		It("reads plaintext files and encrypts them on next save", func() {
+			fs.WriteFileString("/file", "key: val")
+
+			err := store.Put("key2", "val2")
+			Expect(err).ToNot(HaveOccurred())
+
+			contents, err := fs.ReadFile("/file")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(bicrypto.IsEncrypted(contents)).To(BeTrue())
+
+			val, found, err := store.Find("key")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(found).To(BeTrue())
+			Expect(val).To(Equal("val"))
+		})
+
+		It("returns error if file is encrypted but encryptor is not configured", func() {
+			err := store.Put("key", "val")
+			Expect(err).ToNot(HaveOccurred())
+
+			store.Encryptor = nil
+
+			_, _, err = store.Find("key")
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(Equal("Variables file store '/file' is encrypted but no encryption key was provided"))
 		})
 	})
 
@@ -0,0 +1,168 @@
+package opts
+
+import (
+	"net/url"
+	"strings"
+
+	bosherr "github.com/cloudfoundry/bosh-utils/errors"
+	boshlog "github.com/cloudfoundry/bosh-utils/logger"
+	boshsys "github.com/cloudfoundry/bosh-utils/system"
+	cfgtypes "github.com/cloudfoundry/config-server/types"
+
+	bicrypto "github.com/cloudfoundry/bosh-cli/crypto"
+	boshtpl "github.com/cloudfoundry/bosh-cli/director/template"
+)
+
+// VarsStoreBackend persists individual variables on behalf of VarsStore.
+// Value generation is handled by VarsStore so that backends only need to
+// know how to find, save and list variables.
+type VarsStoreBackend interface {
+	Find(name string) (interface{}, bool, error)
+	Put(name string, val interface{}) error
+	List() ([]boshtpl.VariableDefinition, error)
+}
+
+// VarsStore is a writable variables source selected by --vars-store.
+// Plain paths and file:// URLs are backed by a YAML file (VarsFSStore);
+// exec:// URLs are backed by an external command (VarsExecStore).
+type VarsStore struct {
+	FS        boshsys.FileSystem
+	CmdRunner boshsys.CmdRunner
+
+	ValueGeneratorFactory cfgtypes.ValueGeneratorFactory
+
+	Backend VarsStoreBackend
+}
+
+var _ boshtpl.Variables = VarsStore{}
+
+func (s VarsStore) IsSet() bool { return s.Backend != nil }
+
+func (s VarsStore) Get(varDef boshtpl.VariableDefinition) (interface{}, bool, error) {
+	val, found, err := s.Backend.Find(varDef.Name)
+	if err != nil {
+		return nil, false, err
+	}
+
+	if found {
+		return val, true, nil
+	}
+
+	if len(varDef.Type) == 0 {
+		return nil, false, nil
+	}
+
+	val, err = s.generateAndSet(varDef)
+	if err != nil {
+		return nil, false, bosherr.WrapErrorf(err, "Generating variable '%s'", varDef.Name)
+	}
+
+	return val, true, nil
+}
+
+func (s VarsStore) List() ([]boshtpl.VariableDefinition, error) {
+	return s.Backend.List()
+}
+
+// NonGenerating returns variables backed by the store
+// that do not generate and save missing variables.
+func (s VarsStore) NonGenerating() boshtpl.Variables {
+	return nonGeneratingVarsStore{backend: s.Backend}
+}
+
+type nonGeneratingVarsStore struct {
+	backend VarsStoreBackend
+}
+
+func (s nonGeneratingVarsStore) Get(varDef boshtpl.VariableDefinition) (interface{}, bool, error) {
+	return s.backend.Find(varDef.Name)
+}
+
+func (s nonGeneratingVarsStore) List() ([]boshtpl.VariableDefinition, error) {
+	return s.backend.List()
+}
+
+// UseEncryptor configures encryption at rest for file backed stores.
+// Other backends are expected to protect stored values themselves.
+func (s VarsStore) UseEncryptor(encryptor bicrypto.Encryptor) {
+	if fsStore, ok := s.Backend.(*VarsFSStore); ok {
+		fsStore.Encryptor = encryptor
+	}
+}
+
+func (s VarsStore) generateAndSet(varDef boshtpl.VariableDefinition) (interface{}, error) {
+	generator, err := s.ValueGeneratorFactory.GetGenerator(varDef.Type)
+	if err != nil {
+		return nil, err
+	}
+
+	val, err := generator.Generate(varDef.Options)
+	if err != nil {
+		return nil, err
+	}
+
+	err = s.Backend.Put(varDef.Name, val)
+	if err != nil {
+		return nil, err
+	}
+
+	return val, nil
+}
+
+func (s *VarsStore) UnmarshalFlag(data string) error {
+	if s.FS == nil {
+		s.FS = boshsys.NewOsFileSystemWithStrictTempRoot(boshlog.NewLogger(boshlog.LevelNone))
+	}
+
+	if s.CmdRunner == nil {
+		s.CmdRunner = boshsys.NewExecCmdRunner(boshlog.NewLogger(boshlog.LevelNone))
+	}
+
+	if len(data) == 0 {
+		return bosherr.Errorf("Expected file path or URL to be non-empty")
+	}
+
+	scheme, location := s.parseLocation(data)
+
+	switch scheme {
+	case "", "file":
+		fsStore := &VarsFSStore{FS: s.FS}
+
+		err := fsStore.UnmarshalFlag(location)
+		if err != nil {
+			return err
+		}
+
+		s.Backend = fsStore
+
+	case "exec":
+		if len(location) == 0 {
+			return bosherr.Errorf("Expected command path to be non-empty in '%s'", data)
+		}
+
+		s.Backend = VarsExecStore{CmdRunner: s.CmdRunner, Command: location}
+
+	default:
+		return bosherr.Errorf("Unsupported variables store scheme '%s' in '%s' (supported: file, exec)", scheme, data)
+	}
+
+	s.ValueGeneratorFactory = cfgtypes.NewValueGeneratorConcrete(nil)
+
+	return nil
+}
+
+// parseLocation splits value into URL scheme and the remaining location.
+// Values without a scheme (e.g. 'creds.yml' or 'C:\creds.yml') are treated as file paths.
+func (VarsStore) parseLocation(data string) (string, string) {
+	pieces := strings.SplitN(data, "://", 2)
+	if len(pieces) != 2 {
+		return "", data
+	}
+
+	parsed, err := url.Parse(data)
+	if err != nil || len(parsed.Scheme) == 0 {
+		return "", data
+	}
+
+	return strings.ToLower(parsed.Scheme), pieces[1]
+}
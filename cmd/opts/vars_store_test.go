@@ -0,0 +1,253 @@
+package opts_test
+
+import (
+	"errors"
+	"fmt"
+
+	fakesys "github.com/cloudfoundry/bosh-utils/system/fakes"
+	fakecfgtypes "github.com/cloudfoundry/config-server/types/typesfakes"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	. "github.com/cloudfoundry/bosh-cli/cmd/opts"
+	boshtpl "github.com/cloudfoundry/bosh-cli/director/template"
+)
+
+var _ = Describe("VarsStore", func() {
+	var (
+		fs        *fakesys.FakeFileSystem
+		cmdRunner *fakesys.FakeCmdRunner
+		store     VarsStore
+	)
+
+	BeforeEach(func() {
+		fs = fakesys.NewFakeFileSystem()
+		cmdRunner = fakesys.NewFakeCmdRunner()
+		store = VarsStore{FS: fs, CmdRunner: cmdRunner}
+	})
+
+	Describe("UnmarshalFlag", func() {
+		It("uses file backend for plain paths", func() {
+			err := (&store).UnmarshalFlag("/file")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(store.IsSet()).To(BeTrue())
+			Expect(store.Backend).To(BeAssignableToTypeOf(&VarsFSStore{}))
+		})
+
+		It("uses file backend for file:// URLs", func() {
+			fs.WriteFileString("/file", "key: val")
+
+			err := (&store).UnmarshalFlag("file:///file")
+			Expect(err).ToNot(HaveOccurred())
+
+			val, found, err := store.Get(boshtpl.VariableDefinition{Name: "key"})
+			Expect(err).ToNot(HaveOccurred())
+			Expect(found).To(BeTrue())
+			Expect(val).To(Equal("val"))
+		})
+
+		It("uses exec backend for exec:// URLs", func() {
+			err := (&store).UnmarshalFlag("exec:///usr/bin/store")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(store.Backend).To(Equal(VarsExecStore{CmdRunner: cmdRunner, Command: "/usr/bin/store"}))
+		})
+
+		It("returns error if value is empty", func() {
+			err := (&store).UnmarshalFlag("")
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(Equal("Expected file path or URL to be non-empty"))
+		})
+
+		It("returns error if exec command is empty", func() {
+			err := (&store).UnmarshalFlag("exec://")
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(Equal("Expected command path to be non-empty in 'exec://'"))
+		})
+
+		It("returns error for unsupported schemes", func() {
+			err := (&store).UnmarshalFlag("vault://host/path")
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("Unsupported variables store scheme 'vault'"))
+		})
+
+		It("returns error if file path cannot be expanded", func() {
+			fs.ExpandPathErr = errors.New("fake-err")
+
+			err := (&store).UnmarshalFlag("/file")
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("fake-err"))
+		})
+	})
+
+	Describe("IsSet", func() {
+		It("returns false if store is not configured", func() {
+			Expect(store.IsSet()).To(BeFalse())
+		})
+	})
+
+	Describe("Get", func() {
+		var (
+			backend *FakeVarsStoreBackend
+		)
+
+		BeforeEach(func() {
+			backend = &FakeVarsStoreBackend{Vars: map[string]interface{}{"key": "val"}}
+			store.Backend = backend
+			store.ValueGeneratorFactory = &fakecfgtypes.FakeValueGeneratorFactory{}
+		})
+
+		It("returns value found by backend", func() {
+			val, found, err := store.Get(boshtpl.VariableDefinition{Name: "key"})
+			Expect(err).ToNot(HaveOccurred())
+			Expect(found).To(BeTrue())
+			Expect(val).To(Equal("val"))
+		})
+
+		It("returns not found if variable type is not available", func() {
+			val, found, err := store.Get(boshtpl.VariableDefinition{Name: "key2"})
+			Expect(err).ToNot(HaveOccurred())
+			Expect(found).To(BeFalse())
+			Expect(val).To(BeNil())
+		})
+
+		It("generates value and puts it into backend if variable type is available", func() {
+			generator := &fakecfgtypes.FakeValueGenerator{}
+			generator.GenerateReturns("generated", nil)
+
+			factory := &fakecfgtypes.FakeValueGeneratorFactory{}
+			factory.GetGeneratorReturns(generator, nil)
+			store.ValueGeneratorFactory = factory
+
+			val, found, err := store.Get(boshtpl.VariableDefinition{Name: "key2", Type: "password"})
+			Expect(err).ToNot(HaveOccurred())
+			Expect(found).To(BeTrue())
+			Expect(val).To(Equal("generated"))
+			Expect(backend.Vars["key2"]).To(Equal("generated"))
+			Expect(factory.GetGeneratorArgsForCall(0)).To(Equal("password"))
+		})
+
+		It("returns error if backend fails to find variable", func() {
+			backend.FindErr = errors.New("fake-err")
+
+			_, _, err := store.Get(boshtpl.VariableDefinition{Name: "key"})
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(Equal("fake-err"))
+		})
+
+		It("returns error if backend fails to put variable", func() {
+			generator := &fakecfgtypes.FakeValueGenerator{}
+			generator.GenerateReturns("generated", nil)
+
+			factory := &fakecfgtypes.FakeValueGeneratorFactory{}
+			factory.GetGeneratorReturns(generator, nil)
+			store.ValueGeneratorFactory = factory
+
+			backend.PutErr = errors.New("fake-err")
+
+			_, found, err := store.Get(boshtpl.VariableDefinition{Name: "key2", Type: "password"})
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(Equal("Generating variable 'key2': fake-err"))
+			Expect(found).To(BeFalse())
+		})
+
+		Context("when backed by a file", func() {
+			BeforeEach(func() {
+				err := (&store).UnmarshalFlag("/file")
+				Expect(err).ToNot(HaveOccurred())
+
+				fs.WriteFileString("/file", "key: val")
+			})
+
+			It("generates value and saves it into file if variable type is available", func() {
+				val, found, err := store.Get(boshtpl.VariableDefinition{Name: "key2", Type: "password"})
+				Expect(len(val.(string))).To(BeNumerically(">", 10))
+				Expect(found).To(BeTrue())
+				Expect(err).ToNot(HaveOccurred())
+
+				Expect(fs.ReadFileString("/file")).To(Equal(fmt.Sprintf("key: val\nkey2: %s\n", val.(string))))
+			})
+
+			It("returns error if variable type is not known", func() {
+				val, found, err := store.Get(boshtpl.VariableDefinition{Name: "key2", Type: "unknown"})
+				Expect(val).To(BeNil())
+				Expect(found).To(BeFalse())
+				Expect(err).To(HaveOccurred())
+				Expect(err.Error()).To(Equal("Generating variable 'key2': Unsupported value type: unknown"))
+			})
+		})
+	})
+
+	Describe("NonGenerating", func() {
+		var (
+			backend *FakeVarsStoreBackend
+		)
+
+		BeforeEach(func() {
+			backend = &FakeVarsStoreBackend{Vars: map[string]interface{}{"key": "val"}}
+			store.Backend = backend
+			store.ValueGeneratorFactory = &fakecfgtypes.FakeValueGeneratorFactory{}
+		})
+
+		It("returns value found by backend", func() {
+			val, found, err := store.NonGenerating().Get(boshtpl.VariableDefinition{Name: "key"})
+			Expect(err).ToNot(HaveOccurred())
+			Expect(found).To(BeTrue())
+			Expect(val).To(Equal("val"))
+		})
+
+		It("returns not found without generating value even if variable type is available", func() {
+			val, found, err := store.NonGenerating().Get(boshtpl.VariableDefinition{Name: "key2", Type: "password"})
+			Expect(err).ToNot(HaveOccurred())
+			Expect(found).To(BeFalse())
+			Expect(val).To(BeNil())
+			Expect(backend.Vars).ToNot(HaveKey("key2"))
+		})
+	})
+
+	Describe("List", func() {
+		It("returns variables listed by backend", func() {
+			store.Backend = &FakeVarsStoreBackend{Vars: map[string]interface{}{"key": "val"}}
+
+			defs, err := store.List()
+			Expect(err).ToNot(HaveOccurred())
+			Expect(defs).To(Equal([]boshtpl.VariableDefinition{{Name: "key"}}))
+		})
+	})
+})
+
+type FakeVarsStoreBackend struct {
+	Vars map[string]interface{}
+
+	FindErr error
+	PutErr  error
+}
+
+func (b *FakeVarsStoreBackend) Find(name string) (interface{}, bool, error) {
+	if b.FindErr != nil {
+		return nil, false, b.FindErr
+	}
+
+	val, found := b.Vars[name]
+
+	return val, found, nil
+}
+
+func (b *FakeVarsStoreBackend) Put(name string, val interface{}) error {
+	if b.PutErr != nil {
+		return b.PutErr
+	}
+
+	b.Vars[name] = val
+
+	return nil
+}
+
+func (b *FakeVarsStoreBackend) List() ([]boshtpl.VariableDefinition, error) {
+	var defs []boshtpl.VariableDefinition
+
+	for name := range b.Vars {
+		defs = append(defs, boshtpl.VariableDefinition{Name: name})
+	}
+
+	return defs, nil
+}
@@ -1,10 +1,21 @@
 package cmd
 
 import (
+	"encoding/json"
 	"fmt"
+
+	bosherr "github.com/cloudfoundry/bosh-utils/errors"
+
+	boshdir "github.com/cloudfoundry/bosh-cli/director"
 	boshui "github.com/cloudfoundry/bosh-cli/ui"
 )
 
+const (
+	DiffFormatUnified    = "unified"
+	DiffFormatJSON       = "json"
+	DiffFormatSideBySide = "side-by-side"
+)
+
 type Diff struct {
 	lines [][]interface{}
 }
@@ -29,6 +40,96 @@ func (d Diff) Print(ui boshui.UI) {
 	}
 }
 
+// PrintFormat prints diff in one of the supported formats;
+// empty format is treated as unified.
+func (d Diff) PrintFormat(ui boshui.UI, format string) error {
+	switch format {
+	case "", DiffFormatUnified:
+		d.Print(ui)
+
+	case DiffFormatJSON:
+		return d.printJSON(ui)
+
+	case DiffFormatSideBySide:
+		d.printSideBySide(ui)
+
+	default:
+		return bosherr.Errorf("Unknown diff format '%s'", format)
+	}
+
+	return nil
+}
+
+func (d Diff) printJSON(ui boshui.UI) error {
+	changes := boshdir.NewDiffChanges(d.lines)
+	if changes == nil {
+		changes = []boshdir.DiffChange{}
+	}
+
+	bytes, err := json.MarshalIndent(struct {
+		Changes []boshdir.DiffChange `json:"changes"`
+	}{changes}, "", "  ")
+	if err != nil {
+		return bosherr.WrapErrorf(err, "Marshaling diff")
+	}
+
+	ui.PrintBlock(append(bytes, '\n'))
+
+	return nil
+}
+
+func (d Diff) printSideBySide(ui boshui.UI) {
+	var (
+		rows             [][2]string
+		removed, added   []string
+		leftColumnLength int
+	)
+
+	flush := func() {
+		for i := 0; i < len(removed) || i < len(added); i++ {
+			var row [2]string
+			if i < len(removed) {
+				row[0] = "- " + removed[i]
+			}
+			if i < len(added) {
+				row[1] = "+ " + added[i]
+			}
+			rows = append(rows, row)
+		}
+		removed, added = nil, nil
+	}
+
+	for _, line := range d.lines {
+		text := fmt.Sprintf("%s", line[0])
+		lineMod, _ := line[1].(string)
+
+		if lineMod == "added" {
+			added = append(added, text)
+		} else if lineMod == "removed" {
+			// Removed lines following added ones start a new block
+			if len(added) > 0 {
+				flush()
+			}
+			removed = append(removed, text)
+		} else {
+			flush()
+			rows = append(rows, [2]string{"  " + text, "  " + text})
+		}
+	}
+
+	flush()
+
+	for _, row := range rows {
+		if len(row[0]) > leftColumnLength {
+			leftColumnLength = len(row[0])
+		}
+	}
+
+	for _, row := range rows {
+		ui.BeginLinef("%-*s | %s\n", leftColumnLength, row[0], row[1])
+	}
+}
+
 func (d Diff) String() string {
 	var result string
 	for _, line := range d.lines {
@@ -0,0 +1,416 @@
+package cmd
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	bosherr "github.com/cloudfoundry/bosh-utils/errors"
+	"gopkg.in/yaml.v2"
+
+	. "github.com/cloudfoundry/bosh-cli/cmd/opts"
+	boshdir "github.com/cloudfoundry/bosh-cli/director"
+	boshrel "github.com/cloudfoundry/bosh-cli/release"
+	boshjob "github.com/cloudfoundry/bosh-cli/release/job"
+	boshui "github.com/cloudfoundry/bosh-cli/ui"
+	boshtbl "github.com/cloudfoundry/bosh-cli/ui/table"
+)
+
+//counterfeiter:generate . PropertiesValidator
+
+type PropertiesValidator interface {
+	Validate(manifest []byte) ([]PropertyProblem, error)
+}
+
+type PropertyProblem struct {
+	InstanceGroup string
+	Job           string
+	Property      string
+	Problem       string
+}
+
+// jobPropertyDefs maps declared property names (e.g. 'tls.enabled') to their definitions
+type jobPropertyDefs map[string]boshjob.PropertyDefinition
+
+type ReleasePropertiesValidator struct {
+	director      boshdir.Director
+	releaseReader boshrel.Reader
+}
+
+type propertiesManifest struct {
+	Releases       []boshdir.ManifestRelease
+	InstanceGroups []propertiesManifestInstanceGroup `yaml:"instance_groups"`
+}
+
+type propertiesManifestInstanceGroup struct {
+	Name string
+	Jobs []propertiesManifestJob
+}
+
+type propertiesManifestJob struct {
+	Name       string
+	Release    string
+	Properties map[interface{}]interface{}
+}
+
+func NewReleasePropertiesValidator(director boshdir.Director, releaseReader boshrel.Reader) ReleasePropertiesValidator {
+	return ReleasePropertiesValidator{director: director, releaseReader: releaseReader}
+}
+
+// Validate compares properties of each job in the manifest against properties declared in job specs.
+// Job specs are read from local release tarballs (file:// URLs) or from the Director;
+// releases that are yet to be uploaded from remote URLs are skipped.
+func (v ReleasePropertiesValidator) Validate(manifestBytes []byte) ([]PropertyProblem, error) {
+	var manifest propertiesManifest
+
+	err := yaml.Unmarshal(manifestBytes, &manifest)
+	if err != nil {
+		return nil, bosherr.WrapError(err, "Unmarshalling manifest")
+	}
+
+	releaseJobs := map[string]map[string]jobPropertyDefs{}
+	skippedReleases := map[string]struct{}{}
+
+	for _, rel := range manifest.Releases {
+		jobs, found, err := v.releaseJobs(rel)
+		if err != nil {
+			return nil, bosherr.WrapErrorf(err, "Loading job specs for release '%s/%s'", rel.Name, rel.Version)
+		}
+
+		if !found {
+			skippedReleases[rel.Name] = struct{}{}
+			continue
+		}
+
+		releaseJobs[rel.Name] = jobs
+	}
+
+	var problems []PropertyProblem
+
+	for _, group := range manifest.InstanceGroups {
+		for _, job := range group.Jobs {
+			if _, skipped := skippedReleases[job.Release]; skipped {
+				continue
+			}
+
+			jobs, found := releaseJobs[job.Release]
+			if !found {
+				return nil, bosherr.Errorf("Expected release '%s' of job '%s' to be specified in manifest releases", job.Release, job.Name)
+			}
+
+			defs, found := jobs[job.Name]
+			if !found {
+				return nil, bosherr.Errorf("Expected to find job '%s' in release '%s'", job.Name, job.Release)
+			}
+
+			report := func(property, problem string) {
+				problems = append(problems, PropertyProblem{
+					InstanceGroup: group.Name,
+					Job:           job.Name,
+					Property:      property,
+					Problem:       problem,
+				})
+			}
+
+			v.validateProperties(job.Properties, defs, report)
+		}
+	}
+
+	sort.SliceStable(problems, func(i, j int) bool {
+		if problems[i].InstanceGroup != problems[j].InstanceGroup {
+			return problems[i].InstanceGroup < problems[j].InstanceGroup
+		}
+		if problems[i].Job != problems[j].Job {
+			return problems[i].Job < problems[j].Job
+		}
+		return problems[i].Property < problems[j].Property
+	})
+
+	return problems, nil
+}
+
+// releaseJobs returns property definitions of release jobs keyed by job name.
+// It does not find a release that is only available from a remote URL.
+func (v ReleasePropertiesValidator) releaseJobs(rel boshdir.ManifestRelease) (map[string]jobPropertyDefs, bool, error) {
+	result := map[string]jobPropertyDefs{}
+
+	url := URLArg(rel.URL)
+
+	if !url.IsEmpty() && !url.IsRemote() && !url.IsGit() {
+		release, err := v.releaseReader.Read(url.FilePath())
+		if err != nil {
+			return nil, false, bosherr.WrapErrorf(err, "Reading release '%s'", url.FilePath())
+		}
+
+		defer release.CleanUp()
+
+		for _, job := range release.Jobs() {
+			result[job.Name()] = job.Properties
+		}
+
+		return result, true, nil
+	}
+
+	if url.IsRemote() || url.IsGit() {
+		uploaded, err := v.isUploaded(rel)
+		if err != nil || !uploaded {
+			return nil, false, err
+		}
+	}
+
+	release, err := v.findRelease(rel)
+	if err != nil {
+		return nil, false, err
+	}
+
+	jobs, err := release.Jobs()
+	if err != nil {
+		return nil, false, err
+	}
+
+	for _, job := range jobs {
+		defs := jobPropertyDefs{}
+		for name, prop := range job.Properties {
+			defs[name] = boshjob.PropertyDefinition{Type: prop.Type}
+		}
+		result[job.Name] = defs
+	}
+
+	return result, true, nil
+}
+
+func (v ReleasePropertiesValidator) isUploaded(rel boshdir.ManifestRelease) (bool, error) {
+	if rel.Version != "latest" {
+		return v.director.HasRelease(rel.Name, rel.Version, boshdir.OSVersionSlug{})
+	}
+
+	releases, err := v.director.Releases()
+	if err != nil {
+		return false, err
+	}
+
+	for _, release := range releases {
+		if release.Name() == rel.Name {
+			return true, nil
+		}
+	}
+
+	return false, nil
+}
+
+func (v ReleasePropertiesValidator) findRelease(rel boshdir.ManifestRelease) (boshdir.Release, error) {
+	if rel.Version != "latest" {
+		return v.director.FindRelease(boshdir.NewReleaseSlug(rel.Name, rel.Version))
+	}
+
+	releases, err := v.director.Releases()
+	if err != nil {
+		return nil, err
+	}
+
+	var latest boshdir.Release
+
+	for _, release := range releases {
+		if release.Name() != rel.Name {
+			continue
+		}
+		if latest == nil || release.Version().IsGt(latest.Version()) {
+			latest = release
+		}
+	}
+
+	if latest == nil {
+		return nil, bosherr.Errorf("Expected to find release '%s' on the Director", rel.Name)
+	}
+
+	return v.director.FindRelease(boshdir.NewReleaseSlug(rel.Name, latest.Version().AsString()))
+}
+
+// validateProperties checks types of declared properties and reports unknown ones
+func (v ReleasePropertiesValidator) validateProperties(props map[interface{}]interface{}, defs jobPropertyDefs, report func(string, string)) {
+	boshjob.WalkProperties(props, defs, func(name string, value interface{}, kind boshjob.PropertyKind) {
+		switch kind {
+		case boshjob.DeclaredProperty:
+			if problem := v.typeProblem(defs[name].Type, value); len(problem) > 0 {
+				report(name, problem)
+			}
+
+		case boshjob.NonHashProperty:
+			if !v.isVariable(value) {
+				report(name, fmt.Sprintf("Expected hash but got '%s'", v.valueType(value)))
+			}
+
+		case boshjob.UndeclaredProperty:
+			problem := "Unknown property"
+
+			if suggestion := v.suggest(name, defs); len(suggestion) > 0 {
+				problem += fmt.Sprintf(" (did you mean '%s'?)", suggestion)
+			}
+
+			report(name, problem)
+		}
+	})
+}
+
+// suggest picks the closest declared sibling key at the same nesting level
+func (v ReleasePropertiesValidator) suggest(name string, defs jobPropertyDefs) string {
+	prefix := name[:strings.LastIndex(name, ".")+1]
+	key := strings.TrimPrefix(name, prefix)
+	siblings := map[string]struct{}{}
+
+	for declared := range defs {
+		if strings.HasPrefix(declared, prefix) {
+			siblings[strings.SplitN(strings.TrimPrefix(declared, prefix), ".", 2)[0]] = struct{}{}
+		}
+	}
+
+	var best string
+	bestDistance := len(key)/3 + 2
+
+	for sibling := range siblings {
+		distance := levenshteinDistance(key, sibling)
+		if distance < bestDistance || (distance == bestDistance && sibling < best) {
+			best, bestDistance = sibling, distance
+		}
+	}
+
+	if len(best) == 0 {
+		return ""
+	}
+
+	return prefix + best
+}
+
+func (v ReleasePropertiesValidator) typeProblem(typ string, value interface{}) string {
+	if value == nil || v.isVariable(value) {
+		return ""
+	}
+
+	var ok bool
+
+	switch typ {
+	case "string":
+		_, ok = value.(string)
+	case "integer", "int":
+		switch value.(type) {
+		case int, int64, uint64:
+			ok = true
+		}
+	case "float", "number":
+		switch value.(type) {
+		case int, int64, uint64, float64:
+			ok = true
+		}
+	case "boolean", "bool":
+		_, ok = value.(bool)
+	case "array", "list":
+		_, ok = value.([]interface{})
+	case "hash", "map":
+		_, ok = value.(map[interface{}]interface{})
+	default:
+		return "" // types without well-known representation are not checked
+	}
+
+	if ok {
+		return ""
+	}
+
+	return fmt.Sprintf("Expected type '%s' but got '%s'", typ, v.valueType(value))
+}
+
+func (v ReleasePropertiesValidator) valueType(value interface{}) string {
+	switch value.(type) {
+	case string:
+		return "string"
+	case int, int64, uint64:
+		return "integer"
+	case float64:
+		return "float"
+	case bool:
+		return "boolean"
+	case []interface{}:
+		return "array"
+	case map[interface{}]interface{}:
+		return "hash"
+	default:
+		return fmt.Sprintf("%T", value)
+	}
+}
+
+// isVariable checks if value is left to be interpolated by the Director (e.g. '((password))')
+func (v ReleasePropertiesValidator) isVariable(value interface{}) bool {
+	str, ok := value.(string)
+	return ok && strings.HasPrefix(str, "((") && strings.HasSuffix(str, "))")
+}
+
+func levenshteinDistance(a, b string) int {
+	prev := make([]int, len(b)+1)
+	curr := make([]int, len(b)+1)
+
+	for j := range prev {
+		prev[j] = j
+	}
+
+	for i := 1; i <= len(a); i++ {
+		curr[0] = i
+
+		for j := 1; j <= len(b); j++ {
+			cost := 1
+			if a[i-1] == b[j-1] {
+				cost = 0
+			}
+
+			curr[j] = minInt(prev[j]+1, curr[j-1]+1, prev[j-1]+cost)
+		}
+
+		prev, curr = curr, prev
+	}
+
+	return prev[len(b)]
+}
+
+func minInt(first int, rest ...int) int {
+	min := first
+	for _, i := range rest {
+		if i < min {
+			min = i
+		}
+	}
+	return min
+}
+
+// PropertyProblemsTable lists problems found during properties validation.
+type PropertyProblemsTable struct {
+	Problems []PropertyProblem
+	UI       boshui.UI
+}
+
+func (t PropertyProblemsTable) Print() error {
+	if len(t.Problems) == 0 {
+		t.UI.PrintLinef("No problems found in job properties")
+		return nil
+	}
+
+	table := boshtbl.Table{
+		Content: "property problems",
+		Header: []boshtbl.Header{
+			boshtbl.NewHeader("Instance Group"),
+			boshtbl.NewHeader("Job"),
+			boshtbl.NewHeader("Property"),
+			boshtbl.NewHeader("Problem"),
+		},
+	}
+
+	for _, problem := range t.Problems {
+		table.Rows = append(table.Rows, []boshtbl.Value{
+			boshtbl.NewValueString(problem.InstanceGroup),
+			boshtbl.NewValueString(problem.Job),
+			boshtbl.NewValueString(problem.Property),
+			boshtbl.NewValueString(problem.Problem),
+		})
+	}
+
+	t.UI.PrintTable(table)
+
+	return bosherr.Errorf("Found %d problem(s) in job properties", len(t.Problems))
+}
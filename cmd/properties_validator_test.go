@@ -0,0 +1,258 @@
+package cmd_test
+
+import (
+	"errors"
+
+	semver "github.com/cppforlife/go-semi-semantic/version"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	. "github.com/cloudfoundry/bosh-cli/cmd"
+	boshdir "github.com/cloudfoundry/bosh-cli/director"
+	fakedir "github.com/cloudfoundry/bosh-cli/director/directorfakes"
+	boshjob "github.com/cloudfoundry/bosh-cli/release/job"
+	fakerel "github.com/cloudfoundry/bosh-cli/release/releasefakes"
+	. "github.com/cloudfoundry/bosh-cli/release/resource"
+)
+
+var _ = Describe("ReleasePropertiesValidator", func() {
+	var (
+		director      *fakedir.FakeDirector
+		releaseReader *fakerel.FakeReader
+		validator     ReleasePropertiesValidator
+	)
+
+	BeforeEach(func() {
+		director = &fakedir.FakeDirector{}
+		releaseReader = &fakerel.FakeReader{}
+		validator = NewReleasePropertiesValidator(director, releaseReader)
+
+		release := &fakedir.FakeRelease{}
+		release.JobsReturns([]boshdir.Job{{
+			Name: "nginx",
+			Properties: map[string]boshdir.JobProperty{
+				"port":            {Type: "integer"},
+				"tls.enabled":     {Type: "boolean"},
+				"tls.certificate": {},
+				"upstreams":       {Type: "array"},
+			},
+		}}, nil)
+		director.FindReleaseReturns(release, nil)
+	})
+
+	It("returns no problems if properties match job specs", func() {
+		problems, err := validator.Validate([]byte(`
+releases:
+- name: web
+  version: 1
+instance_groups:
+- name: web
+  jobs:
+  - name: nginx
+    release: web
+    properties:
+      port: 80
+      tls:
+        enabled: true
+        certificate: ((cert.certificate))
+      upstreams: ((upstreams))
+`))
+		Expect(err).ToNot(HaveOccurred())
+		Expect(problems).To(BeEmpty())
+
+		Expect(director.FindReleaseArgsForCall(0)).To(Equal(boshdir.NewReleaseSlug("web", "1")))
+	})
+
+	It("reports unknown properties with suggestions and type mismatches", func() {
+		problems, err := validator.Validate([]byte(`
+releases:
+- name: web
+  version: 1
+instance_groups:
+- name: web
+  jobs:
+  - name: nginx
+    release: web
+    properties:
+      prot: 80
+      port: "80"
+      tls:
+        enabeld: yes
+        certificate: {}
+      unrelated: val
+      upstreams: host
+`))
+		Expect(err).ToNot(HaveOccurred())
+		Expect(problems).To(Equal([]PropertyProblem{
+			{InstanceGroup: "web", Job: "nginx", Property: "port", Problem: "Expected type 'integer' but got 'string'"},
+			{InstanceGroup: "web", Job: "nginx", Property: "prot", Problem: "Unknown property (did you mean 'port'?)"},
+			{InstanceGroup: "web", Job: "nginx", Property: "tls.enabeld", Problem: "Unknown property (did you mean 'tls.enabled'?)"},
+			{InstanceGroup: "web", Job: "nginx", Property: "unrelated", Problem: "Unknown property"},
+			{InstanceGroup: "web", Job: "nginx", Property: "upstreams", Problem: "Expected type 'array' but got 'string'"},
+		}))
+	})
+
+	It("reports non-hash values for nested properties", func() {
+		problems, err := validator.Validate([]byte(`
+releases:
+- name: web
+  version: 1
+instance_groups:
+- name: web
+  jobs:
+  - name: nginx
+    release: web
+    properties:
+      tls: true
+`))
+		Expect(err).ToNot(HaveOccurred())
+		Expect(problems).To(Equal([]PropertyProblem{
+			{InstanceGroup: "web", Job: "nginx", Property: "tls", Problem: "Expected hash but got 'boolean'"},
+		}))
+	})
+
+	It("reads job specs from local release tarballs", func() {
+		job := boshjob.NewJob(NewResource("nginx", "fp", nil))
+		job.Properties = map[string]boshjob.PropertyDefinition{"port": {Type: "integer"}}
+
+		release := &fakerel.FakeRelease{}
+		release.JobsReturns([]*boshjob.Job{job})
+		releaseReader.ReadReturns(release, nil)
+
+		problems, err := validator.Validate([]byte(`
+releases:
+- name: web
+  version: create
+  url: file:///tmp/web.tgz
+instance_groups:
+- name: web
+  jobs:
+  - name: nginx
+    release: web
+    properties:
+      tls: {enabled: true}
+`))
+		Expect(err).ToNot(HaveOccurred())
+		Expect(problems).To(Equal([]PropertyProblem{
+			{InstanceGroup: "web", Job: "nginx", Property: "tls", Problem: "Unknown property"},
+		}))
+
+		Expect(releaseReader.ReadArgsForCall(0)).To(Equal("/tmp/web.tgz"))
+		Expect(release.CleanUpCallCount()).To(Equal(1))
+		Expect(director.FindReleaseCallCount()).To(Equal(0))
+	})
+
+	It("skips jobs of releases that are yet to be uploaded from remote URLs", func() {
+		problems, err := validator.Validate([]byte(`
+releases:
+- name: web
+  version: 1
+  url: https://example.com/web.tgz
+instance_groups:
+- name: web
+  jobs:
+  - name: nginx
+    release: web
+    properties:
+      prot: 80
+`))
+		Expect(err).ToNot(HaveOccurred())
+		Expect(problems).To(BeEmpty())
+
+		name, version, _ := director.HasReleaseArgsForCall(0)
+		Expect(name).To(Equal("web"))
+		Expect(version).To(Equal("1"))
+		Expect(director.FindReleaseCallCount()).To(Equal(0))
+	})
+
+	It("validates jobs of releases with remote URLs that are already on the Director", func() {
+		director.HasReleaseReturns(true, nil)
+
+		problems, err := validator.Validate([]byte(`
+releases:
+- name: web
+  version: 1
+  url: https://example.com/web.tgz
+instance_groups:
+- name: web
+  jobs:
+  - name: nginx
+    release: web
+    properties:
+      prot: 80
+`))
+		Expect(err).ToNot(HaveOccurred())
+		Expect(problems).To(Equal([]PropertyProblem{
+			{InstanceGroup: "web", Job: "nginx", Property: "prot", Problem: "Unknown property (did you mean 'port'?)"},
+		}))
+
+		Expect(director.FindReleaseArgsForCall(0)).To(Equal(boshdir.NewReleaseSlug("web", "1")))
+	})
+
+	It("returns error if checking for uploaded release fails", func() {
+		director.HasReleaseReturns(false, errors.New("fake-err"))
+
+		_, err := validator.Validate([]byte(`
+releases:
+- name: web
+  version: 1
+  url: https://example.com/web.tgz
+`))
+		Expect(err).To(HaveOccurred())
+		Expect(err.Error()).To(ContainSubstring("fake-err"))
+	})
+
+	It("finds latest release version on the Director", func() {
+		older := &fakedir.FakeRelease{}
+		older.NameReturns("web")
+		older.VersionReturns(semver.MustNewVersionFromString("2"))
+
+		newer := &fakedir.FakeRelease{}
+		newer.NameReturns("web")
+		newer.VersionReturns(semver.MustNewVersionFromString("10"))
+
+		other := &fakedir.FakeRelease{}
+		other.NameReturns("other")
+		other.VersionReturns(semver.MustNewVersionFromString("20"))
+
+		director.ReleasesReturns([]boshdir.Release{older, other, newer}, nil)
+
+		_, err := validator.Validate([]byte(`
+releases:
+- name: web
+  version: latest
+instance_groups: []
+`))
+		Expect(err).ToNot(HaveOccurred())
+
+		Expect(director.FindReleaseArgsForCall(0)).To(Equal(boshdir.NewReleaseSlug("web", "10")))
+	})
+
+	It("returns error if job is not found in release", func() {
+		_, err := validator.Validate([]byte(`
+releases:
+- name: web
+  version: 1
+instance_groups:
+- name: web
+  jobs:
+  - name: missing
+    release: web
+`))
+		Expect(err).To(HaveOccurred())
+		Expect(err.Error()).To(Equal("Expected to find job 'missing' in release 'web'"))
+	})
+
+	It("returns error if release cannot be found on the Director", func() {
+		director.FindReleaseReturns(nil, errors.New("fake-err"))
+
+		_, err := validator.Validate([]byte(`
+releases:
+- name: web
+  version: 1
+`))
+		Expect(err).To(HaveOccurred())
+		Expect(err.Error()).To(ContainSubstring("Loading job specs for release 'web/1'"))
+		Expect(err.Error()).To(ContainSubstring("fake-err"))
+	})
+})
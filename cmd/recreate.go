@@ -26,6 +26,18 @@ func (c RecreateCmd) Run(opts RecreateOpts) error {
 	if err != nil {
 		return err
 	}
+
+	if opts.NoTrack {
+		task, err := c.deployment.RecreateAsync(opts.Args.Slug, recreateOpts)
+		if err != nil {
+			return err
+		}
+
+		StartedTaskTable{Task: task, UI: c.ui}.Print()
+
+		return nil
+	}
+
 	return c.deployment.Recreate(opts.Args.Slug, recreateOpts)
 }
 
@@ -222,5 +222,32 @@ var _ = Describe("RecreateCmd", func() {
 				})
 			})
 		})
+
+		Context("when no-track is set", func() {
+			BeforeEach(func() {
+				opts.NoTrack = true
+			})
+
+			It("starts task and prints it without waiting for it to finish", func() {
+				task := &fakedir.FakeTask{}
+				task.IDReturns(123)
+				deployment.RecreateAsyncReturns(task, nil)
+
+				err := act()
+				Expect(err).ToNot(HaveOccurred())
+
+				Expect(deployment.RecreateAsyncCallCount()).To(Equal(1))
+				Expect(deployment.RecreateCallCount()).To(Equal(0))
+				Expect(ui.Table.Content).To(Equal("tasks"))
+			})
+
+			It("returns error if starting task failed", func() {
+				deployment.RecreateAsyncReturns(nil, errors.New("fake-err"))
+
+				err := act()
+				Expect(err).To(HaveOccurred())
+				Expect(err.Error()).To(ContainSubstring("fake-err"))
+			})
+		})
 	})
 })
@@ -5,6 +5,7 @@ import (
 	"strings"
 
 	boshrel "github.com/cloudfoundry/bosh-cli/release"
+	boshjob "github.com/cloudfoundry/bosh-cli/release/job"
 	boshrelpkg "github.com/cloudfoundry/bosh-cli/release/pkg"
 	boshui "github.com/cloudfoundry/bosh-cli/ui"
 	boshtbl "github.com/cloudfoundry/bosh-cli/ui/table"
@@ -114,6 +115,76 @@ func (t ReleaseTables) Print(ui boshui.UI) {
 	ui.PrintTable(pkgsTable)
 }
 
+func (t ReleaseTables) PrintLinks(ui boshui.UI) {
+	linksTable := boshtbl.Table{
+		Content: "links",
+		Header: []boshtbl.Header{
+			boshtbl.NewHeader("Job"),
+			boshtbl.NewHeader("Direction"),
+			boshtbl.NewHeader("Name"),
+			boshtbl.NewHeader("Type"),
+			boshtbl.NewHeader("Optional"),
+			boshtbl.NewHeader("Properties"),
+		},
+		SortBy: []boshtbl.ColumnSort{{Column: 0, Asc: true}, {Column: 1, Asc: true}, {Column: 2, Asc: true}},
+	}
+
+	for _, job := range t.Release.Jobs() {
+		for _, link := range job.Consumes {
+			linksTable.Rows = append(linksTable.Rows, t.linkRow(job, "consumes", link))
+		}
+
+		for _, link := range job.Provides {
+			linksTable.Rows = append(linksTable.Rows, t.linkRow(job, "provides", link))
+		}
+	}
+
+	ui.PrintTable(linksTable)
+}
+
+func (t ReleaseTables) PrintProperties(ui boshui.UI) {
+	propsTable := boshtbl.Table{
+		Content: "properties",
+		Header: []boshtbl.Header{
+			boshtbl.NewHeader("Job"),
+			boshtbl.NewHeader("Property"),
+			boshtbl.NewHeader("Type"),
+			boshtbl.NewHeader("Default"),
+			boshtbl.NewHeader("Example"),
+			boshtbl.NewHeader("Env"),
+			boshtbl.NewHeader("Description"),
+		},
+		SortBy: []boshtbl.ColumnSort{{Column: 0, Asc: true}, {Column: 1, Asc: true}},
+	}
+
+	for _, job := range t.Release.Jobs() {
+		for name, prop := range job.Properties {
+			propsTable.Rows = append(propsTable.Rows, []boshtbl.Value{
+				boshtbl.NewValueString(job.Name()),
+				boshtbl.NewValueString(name),
+				boshtbl.NewValueString(prop.Type),
+				boshtbl.NewValueInterface(prop.Default),
+				boshtbl.NewValueInterface(prop.Example),
+				boshtbl.NewValueString(prop.Env),
+				boshtbl.NewValueString(prop.Description),
+			})
+		}
+	}
+
+	ui.PrintTable(propsTable)
+}
+
+func (t ReleaseTables) linkRow(job *boshjob.Job, direction string, link boshjob.LinkDefinition) []boshtbl.Value {
+	return []boshtbl.Value{
+		boshtbl.NewValueString(job.Name()),
+		boshtbl.NewValueString(direction),
+		boshtbl.NewValueString(link.Name),
+		boshtbl.NewValueString(link.Type),
+		boshtbl.NewValueBool(link.Optional),
+		boshtbl.NewValueStrings(link.Properties),
+	}
+}
+
 func (t ReleaseTables) sumPkgNames(packages []boshrelpkg.Compilable) []string {
 	var names []string
 	for _, pkg := range packages {
@@ -0,0 +1,133 @@
+package cmd
+
+import (
+	"os"
+	"path/filepath"
+
+	bosherr "github.com/cloudfoundry/bosh-utils/errors"
+	biproperty "github.com/cloudfoundry/bosh-utils/property"
+	boshsys "github.com/cloudfoundry/bosh-utils/system"
+	"gopkg.in/yaml.v2"
+
+	. "github.com/cloudfoundry/bosh-cli/cmd/opts"
+	boshrel "github.com/cloudfoundry/bosh-cli/release"
+	boshjob "github.com/cloudfoundry/bosh-cli/release/job"
+	bitemplate "github.com/cloudfoundry/bosh-cli/templatescompiler"
+	boshui "github.com/cloudfoundry/bosh-cli/ui"
+)
+
+type RenderJobCmd struct {
+	jobRenderer   bitemplate.InstanceJobRenderer
+	releaseReader boshrel.Reader
+	fs            boshsys.FileSystem
+	ui            boshui.UI
+}
+
+func NewRenderJobCmd(
+	jobRenderer bitemplate.InstanceJobRenderer,
+	releaseReader boshrel.Reader,
+	fs boshsys.FileSystem,
+	ui boshui.UI,
+) RenderJobCmd {
+	return RenderJobCmd{
+		jobRenderer:   jobRenderer,
+		releaseReader: releaseReader,
+		fs:            fs,
+		ui:            ui,
+	}
+}
+
+func (c RenderJobCmd) Run(opts RenderJobOpts) error {
+	properties, err := c.buildMap(opts.Properties.Bytes, "properties")
+	if err != nil {
+		return err
+	}
+
+	links, err := c.buildMap(opts.Links.Bytes, "links")
+	if err != nil {
+		return err
+	}
+
+	var instance bitemplate.InstanceSpec
+
+	err = yaml.Unmarshal(opts.Spec.Bytes, &instance)
+	if err != nil {
+		return bosherr.WrapErrorf(err, "Unmarshalling instance spec")
+	}
+
+	job, cleanUp, err := c.findJob(opts)
+	if err != nil {
+		return err
+	}
+
+	defer cleanUp()
+
+	outputPath := opts.OutputDir.Path
+
+	err = c.fs.MkdirAll(outputPath, os.ModePerm)
+	if err != nil {
+		return bosherr.WrapErrorf(err, "Creating output directory '%s'", outputPath)
+	}
+
+	err = c.jobRenderer.RenderInstance(*job, properties, instance, links, outputPath)
+	if err != nil {
+		return bosherr.WrapErrorf(err, "Rendering job '%s'", job.Name())
+	}
+
+	c.ui.PrintLinef("Rendered job '%s' to '%s'", job.Name(), outputPath)
+
+	return nil
+}
+
+// findJob returns job from release tarball if it's given, otherwise from release directory.
+func (c RenderJobCmd) findJob(opts RenderJobOpts) (*boshjob.Job, func(), error) {
+	noop := func() {}
+
+	if len(opts.Release.ExpandedPath) > 0 {
+		release, err := c.releaseReader.Read(opts.Release.ExpandedPath)
+		if err != nil {
+			return nil, noop, bosherr.WrapErrorf(err, "Reading release '%s'", opts.Release.ExpandedPath)
+		}
+
+		cleanUp := func() { release.CleanUp() }
+
+		for _, job := range release.Jobs() {
+			if job.Name() == opts.Job {
+				return job, cleanUp, nil
+			}
+		}
+
+		cleanUp()
+
+		return nil, noop, bosherr.Errorf("Expected to find job '%s' in release '%s'", opts.Job, opts.Release.ExpandedPath)
+	}
+
+	jobPath := filepath.Join(opts.ReleaseDir.Path, "jobs", opts.Job)
+
+	if !c.fs.FileExists(jobPath) {
+		return nil, noop, bosherr.Errorf("Expected to find job '%s' in release directory '%s'", opts.Job, opts.ReleaseDir.Path)
+	}
+
+	job, err := boshjob.NewDirReaderImpl(nil, c.fs).ReadSource(jobPath)
+	if err != nil {
+		return nil, noop, bosherr.WrapErrorf(err, "Reading job '%s'", opts.Job)
+	}
+
+	return job, noop, nil
+}
+
+func (c RenderJobCmd) buildMap(bytes []byte, desc string) (biproperty.Map, error) {
+	var raw map[interface{}]interface{}
+
+	err := yaml.Unmarshal(bytes, &raw)
+	if err != nil {
+		return nil, bosherr.WrapErrorf(err, "Unmarshalling %s", desc)
+	}
+
+	result, err := biproperty.BuildMap(raw)
+	if err != nil {
+		return nil, bosherr.WrapErrorf(err, "Building %s", desc)
+	}
+
+	return result, nil
+}
@@ -0,0 +1,185 @@
+package cmd_test
+
+import (
+	"errors"
+	"path/filepath"
+
+	biproperty "github.com/cloudfoundry/bosh-utils/property"
+	fakesys "github.com/cloudfoundry/bosh-utils/system/fakes"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	. "github.com/cloudfoundry/bosh-cli/cmd"
+	. "github.com/cloudfoundry/bosh-cli/cmd/opts"
+	boshjob "github.com/cloudfoundry/bosh-cli/release/job"
+	fakerel "github.com/cloudfoundry/bosh-cli/release/releasefakes"
+	. "github.com/cloudfoundry/bosh-cli/release/resource"
+	bitemplate "github.com/cloudfoundry/bosh-cli/templatescompiler"
+	fakeui "github.com/cloudfoundry/bosh-cli/ui/fakes"
+)
+
+var _ = Describe("RenderJobCmd", func() {
+	var (
+		jobRenderer   *fakeInstanceJobRenderer
+		releaseReader *fakerel.FakeReader
+		fs            *fakesys.FakeFileSystem
+		ui            *fakeui.FakeUI
+		command       RenderJobCmd
+		opts          RenderJobOpts
+	)
+
+	BeforeEach(func() {
+		jobRenderer = &fakeInstanceJobRenderer{}
+		releaseReader = &fakerel.FakeReader{}
+		fs = fakesys.NewFakeFileSystem()
+		ui = &fakeui.FakeUI{}
+		command = NewRenderJobCmd(jobRenderer, releaseReader, fs, ui)
+
+		fs.WriteFileString(filepath.Join("/release", "jobs", "my-job", "spec"), `---
+name: my-job
+templates: {config.yml.erb: config/config.yml}
+properties:
+  port: {default: 80}
+`)
+
+		opts = RenderJobOpts{
+			ReleaseDir: DirOrCWDArg{Path: "/release"},
+			Job:        "my-job",
+			Properties: FileBytesArg{Bytes: []byte("port: 8080\ntls: {enabled: true}\n")},
+			Links:      FileBytesArg{Bytes: []byte("db:\n  address: db.internal\n")},
+			Spec:       FileBytesArg{Bytes: []byte("id: my-id\nindex: 1\naz: z1\n")},
+			OutputDir:  DirOrCWDArg{Path: "/output"},
+		}
+	})
+
+	act := func() error { return command.Run(opts) }
+
+	It("renders job from release directory into output directory", func() {
+		err := act()
+		Expect(err).ToNot(HaveOccurred())
+
+		Expect(jobRenderer.renderCalls).To(Equal(1))
+
+		Expect(jobRenderer.job.Name()).To(Equal("my-job"))
+		Expect(jobRenderer.job.ExtractedPath()).To(Equal(filepath.Join("/release", "jobs", "my-job")))
+		Expect(jobRenderer.job.Templates).To(Equal(map[string]string{"config.yml.erb": "config/config.yml"}))
+		Expect(jobRenderer.job.Properties).To(HaveKey("port"))
+
+		Expect(jobRenderer.properties).To(Equal(biproperty.Map{
+			"port": 8080,
+			"tls":  biproperty.Map{"enabled": true},
+		}))
+		Expect(jobRenderer.links).To(Equal(biproperty.Map{
+			"db": biproperty.Map{"address": "db.internal"},
+		}))
+		Expect(jobRenderer.instance).To(Equal(bitemplate.InstanceSpec{ID: "my-id", Index: 1, AZ: "z1"}))
+		Expect(jobRenderer.destinationPath).To(Equal("/output"))
+
+		Expect(fs.FileExists("/output")).To(BeTrue())
+		Expect(ui.Said).To(Equal([]string{"Rendered job 'my-job' to '/output'"}))
+	})
+
+	It("renders job with empty properties, links and spec if they are not given", func() {
+		opts.Properties = FileBytesArg{}
+		opts.Links = FileBytesArg{}
+		opts.Spec = FileBytesArg{}
+
+		err := act()
+		Expect(err).ToNot(HaveOccurred())
+
+		Expect(jobRenderer.properties).To(Equal(biproperty.Map{}))
+		Expect(jobRenderer.links).To(Equal(biproperty.Map{}))
+		Expect(jobRenderer.instance).To(Equal(bitemplate.InstanceSpec{}))
+	})
+
+	It("returns an error if job is not found in release directory", func() {
+		opts.Job = "other-job"
+
+		err := act()
+		Expect(err).To(HaveOccurred())
+		Expect(err.Error()).To(Equal("Expected to find job 'other-job' in release directory '/release'"))
+	})
+
+	It("returns an error if properties cannot be parsed", func() {
+		opts.Properties = FileBytesArg{Bytes: []byte("-")}
+
+		err := act()
+		Expect(err).To(HaveOccurred())
+		Expect(err.Error()).To(ContainSubstring("Unmarshalling properties"))
+	})
+
+	It("returns an error if rendering fails", func() {
+		jobRenderer.err = errors.New("fake-err")
+
+		err := act()
+		Expect(err).To(HaveOccurred())
+		Expect(err.Error()).To(ContainSubstring("Rendering job 'my-job'"))
+		Expect(err.Error()).To(ContainSubstring("fake-err"))
+	})
+
+	Context("when release tarball is given", func() {
+		var (
+			release *fakerel.FakeRelease
+		)
+
+		BeforeEach(func() {
+			opts.Release = FileArg{ExpandedPath: "/release.tgz"}
+
+			release = &fakerel.FakeRelease{}
+			release.JobsReturns([]*boshjob.Job{
+				boshjob.NewJob(NewResource("other-job", "", nil)),
+				boshjob.NewJob(NewResource("my-job", "fp", nil)),
+			})
+
+			releaseReader.ReadReturns(release, nil)
+		})
+
+		It("renders job from release tarball and cleans up release", func() {
+			err := act()
+			Expect(err).ToNot(HaveOccurred())
+
+			Expect(releaseReader.ReadArgsForCall(0)).To(Equal("/release.tgz"))
+			Expect(jobRenderer.job.Name()).To(Equal("my-job"))
+			Expect(jobRenderer.job.Fingerprint()).To(Equal("fp"))
+			Expect(release.CleanUpCallCount()).To(Equal(1))
+		})
+
+		It("returns an error if job is not found in release", func() {
+			opts.Job = "missing-job"
+
+			err := act()
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(Equal("Expected to find job 'missing-job' in release '/release.tgz'"))
+			Expect(release.CleanUpCallCount()).To(Equal(1))
+		})
+
+		It("returns an error if release cannot be read", func() {
+			releaseReader.ReadReturns(nil, errors.New("fake-err"))
+
+			err := act()
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("Reading release '/release.tgz'"))
+		})
+	})
+})
+
+type fakeInstanceJobRenderer struct {
+	renderCalls     int
+	job             boshjob.Job
+	properties      biproperty.Map
+	instance        bitemplate.InstanceSpec
+	links           biproperty.Map
+	destinationPath string
+
+	err error
+}
+
+func (r *fakeInstanceJobRenderer) RenderInstance(job boshjob.Job, properties biproperty.Map, instance bitemplate.InstanceSpec, links biproperty.Map, destinationPath string) error {
+	r.renderCalls++
+	r.job = job
+	r.properties = properties
+	r.instance = instance
+	r.links = links
+	r.destinationPath = destinationPath
+	return r.err
+}
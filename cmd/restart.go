@@ -26,6 +26,18 @@ func (c RestartCmd) Run(opts RestartOpts) error {
 	if err != nil {
 		return err
 	}
+
+	if opts.NoTrack {
+		task, err := c.deployment.RestartAsync(opts.Args.Slug, restartOpts)
+		if err != nil {
+			return err
+		}
+
+		StartedTaskTable{Task: task, UI: c.ui}.Print()
+
+		return nil
+	}
+
 	return c.deployment.Restart(opts.Args.Slug, restartOpts)
 }
 
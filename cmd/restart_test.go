@@ -189,5 +189,32 @@ var _ = Describe("RestartCmd", func() {
 				})
 			})
 		})
+
+		Context("when no-track is set", func() {
+			BeforeEach(func() {
+				opts.NoTrack = true
+			})
+
+			It("starts task and prints it without waiting for it to finish", func() {
+				task := &fakedir.FakeTask{}
+				task.IDReturns(123)
+				deployment.RestartAsyncReturns(task, nil)
+
+				err := act()
+				Expect(err).ToNot(HaveOccurred())
+
+				Expect(deployment.RestartAsyncCallCount()).To(Equal(1))
+				Expect(deployment.RestartCallCount()).To(Equal(0))
+				Expect(ui.Table.Content).To(Equal("tasks"))
+			})
+
+			It("returns error if starting task failed", func() {
+				deployment.RestartAsyncReturns(nil, errors.New("fake-err"))
+
+				err := act()
+				Expect(err).To(HaveOccurred())
+				Expect(err.Error()).To(ContainSubstring("fake-err"))
+			})
+		})
 	})
 })
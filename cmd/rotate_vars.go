@@ -0,0 +1,398 @@
+package cmd
+
+import (
+	"crypto/x509"
+	"encoding/pem"
+	"strings"
+	"time"
+
+	"code.cloudfoundry.org/clock"
+	bosherr "github.com/cloudfoundry/bosh-utils/errors"
+	cfgtypes "github.com/cloudfoundry/config-server/types"
+	"gopkg.in/yaml.v2"
+
+	. "github.com/cloudfoundry/bosh-cli/cmd/opts"
+	boshdir "github.com/cloudfoundry/bosh-cli/director"
+	boshtpl "github.com/cloudfoundry/bosh-cli/director/template"
+	boshui "github.com/cloudfoundry/bosh-cli/ui"
+)
+
+const (
+	RotateVarsCAPhaseTransition = "transition"
+	RotateVarsCAPhaseSwitch     = "switch"
+)
+
+type RotateVarsCmd struct {
+	ui          boshui.UI
+	timeService clock.Clock
+}
+
+func NewRotateVarsCmd(ui boshui.UI, timeService clock.Clock) RotateVarsCmd {
+	return RotateVarsCmd{ui: ui, timeService: timeService}
+}
+
+// rotatedCert is a certificate variable as kept in the vars store.
+// Transitional holds CA that is trusted (via bundles) but not yet used for signing.
+type rotatedCert struct {
+	Certificate  string       `json:"certificate"            yaml:"certificate"`
+	PrivateKey   string       `json:"private_key"            yaml:"private_key"`
+	CA           string       `json:"ca"                     yaml:"ca"`
+	Transitional *rotatedCert `json:"transitional,omitempty" yaml:"transitional,omitempty"`
+}
+
+func (c RotateVarsCmd) Run(opts RotateVarsOpts) error {
+	if !opts.VarsStore.IsSet() {
+		return bosherr.Error("Expected vars store to be provided via '--vars-store'")
+	}
+
+	switch opts.CAPhase {
+	case "", RotateVarsCAPhaseTransition, RotateVarsCAPhaseSwitch:
+	default:
+		return bosherr.Errorf("Unknown CA phase '%s' (supported: transition, switch)", opts.CAPhase)
+	}
+
+	defs, err := c.certificateDefinitions(opts)
+	if err != nil {
+		return err
+	}
+
+	selected, err := c.selectDefinitions(defs, opts.Names)
+	if err != nil {
+		return err
+	}
+
+	vars := opts.VarFlags.AsVariables()
+	store := opts.VarsStore
+
+	if !opts.DryRun {
+		generator, err := cfgtypes.NewValueGeneratorConcrete(NewVarsCertLoader(vars)).GetGenerator("certificate")
+		if err != nil {
+			return err
+		}
+
+		rotator := certRotator{store: store.Backend, generator: generator, ui: c.ui}
+
+		switch opts.CAPhase {
+		case RotateVarsCAPhaseTransition:
+			err = rotator.TransitionCAs(selected, defs)
+		case RotateVarsCAPhaseSwitch:
+			err = rotator.SwitchCAs(selected, defs)
+		default:
+			err = rotator.RotateLeafs(selected)
+		}
+		if err != nil {
+			return err
+		}
+	}
+
+	return c.printExpiry(store.Backend, selected)
+}
+
+// certificateDefinitions returns certificate variables from the manifest.
+// Vars store is not consulted to avoid generating variables while reading definitions.
+func (c RotateVarsCmd) certificateDefinitions(opts RotateVarsOpts) ([]boshtpl.VariableDefinition, error) {
+	staticFlags := opts.VarFlags
+	staticFlags.VarsStore = VarsStore{}
+
+	tpl := boshtpl.NewTemplate(opts.Args.Manifest.Bytes)
+
+	bytes, err := tpl.Evaluate(staticFlags.AsVariables(), opts.OpsFlags.AsOp(), boshtpl.EvaluateOpts{})
+	if err != nil {
+		return nil, bosherr.WrapErrorf(err, "Evaluating manifest")
+	}
+
+	var manifest struct {
+		Variables []boshtpl.VariableDefinition
+	}
+
+	err = yaml.Unmarshal(bytes, &manifest)
+	if err != nil {
+		return nil, bosherr.WrapErrorf(err, "Unmarshalling manifest")
+	}
+
+	var defs []boshtpl.VariableDefinition
+
+	for _, def := range manifest.Variables {
+		if def.Type == "certificate" {
+			defs = append(defs, def)
+		}
+	}
+
+	return defs, nil
+}
+
+func (c RotateVarsCmd) selectDefinitions(defs []boshtpl.VariableDefinition, names []string) ([]boshtpl.VariableDefinition, error) {
+	if len(names) == 0 {
+		return defs, nil
+	}
+
+	var selected []boshtpl.VariableDefinition
+
+	for _, name := range names {
+		var found bool
+
+		for _, def := range defs {
+			if def.Name == name {
+				selected = append(selected, def)
+				found = true
+				break
+			}
+		}
+
+		if !found {
+			return nil, bosherr.Errorf("Expected certificate variable '%s' to be defined in manifest", name)
+		}
+	}
+
+	return selected, nil
+}
+
+func (c RotateVarsCmd) printExpiry(store VarsStoreBackend, defs []boshtpl.VariableDefinition) error {
+	var infos []boshdir.CertificateExpiryInfo
+
+	for _, def := range defs {
+		cert, found, err := findRotatedCert(store, def.Name)
+		if err != nil {
+			return err
+		} else if !found {
+			continue
+		}
+
+		info, err := c.expiryInfo(def.Name, cert.Certificate)
+		if err != nil {
+			return err
+		}
+
+		infos = append(infos, info)
+
+		if cert.Transitional != nil {
+			info, err = c.expiryInfo(def.Name+" (transitional)", cert.Transitional.Certificate)
+			if err != nil {
+				return err
+			}
+
+			infos = append(infos, info)
+		}
+	}
+
+	CertificateInfoTable{infos, c.ui}.Print()
+
+	return nil
+}
+
+func (c RotateVarsCmd) expiryInfo(path, certPEM string) (boshdir.CertificateExpiryInfo, error) {
+	block, _ := pem.Decode([]byte(certPEM))
+	if block == nil {
+		return boshdir.CertificateExpiryInfo{}, bosherr.Errorf("Expected certificate '%s' to contain PEM formatted block", path)
+	}
+
+	crt, err := x509.ParseCertificate(block.Bytes)
+	if err != nil {
+		return boshdir.CertificateExpiryInfo{}, bosherr.WrapErrorf(err, "Parsing certificate '%s'", path)
+	}
+
+	return boshdir.CertificateExpiryInfo{
+		Path:     path,
+		Expiry:   crt.NotAfter.UTC().Format(time.RFC3339),
+		DaysLeft: int(crt.NotAfter.Sub(c.timeService.Now()).Hours() / 24),
+	}, nil
+}
+
+type certRotator struct {
+	store     VarsStoreBackend
+	generator cfgtypes.ValueGenerator
+	ui        boshui.UI
+}
+
+// RotateLeafs regenerates certificates signed by their current CAs.
+// CAs are only rotated in phases so that dependents keep trusting them.
+func (r certRotator) RotateLeafs(defs []boshtpl.VariableDefinition) error {
+	for _, def := range defs {
+		if certDefIsCA(def) {
+			continue
+		}
+
+		err := r.regenerate(def)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// TransitionCAs generates new CAs and adds them to trust bundles
+// of the CAs and of the certificates signed by them. Signing CA is not changed.
+func (r certRotator) TransitionCAs(selected, defs []boshtpl.VariableDefinition) error {
+	for _, def := range selected {
+		if !certDefIsCA(def) {
+			continue
+		}
+
+		cert, found, err := findRotatedCert(r.store, def.Name)
+		if err != nil {
+			return err
+		} else if !found {
+			return bosherr.Errorf("Expected CA '%s' to be found in vars store", def.Name)
+		}
+
+		if cert.Transitional != nil {
+			return bosherr.Errorf("Expected CA '%s' to not be in transition already", def.Name)
+		}
+
+		newCert, err := r.generate(def)
+		if err != nil {
+			return err
+		}
+
+		cert.Certificate = bundleCerts(cert.Certificate, newCert.Certificate)
+		cert.CA = bundleCerts(cert.CA, newCert.CA)
+		cert.Transitional = &newCert
+
+		err = r.store.Put(def.Name, cert)
+		if err != nil {
+			return bosherr.WrapErrorf(err, "Saving CA '%s'", def.Name)
+		}
+
+		r.ui.PrintLinef("Added transitional certificate to CA '%s'", def.Name)
+
+		for _, signedDef := range certDefsSignedBy(defs, def.Name) {
+			signedCert, found, err := findRotatedCert(r.store, signedDef.Name)
+			if err != nil {
+				return err
+			} else if !found {
+				continue
+			}
+
+			signedCert.CA = bundleCerts(signedCert.CA, newCert.Certificate)
+
+			err = r.store.Put(signedDef.Name, signedCert)
+			if err != nil {
+				return bosherr.WrapErrorf(err, "Saving certificate '%s'", signedDef.Name)
+			}
+		}
+	}
+
+	return nil
+}
+
+// SwitchCAs replaces CAs with their transitional certificates
+// and regenerates certificates signed by them.
+func (r certRotator) SwitchCAs(selected, defs []boshtpl.VariableDefinition) error {
+	for _, def := range selected {
+		if !certDefIsCA(def) {
+			continue
+		}
+
+		cert, found, err := findRotatedCert(r.store, def.Name)
+		if err != nil {
+			return err
+		} else if !found || cert.Transitional == nil {
+			return bosherr.Errorf("Expected CA '%s' to have transitional certificate; rotate with '--ca-phase transition' first", def.Name)
+		}
+
+		err = r.store.Put(def.Name, *cert.Transitional)
+		if err != nil {
+			return bosherr.WrapErrorf(err, "Saving CA '%s'", def.Name)
+		}
+
+		r.ui.PrintLinef("Switched CA '%s' to transitional certificate", def.Name)
+
+		for _, signedDef := range certDefsSignedBy(defs, def.Name) {
+			err := r.regenerate(signedDef)
+			if err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+func (r certRotator) regenerate(def boshtpl.VariableDefinition) error {
+	cert, err := r.generate(def)
+	if err != nil {
+		return err
+	}
+
+	err = r.store.Put(def.Name, cert)
+	if err != nil {
+		return bosherr.WrapErrorf(err, "Saving certificate '%s'", def.Name)
+	}
+
+	r.ui.PrintLinef("Rotated certificate '%s'", def.Name)
+
+	return nil
+}
+
+func (r certRotator) generate(def boshtpl.VariableDefinition) (rotatedCert, error) {
+	val, err := r.generator.Generate(def.Options)
+	if err != nil {
+		return rotatedCert{}, bosherr.WrapErrorf(err, "Generating certificate '%s'", def.Name)
+	}
+
+	return toRotatedCert(def.Name, val)
+}
+
+func findRotatedCert(store VarsStoreBackend, name string) (rotatedCert, bool, error) {
+	val, found, err := store.Find(name)
+	if err != nil || !found {
+		return rotatedCert{}, found, err
+	}
+
+	cert, err := toRotatedCert(name, val)
+
+	return cert, true, err
+}
+
+func toRotatedCert(name string, val interface{}) (rotatedCert, error) {
+	var cert rotatedCert
+
+	// Convert to YAML for easier struct parsing
+	bytes, err := yaml.Marshal(val)
+	if err != nil {
+		return cert, bosherr.WrapErrorf(err, "Expected variable '%s' to be serializable", name)
+	}
+
+	err = yaml.Unmarshal(bytes, &cert)
+	if err != nil {
+		return cert, bosherr.WrapErrorf(err, "Expected variable '%s' to be a certificate", name)
+	}
+
+	return cert, nil
+}
+
+func certDefIsCA(def boshtpl.VariableDefinition) bool {
+	opts, ok := def.Options.(map[interface{}]interface{})
+	if !ok {
+		return false
+	}
+
+	isCA, _ := opts["is_ca"].(bool)
+
+	return isCA
+}
+
+func certDefsSignedBy(defs []boshtpl.VariableDefinition, caName string) []boshtpl.VariableDefinition {
+	var signed []boshtpl.VariableDefinition
+
+	for _, def := range defs {
+		if opts, ok := def.Options.(map[interface{}]interface{}); ok && opts["ca"] == caName {
+			signed = append(signed, def)
+		}
+	}
+
+	return signed
+}
+
+func bundleCerts(bundle, cert string) string {
+	if len(cert) == 0 || strings.Contains(bundle, cert) {
+		return bundle
+	}
+
+	if len(bundle) > 0 && !strings.HasSuffix(bundle, "\n") {
+		bundle += "\n"
+	}
+
+	return bundle + cert
+}
@@ -0,0 +1,272 @@
+package cmd_test
+
+import (
+	"crypto/x509"
+	"encoding/pem"
+	"time"
+
+	"code.cloudfoundry.org/clock/fakeclock"
+	fakesys "github.com/cloudfoundry/bosh-utils/system/fakes"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"gopkg.in/yaml.v2"
+
+	. "github.com/cloudfoundry/bosh-cli/cmd"
+	. "github.com/cloudfoundry/bosh-cli/cmd/opts"
+	boshtpl "github.com/cloudfoundry/bosh-cli/director/template"
+	fakeui "github.com/cloudfoundry/bosh-cli/ui/fakes"
+	boshtbl "github.com/cloudfoundry/bosh-cli/ui/table"
+)
+
+var _ = Describe("RotateVarsCmd", func() {
+	const manifest = `
+variables:
+- name: ca
+  type: certificate
+  options: {is_ca: true, common_name: ca}
+- name: cert
+  type: certificate
+  options: {ca: ca, common_name: cert}
+- name: password
+  type: password
+`
+
+	type storedCert struct {
+		Certificate  string
+		PrivateKey   string `yaml:"private_key"`
+		CA           string
+		Transitional *storedCert
+	}
+
+	var (
+		ui      *fakeui.FakeUI
+		fs      *fakesys.FakeFileSystem
+		now     time.Time
+		command RotateVarsCmd
+		opts    RotateVarsOpts
+	)
+
+	BeforeEach(func() {
+		ui = &fakeui.FakeUI{}
+		fs = fakesys.NewFakeFileSystem()
+		now = time.Now()
+		command = NewRotateVarsCmd(ui, fakeclock.NewFakeClock(now))
+
+		varsStore := &VarsStore{FS: fs}
+
+		err := varsStore.UnmarshalFlag("/creds.yml")
+		Expect(err).ToNot(HaveOccurred())
+
+		opts = RotateVarsOpts{
+			Args:     RotateVarsArgs{Manifest: FileBytesArg{Bytes: []byte(manifest)}},
+			VarFlags: VarFlags{VarsStore: *varsStore},
+		}
+
+		// Generate initial certificates the same way create-env does
+		tpl := boshtpl.NewTemplate([]byte(manifest + "ca: ((ca))\ncert: ((cert))\n"))
+
+		_, err = tpl.Evaluate(opts.VarFlags.AsVariables(), nil, boshtpl.EvaluateOpts{})
+		Expect(err).ToNot(HaveOccurred())
+	})
+
+	act := func() error { return command.Run(opts) }
+
+	readCert := func(name string) storedCert {
+		bytes, err := fs.ReadFile("/creds.yml")
+		Expect(err).ToNot(HaveOccurred())
+
+		var vars map[string]interface{}
+
+		err = yaml.Unmarshal(bytes, &vars)
+		Expect(err).ToNot(HaveOccurred())
+
+		certBytes, err := yaml.Marshal(vars[name])
+		Expect(err).ToNot(HaveOccurred())
+
+		var cert storedCert
+
+		err = yaml.Unmarshal(certBytes, &cert)
+		Expect(err).ToNot(HaveOccurred())
+
+		return cert
+	}
+
+	parseCert := func(certPEM string) *x509.Certificate {
+		block, _ := pem.Decode([]byte(certPEM))
+		Expect(block).ToNot(BeNil())
+
+		crt, err := x509.ParseCertificate(block.Bytes)
+		Expect(err).ToNot(HaveOccurred())
+
+		return crt
+	}
+
+	expectSignedBy := func(cert, ca storedCert) {
+		pool := x509.NewCertPool()
+		Expect(pool.AppendCertsFromPEM([]byte(ca.Certificate))).To(BeTrue())
+
+		_, err := parseCert(cert.Certificate).Verify(x509.VerifyOptions{Roots: pool})
+		Expect(err).ToNot(HaveOccurred())
+	}
+
+	It("regenerates certificates signed by current CA without rotating CA", func() {
+		oldCA := readCert("ca")
+		oldCert := readCert("cert")
+
+		err := act()
+		Expect(err).ToNot(HaveOccurred())
+
+		newCert := readCert("cert")
+		Expect(newCert.Certificate).ToNot(Equal(oldCert.Certificate))
+		Expect(newCert.CA).To(Equal(oldCA.Certificate))
+		Expect(readCert("ca")).To(Equal(oldCA))
+
+		expectSignedBy(newCert, oldCA)
+
+		Expect(ui.Said).To(Equal([]string{"Rotated certificate 'cert'"}))
+	})
+
+	It("shows expiry dates of certificates", func() {
+		err := act()
+		Expect(err).ToNot(HaveOccurred())
+
+		caExpiry := parseCert(readCert("ca").Certificate).NotAfter
+		certExpiry := parseCert(readCert("cert").Certificate).NotAfter
+
+		Expect(ui.Table.Header).To(Equal([]boshtbl.Header{
+			boshtbl.NewHeader("Certificate"),
+			boshtbl.NewHeader("Expiry Date (UTC)"),
+			boshtbl.NewHeader("Days Left"),
+		}))
+
+		Expect(ui.Table.Rows).To(Equal([][]boshtbl.Value{
+			{
+				boshtbl.NewValueString("ca"),
+				boshtbl.NewValueString(caExpiry.UTC().Format(time.RFC3339)),
+				boshtbl.NewValueFmt(boshtbl.NewValueInt(int(caExpiry.Sub(now).Hours()/24)), false),
+			},
+			{
+				boshtbl.NewValueString("cert"),
+				boshtbl.NewValueString(certExpiry.UTC().Format(time.RFC3339)),
+				boshtbl.NewValueFmt(boshtbl.NewValueInt(int(certExpiry.Sub(now).Hours()/24)), false),
+			},
+		}))
+	})
+
+	It("only shows expiry dates if dry run is requested", func() {
+		oldCert := readCert("cert")
+
+		opts.DryRun = true
+
+		err := act()
+		Expect(err).ToNot(HaveOccurred())
+
+		Expect(readCert("cert")).To(Equal(oldCert))
+		Expect(ui.Said).To(BeEmpty())
+		Expect(ui.Table.Rows).To(HaveLen(2))
+	})
+
+	It("only rotates and shows given certificates", func() {
+		opts.Names = []string{"cert"}
+
+		err := act()
+		Expect(err).ToNot(HaveOccurred())
+
+		Expect(ui.Said).To(Equal([]string{"Rotated certificate 'cert'"}))
+		Expect(ui.Table.Rows).To(HaveLen(1))
+		Expect(ui.Table.Rows[0][0]).To(Equal(boshtbl.NewValueString("cert")))
+	})
+
+	It("returns an error if given certificate is not defined in manifest", func() {
+		opts.Names = []string{"password"}
+
+		err := act()
+		Expect(err).To(HaveOccurred())
+		Expect(err.Error()).To(Equal("Expected certificate variable 'password' to be defined in manifest"))
+	})
+
+	It("returns an error if vars store is not set", func() {
+		opts.VarsStore = VarsStore{}
+
+		err := act()
+		Expect(err).To(HaveOccurred())
+		Expect(err.Error()).To(Equal("Expected vars store to be provided via '--vars-store'"))
+	})
+
+	It("returns an error if CA phase is unknown", func() {
+		opts.CAPhase = "unknown"
+
+		err := act()
+		Expect(err).To(HaveOccurred())
+		Expect(err.Error()).To(Equal("Unknown CA phase 'unknown' (supported: transition, switch)"))
+	})
+
+	Context("when rotating CAs", func() {
+		It("adds new CA to trust bundles during transition without changing signing CA", func() {
+			oldCA := readCert("ca")
+			oldCert := readCert("cert")
+
+			opts.CAPhase = "transition"
+
+			err := act()
+			Expect(err).ToNot(HaveOccurred())
+
+			ca := readCert("ca")
+			Expect(ca.Transitional).ToNot(BeNil())
+			Expect(ca.PrivateKey).To(Equal(oldCA.PrivateKey))
+			Expect(ca.Certificate).To(Equal(oldCA.Certificate + ca.Transitional.Certificate))
+
+			cert := readCert("cert")
+			Expect(cert.Certificate).To(Equal(oldCert.Certificate))
+			Expect(cert.CA).To(Equal(oldCert.CA + ca.Transitional.Certificate))
+
+			Expect(ui.Said).To(Equal([]string{"Added transitional certificate to CA 'ca'"}))
+
+			Expect(ui.Table.Rows).To(HaveLen(3))
+			Expect(ui.Table.Rows[1][0]).To(Equal(boshtbl.NewValueString("ca (transitional)")))
+		})
+
+		It("switches to transitional CA and regenerates certificates signed by it", func() {
+			opts.CAPhase = "transition"
+
+			err := act()
+			Expect(err).ToNot(HaveOccurred())
+
+			transitional := *readCert("ca").Transitional
+
+			opts.CAPhase = "switch"
+
+			err = act()
+			Expect(err).ToNot(HaveOccurred())
+
+			ca := readCert("ca")
+			Expect(ca).To(Equal(transitional))
+
+			cert := readCert("cert")
+			Expect(cert.CA).To(Equal(ca.Certificate))
+			expectSignedBy(cert, ca)
+
+			Expect(ui.Said).To(ContainElement("Switched CA 'ca' to transitional certificate"))
+			Expect(ui.Said).To(ContainElement("Rotated certificate 'cert'"))
+		})
+
+		It("returns an error when switching CA that is not in transition", func() {
+			opts.CAPhase = "switch"
+
+			err := act()
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("Expected CA 'ca' to have transitional certificate"))
+		})
+
+		It("returns an error when CA is already in transition", func() {
+			opts.CAPhase = "transition"
+
+			err := act()
+			Expect(err).ToNot(HaveOccurred())
+
+			err = act()
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(Equal("Expected CA 'ca' to not be in transition already"))
+		})
+	})
+})
@@ -26,6 +26,26 @@ func NewRunErrandCmd(
 }
 
 func (c RunErrandCmd) Run(opts RunErrandOpts) error {
+	if opts.NoTrack {
+		if opts.DownloadLogs {
+			return bosherr.Error("Can't set download-logs and no-track")
+		}
+
+		task, err := c.deployment.RunErrandAsync(
+			opts.Args.Name,
+			opts.KeepAlive,
+			opts.WhenChanged,
+			opts.InstanceGroupOrInstanceSlugFlags.Slugs,
+		)
+		if err != nil {
+			return err
+		}
+
+		StartedTaskTable{Task: task, UI: c.ui}.Print()
+
+		return nil
+	}
+
 	results, err := c.deployment.RunErrand(
 		opts.Args.Name,
 		opts.KeepAlive,
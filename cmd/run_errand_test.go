@@ -291,5 +291,42 @@ var _ = Describe("RunErrandCmd", func() {
 			Expect(err).To(HaveOccurred())
 			Expect(err.Error()).To(ContainSubstring("fake-err"))
 		})
+
+		Context("when no-track is set", func() {
+			BeforeEach(func() {
+				opts.NoTrack = true
+			})
+
+			It("starts task and prints it without waiting for it to finish", func() {
+				task := &fakedir.FakeTask{}
+				task.IDReturns(123)
+				deployment.RunErrandAsyncReturns(task, nil)
+
+				err := act()
+				Expect(err).ToNot(HaveOccurred())
+
+				Expect(deployment.RunErrandAsyncCallCount()).To(Equal(1))
+				Expect(deployment.RunErrandCallCount()).To(Equal(0))
+				Expect(ui.Table.Content).To(Equal("tasks"))
+			})
+
+			It("returns error if starting task failed", func() {
+				deployment.RunErrandAsyncReturns(nil, errors.New("fake-err"))
+
+				err := act()
+				Expect(err).To(HaveOccurred())
+				Expect(err.Error()).To(ContainSubstring("fake-err"))
+			})
+
+			It("returns error if logs download is requested", func() {
+				opts.DownloadLogs = true
+
+				err := act()
+				Expect(err).To(HaveOccurred())
+				Expect(err.Error()).To(Equal("Can't set download-logs and no-track"))
+
+				Expect(deployment.RunErrandAsyncCallCount()).To(Equal(0))
+			})
+		})
 	})
 })
@@ -1,18 +1,21 @@
 package cmd
 
 import (
+	"io"
+
 	boshlog "github.com/cloudfoundry/bosh-utils/logger"
 	boshsys "github.com/cloudfoundry/bosh-utils/system"
 
 	cmdconf "github.com/cloudfoundry/bosh-cli/cmd/config"
 	. "github.com/cloudfoundry/bosh-cli/cmd/opts"
 	boshui "github.com/cloudfoundry/bosh-cli/ui"
+	boshuit "github.com/cloudfoundry/bosh-cli/ui/task"
 )
 
 func NewSessionFromOpts(
 	opts BoshOpts,
 	config cmdconf.Config,
-	ui boshui.UI,
+	ui *boshui.ConfUI,
 	printEnvironment bool,
 	printDeployment bool,
 	fs boshsys.FileSystem,
@@ -20,5 +23,15 @@ func NewSessionFromOpts(
 ) Session {
 	context := NewSessionContextImpl(opts, config, fs)
 
-	return NewSessionImpl(context, ui, printEnvironment, printDeployment, logger)
+	return NewSessionImpl(context, ui, NewTaskReporterFromOpts(opts, ui, ui.EventWriter()), printEnvironment, printDeployment, logger)
+}
+
+// NewTaskReporterFromOpts returns reporter that shows director task events
+// either in a human readable form or as newline-delimited JSON written to events.
+func NewTaskReporterFromOpts(opts BoshOpts, ui boshui.UI, events io.Writer) boshuit.Reporter {
+	if opts.EventJSONOpt && events != nil {
+		return boshuit.NewEventJSONReporter(events)
+	}
+
+	return boshuit.NewReporter(ui, true)
 }
@@ -0,0 +1,30 @@
+package cmd_test
+
+import (
+	"bytes"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	. "github.com/cloudfoundry/bosh-cli/cmd"
+	. "github.com/cloudfoundry/bosh-cli/cmd/opts"
+	fakeui "github.com/cloudfoundry/bosh-cli/ui/fakes"
+	boshuit "github.com/cloudfoundry/bosh-cli/ui/task"
+)
+
+var _ = Describe("NewTaskReporterFromOpts", func() {
+	It("returns human readable reporter by default", func() {
+		reporter := NewTaskReporterFromOpts(BoshOpts{}, &fakeui.FakeUI{}, nil)
+		Expect(reporter).To(BeAssignableToTypeOf(&boshuit.ReporterImpl{}))
+	})
+
+	It("returns JSON events reporter when event JSON output is requested", func() {
+		reporter := NewTaskReporterFromOpts(BoshOpts{EventJSONOpt: true}, &fakeui.FakeUI{}, &bytes.Buffer{})
+		Expect(reporter).To(BeAssignableToTypeOf(&boshuit.EventJSONReporter{}))
+	})
+
+	It("returns human readable reporter when there is no events writer", func() {
+		reporter := NewTaskReporterFromOpts(BoshOpts{EventJSONOpt: true}, &fakeui.FakeUI{}, nil)
+		Expect(reporter).To(BeAssignableToTypeOf(&boshuit.ReporterImpl{}))
+	})
+})
@@ -16,6 +16,7 @@ import (
 
 	fakecmd "github.com/cloudfoundry/bosh-cli/cmd/cmdfakes"
 	fakeui "github.com/cloudfoundry/bosh-cli/ui/fakes"
+	fakeuit "github.com/cloudfoundry/bosh-cli/ui/task/taskfakes"
 )
 
 var _ = Describe("SessionImpl", func() {
@@ -37,7 +38,7 @@ var _ = Describe("SessionImpl", func() {
 		printEnvironment = false
 		printDeployment = false
 		logger = boshlog.NewLogger(boshlog.LevelNone)
-		sess = NewSessionImpl(context, ui, printEnvironment, printDeployment, logger)
+		sess = NewSessionImpl(context, ui, &fakeuit.FakeReporter{}, printEnvironment, printDeployment, logger)
 	})
 
 	Describe("UAA", func() {
@@ -31,6 +31,18 @@ func (c StartCmd) Run(opts StartOpts) error {
 	if err != nil {
 		return err
 	}
+
+	if opts.NoTrack {
+		task, err := c.deployment.StartAsync(opts.Args.Slug, startOpts)
+		if err != nil {
+			return err
+		}
+
+		StartedTaskTable{Task: task, UI: c.ui}.Print()
+
+		return nil
+	}
+
 	return c.deployment.Start(opts.Args.Slug, startOpts)
 }
 
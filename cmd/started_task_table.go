@@ -0,0 +1,41 @@
+package cmd
+
+import (
+	"fmt"
+
+	boshdir "github.com/cloudfoundry/bosh-cli/director"
+	boshui "github.com/cloudfoundry/bosh-cli/ui"
+	boshtbl "github.com/cloudfoundry/bosh-cli/ui/table"
+)
+
+// StartedTaskTable shows task that was started without waiting for it to finish
+type StartedTaskTable struct {
+	Task boshdir.Task
+	UI   boshui.UI
+}
+
+func (t StartedTaskTable) Print() {
+	table := boshtbl.Table{
+		Content: "tasks",
+
+		Header: []boshtbl.Header{
+			boshtbl.NewHeader("ID"),
+			boshtbl.NewHeader("State"),
+			boshtbl.NewHeader("Deployment"),
+		},
+
+		Rows: [][]boshtbl.Value{
+			{
+				boshtbl.NewValueInt(t.Task.ID()),
+				boshtbl.NewValueString(t.Task.State()),
+				boshtbl.NewValueString(t.Task.DeploymentName()),
+			},
+		},
+
+		Notes: []string{
+			fmt.Sprintf("Use 'bosh task %d' to follow task output", t.Task.ID()),
+		},
+	}
+
+	t.UI.PrintTable(table)
+}
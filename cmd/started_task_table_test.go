@@ -0,0 +1,44 @@
+package cmd_test
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	. "github.com/cloudfoundry/bosh-cli/cmd"
+	fakedir "github.com/cloudfoundry/bosh-cli/director/directorfakes"
+	fakeui "github.com/cloudfoundry/bosh-cli/ui/fakes"
+	boshtbl "github.com/cloudfoundry/bosh-cli/ui/table"
+)
+
+var _ = Describe("StartedTaskTable", func() {
+	It("prints task with a hint how to follow it", func() {
+		ui := &fakeui.FakeUI{}
+
+		task := &fakedir.FakeTask{}
+		task.IDReturns(123)
+		task.StateReturns("queued")
+		task.DeploymentNameReturns("dep")
+
+		StartedTaskTable{Task: task, UI: ui}.Print()
+
+		Expect(ui.Table).To(Equal(boshtbl.Table{
+			Content: "tasks",
+
+			Header: []boshtbl.Header{
+				boshtbl.NewHeader("ID"),
+				boshtbl.NewHeader("State"),
+				boshtbl.NewHeader("Deployment"),
+			},
+
+			Rows: [][]boshtbl.Value{
+				{
+					boshtbl.NewValueInt(123),
+					boshtbl.NewValueString("queued"),
+					boshtbl.NewValueString("dep"),
+				},
+			},
+
+			Notes: []string{"Use 'bosh task 123' to follow task output"},
+		}))
+	})
+})
@@ -0,0 +1,445 @@
+package cmd
+
+import (
+	"fmt"
+	"strings"
+
+	"code.cloudfoundry.org/clock"
+	bosherr "github.com/cloudfoundry/bosh-utils/errors"
+	biproperty "github.com/cloudfoundry/bosh-utils/property"
+	boshuuid "github.com/cloudfoundry/bosh-utils/uuid"
+	"gopkg.in/yaml.v2"
+
+	. "github.com/cloudfoundry/bosh-cli/cmd/opts"
+	biconfig "github.com/cloudfoundry/bosh-cli/config"
+	boshui "github.com/cloudfoundry/bosh-cli/ui"
+	boshtbl "github.com/cloudfoundry/bosh-cli/ui/table"
+)
+
+// StateCmd inspects and edits create-env state so that it can be brought
+// back in sync with the IaaS without editing state file by hand.
+type StateCmd struct {
+	ui                     boshui.UI
+	deploymentStateService biconfig.DeploymentStateService
+	deploymentRepo         biconfig.DeploymentRepo
+	vmRepo                 biconfig.VMRepo
+	diskRepo               biconfig.DiskRepo
+	stemcellRepo           biconfig.StemcellRepo
+	timeService            clock.Clock
+}
+
+func NewStateCmd(
+	ui boshui.UI,
+	deploymentStateService biconfig.DeploymentStateService,
+	uuidGenerator boshuuid.Generator,
+	timeService clock.Clock,
+) StateCmd {
+	return StateCmd{
+		ui:                     ui,
+		deploymentStateService: deploymentStateService,
+		deploymentRepo:         biconfig.NewDeploymentRepo(deploymentStateService),
+		vmRepo:                 biconfig.NewVMRepo(deploymentStateService),
+		diskRepo:               biconfig.NewDiskRepo(deploymentStateService, uuidGenerator),
+		stemcellRepo:           biconfig.NewStemcellRepo(deploymentStateService, uuidGenerator),
+		timeService:            timeService,
+	}
+}
+
+func (c StateCmd) Show(opts StateShowOpts) error {
+	state, err := c.load()
+	if err != nil {
+		return err
+	}
+
+	checkpoint := ""
+	if state.Checkpoint != nil {
+		checkpoint = strings.Join(state.Checkpoint.Completed, ", ")
+	}
+
+	c.ui.PrintTable(boshtbl.Table{
+		Header: []boshtbl.Header{
+			boshtbl.NewHeader("Director ID"),
+			boshtbl.NewHeader("Installation ID"),
+			boshtbl.NewHeader("Current VM CID"),
+			boshtbl.NewHeader("Current Manifest SHA"),
+			boshtbl.NewHeader("Checkpoint"),
+		},
+		Rows: [][]boshtbl.Value{{
+			boshtbl.NewValueString(state.DirectorID),
+			boshtbl.NewValueString(state.InstallationID),
+			boshtbl.NewValueString(state.CurrentVMCID),
+			boshtbl.NewValueString(state.CurrentManifestSHA),
+			boshtbl.NewValueString(checkpoint),
+		}},
+		Transpose: true,
+	})
+
+	disksTable := boshtbl.Table{
+		Content: "disks",
+		Header: []boshtbl.Header{
+			boshtbl.NewHeader("ID"),
+			boshtbl.NewHeader("CID"),
+			boshtbl.NewHeader("Size"),
+			boshtbl.NewHeader("Current"),
+		},
+		SortBy: []boshtbl.ColumnSort{{Column: 1, Asc: true}},
+	}
+
+	for _, disk := range state.Disks {
+		disksTable.Rows = append(disksTable.Rows, []boshtbl.Value{
+			boshtbl.NewValueString(disk.ID),
+			boshtbl.NewValueString(disk.CID),
+			boshtbl.NewValueMegaBytes(uint64(disk.Size)),
+			boshtbl.NewValueBool(disk.ID == state.CurrentDiskID),
+		})
+	}
+
+	c.ui.PrintTable(disksTable)
+
+	stemcellsTable := boshtbl.Table{
+		Content: "stemcells",
+		Header: []boshtbl.Header{
+			boshtbl.NewHeader("ID"),
+			boshtbl.NewHeader("Name"),
+			boshtbl.NewHeader("Version"),
+			boshtbl.NewHeader("CID"),
+			boshtbl.NewHeader("Current"),
+		},
+		SortBy: []boshtbl.ColumnSort{{Column: 1, Asc: true}, {Column: 2, Asc: true}},
+	}
+
+	for _, stemcell := range state.Stemcells {
+		stemcellsTable.Rows = append(stemcellsTable.Rows, []boshtbl.Value{
+			boshtbl.NewValueString(stemcell.ID),
+			boshtbl.NewValueString(stemcell.Name),
+			boshtbl.NewValueString(stemcell.Version),
+			boshtbl.NewValueString(stemcell.CID),
+			boshtbl.NewValueBool(stemcell.ID == state.CurrentStemcellID),
+		})
+	}
+
+	c.ui.PrintTable(stemcellsTable)
+
+	releasesTable := boshtbl.Table{
+		Content: "releases",
+		Header: []boshtbl.Header{
+			boshtbl.NewHeader("ID"),
+			boshtbl.NewHeader("Name"),
+			boshtbl.NewHeader("Version"),
+			boshtbl.NewHeader("Current"),
+		},
+		SortBy: []boshtbl.ColumnSort{{Column: 1, Asc: true}},
+	}
+
+	for _, release := range state.Releases {
+		releasesTable.Rows = append(releasesTable.Rows, []boshtbl.Value{
+			boshtbl.NewValueString(release.ID),
+			boshtbl.NewValueString(release.Name),
+			boshtbl.NewValueString(release.Version),
+			boshtbl.NewValueBool(contains(state.CurrentReleaseIDs, release.ID)),
+		})
+	}
+
+	c.ui.PrintTable(releasesTable)
+
+	return nil
+}
+
+func (c StateCmd) SetVMCID(opts StateSetVMCIDOpts) error {
+	_, err := c.load()
+	if err != nil {
+		return err
+	}
+
+	err = c.backup()
+	if err != nil {
+		return err
+	}
+
+	// Jobs have to be applied again to a different VM,
+	// so the next create-env must not consider deployment up to date
+	err = c.deploymentRepo.UpdateCurrent("")
+	if err != nil {
+		return bosherr.WrapError(err, "Clearing current manifest SHA")
+	}
+
+	if len(opts.Args.CID) == 0 {
+		err = c.vmRepo.ClearCurrent()
+		if err != nil {
+			return bosherr.WrapError(err, "Clearing current VM CID")
+		}
+
+		c.ui.PrintLinef("Cleared current VM CID")
+
+		return nil
+	}
+
+	err = c.vmRepo.UpdateCurrent(opts.Args.CID)
+	if err != nil {
+		return bosherr.WrapErrorf(err, "Setting current VM CID to '%s'", opts.Args.CID)
+	}
+
+	c.ui.PrintLinef("Set current VM CID to '%s'", opts.Args.CID)
+
+	return nil
+}
+
+func (c StateCmd) ForgetDisk(opts StateForgetDiskOpts) error {
+	_, err := c.load()
+	if err != nil {
+		return err
+	}
+
+	record, found, err := c.diskRepo.Find(opts.Args.CID)
+	if err != nil {
+		return bosherr.WrapErrorf(err, "Finding disk '%s'", opts.Args.CID)
+	}
+
+	if !found {
+		return bosherr.Errorf("Expected to find disk '%s' in state", opts.Args.CID)
+	}
+
+	err = c.backup()
+	if err != nil {
+		return err
+	}
+
+	err = c.diskRepo.Delete(record)
+	if err != nil {
+		return bosherr.WrapErrorf(err, "Forgetting disk '%s'", opts.Args.CID)
+	}
+
+	c.ui.PrintLinef("Forgot disk '%s'", opts.Args.CID)
+
+	return nil
+}
+
+func (c StateCmd) ImportDisk(opts StateImportDiskOpts) error {
+	if opts.Size <= 0 {
+		return bosherr.Errorf("Expected disk size to be greater than 0")
+	}
+
+	cloudProperties := biproperty.Map{}
+
+	if len(opts.CloudProperties) > 0 {
+		var rawCloudProperties map[interface{}]interface{}
+
+		err := yaml.Unmarshal([]byte(opts.CloudProperties), &rawCloudProperties)
+		if err != nil {
+			return bosherr.WrapError(err, "Unmarshalling disk cloud properties")
+		}
+
+		cloudProperties, err = biproperty.BuildMap(rawCloudProperties)
+		if err != nil {
+			return bosherr.WrapError(err, "Building disk cloud properties")
+		}
+	}
+
+	_, err := c.load()
+	if err != nil {
+		return err
+	}
+
+	currentRecord, found, err := c.diskRepo.FindCurrent()
+	if err != nil {
+		return bosherr.WrapError(err, "Finding current disk")
+	}
+
+	// Other disks are deleted by the next create-env as unused,
+	// so current disk is not silently replaced.
+	if found && currentRecord.CID != opts.Args.CID {
+		return bosherr.Errorf("Expected current disk '%s' to be forgotten before importing disk '%s'", currentRecord.CID, opts.Args.CID)
+	}
+
+	err = c.backup()
+	if err != nil {
+		return err
+	}
+
+	record, found, err := c.diskRepo.Find(opts.Args.CID)
+	if err != nil {
+		return bosherr.WrapErrorf(err, "Finding disk '%s'", opts.Args.CID)
+	}
+
+	if found {
+		err = c.diskRepo.Delete(record)
+		if err != nil {
+			return bosherr.WrapErrorf(err, "Replacing disk '%s'", opts.Args.CID)
+		}
+	}
+
+	record, err = c.diskRepo.Save(opts.Args.CID, opts.Size, cloudProperties)
+	if err != nil {
+		return bosherr.WrapErrorf(err, "Saving disk '%s'", opts.Args.CID)
+	}
+
+	err = c.diskRepo.UpdateCurrent(record.ID)
+	if err != nil {
+		return bosherr.WrapErrorf(err, "Setting current disk to '%s'", opts.Args.CID)
+	}
+
+	c.ui.PrintLinef("Imported disk '%s' as current disk", opts.Args.CID)
+
+	return nil
+}
+
+func (c StateCmd) ForgetStemcell(opts StateForgetStemcellOpts) error {
+	_, err := c.load()
+	if err != nil {
+		return err
+	}
+
+	records, err := c.stemcellRepo.All()
+	if err != nil {
+		return bosherr.WrapError(err, "Finding stemcells")
+	}
+
+	var record biconfig.StemcellRecord
+	var found bool
+
+	for _, rec := range records {
+		if rec.CID == opts.Args.CID {
+			record, found = rec, true
+			break
+		}
+	}
+
+	if !found {
+		return bosherr.Errorf("Expected to find stemcell '%s' in state", opts.Args.CID)
+	}
+
+	err = c.backup()
+	if err != nil {
+		return err
+	}
+
+	err = c.stemcellRepo.Delete(record)
+	if err != nil {
+		return bosherr.WrapErrorf(err, "Forgetting stemcell '%s'", opts.Args.CID)
+	}
+
+	c.ui.PrintLinef("Forgot stemcell '%s/%s' (%s)", record.Name, record.Version, record.CID)
+
+	return nil
+}
+
+func (c StateCmd) Validate(opts StateValidateOpts) error {
+	state, err := c.load()
+	if err != nil {
+		return err
+	}
+
+	problems := StateProblems(state)
+
+	if len(problems) == 0 {
+		c.ui.PrintLinef("State '%s' is valid", c.deploymentStateService.Path())
+		return nil
+	}
+
+	table := boshtbl.Table{
+		Content: "problems",
+		Header:  []boshtbl.Header{boshtbl.NewHeader("Problem")},
+	}
+
+	for _, problem := range problems {
+		table.Rows = append(table.Rows, []boshtbl.Value{boshtbl.NewValueString(problem)})
+	}
+
+	c.ui.PrintTable(table)
+
+	return bosherr.Errorf("State '%s' has %d problem(s)", c.deploymentStateService.Path(), len(problems))
+}
+
+// StateProblems returns inconsistencies between current IDs and records
+// that create-env would otherwise trip over.
+func StateProblems(state biconfig.DeploymentState) []string {
+	var problems []string
+
+	diskIDs := map[string]bool{}
+	diskCIDs := map[string]bool{}
+
+	for _, disk := range state.Disks {
+		if len(disk.CID) == 0 {
+			problems = append(problems, fmt.Sprintf("Disk '%s' has empty CID", disk.ID))
+		} else if diskCIDs[disk.CID] {
+			problems = append(problems, fmt.Sprintf("Disk CID '%s' is recorded more than once", disk.CID))
+		}
+
+		if diskIDs[disk.ID] {
+			problems = append(problems, fmt.Sprintf("Disk ID '%s' is recorded more than once", disk.ID))
+		}
+
+		if disk.Size <= 0 {
+			problems = append(problems, fmt.Sprintf("Disk '%s' has invalid size %d", disk.CID, disk.Size))
+		}
+
+		diskIDs[disk.ID] = true
+		diskCIDs[disk.CID] = true
+	}
+
+	if len(state.CurrentDiskID) > 0 && !diskIDs[state.CurrentDiskID] {
+		problems = append(problems, fmt.Sprintf("Current disk ID '%s' does not match any disk", state.CurrentDiskID))
+	}
+
+	stemcellIDs := map[string]bool{}
+	stemcellCIDs := map[string]bool{}
+
+	for _, stemcell := range state.Stemcells {
+		if len(stemcell.CID) == 0 {
+			problems = append(problems, fmt.Sprintf("Stemcell '%s/%s' has empty CID", stemcell.Name, stemcell.Version))
+		} else if stemcellCIDs[stemcell.CID] {
+			problems = append(problems, fmt.Sprintf("Stemcell CID '%s' is recorded more than once", stemcell.CID))
+		}
+
+		if stemcellIDs[stemcell.ID] {
+			problems = append(problems, fmt.Sprintf("Stemcell ID '%s' is recorded more than once", stemcell.ID))
+		}
+
+		stemcellIDs[stemcell.ID] = true
+		stemcellCIDs[stemcell.CID] = true
+	}
+
+	if len(state.CurrentStemcellID) > 0 && !stemcellIDs[state.CurrentStemcellID] {
+		problems = append(problems, fmt.Sprintf("Current stemcell ID '%s' does not match any stemcell", state.CurrentStemcellID))
+	}
+
+	releaseIDs := map[string]bool{}
+
+	for _, release := range state.Releases {
+		releaseIDs[release.ID] = true
+	}
+
+	for _, id := range state.CurrentReleaseIDs {
+		if !releaseIDs[id] {
+			problems = append(problems, fmt.Sprintf("Current release ID '%s' does not match any release", id))
+		}
+	}
+
+	if len(state.CurrentManifestSHA) > 0 && len(state.CurrentVMCID) == 0 {
+		problems = append(problems, "Manifest is recorded as deployed without current VM CID")
+	}
+
+	return problems
+}
+
+func (c StateCmd) load() (biconfig.DeploymentState, error) {
+	if !c.deploymentStateService.Exists() {
+		return biconfig.DeploymentState{}, bosherr.Errorf("Expected state '%s' to exist", c.deploymentStateService.Path())
+	}
+
+	return c.deploymentStateService.Load()
+}
+
+func (c StateCmd) backup() error {
+	// Nanoseconds keep backups of edits made within the same second apart
+	suffix := fmt.Sprintf("backup-%s", c.timeService.Now().UTC().Format("20060102T150405.000000000Z"))
+
+	location, err := c.deploymentStateService.Backup(suffix)
+	if err != nil {
+		return bosherr.WrapError(err, "Backing up state")
+	}
+
+	c.ui.PrintLinef("Backed up state to '%s'", location)
+
+	return nil
+}
@@ -0,0 +1,266 @@
+package cmd_test
+
+import (
+	"encoding/json"
+	"time"
+
+	"code.cloudfoundry.org/clock/fakeclock"
+	boshlog "github.com/cloudfoundry/bosh-utils/logger"
+	biproperty "github.com/cloudfoundry/bosh-utils/property"
+	fakesys "github.com/cloudfoundry/bosh-utils/system/fakes"
+	fakeuuid "github.com/cloudfoundry/bosh-utils/uuid/fakes"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	. "github.com/cloudfoundry/bosh-cli/cmd"
+	. "github.com/cloudfoundry/bosh-cli/cmd/opts"
+	biconfig "github.com/cloudfoundry/bosh-cli/config"
+	fakeui "github.com/cloudfoundry/bosh-cli/ui/fakes"
+	boshtbl "github.com/cloudfoundry/bosh-cli/ui/table"
+)
+
+var _ = Describe("StateCmd", func() {
+	const (
+		statePath  = "/state.json"
+		backupPath = "/state.json.backup-20201231T235959.123456789Z"
+	)
+
+	var (
+		fs      *fakesys.FakeFileSystem
+		ui      *fakeui.FakeUI
+		command StateCmd
+		state   biconfig.DeploymentState
+	)
+
+	BeforeEach(func() {
+		fs = fakesys.NewFakeFileSystem()
+		ui = &fakeui.FakeUI{}
+
+		uuidGen := fakeuuid.NewFakeGenerator()
+		uuidGen.GeneratedUUID = "new-id"
+
+		logger := boshlog.NewLogger(boshlog.LevelNone)
+		service := biconfig.NewFileSystemDeploymentStateService(fs, uuidGen, logger, statePath)
+		timeService := fakeclock.NewFakeClock(time.Date(2020, time.December, 31, 23, 59, 59, 123456789, time.UTC))
+
+		command = NewStateCmd(ui, service, uuidGen, timeService)
+
+		state = biconfig.DeploymentState{
+			DirectorID:         "director-id",
+			CurrentVMCID:       "vm-cid",
+			CurrentManifestSHA: "sha",
+			CurrentDiskID:      "disk-id",
+			CurrentStemcellID:  "stemcell-id",
+			CurrentReleaseIDs:  []string{"release-id"},
+			Disks: []biconfig.DiskRecord{
+				{ID: "disk-id", CID: "disk-cid", Size: 1024, CloudProperties: biproperty.Map{}},
+				{ID: "old-disk-id", CID: "old-disk-cid", Size: 512, CloudProperties: biproperty.Map{}},
+			},
+			Stemcells: []biconfig.StemcellRecord{
+				{ID: "stemcell-id", Name: "stemcell", Version: "1", CID: "stemcell-cid"},
+			},
+			Releases: []biconfig.ReleaseRecord{
+				{ID: "release-id", Name: "release", Version: "2"},
+			},
+		}
+	})
+
+	writeState := func() {
+		contents, err := json.Marshal(state)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(fs.WriteFile(statePath, contents)).To(Succeed())
+	}
+
+	readState := func(path string) biconfig.DeploymentState {
+		contents, err := fs.ReadFile(path)
+		Expect(err).ToNot(HaveOccurred())
+
+		var readState biconfig.DeploymentState
+		Expect(json.Unmarshal(contents, &readState)).To(Succeed())
+
+		return readState
+	}
+
+	expectBackup := func() {
+		Expect(readState(backupPath)).To(Equal(state))
+		Expect(ui.Said).To(ContainElement("Backed up state to '/state.json.backup-20201231T235959.123456789Z'"))
+	}
+
+	Describe("Show", func() {
+		It("prints state and its records", func() {
+			writeState()
+
+			err := command.Show(StateShowOpts{})
+			Expect(err).ToNot(HaveOccurred())
+
+			Expect(ui.Tables).To(HaveLen(4))
+			Expect(ui.Tables[0].Rows[0][2]).To(Equal(boshtbl.NewValueString("vm-cid")))
+
+			Expect(ui.Tables[1].Content).To(Equal("disks"))
+			Expect(ui.Tables[1].Rows).To(Equal([][]boshtbl.Value{
+				{
+					boshtbl.NewValueString("disk-id"),
+					boshtbl.NewValueString("disk-cid"),
+					boshtbl.NewValueMegaBytes(1024),
+					boshtbl.NewValueBool(true),
+				},
+				{
+					boshtbl.NewValueString("old-disk-id"),
+					boshtbl.NewValueString("old-disk-cid"),
+					boshtbl.NewValueMegaBytes(512),
+					boshtbl.NewValueBool(false),
+				},
+			}))
+
+			Expect(ui.Tables[2].Content).To(Equal("stemcells"))
+			Expect(ui.Tables[2].Rows[0][3]).To(Equal(boshtbl.NewValueString("stemcell-cid")))
+
+			Expect(ui.Tables[3].Content).To(Equal("releases"))
+			Expect(ui.Tables[3].Rows[0][3]).To(Equal(boshtbl.NewValueBool(true)))
+		})
+
+		It("returns error and does not create state if it does not exist", func() {
+			err := command.Show(StateShowOpts{})
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(Equal("Expected state '/state.json' to exist"))
+			Expect(fs.FileExists(statePath)).To(BeFalse())
+		})
+	})
+
+	Describe("SetVMCID", func() {
+		BeforeEach(func() { writeState() })
+
+		It("backs up state and sets current VM CID so that jobs are applied again", func() {
+			err := command.SetVMCID(StateSetVMCIDOpts{Args: StateSetVMCIDArgs{CID: "new-vm-cid"}})
+			Expect(err).ToNot(HaveOccurred())
+
+			expectBackup()
+
+			newState := readState(statePath)
+			Expect(newState.CurrentVMCID).To(Equal("new-vm-cid"))
+			Expect(newState.CurrentManifestSHA).To(BeEmpty())
+		})
+
+		It("clears current VM CID when CID is empty", func() {
+			err := command.SetVMCID(StateSetVMCIDOpts{})
+			Expect(err).ToNot(HaveOccurred())
+
+			expectBackup()
+			Expect(readState(statePath).CurrentVMCID).To(BeEmpty())
+			Expect(ui.Said).To(ContainElement("Cleared current VM CID"))
+		})
+	})
+
+	Describe("ForgetDisk", func() {
+		BeforeEach(func() { writeState() })
+
+		It("backs up state and removes disk along with current disk reference", func() {
+			err := command.ForgetDisk(StateForgetDiskOpts{Args: StateDiskArgs{CID: "disk-cid"}})
+			Expect(err).ToNot(HaveOccurred())
+
+			expectBackup()
+
+			newState := readState(statePath)
+			Expect(newState.CurrentDiskID).To(BeEmpty())
+			Expect(newState.Disks).To(Equal([]biconfig.DiskRecord{state.Disks[1]}))
+		})
+
+		It("returns error without backing up state if disk is not found", func() {
+			err := command.ForgetDisk(StateForgetDiskOpts{Args: StateDiskArgs{CID: "unknown-cid"}})
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(Equal("Expected to find disk 'unknown-cid' in state"))
+			Expect(fs.FileExists(backupPath)).To(BeFalse())
+		})
+	})
+
+	Describe("ImportDisk", func() {
+		BeforeEach(func() {
+			state.CurrentDiskID = ""
+			writeState()
+		})
+
+		It("backs up state and records disk as current disk", func() {
+			err := command.ImportDisk(StateImportDiskOpts{
+				Args:            StateDiskArgs{CID: "imported-disk-cid"},
+				Size:            2048,
+				CloudProperties: `{"type": "ssd"}`,
+			})
+			Expect(err).ToNot(HaveOccurred())
+
+			expectBackup()
+
+			newState := readState(statePath)
+			Expect(newState.CurrentDiskID).To(Equal("new-id"))
+			Expect(newState.Disks).To(ContainElement(biconfig.DiskRecord{
+				ID:              "new-id",
+				CID:             "imported-disk-cid",
+				Size:            2048,
+				CloudProperties: biproperty.Map{"type": "ssd"},
+			}))
+		})
+
+		It("returns error if another disk is current", func() {
+			state.CurrentDiskID = "disk-id"
+			writeState()
+
+			err := command.ImportDisk(StateImportDiskOpts{Args: StateDiskArgs{CID: "imported-disk-cid"}, Size: 2048})
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(Equal("Expected current disk 'disk-cid' to be forgotten before importing disk 'imported-disk-cid'"))
+			Expect(fs.FileExists(backupPath)).To(BeFalse())
+		})
+
+		It("returns error if cloud properties are not valid YAML", func() {
+			err := command.ImportDisk(StateImportDiskOpts{Args: StateDiskArgs{CID: "imported-disk-cid"}, Size: 2048, CloudProperties: "-"})
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("Unmarshalling disk cloud properties"))
+		})
+	})
+
+	Describe("ForgetStemcell", func() {
+		BeforeEach(func() { writeState() })
+
+		It("backs up state and removes stemcell along with current stemcell reference", func() {
+			err := command.ForgetStemcell(StateForgetStemcellOpts{Args: StateStemcellArgs{CID: "stemcell-cid"}})
+			Expect(err).ToNot(HaveOccurred())
+
+			expectBackup()
+
+			newState := readState(statePath)
+			Expect(newState.CurrentStemcellID).To(BeEmpty())
+			Expect(newState.Stemcells).To(BeEmpty())
+		})
+
+		It("returns error if stemcell is not found", func() {
+			err := command.ForgetStemcell(StateForgetStemcellOpts{Args: StateStemcellArgs{CID: "unknown-cid"}})
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(Equal("Expected to find stemcell 'unknown-cid' in state"))
+		})
+	})
+
+	Describe("Validate", func() {
+		It("succeeds when state is consistent", func() {
+			writeState()
+
+			err := command.Validate(StateValidateOpts{})
+			Expect(err).ToNot(HaveOccurred())
+			Expect(ui.Said).To(Equal([]string{"State '/state.json' is valid"}))
+		})
+
+		It("prints problems and returns error when state is inconsistent", func() {
+			state.CurrentDiskID = "missing-disk-id"
+			state.CurrentReleaseIDs = []string{"missing-release-id"}
+			state.CurrentVMCID = ""
+			writeState()
+
+			err := command.Validate(StateValidateOpts{})
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(Equal("State '/state.json' has 3 problem(s)"))
+
+			Expect(ui.Tables[0].Rows).To(Equal([][]boshtbl.Value{
+				{boshtbl.NewValueString("Current disk ID 'missing-disk-id' does not match any disk")},
+				{boshtbl.NewValueString("Current release ID 'missing-release-id' does not match any release")},
+				{boshtbl.NewValueString("Manifest is recorded as deployed without current VM CID")},
+			}))
+		})
+	})
+})
@@ -27,6 +27,17 @@ func (c StopCmd) Run(opts StopOpts) error {
 		return err
 	}
 
+	if opts.NoTrack {
+		task, err := c.deployment.StopAsync(opts.Args.Slug, stopOpts)
+		if err != nil {
+			return err
+		}
+
+		StartedTaskTable{Task: task, UI: c.ui}.Print()
+
+		return nil
+	}
+
 	return c.deployment.Stop(opts.Args.Slug, stopOpts)
 }
 
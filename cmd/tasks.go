@@ -1,41 +1,180 @@
 package cmd
 
 import (
+	"time"
+
+	bosherr "github.com/cloudfoundry/bosh-utils/errors"
+
 	. "github.com/cloudfoundry/bosh-cli/cmd/opts"
 	boshdir "github.com/cloudfoundry/bosh-cli/director"
 	boshui "github.com/cloudfoundry/bosh-cli/ui"
 	boshtbl "github.com/cloudfoundry/bosh-cli/ui/table"
+	boshuit "github.com/cloudfoundry/bosh-cli/ui/task"
 )
 
+const tasksFollowPollInterval = 5 * time.Second
+
 type TasksCmd struct {
-	ui       boshui.UI
-	director boshdir.Director
+	ui           boshui.UI
+	director     boshdir.Director
+	taskReporter boshuit.Reporter
 }
 
-func NewTasksCmd(ui boshui.UI, director boshdir.Director) TasksCmd {
-	return TasksCmd{ui: ui, director: director}
+func NewTasksCmd(ui boshui.UI, director boshdir.Director, taskReporter boshuit.Reporter) TasksCmd {
+	return TasksCmd{ui: ui, director: director, taskReporter: taskReporter}
 }
 
 func (c TasksCmd) Run(opts TasksOpts) error {
+	if opts.Follow {
+		if opts.Recent != nil {
+			return bosherr.Error("Can't set recent and follow")
+		}
+		return c.follow(opts)
+	}
+
+	tasks, err := c.findTasks(opts)
+	if err != nil {
+		return err
+	}
+
+	return c.printTable(tasks)
+}
+
+func (c TasksCmd) findTasks(opts TasksOpts) ([]boshdir.Task, error) {
 	filter := boshdir.TasksFilter{
 		All:        opts.All,
 		Deployment: opts.Deployment,
 	}
 
-	if opts.Recent != nil {
-		tasks, err := c.director.RecentTasks(*opts.Recent, filter)
+	var tasks []boshdir.Task
+	var err error
+
+	switch {
+	case len(opts.ContextID) > 0:
+		tasks, err = c.director.FindTasksByContextId(opts.ContextID)
+		if err != nil {
+			return nil, err
+		}
+
+		tasks = filterTasks(tasks, func(t boshdir.Task) bool {
+			return len(opts.Deployment) == 0 || t.DeploymentName() == opts.Deployment
+		})
+
+		if opts.Recent == nil {
+			tasks = filterTasks(tasks, isTaskRunning)
+		} else if len(tasks) > *opts.Recent {
+			tasks = tasks[:*opts.Recent]
+		}
+
+	case opts.Recent != nil:
+		tasks, err = c.director.RecentTasks(*opts.Recent, filter)
+		if err != nil {
+			return nil, err
+		}
+
+	default:
+		filter.All = true
+		tasks, err = c.director.CurrentTasks(filter)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if len(opts.User) > 0 {
+		tasks = filterTasks(tasks, func(t boshdir.Task) bool { return t.User() == opts.User })
+	}
+
+	return tasks, nil
+}
+
+type followedTask struct {
+	Task boshdir.Task
+	Err  error
+}
+
+// follow tracks output of all running tasks matching opts
+// until there are no more running tasks left
+func (c TasksCmd) follow(opts TasksOpts) error {
+	followedIDs := map[int]bool{}
+	results := make(chan followedTask)
+	running := 0
+
+	var finished []followedTask
+
+	for {
+		tasks, err := c.findTasks(opts)
+		if err != nil {
+			// Followed tasks must not block forever once nobody waits for their results
+			go func(running int) {
+				for ; running > 0; running-- {
+					<-results
+				}
+			}(running)
+
+			return err
+		}
+
+		for _, task := range tasks {
+			if followedIDs[task.ID()] {
+				continue
+			}
+
+			followedIDs[task.ID()] = true
+			running++
+
+			go func(task boshdir.Task) {
+				results <- followedTask{Task: task, Err: task.EventOutput(c.taskReporter)}
+			}(task)
+		}
+
+		if running == 0 {
+			break
+		}
+
+		select {
+		case result := <-results:
+			running--
+			finished = append(finished, result)
+		case <-time.After(tasksFollowPollInterval):
+		}
+	}
+
+	if len(finished) == 0 {
+		c.ui.PrintLinef("No running tasks to follow")
+		return nil
+	}
+
+	return c.summarize(finished)
+}
+
+func (c TasksCmd) summarize(followed []followedTask) error {
+	var tasks []boshdir.Task
+	var failed int
+
+	for _, f := range followed {
+		// Refetch task to show its final state
+		task, err := c.director.FindTask(f.Task.ID())
 		if err != nil {
 			return err
 		}
-		return c.printTable(tasks)
+
+		if task.IsError() {
+			failed++
+		}
+
+		tasks = append(tasks, task)
 	}
 
-	filter.All = true
-	tasks, err := c.director.CurrentTasks(filter)
+	err := c.printTable(tasks)
 	if err != nil {
 		return err
 	}
-	return c.printTable(tasks)
+
+	if failed > 0 {
+		return bosherr.Errorf("Expected all followed tasks to succeed but %d of %d did not", failed, len(tasks))
+	}
+
+	return nil
 }
 
 func (c TasksCmd) printTable(tasks []boshdir.Task) error {
@@ -81,3 +220,20 @@ func sanitizeFinishedAtDisplayValue(task boshdir.Task) boshtbl.Value {
 	}
 	return boshtbl.NewValueTime(task.FinishedAt())
 }
+
+func isTaskRunning(task boshdir.Task) bool {
+	state := task.State()
+	return state == "queued" || state == "processing" || state == "cancelling"
+}
+
+func filterTasks(tasks []boshdir.Task, keep func(boshdir.Task) bool) []boshdir.Task {
+	var filtered []boshdir.Task
+
+	for _, t := range tasks {
+		if keep(t) {
+			filtered = append(filtered, t)
+		}
+	}
+
+	return filtered
+}
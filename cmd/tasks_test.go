@@ -2,6 +2,7 @@ package cmd_test
 
 import (
 	"errors"
+	"runtime"
 	"time"
 
 	. "github.com/onsi/ginkgo"
@@ -13,19 +14,22 @@ import (
 	fakedir "github.com/cloudfoundry/bosh-cli/director/directorfakes"
 	fakeui "github.com/cloudfoundry/bosh-cli/ui/fakes"
 	boshtbl "github.com/cloudfoundry/bosh-cli/ui/table"
+	fakeuit "github.com/cloudfoundry/bosh-cli/ui/task/taskfakes"
 )
 
 var _ = Describe("TasksCmd", func() {
 	var (
-		ui       *fakeui.FakeUI
-		director *fakedir.FakeDirector
-		command  TasksCmd
+		ui           *fakeui.FakeUI
+		director     *fakedir.FakeDirector
+		taskReporter *fakeuit.FakeReporter
+		command      TasksCmd
 	)
 
 	BeforeEach(func() {
 		ui = &fakeui.FakeUI{}
 		director = &fakedir.FakeDirector{}
-		command = NewTasksCmd(ui, director)
+		taskReporter = &fakeuit.FakeReporter{}
+		command = NewTasksCmd(ui, director, taskReporter)
 	})
 
 	Describe("Run", func() {
@@ -277,5 +281,198 @@ var _ = Describe("TasksCmd", func() {
 				Expect(err.Error()).To(ContainSubstring("fake-err"))
 			})
 		})
+
+		Context("when filtering by user or context ID", func() {
+			var tasks []boshdir.Task
+
+			BeforeEach(func() {
+				tasks = []boshdir.Task{
+					&fakedir.FakeTask{
+						IDStub:             func() int { return 1 },
+						StateStub:          func() string { return "processing" },
+						UserStub:           func() string { return "user1" },
+						DeploymentNameStub: func() string { return "dep1" },
+					},
+					&fakedir.FakeTask{
+						IDStub:             func() int { return 2 },
+						StateStub:          func() string { return "done" },
+						UserStub:           func() string { return "user2" },
+						DeploymentNameStub: func() string { return "dep1" },
+					},
+					&fakedir.FakeTask{
+						IDStub:             func() int { return 3 },
+						StateStub:          func() string { return "queued" },
+						UserStub:           func() string { return "user2" },
+						DeploymentNameStub: func() string { return "dep2" },
+					},
+				}
+			})
+
+			It("lists only tasks started by user", func() {
+				director.CurrentTasksReturns(tasks, nil)
+				opts.User = "user2"
+
+				Expect(act()).ToNot(HaveOccurred())
+				Expect(ui.Table.Rows).To(HaveLen(2))
+				Expect(ui.Table.Rows[0][0]).To(Equal(boshtbl.NewValueInt(2)))
+				Expect(ui.Table.Rows[1][0]).To(Equal(boshtbl.NewValueInt(3)))
+			})
+
+			It("lists running tasks with context ID", func() {
+				director.FindTasksByContextIdReturns(tasks, nil)
+				opts.ContextID = "ctx"
+
+				Expect(act()).ToNot(HaveOccurred())
+				Expect(director.FindTasksByContextIdArgsForCall(0)).To(Equal("ctx"))
+				Expect(director.CurrentTasksCallCount()).To(Equal(0))
+
+				Expect(ui.Table.Rows).To(HaveLen(2))
+				Expect(ui.Table.Rows[0][0]).To(Equal(boshtbl.NewValueInt(1)))
+				Expect(ui.Table.Rows[1][0]).To(Equal(boshtbl.NewValueInt(3)))
+			})
+
+			It("lists recent tasks with context ID for deployment", func() {
+				director.FindTasksByContextIdReturns(tasks, nil)
+				opts.ContextID = "ctx"
+				opts.Deployment = "dep1"
+				recent := 30
+				opts.Recent = &recent
+
+				Expect(act()).ToNot(HaveOccurred())
+				Expect(ui.Table.Rows).To(HaveLen(2))
+				Expect(ui.Table.Rows[0][0]).To(Equal(boshtbl.NewValueInt(1)))
+				Expect(ui.Table.Rows[1][0]).To(Equal(boshtbl.NewValueInt(2)))
+			})
+		})
+
+		Context("when following tasks", func() {
+			newTask := func(id int, state string) *fakedir.FakeTask {
+				return &fakedir.FakeTask{
+					IDStub:             func() int { return id },
+					StateStub:          func() string { return state },
+					IsErrorStub:        func() bool { return state == "error" },
+					DeploymentNameStub: func() string { return "dep" },
+					DescriptionStub:    func() string { return "desc" },
+				}
+			}
+
+			BeforeEach(func() {
+				opts.Follow = true
+
+				director.FindTaskStub = func(id int) (boshdir.Task, error) {
+					return newTask(id, "done"), nil
+				}
+			})
+
+			It("follows output of running tasks and summarizes them", func() {
+				task1 := newTask(1, "processing")
+				task2 := newTask(2, "queued")
+
+				director.CurrentTasksReturnsOnCall(0, []boshdir.Task{task1, task2}, nil)
+
+				Expect(act()).ToNot(HaveOccurred())
+
+				Expect(task1.EventOutputCallCount()).To(Equal(1))
+				Expect(task1.EventOutputArgsForCall(0)).To(Equal(taskReporter))
+				Expect(task2.EventOutputCallCount()).To(Equal(1))
+
+				filter := director.CurrentTasksArgsForCall(0)
+				Expect(filter.All).To(BeTrue())
+
+				Expect(ui.Table.Content).To(Equal("tasks"))
+				Expect(ui.Table.Rows).To(HaveLen(2))
+			})
+
+			It("follows tasks that start while following others", func() {
+				task1 := newTask(1, "processing")
+				task2 := newTask(2, "queued")
+
+				director.CurrentTasksReturnsOnCall(0, []boshdir.Task{task1}, nil)
+				director.CurrentTasksReturnsOnCall(1, []boshdir.Task{task1, task2}, nil)
+
+				Expect(act()).ToNot(HaveOccurred())
+
+				Expect(task1.EventOutputCallCount()).To(Equal(1))
+				Expect(task2.EventOutputCallCount()).To(Equal(1))
+				Expect(ui.Table.Rows).To(HaveLen(2))
+			})
+
+			It("returns error if any followed task failed", func() {
+				director.CurrentTasksReturnsOnCall(0, []boshdir.Task{newTask(1, "processing"), newTask(2, "processing")}, nil)
+				director.FindTaskStub = func(id int) (boshdir.Task, error) {
+					if id == 2 {
+						return newTask(id, "error"), nil
+					}
+					return newTask(id, "done"), nil
+				}
+
+				err := act()
+				Expect(err).To(HaveOccurred())
+				Expect(err.Error()).To(Equal("Expected all followed tasks to succeed but 1 of 2 did not"))
+
+				Expect(ui.Table.Rows).To(HaveLen(2))
+			})
+
+			It("follows only tasks matching filters", func() {
+				task1 := newTask(1, "processing")
+				task1.UserStub = func() string { return "user1" }
+				task2 := newTask(2, "processing")
+				task2.UserStub = func() string { return "user2" }
+
+				director.CurrentTasksReturnsOnCall(0, []boshdir.Task{task1, task2}, nil)
+				opts.User = "user2"
+
+				Expect(act()).ToNot(HaveOccurred())
+
+				Expect(task1.EventOutputCallCount()).To(Equal(0))
+				Expect(task2.EventOutputCallCount()).To(Equal(1))
+			})
+
+			It("prints message if there are no running tasks", func() {
+				Expect(act()).ToNot(HaveOccurred())
+				Expect(ui.Said).To(Equal([]string{"No running tasks to follow"}))
+			})
+
+			It("returns error if recent tasks are requested", func() {
+				recent := 30
+				opts.Recent = &recent
+
+				err := act()
+				Expect(err).To(HaveOccurred())
+				Expect(err.Error()).To(Equal("Can't set recent and follow"))
+			})
+
+			It("returns error if tasks cannot be retrieved", func() {
+				director.CurrentTasksReturns(nil, errors.New("fake-err"))
+
+				err := act()
+				Expect(err).To(HaveOccurred())
+				Expect(err.Error()).To(ContainSubstring("fake-err"))
+			})
+
+			It("lets followed tasks finish if tasks cannot be retrieved anymore", func() {
+				task1 := newTask(1, "processing")
+				task2 := newTask(2, "processing")
+
+				outputFinished := make(chan struct{})
+				task2.EventOutputStub = func(boshdir.TaskReporter) error {
+					<-outputFinished
+					return nil
+				}
+
+				director.CurrentTasksReturnsOnCall(0, []boshdir.Task{task1, task2}, nil)
+				director.CurrentTasksReturnsOnCall(1, nil, errors.New("fake-err"))
+
+				goroutines := runtime.NumGoroutine()
+
+				err := act()
+				Expect(err).To(HaveOccurred())
+				Expect(err.Error()).To(ContainSubstring("fake-err"))
+
+				close(outputFinished)
+
+				Eventually(runtime.NumGoroutine).Should(BeNumerically("<=", goroutines))
+			})
+		})
 	})
 })
@@ -0,0 +1,34 @@
+package cmd
+
+import (
+	bosherr "github.com/cloudfoundry/bosh-utils/errors"
+
+	. "github.com/cloudfoundry/bosh-cli/cmd/opts"
+	boshtpl "github.com/cloudfoundry/bosh-cli/director/template"
+	boshui "github.com/cloudfoundry/bosh-cli/ui"
+)
+
+type ValidatePropertiesCmd struct {
+	ui        boshui.UI
+	validator PropertiesValidator
+}
+
+func NewValidatePropertiesCmd(ui boshui.UI, validator PropertiesValidator) ValidatePropertiesCmd {
+	return ValidatePropertiesCmd{ui: ui, validator: validator}
+}
+
+func (c ValidatePropertiesCmd) Run(opts ValidatePropertiesOpts) error {
+	tpl := boshtpl.NewTemplate(opts.Args.Manifest.Bytes)
+
+	bytes, err := tpl.Evaluate(opts.VarFlags.AsVariables(), opts.OpsFlags.AsOp(), boshtpl.EvaluateOpts{})
+	if err != nil {
+		return bosherr.WrapErrorf(err, "Evaluating manifest")
+	}
+
+	problems, err := c.validator.Validate(bytes)
+	if err != nil {
+		return bosherr.WrapErrorf(err, "Validating job properties")
+	}
+
+	return PropertyProblemsTable{Problems: problems, UI: c.ui}.Print()
+}
@@ -0,0 +1,85 @@
+package cmd_test
+
+import (
+	"errors"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	. "github.com/cloudfoundry/bosh-cli/cmd"
+	fakecmd "github.com/cloudfoundry/bosh-cli/cmd/cmdfakes"
+	. "github.com/cloudfoundry/bosh-cli/cmd/opts"
+	boshtpl "github.com/cloudfoundry/bosh-cli/director/template"
+	fakeui "github.com/cloudfoundry/bosh-cli/ui/fakes"
+	boshtbl "github.com/cloudfoundry/bosh-cli/ui/table"
+)
+
+var _ = Describe("ValidatePropertiesCmd", func() {
+	var (
+		ui        *fakeui.FakeUI
+		validator *fakecmd.FakePropertiesValidator
+		command   ValidatePropertiesCmd
+		opts      ValidatePropertiesOpts
+	)
+
+	BeforeEach(func() {
+		ui = &fakeui.FakeUI{}
+		validator = &fakecmd.FakePropertiesValidator{}
+		command = NewValidatePropertiesCmd(ui, validator)
+
+		opts = ValidatePropertiesOpts{
+			Args: DeployArgs{
+				Manifest: FileBytesArg{Bytes: []byte("name: ((name))")},
+			},
+			VarFlags: VarFlags{
+				VarKVs: []boshtpl.VarKV{{Name: "name", Value: "dep"}},
+			},
+		}
+	})
+
+	act := func() error { return command.Run(opts) }
+
+	It("validates interpolated manifest", func() {
+		err := act()
+		Expect(err).ToNot(HaveOccurred())
+
+		Expect(validator.ValidateArgsForCall(0)).To(Equal([]byte("name: dep\n")))
+		Expect(ui.Said).To(Equal([]string{"No problems found in job properties"}))
+	})
+
+	It("prints problems and returns an error if problems are found", func() {
+		validator.ValidateReturns([]PropertyProblem{
+			{InstanceGroup: "web", Job: "nginx", Property: "prot", Problem: "Unknown property (did you mean 'port'?)"},
+		}, nil)
+
+		err := act()
+		Expect(err).To(HaveOccurred())
+		Expect(err.Error()).To(Equal("Found 1 problem(s) in job properties"))
+
+		Expect(ui.Table).To(Equal(boshtbl.Table{
+			Content: "property problems",
+			Header: []boshtbl.Header{
+				boshtbl.NewHeader("Instance Group"),
+				boshtbl.NewHeader("Job"),
+				boshtbl.NewHeader("Property"),
+				boshtbl.NewHeader("Problem"),
+			},
+			Rows: [][]boshtbl.Value{
+				{
+					boshtbl.NewValueString("web"),
+					boshtbl.NewValueString("nginx"),
+					boshtbl.NewValueString("prot"),
+					boshtbl.NewValueString("Unknown property (did you mean 'port'?)"),
+				},
+			},
+		}))
+	})
+
+	It("returns an error if validation fails", func() {
+		validator.ValidateReturns(nil, errors.New("fake-err"))
+
+		err := act()
+		Expect(err).To(HaveOccurred())
+		Expect(err.Error()).To(ContainSubstring("fake-err"))
+	})
+})
@@ -0,0 +1,46 @@
+package cmd
+
+import (
+	bosherr "github.com/cloudfoundry/bosh-utils/errors"
+
+	. "github.com/cloudfoundry/bosh-cli/cmd/opts"
+	boshrel "github.com/cloudfoundry/bosh-cli/release"
+	boshui "github.com/cloudfoundry/bosh-cli/ui"
+	boshtbl "github.com/cloudfoundry/bosh-cli/ui/table"
+)
+
+type VerifyReleaseReproducibleCmd struct {
+	verifier boshrel.ArchiveVerifier
+	ui       boshui.UI
+}
+
+func NewVerifyReleaseReproducibleCmd(verifier boshrel.ArchiveVerifier, ui boshui.UI) VerifyReleaseReproducibleCmd {
+	return VerifyReleaseReproducibleCmd{verifier: verifier, ui: ui}
+}
+
+func (c VerifyReleaseReproducibleCmd) Run(opts VerifyReleaseReproducibleOpts) error {
+	path := opts.Args.Path.ExpandedPath
+
+	problems, err := c.verifier.Verify(path)
+	if err != nil {
+		return bosherr.WrapErrorf(err, "Verifying release tarball '%s'", path)
+	}
+
+	if len(problems) == 0 {
+		c.ui.PrintLinef("Release tarball '%s' is reproducible", path)
+		return nil
+	}
+
+	table := boshtbl.Table{
+		Content: "problems",
+		Header:  []boshtbl.Header{boshtbl.NewHeader("Problem")},
+	}
+
+	for _, problem := range problems {
+		table.Rows = append(table.Rows, []boshtbl.Value{boshtbl.NewValueString(problem)})
+	}
+
+	c.ui.PrintTable(table)
+
+	return bosherr.Errorf("Found %d problem(s) preventing release tarball from being reproducible", len(problems))
+}
@@ -0,0 +1,68 @@
+package cmd_test
+
+import (
+	"errors"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	. "github.com/cloudfoundry/bosh-cli/cmd"
+	. "github.com/cloudfoundry/bosh-cli/cmd/opts"
+	fakerel "github.com/cloudfoundry/bosh-cli/release/releasefakes"
+	fakeui "github.com/cloudfoundry/bosh-cli/ui/fakes"
+	boshtbl "github.com/cloudfoundry/bosh-cli/ui/table"
+)
+
+var _ = Describe("VerifyReleaseReproducibleCmd", func() {
+	var (
+		verifier *fakerel.FakeArchiveVerifier
+		ui       *fakeui.FakeUI
+		command  VerifyReleaseReproducibleCmd
+		opts     VerifyReleaseReproducibleOpts
+	)
+
+	BeforeEach(func() {
+		verifier = &fakerel.FakeArchiveVerifier{}
+		ui = &fakeui.FakeUI{}
+		command = NewVerifyReleaseReproducibleCmd(verifier, ui)
+
+		opts = VerifyReleaseReproducibleOpts{
+			Args: VerifyReleaseReproducibleArgs{Path: FileArg{ExpandedPath: "/release.tgz"}},
+		}
+	})
+
+	act := func() error { return command.Run(opts) }
+
+	It("verifies release tarball", func() {
+		err := act()
+		Expect(err).ToNot(HaveOccurred())
+
+		Expect(verifier.VerifyArgsForCall(0)).To(Equal("/release.tgz"))
+		Expect(ui.Said).To(Equal([]string{"Release tarball '/release.tgz' is reproducible"}))
+	})
+
+	It("prints problems and returns an error if tarball is not reproducible", func() {
+		verifier.VerifyReturns([]string{"release.tgz/jobs/web.tgz: entry './monit' has mode '600' instead of '644'"}, nil)
+
+		err := act()
+		Expect(err).To(HaveOccurred())
+		Expect(err.Error()).To(Equal("Found 1 problem(s) preventing release tarball from being reproducible"))
+
+		Expect(ui.Table).To(Equal(boshtbl.Table{
+			Content: "problems",
+			Header:  []boshtbl.Header{boshtbl.NewHeader("Problem")},
+			Rows: [][]boshtbl.Value{
+				{boshtbl.NewValueString("release.tgz/jobs/web.tgz: entry './monit' has mode '600' instead of '644'")},
+			},
+		}))
+	})
+
+	It("returns an error if verifying fails", func() {
+		verifier.VerifyReturns(nil, errors.New("fake-err"))
+
+		err := act()
+		Expect(err).To(HaveOccurred())
+		Expect(err.Error()).To(ContainSubstring("Verifying release tarball '/release.tgz'"))
+		Expect(err.Error()).To(ContainSubstring("fake-err"))
+	})
+})
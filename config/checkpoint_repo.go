@@ -0,0 +1,112 @@
+package config
+
+import (
+	bosherr "github.com/cloudfoundry/bosh-utils/errors"
+)
+
+// Checkpoints are recorded in order while deploying
+// so that failed deploy can be resumed from the last completed one.
+const (
+	CheckpointVMCreated    = "vm_created"
+	CheckpointDiskAttached = "disk_attached"
+	CheckpointDiskMigrated = "disk_migrated"
+	CheckpointJobsApplied  = "jobs_applied"
+)
+
+type CheckpointRecord struct {
+	ManifestSHA string   `json:"manifest_sha"`
+	StemcellCID string   `json:"stemcell_cid"`
+	VMCID       string   `json:"vm_cid"`
+	DiskCID     string   `json:"disk_cid,omitempty"`
+	Completed   []string `json:"completed"`
+}
+
+func (r CheckpointRecord) Reached(checkpoint string) bool {
+	for _, completed := range r.Completed {
+		if completed == checkpoint {
+			return true
+		}
+	}
+	return false
+}
+
+type CheckpointRepo interface {
+	FindCurrent() (CheckpointRecord, bool, error)
+	UpdateCurrent(CheckpointRecord) error
+	Complete(checkpoint string) error
+	CompleteDisk(checkpoint string, diskCID string) error
+	ClearCurrent() error
+}
+
+type checkpointRepo struct {
+	deploymentStateService DeploymentStateService
+}
+
+func NewCheckpointRepo(deploymentStateService DeploymentStateService) CheckpointRepo {
+	return checkpointRepo{
+		deploymentStateService: deploymentStateService,
+	}
+}
+
+func (r checkpointRepo) FindCurrent() (CheckpointRecord, bool, error) {
+	deploymentState, err := r.deploymentStateService.Load()
+	if err != nil {
+		return CheckpointRecord{}, false, bosherr.WrapError(err, "Loading existing config")
+	}
+
+	if deploymentState.Checkpoint == nil {
+		return CheckpointRecord{}, false, nil
+	}
+
+	return *deploymentState.Checkpoint, true, nil
+}
+
+func (r checkpointRepo) UpdateCurrent(record CheckpointRecord) error {
+	return r.update(func(deploymentState *DeploymentState) {
+		deploymentState.Checkpoint = &record
+	})
+}
+
+// Complete marks checkpoint as reached for current checkpoint record.
+// It's a noop when there is no current checkpoint record.
+func (r checkpointRepo) Complete(checkpoint string) error {
+	return r.CompleteDisk(checkpoint, "")
+}
+
+func (r checkpointRepo) CompleteDisk(checkpoint string, diskCID string) error {
+	return r.update(func(deploymentState *DeploymentState) {
+		record := deploymentState.Checkpoint
+		if record == nil {
+			return
+		}
+
+		if len(diskCID) > 0 {
+			record.DiskCID = diskCID
+		}
+
+		if !record.Reached(checkpoint) {
+			record.Completed = append(record.Completed, checkpoint)
+		}
+	})
+}
+
+func (r checkpointRepo) ClearCurrent() error {
+	return r.update(func(deploymentState *DeploymentState) {
+		deploymentState.Checkpoint = nil
+	})
+}
+
+func (r checkpointRepo) update(updateFunc func(*DeploymentState)) error {
+	deploymentState, err := r.deploymentStateService.Load()
+	if err != nil {
+		return bosherr.WrapError(err, "Loading existing config")
+	}
+
+	updateFunc(&deploymentState)
+
+	err = r.deploymentStateService.Save(deploymentState)
+	if err != nil {
+		return bosherr.WrapError(err, "Saving new config")
+	}
+	return nil
+}
@@ -0,0 +1,88 @@
+package config_test
+
+import (
+	. "github.com/cloudfoundry/bosh-cli/config"
+	boshlog "github.com/cloudfoundry/bosh-utils/logger"
+	fakesys "github.com/cloudfoundry/bosh-utils/system/fakes"
+	fakeuuid "github.com/cloudfoundry/bosh-utils/uuid/fakes"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("CheckpointRepo", func() {
+	var (
+		repo                   CheckpointRepo
+		deploymentStateService DeploymentStateService
+	)
+
+	BeforeEach(func() {
+		logger := boshlog.NewLogger(boshlog.LevelNone)
+		fs := fakesys.NewFakeFileSystem()
+		deploymentStateService = NewFileSystemDeploymentStateService(fs, fakeuuid.NewFakeGenerator(), logger, "/fake/path")
+		repo = NewCheckpointRepo(deploymentStateService)
+	})
+
+	Describe("FindCurrent", func() {
+		It("returns false when checkpoint is not set", func() {
+			_, found, err := repo.FindCurrent()
+			Expect(err).ToNot(HaveOccurred())
+			Expect(found).To(BeFalse())
+		})
+
+		It("returns current checkpoint record", func() {
+			record := CheckpointRecord{
+				ManifestSHA: "fake-manifest-sha",
+				StemcellCID: "fake-stemcell-cid",
+				VMCID:       "fake-vm-cid",
+				Completed:   []string{CheckpointVMCreated},
+			}
+
+			err := repo.UpdateCurrent(record)
+			Expect(err).ToNot(HaveOccurred())
+
+			foundRecord, found, err := repo.FindCurrent()
+			Expect(err).ToNot(HaveOccurred())
+			Expect(found).To(BeTrue())
+			Expect(foundRecord).To(Equal(record))
+		})
+	})
+
+	Describe("Complete", func() {
+		It("appends completed checkpoints once", func() {
+			err := repo.UpdateCurrent(CheckpointRecord{VMCID: "fake-vm-cid", Completed: []string{CheckpointVMCreated}})
+			Expect(err).ToNot(HaveOccurred())
+
+			Expect(repo.CompleteDisk(CheckpointDiskAttached, "fake-disk-cid")).To(Succeed())
+			Expect(repo.Complete(CheckpointJobsApplied)).To(Succeed())
+			Expect(repo.Complete(CheckpointJobsApplied)).To(Succeed())
+
+			record, _, err := repo.FindCurrent()
+			Expect(err).ToNot(HaveOccurred())
+			Expect(record.DiskCID).To(Equal("fake-disk-cid"))
+			Expect(record.Completed).To(Equal([]string{CheckpointVMCreated, CheckpointDiskAttached, CheckpointJobsApplied}))
+			Expect(record.Reached(CheckpointDiskAttached)).To(BeTrue())
+			Expect(record.Reached(CheckpointDiskMigrated)).To(BeFalse())
+		})
+
+		It("does nothing when checkpoint is not set", func() {
+			Expect(repo.Complete(CheckpointJobsApplied)).To(Succeed())
+
+			_, found, err := repo.FindCurrent()
+			Expect(err).ToNot(HaveOccurred())
+			Expect(found).To(BeFalse())
+		})
+	})
+
+	Describe("ClearCurrent", func() {
+		It("removes checkpoint record", func() {
+			err := repo.UpdateCurrent(CheckpointRecord{VMCID: "fake-vm-cid"})
+			Expect(err).ToNot(HaveOccurred())
+
+			Expect(repo.ClearCurrent()).To(Succeed())
+
+			_, found, err := repo.FindCurrent()
+			Expect(err).ToNot(HaveOccurred())
+			Expect(found).To(BeFalse())
+		})
+	})
+})
@@ -0,0 +1,115 @@
+package config
+
+import (
+	"bytes"
+	"crypto/sha1"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"path"
+	"strings"
+
+	boshdavcli "github.com/cloudfoundry/bosh-davcli/client"
+	boshdavcliconf "github.com/cloudfoundry/bosh-davcli/config"
+	bosherr "github.com/cloudfoundry/bosh-utils/errors"
+	"github.com/cloudfoundry/bosh-utils/httpclient"
+	boshlog "github.com/cloudfoundry/bosh-utils/logger"
+)
+
+type davStateBackend struct {
+	location string
+	client   boshdavcli.Client
+
+	// httpClient and config are used to read objects directly
+	// since dav client does not expose response status codes
+	httpClient httpclient.Client
+	config     boshdavcliconf.Config
+}
+
+func NewDAVStateBackend(stateURL *url.URL, dir string, logger boshlog.Logger) (StateBackend, error) {
+	var username, password string
+
+	if stateURL.User != nil {
+		username = stateURL.User.Username()
+		password, _ = stateURL.User.Password()
+	}
+
+	endpoint := fmt.Sprintf("%s://%s%s",
+		strings.TrimPrefix(stateURL.Scheme, "dav+"), stateURL.Host, strings.TrimSuffix(dir, "/"))
+
+	config := boshdavcliconf.Config{
+		Endpoint: endpoint,
+		User:     username,
+		Password: password,
+	}
+
+	httpClient := httpclient.CreateDefaultClient(nil)
+
+	return NewDAVStateBackendWithClient(redactStateURL(stateURL), boshdavcli.NewClient(config, httpClient, logger), httpClient, config), nil
+}
+
+func NewDAVStateBackendWithClient(location string, client boshdavcli.Client, httpClient httpclient.Client, config boshdavcliconf.Config) StateBackend {
+	return davStateBackend{location: location, client: client, httpClient: httpClient, config: config}
+}
+
+func (b davStateBackend) Location() string { return b.location }
+
+func (b davStateBackend) Get(name string) ([]byte, bool, error) {
+	req, err := b.objectRequest("GET", name)
+	if err != nil {
+		return nil, false, bosherr.WrapErrorf(err, "Creating request for '%s'", name)
+	}
+
+	resp, err := b.httpClient.Do(req)
+	if err != nil {
+		return nil, false, bosherr.WrapErrorf(err, "Getting '%s'", name)
+	}
+
+	defer resp.Body.Close()
+
+	if resp.StatusCode == http.StatusNotFound {
+		return nil, false, nil
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, false, bosherr.Errorf("Getting '%s': Wrong response code: %d", name, resp.StatusCode)
+	}
+
+	contents, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return nil, false, bosherr.WrapErrorf(err, "Reading '%s'", name)
+	}
+
+	return contents, true, nil
+}
+
+func (b davStateBackend) Put(name string, contents []byte) error {
+	return b.client.Put(name, ioutil.NopCloser(bytes.NewReader(contents)), int64(len(contents)))
+}
+
+func (b davStateBackend) Delete(name string) error {
+	return b.client.Delete(name)
+}
+
+// objectRequest builds request for the object the same way dav client does:
+// objects are stored under a directory named after the first byte of their SHA1.
+func (b davStateBackend) objectRequest(method, name string) (*http.Request, error) {
+	objectURL, err := url.Parse(b.config.Endpoint)
+	if err != nil {
+		return nil, err
+	}
+
+	objectURL.Path = path.Join("/", objectURL.Path, fmt.Sprintf("%02x", sha1.Sum([]byte(name))[0]), name)
+
+	req, err := http.NewRequest(method, objectURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(b.config.User) > 0 {
+		req.SetBasicAuth(b.config.User, b.config.Password)
+	}
+
+	return req, nil
+}
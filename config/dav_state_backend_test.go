@@ -0,0 +1,79 @@
+package config_test
+
+import (
+	"crypto/sha1"
+	"fmt"
+	"net/http"
+	"net/url"
+
+	boshlog "github.com/cloudfoundry/bosh-utils/logger"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/onsi/gomega/ghttp"
+
+	. "github.com/cloudfoundry/bosh-cli/config"
+)
+
+var _ = Describe("DAVStateBackend", func() {
+	var (
+		server  *ghttp.Server
+		backend StateBackend
+	)
+
+	objectPath := func(name string) string {
+		return fmt.Sprintf("/dir/%02x/%s", sha1.Sum([]byte(name))[0], name)
+	}
+
+	BeforeEach(func() {
+		server = ghttp.NewServer()
+
+		stateURL, err := url.Parse("dav+" + server.URL() + "/dir/state.json")
+		Expect(err).ToNot(HaveOccurred())
+
+		stateURL.User = url.UserPassword("user", "pass")
+
+		backend, err = NewDAVStateBackend(stateURL, "/dir", boshlog.NewLogger(boshlog.LevelNone))
+		Expect(err).ToNot(HaveOccurred())
+	})
+
+	AfterEach(func() {
+		server.Close()
+	})
+
+	Describe("Get", func() {
+		It("returns object contents", func() {
+			server.AppendHandlers(ghttp.CombineHandlers(
+				ghttp.VerifyRequest("GET", objectPath("state.json")),
+				ghttp.VerifyBasicAuth("user", "pass"),
+				ghttp.RespondWith(http.StatusOK, "{}"),
+			))
+
+			contents, found, err := backend.Get("state.json")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(found).To(BeTrue())
+			Expect(string(contents)).To(Equal("{}"))
+		})
+
+		It("returns not found when object does not exist", func() {
+			server.AppendHandlers(ghttp.CombineHandlers(
+				ghttp.VerifyRequest("GET", objectPath("state.json")),
+				ghttp.RespondWith(http.StatusNotFound, "state.json not found"),
+			))
+
+			_, found, err := backend.Get("state.json")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(found).To(BeFalse())
+		})
+
+		It("returns error for other response codes", func() {
+			server.AppendHandlers(ghttp.CombineHandlers(
+				ghttp.VerifyRequest("GET", objectPath("state.json")),
+				ghttp.RespondWith(http.StatusInternalServerError, "not found in cache"),
+			))
+
+			_, _, err := backend.Get("state.json")
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("Wrong response code: 500"))
+		})
+	})
+})
@@ -41,6 +41,11 @@ func (r deploymentRepo) UpdateCurrent(manifestSHA string) error {
 
 	deploymentState.CurrentManifestSHA = manifestSHA
 
+	// Deployment has finished, so there is nothing left to resume
+	if manifestSHA != "" {
+		deploymentState.Checkpoint = nil
+	}
+
 	err = r.deploymentStateService.Save(deploymentState)
 	if err != nil {
 		return bosherr.WrapError(err, "Saving new config")
@@ -39,6 +39,30 @@ var _ = Describe("DeploymentRepo", func() {
 			}
 			Expect(deploymentState).To(Equal(expectedConfig))
 		})
+
+		It("clears checkpoint once deployment has finished", func() {
+			err := NewCheckpointRepo(deploymentStateService).UpdateCurrent(CheckpointRecord{VMCID: "fake-vm-cid"})
+			Expect(err).ToNot(HaveOccurred())
+
+			err = repo.UpdateCurrent("fake-manifest-sha1")
+			Expect(err).ToNot(HaveOccurred())
+
+			deploymentState, err := deploymentStateService.Load()
+			Expect(err).ToNot(HaveOccurred())
+			Expect(deploymentState.Checkpoint).To(BeNil())
+		})
+
+		It("keeps checkpoint when clearing manifest sha1", func() {
+			err := NewCheckpointRepo(deploymentStateService).UpdateCurrent(CheckpointRecord{VMCID: "fake-vm-cid"})
+			Expect(err).ToNot(HaveOccurred())
+
+			err = repo.UpdateCurrent("")
+			Expect(err).ToNot(HaveOccurred())
+
+			deploymentState, err := deploymentStateService.Load()
+			Expect(err).ToNot(HaveOccurred())
+			Expect(deploymentState.Checkpoint).To(Equal(&CheckpointRecord{VMCID: "fake-vm-cid"}))
+		})
 	})
 
 	Describe("FindCurrent", func() {
@@ -5,16 +5,17 @@ import (
 )
 
 type DeploymentState struct {
-	DirectorID         string           `json:"director_id"`
-	InstallationID     string           `json:"installation_id"`
-	CurrentVMCID       string           `json:"current_vm_cid"`
-	CurrentStemcellID  string           `json:"current_stemcell_id"`
-	CurrentDiskID      string           `json:"current_disk_id"`
-	CurrentReleaseIDs  []string         `json:"current_release_ids"`
-	CurrentManifestSHA string           `json:"current_manifest_sha"`
-	Disks              []DiskRecord     `json:"disks"`
-	Stemcells          []StemcellRecord `json:"stemcells"`
-	Releases           []ReleaseRecord  `json:"releases"`
+	DirectorID         string            `json:"director_id"`
+	InstallationID     string            `json:"installation_id"`
+	CurrentVMCID       string            `json:"current_vm_cid"`
+	CurrentStemcellID  string            `json:"current_stemcell_id"`
+	CurrentDiskID      string            `json:"current_disk_id"`
+	CurrentReleaseIDs  []string          `json:"current_release_ids"`
+	CurrentManifestSHA string            `json:"current_manifest_sha"`
+	Disks              []DiskRecord      `json:"disks"`
+	Stemcells          []StemcellRecord  `json:"stemcells"`
+	Releases           []ReleaseRecord   `json:"releases"`
+	Checkpoint         *CheckpointRecord `json:"checkpoint,omitempty"`
 }
 
 type StemcellRecord struct {
@@ -44,4 +45,8 @@ type DeploymentStateService interface {
 	Load() (DeploymentState, error)
 	Save(DeploymentState) error
 	Cleanup() error
+
+	// Backup copies current deployment state as is (encrypted state stays encrypted)
+	// next to it with given suffix and returns location of the copy.
+	Backup(suffix string) (string, error)
 }
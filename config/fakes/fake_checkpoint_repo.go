@@ -0,0 +1,62 @@
+package fakes
+
+import (
+	biconfig "github.com/cloudfoundry/bosh-cli/config"
+)
+
+type FakeCheckpointRepo struct {
+	Record *biconfig.CheckpointRecord
+
+	UpdateCurrentErr error
+	CompleteErr      error
+	ClearCurrentErr  error
+
+	FindCurrentErr error
+}
+
+func NewFakeCheckpointRepo() *FakeCheckpointRepo {
+	return &FakeCheckpointRepo{}
+}
+
+func (r *FakeCheckpointRepo) FindCurrent() (biconfig.CheckpointRecord, bool, error) {
+	if r.Record == nil {
+		return biconfig.CheckpointRecord{}, false, r.FindCurrentErr
+	}
+	return *r.Record, true, r.FindCurrentErr
+}
+
+func (r *FakeCheckpointRepo) UpdateCurrent(record biconfig.CheckpointRecord) error {
+	if r.UpdateCurrentErr != nil {
+		return r.UpdateCurrentErr
+	}
+	r.Record = &record
+	return nil
+}
+
+func (r *FakeCheckpointRepo) Complete(checkpoint string) error {
+	return r.CompleteDisk(checkpoint, "")
+}
+
+func (r *FakeCheckpointRepo) CompleteDisk(checkpoint string, diskCID string) error {
+	if r.CompleteErr != nil {
+		return r.CompleteErr
+	}
+	if r.Record == nil {
+		return nil
+	}
+	if len(diskCID) > 0 {
+		r.Record.DiskCID = diskCID
+	}
+	if !r.Record.Reached(checkpoint) {
+		r.Record.Completed = append(r.Record.Completed, checkpoint)
+	}
+	return nil
+}
+
+func (r *FakeCheckpointRepo) ClearCurrent() error {
+	if r.ClearCurrentErr != nil {
+		return r.ClearCurrentErr
+	}
+	r.Record = nil
+	return nil
+}
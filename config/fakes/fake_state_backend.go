@@ -0,0 +1,73 @@
+package fakes
+
+import (
+	"sync"
+)
+
+type FakeStateBackend struct {
+	LocationStr string
+	Objects     map[string][]byte
+
+	GetErr    error
+	PutErr    error
+	DeleteErr error
+
+	// PutHook is called after every successful Put and may be used
+	// to simulate concurrent modification by other holders.
+	PutHook func(name string)
+
+	mutex sync.Mutex
+}
+
+func NewFakeStateBackend() *FakeStateBackend {
+	return &FakeStateBackend{
+		LocationStr: "fake://state",
+		Objects:     map[string][]byte{},
+	}
+}
+
+func (b *FakeStateBackend) Location() string { return b.LocationStr }
+
+func (b *FakeStateBackend) Get(name string) ([]byte, bool, error) {
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+
+	if b.GetErr != nil {
+		return nil, false, b.GetErr
+	}
+
+	contents, found := b.Objects[name]
+
+	return contents, found, nil
+}
+
+func (b *FakeStateBackend) Put(name string, contents []byte) error {
+	b.mutex.Lock()
+
+	if b.PutErr != nil {
+		b.mutex.Unlock()
+		return b.PutErr
+	}
+
+	b.Objects[name] = contents
+	b.mutex.Unlock()
+
+	if b.PutHook != nil {
+		b.PutHook(name)
+	}
+
+	return nil
+}
+
+func (b *FakeStateBackend) Delete(name string) error {
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+
+	if b.DeleteErr != nil {
+		return b.DeleteErr
+	}
+
+	delete(b.Objects, name)
+
+	return nil
+}
@@ -0,0 +1,25 @@
+package fakes
+
+type FakeStateLock struct {
+	AcquireErr error
+	RenewErr   error
+	ReleaseErr error
+	ErrErr     error
+
+	HoldCalled bool
+}
+
+func (l *FakeStateLock) Acquire() error { return l.AcquireErr }
+func (l *FakeStateLock) Renew() error   { return l.RenewErr }
+func (l *FakeStateLock) Release() error { return l.ReleaseErr }
+func (l *FakeStateLock) Err() error     { return l.ErrErr }
+
+func (l *FakeStateLock) Hold(fn func() error) error {
+	l.HoldCalled = true
+
+	if l.AcquireErr != nil {
+		return l.AcquireErr
+	}
+
+	return fn()
+}
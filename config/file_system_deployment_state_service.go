@@ -10,21 +10,32 @@ import (
 	boshlog "github.com/cloudfoundry/bosh-utils/logger"
 	boshsys "github.com/cloudfoundry/bosh-utils/system"
 	boshuuid "github.com/cloudfoundry/bosh-utils/uuid"
+
+	bicrypto "github.com/cloudfoundry/bosh-cli/crypto"
 )
 
 type fileSystemDeploymentStateService struct {
 	configPath    string
 	fs            boshsys.FileSystem
 	uuidGenerator boshuuid.Generator
+	encryptor     bicrypto.Encryptor
 	logger        boshlog.Logger
 	logTag        string
 }
 
 func NewFileSystemDeploymentStateService(fs boshsys.FileSystem, uuidGenerator boshuuid.Generator, logger boshlog.Logger, deploymentStatePath string) DeploymentStateService {
+	return NewEncryptedFileSystemDeploymentStateService(fs, uuidGenerator, nil, logger, deploymentStatePath)
+}
+
+// NewEncryptedFileSystemDeploymentStateService returns a state service that
+// encrypts state on save when encryptor is given. Encrypted state files are
+// always decrypted on load; plaintext files are still accepted to allow migration.
+func NewEncryptedFileSystemDeploymentStateService(fs boshsys.FileSystem, uuidGenerator boshuuid.Generator, encryptor bicrypto.Encryptor, logger boshlog.Logger, deploymentStatePath string) DeploymentStateService {
 	return &fileSystemDeploymentStateService{
 		configPath:    deploymentStatePath,
 		fs:            fs,
 		uuidGenerator: uuidGenerator,
+		encryptor:     encryptor,
 		logger:        logger,
 		logTag:        "config",
 	}
@@ -61,6 +72,18 @@ func (s *fileSystemDeploymentStateService) Load() (DeploymentState, error) {
 		if err != nil {
 			return DeploymentState{}, bosherr.WrapErrorf(err, "Reading deployment state file '%s'", s.configPath)
 		}
+
+		if bicrypto.IsEncrypted(deploymentStateFileContents) {
+			if s.encryptor == nil {
+				return DeploymentState{}, bosherr.Errorf("Deployment state file '%s' is encrypted but no encryption key was provided", s.configPath)
+			}
+
+			deploymentStateFileContents, err = s.encryptor.Decrypt(deploymentStateFileContents)
+			if err != nil {
+				return DeploymentState{}, bosherr.WrapErrorf(err, "Decrypting deployment state file '%s'", s.configPath)
+			}
+		}
+
 		s.logger.Debug(s.logTag, "Deployment File Contents %#s", deploymentStateFileContents)
 
 		err = json.Unmarshal(deploymentStateFileContents, deploymentState)
@@ -89,6 +112,13 @@ func (s *fileSystemDeploymentStateService) Save(deploymentState DeploymentState)
 		return bosherr.WrapError(err, "Marshalling deployment state into JSON")
 	}
 
+	if s.encryptor != nil {
+		jsonContent, err = s.encryptor.Encrypt(jsonContent)
+		if err != nil {
+			return bosherr.WrapErrorf(err, "Encrypting deployment state file '%s'", s.configPath)
+		}
+	}
+
 	err = s.fs.WriteFile(s.configPath, jsonContent)
 	if err != nil {
 		return bosherr.WrapErrorf(err, "Writing deployment state file '%s'", s.configPath)
@@ -121,3 +151,19 @@ func (s *fileSystemDeploymentStateService) Cleanup() error {
 	}
 	return nil
 }
+
+func (s *fileSystemDeploymentStateService) Backup(suffix string) (string, error) {
+	backupPath := fmt.Sprintf("%s.%s", s.configPath, suffix)
+
+	contents, err := s.fs.ReadFile(s.configPath)
+	if err != nil {
+		return "", bosherr.WrapErrorf(err, "Reading deployment state file '%s'", s.configPath)
+	}
+
+	err = s.fs.WriteFile(backupPath, contents)
+	if err != nil {
+		return "", bosherr.WrapErrorf(err, "Writing deployment state backup '%s'", backupPath)
+	}
+
+	return backupPath, nil
+}
@@ -13,6 +13,8 @@ import (
 	biproperty "github.com/cloudfoundry/bosh-utils/property"
 	fakesys "github.com/cloudfoundry/bosh-utils/system/fakes"
 	fakeuuid "github.com/cloudfoundry/bosh-utils/uuid/fakes"
+
+	bicrypto "github.com/cloudfoundry/bosh-cli/crypto"
 )
 
 var _ = Describe("fileSystemDeploymentStateService", func() {
@@ -206,6 +208,65 @@ var _ = Describe("fileSystemDeploymentStateService", func() {
 		})
 	})
 
+	Describe("encryption", func() {
+		var (
+			encryptor bicrypto.Encryptor
+		)
+
+		BeforeEach(func() {
+			encryptor = bicrypto.NewEncryptor([]byte("fake-key"))
+			service = NewEncryptedFileSystemDeploymentStateService(
+				fakeFs, fakeUUIDGenerator, encryptor, boshlog.NewLogger(boshlog.LevelNone), deploymentStatePath)
+		})
+
+		It("encrypts state on save and decrypts it on load", func() {
+			err := service.Save(DeploymentState{DirectorID: "fake-director-id", CurrentVMCID: "fake-vm-cid"})
+			Expect(err).ToNot(HaveOccurred())
+
+			contents, err := fakeFs.ReadFile(deploymentStatePath)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(bicrypto.IsEncrypted(contents)).To(BeTrue())
+			Expect(string(contents)).ToNot(ContainSubstring("fake-vm-cid"))
+
+			state, err := service.Load()
+			Expect(err).ToNot(HaveOccurred())
+			Expect(state.CurrentVMCID).To(Equal("fake-vm-cid"))
+		})
+
+		It("loads plaintext state so that it can be migrated", func() {
+			fakeFs.WriteFileString(deploymentStatePath, `{"director_id":"fake-director-id","current_vm_cid":"fake-vm-cid"}`)
+
+			state, err := service.Load()
+			Expect(err).ToNot(HaveOccurred())
+			Expect(state.CurrentVMCID).To(Equal("fake-vm-cid"))
+		})
+
+		It("returns error when loading encrypted state without encryption key", func() {
+			err := service.Save(DeploymentState{DirectorID: "fake-director-id"})
+			Expect(err).ToNot(HaveOccurred())
+
+			service = NewFileSystemDeploymentStateService(
+				fakeFs, fakeUUIDGenerator, boshlog.NewLogger(boshlog.LevelNone), deploymentStatePath)
+
+			_, err = service.Load()
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(Equal("Deployment state file '/some/deployment.json' is encrypted but no encryption key was provided"))
+		})
+
+		It("returns error when encryption key is wrong", func() {
+			err := service.Save(DeploymentState{DirectorID: "fake-director-id"})
+			Expect(err).ToNot(HaveOccurred())
+
+			service = NewEncryptedFileSystemDeploymentStateService(
+				fakeFs, fakeUUIDGenerator, bicrypto.NewEncryptor([]byte("other-key")),
+				boshlog.NewLogger(boshlog.LevelNone), deploymentStatePath)
+
+			_, err = service.Load()
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("Decrypting deployment state file '/some/deployment.json'"))
+		})
+	})
+
 	Describe("Cleanup", func() {
 		It("returns true if deployment state file deleted", func() {
 			fakeFs.WriteFileString(deploymentStatePath, "")
@@ -231,4 +292,24 @@ var _ = Describe("fileSystemDeploymentStateService", func() {
 			Expect(err.Error()).To(ContainSubstring("Could not do that Dave"))
 		})
 	})
+
+	Describe("Backup", func() {
+		It("copies deployment state file as is next to it", func() {
+			fakeFs.WriteFileString(deploymentStatePath, "fake-state-contents")
+
+			backupPath, err := service.Backup("fake-suffix")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(backupPath).To(Equal("/some/deployment.json.fake-suffix"))
+
+			contents, err := fakeFs.ReadFileString(backupPath)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(contents).To(Equal("fake-state-contents"))
+		})
+
+		It("returns error if deployment state file cannot be read", func() {
+			_, err := service.Backup("fake-suffix")
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("Reading deployment state file '/some/deployment.json'"))
+		})
+	})
 })
@@ -0,0 +1,171 @@
+package config
+
+import (
+	"encoding/json"
+	"fmt"
+
+	bosherr "github.com/cloudfoundry/bosh-utils/errors"
+	boshlog "github.com/cloudfoundry/bosh-utils/logger"
+	boshuuid "github.com/cloudfoundry/bosh-utils/uuid"
+
+	bicrypto "github.com/cloudfoundry/bosh-cli/crypto"
+)
+
+type remoteDeploymentStateService struct {
+	backend       StateBackend
+	name          string
+	uuidGenerator boshuuid.Generator
+	encryptor     bicrypto.Encryptor
+	lock          StateLock
+	logger        boshlog.Logger
+	logTag        string
+}
+
+// NewRemoteDeploymentStateService returns a state service that keeps
+// deployment state as a single object named name in the given backend.
+// When lock is given, state is not modified once the lock was lost.
+func NewRemoteDeploymentStateService(backend StateBackend, name string, uuidGenerator boshuuid.Generator, encryptor bicrypto.Encryptor, lock StateLock, logger boshlog.Logger) DeploymentStateService {
+	return &remoteDeploymentStateService{
+		backend:       backend,
+		name:          name,
+		uuidGenerator: uuidGenerator,
+		encryptor:     encryptor,
+		lock:          lock,
+		logger:        logger,
+		logTag:        "remoteDeploymentStateService",
+	}
+}
+
+func (s *remoteDeploymentStateService) Path() string {
+	return s.backend.Location()
+}
+
+func (s *remoteDeploymentStateService) Exists() bool {
+	_, found, err := s.backend.Get(s.name)
+	if err != nil {
+		s.logger.Warn(s.logTag, "Checking if deployment state '%s' exists: %s", s.Path(), err)
+		return false
+	}
+
+	return found
+}
+
+func (s *remoteDeploymentStateService) Load() (DeploymentState, error) {
+	s.logger.Debug(s.logTag, "Loading deployment state: %s", s.Path())
+
+	deploymentState := &DeploymentState{}
+
+	contents, found, err := s.backend.Get(s.name)
+	if err != nil {
+		return DeploymentState{}, bosherr.WrapErrorf(err, "Reading deployment state '%s'", s.Path())
+	}
+
+	if found {
+		if bicrypto.IsEncrypted(contents) {
+			if s.encryptor == nil {
+				return DeploymentState{}, bosherr.Errorf("Deployment state '%s' is encrypted but no encryption key was provided", s.Path())
+			}
+
+			contents, err = s.encryptor.Decrypt(contents)
+			if err != nil {
+				return DeploymentState{}, bosherr.WrapErrorf(err, "Decrypting deployment state '%s'", s.Path())
+			}
+		}
+
+		err = json.Unmarshal(contents, deploymentState)
+		if err != nil {
+			return DeploymentState{}, bosherr.WrapErrorf(err, "Unmarshalling deployment state '%s'", s.Path())
+		}
+	}
+
+	if deploymentState.DirectorID == "" {
+		uuid, err := s.uuidGenerator.Generate()
+		if err != nil {
+			return DeploymentState{}, bosherr.WrapError(err, "Generating DirectorID")
+		}
+
+		deploymentState.DirectorID = uuid
+
+		err = s.Save(*deploymentState)
+		if err != nil {
+			return DeploymentState{}, bosherr.WrapError(err, "Saving deployment state")
+		}
+	}
+
+	return *deploymentState, nil
+}
+
+func (s *remoteDeploymentStateService) Save(deploymentState DeploymentState) error {
+	s.logger.Debug(s.logTag, "Saving deployment state %#v", deploymentState)
+
+	err := s.checkLock()
+	if err != nil {
+		return err
+	}
+
+	jsonContent, err := json.MarshalIndent(deploymentState, "", "    ")
+	if err != nil {
+		return bosherr.WrapError(err, "Marshalling deployment state into JSON")
+	}
+
+	if s.encryptor != nil {
+		jsonContent, err = s.encryptor.Encrypt(jsonContent)
+		if err != nil {
+			return bosherr.WrapErrorf(err, "Encrypting deployment state '%s'", s.Path())
+		}
+	}
+
+	err = s.backend.Put(s.name, jsonContent)
+	if err != nil {
+		return bosherr.WrapErrorf(err, "Writing deployment state '%s'", s.Path())
+	}
+
+	return nil
+}
+
+func (s *remoteDeploymentStateService) Cleanup() error {
+	err := s.checkLock()
+	if err != nil {
+		return err
+	}
+
+	err = s.backend.Delete(s.name)
+	if err != nil {
+		return bosherr.WrapErrorf(err, "Could not delete deployment state %s", s.Path())
+	}
+
+	return nil
+}
+
+func (s *remoteDeploymentStateService) Backup(suffix string) (string, error) {
+	backupName := fmt.Sprintf("%s.%s", s.name, suffix)
+
+	contents, found, err := s.backend.Get(s.name)
+	if err != nil {
+		return "", bosherr.WrapErrorf(err, "Reading deployment state '%s'", s.Path())
+	}
+
+	if !found {
+		return "", bosherr.Errorf("Deployment state '%s' does not exist", s.Path())
+	}
+
+	err = s.backend.Put(backupName, contents)
+	if err != nil {
+		return "", bosherr.WrapErrorf(err, "Writing deployment state backup '%s'", backupName)
+	}
+
+	return fmt.Sprintf("%s.%s", s.Path(), suffix), nil
+}
+
+func (s *remoteDeploymentStateService) checkLock() error {
+	if s.lock == nil {
+		return nil
+	}
+
+	err := s.lock.Err()
+	if err != nil {
+		return bosherr.WrapErrorf(err, "Refusing to modify deployment state '%s'", s.Path())
+	}
+
+	return nil
+}
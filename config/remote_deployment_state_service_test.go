@@ -0,0 +1,152 @@
+package config_test
+
+import (
+	"errors"
+
+	boshlog "github.com/cloudfoundry/bosh-utils/logger"
+	fakeuuid "github.com/cloudfoundry/bosh-utils/uuid/fakes"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	. "github.com/cloudfoundry/bosh-cli/config"
+	fakeconfig "github.com/cloudfoundry/bosh-cli/config/fakes"
+	bicrypto "github.com/cloudfoundry/bosh-cli/crypto"
+)
+
+var _ = Describe("remoteDeploymentStateService", func() {
+	var (
+		backend           *fakeconfig.FakeStateBackend
+		fakeUUIDGenerator *fakeuuid.FakeGenerator
+		logger            boshlog.Logger
+		service           DeploymentStateService
+	)
+
+	BeforeEach(func() {
+		backend = fakeconfig.NewFakeStateBackend()
+		fakeUUIDGenerator = fakeuuid.NewFakeGenerator()
+		logger = boshlog.NewLogger(boshlog.LevelNone)
+		service = NewRemoteDeploymentStateService(backend, "state.json", fakeUUIDGenerator, nil, nil, logger)
+	})
+
+	Describe("Path", func() {
+		It("returns backend location", func() {
+			Expect(service.Path()).To(Equal("fake://state"))
+		})
+	})
+
+	Describe("Exists", func() {
+		It("returns true if state object exists", func() {
+			backend.Objects["state.json"] = []byte("{}")
+			Expect(service.Exists()).To(BeTrue())
+		})
+
+		It("returns false if state object does not exist", func() {
+			Expect(service.Exists()).To(BeFalse())
+		})
+
+		It("returns false if backend fails", func() {
+			backend.GetErr = errors.New("fake-err")
+			Expect(service.Exists()).To(BeFalse())
+		})
+	})
+
+	Describe("Load", func() {
+		It("loads state from backend", func() {
+			backend.Objects["state.json"] = []byte(`{"director_id":"fake-director-id","current_vm_cid":"fake-vm-cid"}`)
+
+			state, err := service.Load()
+			Expect(err).ToNot(HaveOccurred())
+			Expect(state.DirectorID).To(Equal("fake-director-id"))
+			Expect(state.CurrentVMCID).To(Equal("fake-vm-cid"))
+		})
+
+		It("generates and saves director id if state does not exist", func() {
+			fakeUUIDGenerator.GeneratedUUID = "fake-uuid"
+
+			state, err := service.Load()
+			Expect(err).ToNot(HaveOccurred())
+			Expect(state.DirectorID).To(Equal("fake-uuid"))
+			Expect(string(backend.Objects["state.json"])).To(ContainSubstring(`"director_id": "fake-uuid"`))
+		})
+
+		It("returns error if backend fails", func() {
+			backend.GetErr = errors.New("fake-err")
+
+			_, err := service.Load()
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("Reading deployment state 'fake://state'"))
+		})
+
+		It("returns error if state is encrypted but no key was provided", func() {
+			encrypted, err := bicrypto.NewEncryptor([]byte("key")).Encrypt([]byte("{}"))
+			Expect(err).ToNot(HaveOccurred())
+
+			backend.Objects["state.json"] = encrypted
+
+			_, err = service.Load()
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(Equal("Deployment state 'fake://state' is encrypted but no encryption key was provided"))
+		})
+	})
+
+	Describe("Save", func() {
+		It("encrypts state if encryptor is provided", func() {
+			encryptor := bicrypto.NewEncryptor([]byte("key"))
+			service = NewRemoteDeploymentStateService(backend, "state.json", fakeUUIDGenerator, encryptor, nil, logger)
+
+			err := service.Save(DeploymentState{DirectorID: "fake-director-id"})
+			Expect(err).ToNot(HaveOccurred())
+			Expect(bicrypto.IsEncrypted(backend.Objects["state.json"])).To(BeTrue())
+
+			state, err := service.Load()
+			Expect(err).ToNot(HaveOccurred())
+			Expect(state.DirectorID).To(Equal("fake-director-id"))
+		})
+
+		It("returns error if backend fails", func() {
+			backend.PutErr = errors.New("fake-err")
+
+			err := service.Save(DeploymentState{})
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("Writing deployment state 'fake://state'"))
+		})
+
+		It("refuses to save state once lock was lost", func() {
+			lock := &fakeconfig.FakeStateLock{ErrErr: errors.New("fake-lost-err")}
+			service = NewRemoteDeploymentStateService(backend, "state.json", fakeUUIDGenerator, nil, lock, logger)
+
+			err := service.Save(DeploymentState{})
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("Refusing to modify deployment state 'fake://state'"))
+			Expect(err.Error()).To(ContainSubstring("fake-lost-err"))
+			Expect(backend.Objects).ToNot(HaveKey("state.json"))
+		})
+	})
+
+	Describe("Cleanup", func() {
+		It("deletes state object", func() {
+			backend.Objects["state.json"] = []byte("{}")
+
+			err := service.Cleanup()
+			Expect(err).ToNot(HaveOccurred())
+			Expect(backend.Objects).ToNot(HaveKey("state.json"))
+		})
+	})
+
+	Describe("Backup", func() {
+		It("copies state object as is next to it", func() {
+			backend.Objects["state.json"] = []byte("fake-state-contents")
+
+			location, err := service.Backup("fake-suffix")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(location).To(Equal("fake://state.fake-suffix"))
+			Expect(backend.Objects["state.json.fake-suffix"]).To(Equal([]byte("fake-state-contents")))
+		})
+
+		It("returns error if state object does not exist", func() {
+			_, err := service.Backup("fake-suffix")
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("Deployment state 'fake://state' does not exist"))
+		})
+	})
+})
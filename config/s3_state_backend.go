@@ -0,0 +1,166 @@
+package config
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/url"
+	"strconv"
+	"strings"
+
+	s3client "github.com/cloudfoundry/bosh-s3cli/client"
+	s3config "github.com/cloudfoundry/bosh-s3cli/config"
+	bosherr "github.com/cloudfoundry/bosh-utils/errors"
+	boshsys "github.com/cloudfoundry/bosh-utils/system"
+)
+
+type s3StateBackend struct {
+	location string
+	options  map[string]interface{}
+	fs       boshsys.FileSystem
+}
+
+// NewS3StateBackend configures S3 client from URL such as
+// 's3://bucket/path/state.json?region=us-east-1&host=s3.example.com'.
+// Query parameters are passed through as S3 client options;
+// credentials are taken from the environment unless provided explicitly.
+func NewS3StateBackend(stateURL *url.URL, dir string, fs boshsys.FileSystem) (StateBackend, error) {
+	if len(stateURL.Host) == 0 {
+		return nil, bosherr.Errorf("Expected state URL to include bucket name")
+	}
+
+	options := map[string]interface{}{
+		"bucket_name":        stateURL.Host,
+		"folder_name":        strings.Trim(dir, "/"),
+		"credentials_source": "env_or_profile",
+		"use_ssl":            true,
+		"ssl_verify_peer":    true,
+	}
+
+	for key, vals := range stateURL.Query() {
+		if len(vals) == 0 {
+			continue
+		}
+
+		switch key {
+		case "use_ssl", "ssl_verify_peer", "multipart_upload", "host_style":
+			val, err := strconv.ParseBool(vals[0])
+			if err != nil {
+				return nil, bosherr.WrapErrorf(err, "Parsing state URL option '%s'", key)
+			}
+			options[key] = val
+
+		case "port":
+			val, err := strconv.Atoi(vals[0])
+			if err != nil {
+				return nil, bosherr.WrapErrorf(err, "Parsing state URL option '%s'", key)
+			}
+			options[key] = val
+
+		default:
+			options[key] = vals[0]
+		}
+	}
+
+	if _, found := options["access_key_id"]; found {
+		options["credentials_source"] = "static"
+	}
+
+	backend := s3StateBackend{location: redactStateURL(stateURL), options: options, fs: fs}
+
+	_, err := backend.client()
+	if err != nil {
+		return nil, err
+	}
+
+	return backend, nil
+}
+
+func (b s3StateBackend) Location() string { return b.location }
+
+func (b s3StateBackend) Get(name string) ([]byte, bool, error) {
+	client, err := b.client()
+	if err != nil {
+		return nil, false, err
+	}
+
+	exists, err := client.Exists(name)
+	if err != nil {
+		return nil, false, bosherr.WrapErrorf(err, "Checking if '%s' exists", name)
+	}
+
+	if !exists {
+		return nil, false, nil
+	}
+
+	file, err := b.fs.TempFile("bosh-s3-state")
+	if err != nil {
+		return nil, false, bosherr.WrapError(err, "Creating destination file")
+	}
+
+	defer b.fs.RemoveAll(file.Name())
+	defer file.Close()
+
+	err = client.Get(name, file)
+	if err != nil {
+		return nil, false, bosherr.WrapErrorf(err, "Getting '%s'", name)
+	}
+
+	contents, err := b.fs.ReadFile(file.Name())
+	if err != nil {
+		return nil, false, bosherr.WrapErrorf(err, "Reading '%s'", name)
+	}
+
+	return contents, true, nil
+}
+
+func (b s3StateBackend) Put(name string, contents []byte) error {
+	client, err := b.client()
+	if err != nil {
+		return err
+	}
+
+	err = client.Put(bytes.NewReader(contents), name)
+	if err != nil {
+		return bosherr.WrapErrorf(err, "Putting '%s'", name)
+	}
+
+	return nil
+}
+
+func (b s3StateBackend) Delete(name string) error {
+	client, err := b.client()
+	if err != nil {
+		return err
+	}
+
+	err = client.Delete(name)
+	if err != nil {
+		return bosherr.WrapErrorf(err, "Deleting '%s'", name)
+	}
+
+	return nil
+}
+
+func (b s3StateBackend) client() (s3client.S3Blobstore, error) {
+	optionBytes, err := json.Marshal(b.options)
+	if err != nil {
+		return s3client.S3Blobstore{}, bosherr.WrapErrorf(err, "Marshaling config")
+	}
+
+	conf, err := s3config.NewFromReader(bytes.NewBuffer(optionBytes))
+	if err != nil {
+		return s3client.S3Blobstore{}, bosherr.WrapErrorf(err, "Reading config")
+	}
+
+	s3ClientSDK, err := s3client.NewSDK(conf)
+	if err != nil {
+		return s3client.S3Blobstore{}, bosherr.WrapErrorf(err, "Building client SDK")
+	}
+
+	client, err := s3client.New(s3ClientSDK, &conf)
+	if err != nil {
+		return s3client.S3Blobstore{}, bosherr.WrapErrorf(err, "Validating config")
+	}
+
+	return client, nil
+}
@@ -0,0 +1,251 @@
+package config
+
+import (
+	"encoding/json"
+	"sync"
+	"time"
+
+	"code.cloudfoundry.org/clock"
+	bosherr "github.com/cloudfoundry/bosh-utils/errors"
+	boshlog "github.com/cloudfoundry/bosh-utils/logger"
+)
+
+const DefaultStateLockTTL = 5 * time.Minute
+
+// StateLock guards remote deployment state against concurrent modification.
+//
+// Since supported backends do not provide compare-and-swap operations,
+// lock acquisition is best effort: lock is written and then read back
+// to verify that no other holder has written it in the meantime.
+type StateLock interface {
+	Acquire() error
+	Renew() error
+	Release() error
+
+	// Hold acquires lock, periodically renews it while fn is running and releases it afterwards.
+	// Hold fails if lock could not be renewed while fn was running.
+	Hold(fn func() error) error
+
+	// Err returns renewal error once lock could not be renewed while being held;
+	// state must not be saved afterwards since lock may have been taken over.
+	Err() error
+}
+
+type StateLockInfo struct {
+	Holder     string    `json:"holder"`
+	ID         string    `json:"id"`
+	AcquiredAt time.Time `json:"acquired_at"`
+	ExpiresAt  time.Time `json:"expires_at"`
+}
+
+type backendStateLock struct {
+	backend StateBackend
+	name    string
+
+	holder string
+	id     string
+	ttl    time.Duration
+
+	timeService clock.Clock
+	logger      boshlog.Logger
+	logTag      string
+
+	acquiredAt time.Time
+
+	renewErr     error
+	renewErrLock sync.Mutex
+}
+
+// NewStateLock returns lock stored next to state object as '<stateName>.lock'.
+// Holder identifies lock owner to other operators (e.g. 'user@host');
+// id must be unique for every lock instance.
+func NewStateLock(backend StateBackend, stateName, holder, id string, ttl time.Duration, timeService clock.Clock, logger boshlog.Logger) StateLock {
+	return &backendStateLock{
+		backend: backend,
+		name:    stateName + ".lock",
+
+		holder: holder,
+		id:     id,
+		ttl:    ttl,
+
+		timeService: timeService,
+		logger:      logger,
+		logTag:      "stateLock",
+	}
+}
+
+func (l *backendStateLock) Acquire() error {
+	info, found, err := l.read()
+	if err != nil {
+		return err
+	}
+
+	now := l.timeService.Now()
+
+	if found && info.ID != l.id {
+		if now.Before(info.ExpiresAt) {
+			return bosherr.Errorf(
+				"Deployment state '%s' is locked by '%s' since %s (lock expires at %s)",
+				l.backend.Location(), info.Holder, info.AcquiredAt.Format(time.RFC3339), info.ExpiresAt.Format(time.RFC3339))
+		}
+
+		l.logger.Warn(l.logTag, "Overriding stale lock held by '%s' that expired at %s", info.Holder, info.ExpiresAt)
+	}
+
+	l.acquiredAt = now
+
+	err = l.write()
+	if err != nil {
+		return err
+	}
+
+	info, found, err = l.read()
+	if err != nil {
+		return err
+	}
+
+	if !found || info.ID != l.id {
+		return bosherr.Errorf("Failed to acquire lock for deployment state '%s': lock was taken by '%s'", l.backend.Location(), info.Holder)
+	}
+
+	return nil
+}
+
+func (l *backendStateLock) Renew() error {
+	info, found, err := l.read()
+	if err != nil {
+		return err
+	}
+
+	if !found || info.ID != l.id {
+		return bosherr.Errorf("Expected lock for deployment state '%s' to be held by '%s'", l.backend.Location(), l.holder)
+	}
+
+	return l.write()
+}
+
+func (l *backendStateLock) Release() error {
+	info, found, err := l.read()
+	if err != nil {
+		return err
+	}
+
+	if !found || info.ID != l.id {
+		l.logger.Warn(l.logTag, "Lock for deployment state '%s' is no longer held by '%s'", l.backend.Location(), l.holder)
+		return nil
+	}
+
+	err = l.backend.Delete(l.name)
+	if err != nil {
+		return bosherr.WrapErrorf(err, "Releasing lock for deployment state '%s'", l.backend.Location())
+	}
+
+	return nil
+}
+
+func (l *backendStateLock) Hold(fn func() error) error {
+	err := l.Acquire()
+	if err != nil {
+		return err
+	}
+
+	ticker := l.timeService.NewTicker(l.ttl / 3)
+	done := make(chan struct{})
+	stopped := make(chan struct{})
+
+	go func() {
+		defer close(stopped)
+
+		for {
+			select {
+			case <-ticker.C():
+				err := l.Renew()
+				if err != nil {
+					l.logger.Error(l.logTag, "Renewing lock: %s", err)
+					l.setRenewErr(err)
+				}
+			case <-done:
+				return
+			}
+		}
+	}()
+
+	fnErr := fn()
+
+	ticker.Stop()
+	close(done)
+	<-stopped
+
+	err = l.Release()
+	if fnErr != nil {
+		return fnErr
+	}
+
+	renewErr := l.Err()
+	if renewErr != nil {
+		return renewErr
+	}
+
+	return err
+}
+
+func (l *backendStateLock) Err() error {
+	l.renewErrLock.Lock()
+	defer l.renewErrLock.Unlock()
+
+	if l.renewErr == nil {
+		return nil
+	}
+
+	return bosherr.WrapErrorf(l.renewErr, "Lost lock for deployment state '%s'", l.backend.Location())
+}
+
+func (l *backendStateLock) setRenewErr(err error) {
+	l.renewErrLock.Lock()
+	defer l.renewErrLock.Unlock()
+
+	if l.renewErr == nil {
+		l.renewErr = err
+	}
+}
+
+func (l *backendStateLock) read() (StateLockInfo, bool, error) {
+	var info StateLockInfo
+
+	contents, found, err := l.backend.Get(l.name)
+	if err != nil {
+		return info, false, bosherr.WrapErrorf(err, "Reading lock for deployment state '%s'", l.backend.Location())
+	}
+
+	if !found {
+		return info, false, nil
+	}
+
+	err = json.Unmarshal(contents, &info)
+	if err != nil {
+		return info, false, bosherr.WrapErrorf(err, "Unmarshalling lock for deployment state '%s'", l.backend.Location())
+	}
+
+	return info, true, nil
+}
+
+func (l *backendStateLock) write() error {
+	info := StateLockInfo{
+		Holder:     l.holder,
+		ID:         l.id,
+		AcquiredAt: l.acquiredAt,
+		ExpiresAt:  l.timeService.Now().Add(l.ttl),
+	}
+
+	contents, err := json.Marshal(info)
+	if err != nil {
+		return bosherr.WrapError(err, "Marshalling lock")
+	}
+
+	err = l.backend.Put(l.name, contents)
+	if err != nil {
+		return bosherr.WrapErrorf(err, "Writing lock for deployment state '%s'", l.backend.Location())
+	}
+
+	return nil
+}
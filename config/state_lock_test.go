@@ -0,0 +1,183 @@
+package config_test
+
+import (
+	"encoding/json"
+	"errors"
+	"time"
+
+	"code.cloudfoundry.org/clock/fakeclock"
+	boshlog "github.com/cloudfoundry/bosh-utils/logger"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	. "github.com/cloudfoundry/bosh-cli/config"
+	fakeconfig "github.com/cloudfoundry/bosh-cli/config/fakes"
+)
+
+var _ = Describe("StateLock", func() {
+	var (
+		backend   *fakeconfig.FakeStateBackend
+		fakeClock *fakeclock.FakeClock
+		now       time.Time
+		lock      StateLock
+	)
+
+	BeforeEach(func() {
+		backend = fakeconfig.NewFakeStateBackend()
+		now = time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)
+		fakeClock = fakeclock.NewFakeClock(now)
+		lock = NewStateLock(backend, "state.json", "me@host", "my-id", time.Minute, fakeClock, boshlog.NewLogger(boshlog.LevelNone))
+	})
+
+	readLock := func() StateLockInfo {
+		contents, _, err := backend.Get("state.json.lock")
+		Expect(err).ToNot(HaveOccurred())
+
+		var info StateLockInfo
+		Expect(json.Unmarshal(contents, &info)).To(Succeed())
+		return info
+	}
+
+	writeLock := func(info StateLockInfo) {
+		contents, err := json.Marshal(info)
+		Expect(err).ToNot(HaveOccurred())
+		backend.Objects["state.json.lock"] = contents
+	}
+
+	Describe("Acquire", func() {
+		It("writes lock with holder identity and expiration", func() {
+			err := lock.Acquire()
+			Expect(err).ToNot(HaveOccurred())
+
+			Expect(readLock()).To(Equal(StateLockInfo{
+				Holder:     "me@host",
+				ID:         "my-id",
+				AcquiredAt: now,
+				ExpiresAt:  now.Add(time.Minute),
+			}))
+		})
+
+		It("returns error if lock is held by someone else", func() {
+			writeLock(StateLockInfo{Holder: "other@host", ID: "other-id", AcquiredAt: now, ExpiresAt: now.Add(time.Second)})
+
+			err := lock.Acquire()
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("Deployment state 'fake://state' is locked by 'other@host'"))
+			Expect(readLock().ID).To(Equal("other-id"))
+		})
+
+		It("overrides stale lock held by someone else", func() {
+			writeLock(StateLockInfo{Holder: "other@host", ID: "other-id", AcquiredAt: now, ExpiresAt: now.Add(-time.Second)})
+
+			err := lock.Acquire()
+			Expect(err).ToNot(HaveOccurred())
+			Expect(readLock().ID).To(Equal("my-id"))
+		})
+
+		It("returns error if lock was taken by someone else while acquiring", func() {
+			backend.PutHook = func(name string) {
+				backend.PutHook = nil
+				writeLock(StateLockInfo{Holder: "other@host", ID: "other-id"})
+			}
+
+			err := lock.Acquire()
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("lock was taken by 'other@host'"))
+		})
+
+		It("returns error if backend fails", func() {
+			backend.PutErr = errors.New("fake-err")
+
+			err := lock.Acquire()
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("fake-err"))
+		})
+	})
+
+	Describe("Renew", func() {
+		It("extends lock expiration", func() {
+			Expect(lock.Acquire()).To(Succeed())
+
+			fakeClock.Increment(30 * time.Second)
+
+			Expect(lock.Renew()).To(Succeed())
+			Expect(readLock().AcquiredAt).To(Equal(now))
+			Expect(readLock().ExpiresAt).To(Equal(now.Add(90 * time.Second)))
+		})
+
+		It("returns error if lock is no longer held", func() {
+			Expect(lock.Acquire()).To(Succeed())
+
+			writeLock(StateLockInfo{Holder: "other@host", ID: "other-id"})
+
+			err := lock.Renew()
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(Equal("Expected lock for deployment state 'fake://state' to be held by 'me@host'"))
+		})
+	})
+
+	Describe("Release", func() {
+		It("deletes lock", func() {
+			Expect(lock.Acquire()).To(Succeed())
+			Expect(lock.Release()).To(Succeed())
+			Expect(backend.Objects).ToNot(HaveKey("state.json.lock"))
+		})
+
+		It("does not delete lock held by someone else", func() {
+			writeLock(StateLockInfo{Holder: "other@host", ID: "other-id"})
+
+			Expect(lock.Release()).To(Succeed())
+			Expect(readLock().ID).To(Equal("other-id"))
+		})
+	})
+
+	Describe("Hold", func() {
+		It("holds lock while running function", func() {
+			err := lock.Hold(func() error {
+				Expect(readLock().ID).To(Equal("my-id"))
+				return nil
+			})
+			Expect(err).ToNot(HaveOccurred())
+			Expect(backend.Objects).ToNot(HaveKey("state.json.lock"))
+		})
+
+		It("renews lock periodically", func() {
+			err := lock.Hold(func() error {
+				fakeClock.WaitForWatcherAndIncrement(30 * time.Second)
+				Eventually(func() time.Time { return readLock().ExpiresAt }).Should(Equal(now.Add(90 * time.Second)))
+				return nil
+			})
+			Expect(err).ToNot(HaveOccurred())
+		})
+
+		It("returns error and reports lost lock if lock could not be renewed", func() {
+			err := lock.Hold(func() error {
+				writeLock(StateLockInfo{Holder: "other@host", ID: "other-id"})
+
+				fakeClock.WaitForWatcherAndIncrement(30 * time.Second)
+				Eventually(lock.Err).Should(HaveOccurred())
+				return nil
+			})
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("Lost lock for deployment state"))
+			Expect(readLock().ID).To(Equal("other-id"))
+		})
+
+		It("returns function error and releases lock", func() {
+			err := lock.Hold(func() error { return errors.New("fake-err") })
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(Equal("fake-err"))
+			Expect(backend.Objects).ToNot(HaveKey("state.json.lock"))
+		})
+
+		It("does not run function if lock cannot be acquired", func() {
+			writeLock(StateLockInfo{Holder: "other@host", ID: "other-id", ExpiresAt: now.Add(time.Second)})
+
+			called := false
+
+			err := lock.Hold(func() error { called = true; return nil })
+			Expect(err).To(HaveOccurred())
+			Expect(called).To(BeFalse())
+		})
+	})
+})
@@ -0,0 +1,160 @@
+package crypto
+
+import (
+	"bytes"
+	"crypto/rand"
+	"encoding/base64"
+	"io"
+	"sync"
+
+	bosherr "github.com/cloudfoundry/bosh-utils/errors"
+	"golang.org/x/crypto/nacl/secretbox"
+	"golang.org/x/crypto/scrypt"
+)
+
+// encryptedHeader prefixes encrypted contents so that files
+// can be recognized and transparently decrypted on load.
+const encryptedHeader = "bosh-encrypted:v1:scrypt-secretbox:"
+
+const (
+	saltSize  = 16
+	nonceSize = 24
+	keySize   = 32
+
+	scryptN = 1 << 15
+	scryptR = 8
+	scryptP = 1
+)
+
+type Encryptor interface {
+	Encrypt([]byte) ([]byte, error)
+	Decrypt([]byte) ([]byte, error)
+}
+
+type secretboxEncryptor struct {
+	keyMaterial []byte
+
+	// keys caches derived keys by salt since scrypt is deliberately slow
+	// and the same file may be saved many times during a single command.
+	keys     map[string]*[keySize]byte
+	saveSalt []byte
+	keysLock sync.Mutex
+}
+
+// NewEncryptor returns a NaCl secretbox based Encryptor.
+// Encryption key is derived from given key material (passphrase or key file contents)
+// with scrypt using a random salt that is stored with the encrypted contents.
+func NewEncryptor(keyMaterial []byte) Encryptor {
+	return &secretboxEncryptor{
+		keyMaterial: bytes.TrimSpace(keyMaterial),
+		keys:        map[string]*[keySize]byte{},
+	}
+}
+
+// IsEncrypted returns true if contents were produced by an Encryptor.
+func IsEncrypted(contents []byte) bool {
+	return bytes.HasPrefix(contents, []byte(encryptedHeader))
+}
+
+func (e *secretboxEncryptor) Encrypt(plaintext []byte) ([]byte, error) {
+	salt, key, err := e.saveKey()
+	if err != nil {
+		return nil, err
+	}
+
+	var nonce [nonceSize]byte
+
+	_, err = io.ReadFull(rand.Reader, nonce[:])
+	if err != nil {
+		return nil, bosherr.WrapError(err, "Generating nonce")
+	}
+
+	sealed := append([]byte{}, salt...)
+	sealed = append(sealed, nonce[:]...)
+	sealed = secretbox.Seal(sealed, plaintext, &nonce, key)
+
+	encoded := base64.StdEncoding.EncodeToString(sealed)
+
+	return []byte(encryptedHeader + encoded + "\n"), nil
+}
+
+func (e *secretboxEncryptor) Decrypt(contents []byte) ([]byte, error) {
+	if !IsEncrypted(contents) {
+		return nil, bosherr.Error("Expected contents to be encrypted")
+	}
+
+	encoded := bytes.TrimSpace(bytes.TrimPrefix(contents, []byte(encryptedHeader)))
+
+	sealed, err := base64.StdEncoding.DecodeString(string(encoded))
+	if err != nil {
+		return nil, bosherr.WrapError(err, "Decoding encrypted contents")
+	}
+
+	if len(sealed) < saltSize+nonceSize+secretbox.Overhead {
+		return nil, bosherr.Error("Expected encrypted contents to include salt and nonce")
+	}
+
+	salt, sealed := sealed[:saltSize], sealed[saltSize:]
+
+	var nonce [nonceSize]byte
+	copy(nonce[:], sealed[:nonceSize])
+
+	key, err := e.key(salt)
+	if err != nil {
+		return nil, err
+	}
+
+	plaintext, ok := secretbox.Open(nil, sealed[nonceSize:], &nonce, key)
+	if !ok {
+		return nil, bosherr.Error("Decrypting contents (is the encryption key correct?)")
+	}
+
+	return plaintext, nil
+}
+
+func (e *secretboxEncryptor) saveKey() ([]byte, *[keySize]byte, error) {
+	e.keysLock.Lock()
+	salt := e.saveSalt
+	e.keysLock.Unlock()
+
+	if salt == nil {
+		salt = make([]byte, saltSize)
+
+		_, err := io.ReadFull(rand.Reader, salt)
+		if err != nil {
+			return nil, nil, bosherr.WrapError(err, "Generating salt")
+		}
+	}
+
+	key, err := e.key(salt)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	e.keysLock.Lock()
+	e.saveSalt = salt
+	e.keysLock.Unlock()
+
+	return salt, key, nil
+}
+
+func (e *secretboxEncryptor) key(salt []byte) (*[keySize]byte, error) {
+	e.keysLock.Lock()
+	defer e.keysLock.Unlock()
+
+	if key, found := e.keys[string(salt)]; found {
+		return key, nil
+	}
+
+	derived, err := scrypt.Key(e.keyMaterial, salt, scryptN, scryptR, scryptP, keySize)
+	if err != nil {
+		return nil, bosherr.WrapError(err, "Deriving encryption key")
+	}
+
+	var key [keySize]byte
+	copy(key[:], derived)
+
+	e.keys[string(salt)] = &key
+
+	return &key, nil
+}
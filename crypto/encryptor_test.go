@@ -0,0 +1,87 @@
+package crypto_test
+
+import (
+	"strings"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	. "github.com/cloudfoundry/bosh-cli/crypto"
+)
+
+var _ = Describe("Encryptor", func() {
+	var (
+		encryptor Encryptor
+	)
+
+	BeforeEach(func() {
+		encryptor = NewEncryptor([]byte("some-key\n"))
+	})
+
+	It("encrypts contents so that they can be decrypted with the same key", func() {
+		encrypted, err := encryptor.Encrypt([]byte("secret: value"))
+		Expect(err).ToNot(HaveOccurred())
+		Expect(string(encrypted)).ToNot(ContainSubstring("secret: value"))
+		Expect(IsEncrypted(encrypted)).To(BeTrue())
+
+		decrypted, err := NewEncryptor([]byte("some-key")).Decrypt(encrypted)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(string(decrypted)).To(Equal("secret: value"))
+	})
+
+	It("uses random nonce for every encryption", func() {
+		encrypted1, err := encryptor.Encrypt([]byte("contents"))
+		Expect(err).ToNot(HaveOccurred())
+
+		encrypted2, err := encryptor.Encrypt([]byte("contents"))
+		Expect(err).ToNot(HaveOccurred())
+
+		Expect(encrypted1).ToNot(Equal(encrypted2))
+	})
+
+	It("derives key with a random salt for every encryptor", func() {
+		encrypted1, err := NewEncryptor([]byte("some-key")).Encrypt([]byte("contents"))
+		Expect(err).ToNot(HaveOccurred())
+
+		encrypted2, err := NewEncryptor([]byte("some-key")).Encrypt([]byte("contents"))
+		Expect(err).ToNot(HaveOccurred())
+
+		header := len("bosh-encrypted:v1:scrypt-secretbox:")
+		Expect(encrypted1[header : header+20]).ToNot(Equal(encrypted2[header : header+20]))
+
+		decrypted, err := NewEncryptor([]byte("some-key")).Decrypt(encrypted2)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(string(decrypted)).To(Equal("contents"))
+	})
+
+	It("returns error when decrypting with a different key", func() {
+		encrypted, err := encryptor.Encrypt([]byte("contents"))
+		Expect(err).ToNot(HaveOccurred())
+
+		_, err = NewEncryptor([]byte("other-key")).Decrypt(encrypted)
+		Expect(err).To(HaveOccurred())
+		Expect(err.Error()).To(ContainSubstring("is the encryption key correct?"))
+	})
+
+	It("returns error when decrypting contents that are not encrypted", func() {
+		_, err := encryptor.Decrypt([]byte("plain: text"))
+		Expect(err).To(HaveOccurred())
+		Expect(err.Error()).To(Equal("Expected contents to be encrypted"))
+	})
+
+	It("returns error when decrypting tampered contents", func() {
+		encrypted, err := encryptor.Encrypt([]byte("contents"))
+		Expect(err).ToNot(HaveOccurred())
+
+		tampered := strings.Replace(string(encrypted), "scrypt-secretbox:", "scrypt-secretbox:AAAA", 1)
+
+		_, err = encryptor.Decrypt([]byte(tampered))
+		Expect(err).To(HaveOccurred())
+	})
+
+	Describe("IsEncrypted", func() {
+		It("returns false for plain contents", func() {
+			Expect(IsEncrypted([]byte("{}"))).To(BeFalse())
+		})
+	})
+})
@@ -5,6 +5,7 @@ import (
 
 	biblobstore "github.com/cloudfoundry/bosh-cli/blobstore"
 	bicloud "github.com/cloudfoundry/bosh-cli/cloud"
+	biconfig "github.com/cloudfoundry/bosh-cli/config"
 	bidisk "github.com/cloudfoundry/bosh-cli/deployment/disk"
 	biinstance "github.com/cloudfoundry/bosh-cli/deployment/instance"
 	bideplmanifest "github.com/cloudfoundry/bosh-cli/deployment/manifest"
@@ -20,11 +21,13 @@ type Deployer interface {
 	Deploy(
 		bicloud.Cloud,
 		bideplmanifest.Manifest,
+		string,
 		bistemcell.CloudStemcell,
 		biinstallmanifest.Registry,
 		bivm.Manager,
 		biblobstore.Blobstore,
 		bool,
+		bool,
 		biui.Stage,
 	) (Deployment, error)
 }
@@ -33,6 +36,7 @@ type deployer struct {
 	vmManagerFactory       bivm.ManagerFactory
 	instanceManagerFactory biinstance.ManagerFactory
 	deploymentFactory      Factory
+	checkpointRepo         biconfig.CheckpointRepo
 	logger                 boshlog.Logger
 	logTag                 string
 }
@@ -41,36 +45,52 @@ func NewDeployer(
 	vmManagerFactory bivm.ManagerFactory,
 	instanceManagerFactory biinstance.ManagerFactory,
 	deploymentFactory Factory,
+	checkpointRepo biconfig.CheckpointRepo,
 	logger boshlog.Logger,
 ) Deployer {
 	return &deployer{
 		vmManagerFactory:       vmManagerFactory,
 		instanceManagerFactory: instanceManagerFactory,
 		deploymentFactory:      deploymentFactory,
+		checkpointRepo:         checkpointRepo,
 		logger:                 logger,
 		logTag:                 "deployer",
 	}
 }
 
+// Deploy resumes previously failed deploy of the same manifest and stemcell
+// from its last completed checkpoint if its VM still exists,
+// otherwise it deletes existing instances and creates new ones.
 func (d *deployer) Deploy(
 	cloud bicloud.Cloud,
 	deploymentManifest bideplmanifest.Manifest,
+	manifestSHA string,
 	cloudStemcell bistemcell.CloudStemcell,
 	registryConfig biinstallmanifest.Registry,
 	vmManager bivm.Manager,
 	blobstore biblobstore.Blobstore,
 	skipDrain bool,
+	recreate bool,
 	deployStage biui.Stage,
 ) (Deployment, error) {
 	instanceManager := d.instanceManagerFactory.NewManager(cloud, vmManager, blobstore)
 
-	pingTimeout := 10 * time.Second
-	pingDelay := 500 * time.Millisecond
-	if err := instanceManager.DeleteAll(pingTimeout, pingDelay, skipDrain, deployStage); err != nil {
+	checkpoint, resumeVM, err := d.findResumable(manifestSHA, cloudStemcell, vmManager, recreate)
+	if err != nil {
 		return nil, err
 	}
 
-	instances, disks, err := d.createAllInstances(deploymentManifest, instanceManager, cloudStemcell, registryConfig, deployStage)
+	if resumeVM == nil {
+		pingTimeout := 10 * time.Second
+		pingDelay := 500 * time.Millisecond
+		if err := instanceManager.DeleteAll(pingTimeout, pingDelay, skipDrain, deployStage); err != nil {
+			return nil, err
+		}
+
+		checkpoint = biconfig.CheckpointRecord{ManifestSHA: manifestSHA, StemcellCID: cloudStemcell.CID()}
+	}
+
+	instances, disks, err := d.createAllInstances(deploymentManifest, instanceManager, cloudStemcell, registryConfig, checkpoint, resumeVM, deployStage)
 	if err != nil {
 		return nil, err
 	}
@@ -79,11 +99,71 @@ func (d *deployer) Deploy(
 	return d.deploymentFactory.NewDeployment(instances, disks, stemcells), nil
 }
 
+func (d *deployer) findResumable(
+	manifestSHA string,
+	cloudStemcell bistemcell.CloudStemcell,
+	vmManager bivm.Manager,
+	recreate bool,
+) (biconfig.CheckpointRecord, bivm.VM, error) {
+	if recreate {
+		return biconfig.CheckpointRecord{}, nil, nil
+	}
+
+	checkpoint, found, err := d.checkpointRepo.FindCurrent()
+	if err != nil {
+		return checkpoint, nil, bosherr.WrapError(err, "Finding deploy checkpoint")
+	}
+
+	if !found || !checkpoint.Reached(biconfig.CheckpointVMCreated) {
+		return checkpoint, nil, nil
+	}
+
+	if checkpoint.ManifestSHA != manifestSHA || checkpoint.StemcellCID != cloudStemcell.CID() {
+		d.logger.Info(d.logTag, "Not resuming deploy: manifest or stemcell changed since checkpoint")
+		return checkpoint, nil, nil
+	}
+
+	vm, found, err := vmManager.FindCurrent()
+	if err != nil {
+		return checkpoint, nil, bosherr.WrapError(err, "Finding current VM")
+	}
+
+	if !found || vm.CID() != checkpoint.VMCID {
+		d.logger.Info(d.logTag, "Not resuming deploy: VM '%s' is no longer current", checkpoint.VMCID)
+		return checkpoint, nil, nil
+	}
+
+	exists, err := vm.Exists()
+	if err != nil {
+		return checkpoint, nil, err
+	}
+
+	if !exists {
+		d.logger.Info(d.logTag, "Not resuming deploy: VM '%s' no longer exists", checkpoint.VMCID)
+		return checkpoint, nil, nil
+	}
+
+	// Disks left attached by interrupted disk migration cannot be reconciled
+	// on the same VM. Disks cannot be listed when agent never became ready,
+	// in which case there is nothing to reconcile.
+	if !checkpoint.Reached(biconfig.CheckpointDiskAttached) && !checkpoint.Reached(biconfig.CheckpointDiskMigrated) {
+		disks, err := vm.Disks()
+		if err == nil && len(disks) > 1 {
+			d.logger.Info(d.logTag, "Not resuming deploy: disk migration on VM '%s' was interrupted", checkpoint.VMCID)
+			return checkpoint, nil, nil
+		}
+	}
+
+	return checkpoint, vm, nil
+}
+
 func (d *deployer) createAllInstances(
 	deploymentManifest bideplmanifest.Manifest,
 	instanceManager biinstance.Manager,
 	cloudStemcell bistemcell.CloudStemcell,
 	registryConfig biinstallmanifest.Registry,
+	checkpoint biconfig.CheckpointRecord,
+	resumeVM bivm.VM,
 	deployStage biui.Stage,
 ) ([]biinstance.Instance, []bidisk.Disk, error) {
 	instances := []biinstance.Instance{}
@@ -98,19 +178,105 @@ func (d *deployer) createAllInstances(
 			return instances, disks, bosherr.Errorf("Job '%s' must have only one instance, found %d", jobSpec.Name, jobSpec.Instances)
 		}
 		for instanceID := 0; instanceID < jobSpec.Instances; instanceID++ {
-			instance, instanceDisks, err := instanceManager.Create(jobSpec.Name, instanceID, deploymentManifest, cloudStemcell, registryConfig, deployStage)
+			instance, instanceDisks, err := d.createInstance(jobSpec.Name, instanceID, deploymentManifest, instanceManager, cloudStemcell, registryConfig, checkpoint, resumeVM, deployStage)
 			if err != nil {
 				return instances, disks, bosherr.WrapErrorf(err, "Creating instance '%s/%d'", jobSpec.Name, instanceID)
 			}
 			instances = append(instances, instance)
 			disks = append(disks, instanceDisks...)
 
+			if checkpoint.Reached(biconfig.CheckpointJobsApplied) {
+				d.logger.Info(d.logTag, "Skipping job update: jobs were already applied")
+				continue
+			}
+
 			err = instance.UpdateJobs(deploymentManifest, deployStage)
 			if err != nil {
 				return instances, disks, err
 			}
+
+			err = d.checkpointRepo.Complete(biconfig.CheckpointJobsApplied)
+			if err != nil {
+				return instances, disks, bosherr.WrapError(err, "Recording jobs applied checkpoint")
+			}
 		}
 	}
 
 	return instances, disks, nil
 }
+
+func (d *deployer) createInstance(
+	jobName string,
+	instanceID int,
+	deploymentManifest bideplmanifest.Manifest,
+	instanceManager biinstance.Manager,
+	cloudStemcell bistemcell.CloudStemcell,
+	registryConfig biinstallmanifest.Registry,
+	checkpoint biconfig.CheckpointRecord,
+	resumeVM bivm.VM,
+	deployStage biui.Stage,
+) (biinstance.Instance, []bidisk.Disk, error) {
+	var instance biinstance.Instance
+
+	if resumeVM != nil {
+		d.logger.Info(d.logTag, "Resuming deploy on VM '%s' after checkpoints %v", resumeVM.CID(), checkpoint.Completed)
+		instance = instanceManager.ForVM(jobName, instanceID, resumeVM)
+	} else {
+		var err error
+
+		instance, err = instanceManager.CreateVM(jobName, instanceID, deploymentManifest, cloudStemcell, deployStage)
+		if err != nil {
+			return nil, []bidisk.Disk{}, err
+		}
+
+		checkpoint.VMCID = instance.VMCID()
+		checkpoint.Completed = []string{biconfig.CheckpointVMCreated}
+
+		err = d.checkpointRepo.UpdateCurrent(checkpoint)
+		if err != nil {
+			return instance, []bidisk.Disk{}, bosherr.WrapError(err, "Recording VM created checkpoint")
+		}
+	}
+
+	if err := instance.WaitUntilReady(registryConfig, deployStage); err != nil {
+		return instance, []bidisk.Disk{}, bosherr.WrapError(err, "Waiting until instance is ready")
+	}
+
+	if resumeVM != nil {
+		disks, attached, err := d.attachedCheckpointDisks(instance, checkpoint)
+		if err != nil {
+			return instance, disks, err
+		}
+
+		if attached {
+			d.logger.Info(d.logTag, "Skipping disk update: disk '%s' is already attached", checkpoint.DiskCID)
+			return instance, disks, nil
+		}
+	}
+
+	disks, err := instance.UpdateDisks(deploymentManifest, deployStage)
+	if err != nil {
+		return instance, disks, bosherr.WrapError(err, "Updating instance disks")
+	}
+
+	return instance, disks, nil
+}
+
+// attachedCheckpointDisks verifies that disk recorded by the checkpoint
+// is the only disk attached to the instance.
+func (d *deployer) attachedCheckpointDisks(instance biinstance.Instance, checkpoint biconfig.CheckpointRecord) ([]bidisk.Disk, bool, error) {
+	if !checkpoint.Reached(biconfig.CheckpointDiskAttached) && !checkpoint.Reached(biconfig.CheckpointDiskMigrated) {
+		return []bidisk.Disk{}, false, nil
+	}
+
+	disks, err := instance.Disks()
+	if err != nil {
+		return []bidisk.Disk{}, false, err
+	}
+
+	if len(disks) != 1 || disks[0].CID() != checkpoint.DiskCID {
+		return []bidisk.Disk{}, false, nil
+	}
+
+	return disks, true, nil
+}
@@ -29,6 +29,8 @@ import (
 	"github.com/cloudfoundry/bosh-agent/agentclient"
 	fakebicloud "github.com/cloudfoundry/bosh-cli/cloud/fakes"
 	fakebiconfig "github.com/cloudfoundry/bosh-cli/config/fakes"
+	bidisk "github.com/cloudfoundry/bosh-cli/deployment/disk"
+	fakebidisk "github.com/cloudfoundry/bosh-cli/deployment/disk/fakes"
 	fakebisshtunnel "github.com/cloudfoundry/bosh-cli/deployment/sshtunnel/fakes"
 	fakebivm "github.com/cloudfoundry/bosh-cli/deployment/vm/fakes"
 	fakebiui "github.com/cloudfoundry/bosh-cli/ui/fakes"
@@ -59,7 +61,9 @@ var _ = Describe("Deployer", func() {
 		registryConfig         biinstallmanifest.Registry
 		fakeStage              *fakebiui.FakeStage
 		fakeVM                 *fakebivm.FakeVM
+		fakeCheckpointRepo     *fakebiconfig.FakeCheckpointRepo
 		skipDrain              bool
+		recreate               bool
 
 		cloudStemcell bistemcell.CloudStemcell
 
@@ -109,6 +113,7 @@ var _ = Describe("Deployer", func() {
 		}
 
 		skipDrain = false
+		recreate = false
 		cloud = fakebicloud.NewFakeCloud()
 
 		mockAgentClientFactory = mock_httpagent.NewMockAgentClientFactory(mockCtrl)
@@ -157,10 +162,13 @@ var _ = Describe("Deployer", func() {
 		pingDelay := 500 * time.Millisecond
 		deploymentFactory := NewFactory(pingTimeout, pingDelay)
 
+		fakeCheckpointRepo = fakebiconfig.NewFakeCheckpointRepo()
+
 		deployer = NewDeployer(
 			mockVMManagerFactory,
 			instanceManagerFactory,
 			deploymentFactory,
+			fakeCheckpointRepo,
 			logger,
 		)
 	})
@@ -193,7 +201,7 @@ var _ = Describe("Deployer", func() {
 		})
 
 		It("deletes existing vm", func() {
-			_, err := deployer.Deploy(cloud, deploymentManifest, cloudStemcell, registryConfig, fakeVMManager, mockBlobstore, skipDrain, fakeStage)
+			_, err := deployer.Deploy(cloud, deploymentManifest, "fake-manifest-sha", cloudStemcell, registryConfig, fakeVMManager, mockBlobstore, skipDrain, recreate, fakeStage)
 			Expect(err).NotTo(HaveOccurred())
 
 			Expect(fakeExistingVM.DeleteCalled).To(Equal(1))
@@ -211,7 +219,7 @@ var _ = Describe("Deployer", func() {
 		Context("when skip-drain is specified", func() {
 			It("skips draining", func() {
 				skipDrain = true
-				_, err := deployer.Deploy(cloud, deploymentManifest, cloudStemcell, registryConfig, fakeVMManager, mockBlobstore, skipDrain, fakeStage)
+				_, err := deployer.Deploy(cloud, deploymentManifest, "fake-manifest-sha", cloudStemcell, registryConfig, fakeVMManager, mockBlobstore, skipDrain, recreate, fakeStage)
 				Expect(err).NotTo(HaveOccurred())
 
 				Expect(fakeExistingVM.DeleteCalled).To(Equal(1))
@@ -227,8 +235,123 @@ var _ = Describe("Deployer", func() {
 		})
 	})
 
+	Context("when previous deploy of the same manifest was interrupted", func() {
+		var fakeExistingVM *fakebivm.FakeVM
+
+		BeforeEach(func() {
+			fakeExistingVM = fakebivm.NewFakeVM("existing-vm-cid")
+			fakeExistingVM.AgentClientReturn = mockAgentClient
+			fakeVMManager.SetFindCurrentBehavior(fakeExistingVM, true, nil)
+
+			fakeCheckpointRepo.Record = &biconfig.CheckpointRecord{
+				ManifestSHA: "fake-manifest-sha",
+				StemcellCID: "fake-stemcell-cid",
+				VMCID:       "existing-vm-cid",
+				Completed:   []string{biconfig.CheckpointVMCreated},
+			}
+		})
+
+		It("resumes on existing vm instead of recreating it", func() {
+			_, err := deployer.Deploy(cloud, deploymentManifest, "fake-manifest-sha", cloudStemcell, registryConfig, fakeVMManager, mockBlobstore, skipDrain, recreate, fakeStage)
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(fakeExistingVM.ExistsCalled).To(Equal(1))
+			Expect(fakeExistingVM.DeleteCalled).To(Equal(0))
+			Expect(fakeVMManager.CreateInput).To(Equal(fakebivm.CreateInput{}))
+
+			Expect(fakeExistingVM.UpdateDisksInputs).To(HaveLen(1))
+			Expect(fakeExistingVM.ApplyInputs).To(HaveLen(2))
+
+			Expect(fakeCheckpointRepo.Record.Completed).To(Equal([]string{
+				biconfig.CheckpointVMCreated,
+				biconfig.CheckpointJobsApplied,
+			}))
+		})
+
+		Context("when checkpoint disk is attached", func() {
+			BeforeEach(func() {
+				fakeCheckpointRepo.Record.DiskCID = "fake-disk-cid"
+				fakeCheckpointRepo.Record.Completed = append(fakeCheckpointRepo.Record.Completed, biconfig.CheckpointDiskAttached)
+				fakeExistingVM.ListDisksDisks = []bidisk.Disk{fakebidisk.NewFakeDisk("fake-disk-cid")}
+			})
+
+			It("does not update disks", func() {
+				_, err := deployer.Deploy(cloud, deploymentManifest, "fake-manifest-sha", cloudStemcell, registryConfig, fakeVMManager, mockBlobstore, skipDrain, recreate, fakeStage)
+				Expect(err).NotTo(HaveOccurred())
+
+				Expect(fakeExistingVM.UpdateDisksInputs).To(BeEmpty())
+				Expect(fakeExistingVM.ApplyInputs).To(HaveLen(2))
+			})
+
+			It("updates disks if different disk is attached", func() {
+				fakeExistingVM.ListDisksDisks = []bidisk.Disk{fakebidisk.NewFakeDisk("other-disk-cid")}
+
+				_, err := deployer.Deploy(cloud, deploymentManifest, "fake-manifest-sha", cloudStemcell, registryConfig, fakeVMManager, mockBlobstore, skipDrain, recreate, fakeStage)
+				Expect(err).NotTo(HaveOccurred())
+
+				Expect(fakeExistingVM.UpdateDisksInputs).To(HaveLen(1))
+			})
+		})
+
+		Context("when jobs were already applied", func() {
+			BeforeEach(func() {
+				fakeCheckpointRepo.Record.Completed = append(fakeCheckpointRepo.Record.Completed, biconfig.CheckpointJobsApplied)
+			})
+
+			It("does not update jobs", func() {
+				_, err := deployer.Deploy(cloud, deploymentManifest, "fake-manifest-sha", cloudStemcell, registryConfig, fakeVMManager, mockBlobstore, skipDrain, recreate, fakeStage)
+				Expect(err).NotTo(HaveOccurred())
+
+				Expect(fakeExistingVM.ApplyInputs).To(BeEmpty())
+			})
+		})
+
+		It("recreates vm if it no longer exists", func() {
+			fakeExistingVM.ExistsFound = false
+
+			_, err := deployer.Deploy(cloud, deploymentManifest, "fake-manifest-sha", cloudStemcell, registryConfig, fakeVMManager, mockBlobstore, skipDrain, recreate, fakeStage)
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(fakeVMManager.CreateInput.Manifest).To(Equal(deploymentManifest))
+			Expect(fakeCheckpointRepo.Record.VMCID).To(Equal("fake-vm-cid"))
+		})
+
+		It("recreates vm if manifest changed", func() {
+			_, err := deployer.Deploy(cloud, deploymentManifest, "new-manifest-sha", cloudStemcell, registryConfig, fakeVMManager, mockBlobstore, skipDrain, recreate, fakeStage)
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(fakeExistingVM.DeleteCalled).To(Equal(1))
+			Expect(fakeVMManager.CreateInput.Manifest).To(Equal(deploymentManifest))
+			Expect(fakeCheckpointRepo.Record.ManifestSHA).To(Equal("new-manifest-sha"))
+		})
+
+		It("recreates vm if recreate is requested", func() {
+			recreate = true
+
+			_, err := deployer.Deploy(cloud, deploymentManifest, "fake-manifest-sha", cloudStemcell, registryConfig, fakeVMManager, mockBlobstore, skipDrain, recreate, fakeStage)
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(fakeExistingVM.DeleteCalled).To(Equal(1))
+			Expect(fakeVMManager.CreateInput.Manifest).To(Equal(deploymentManifest))
+		})
+	})
+
+	It("records checkpoints while deploying", func() {
+		fakeVM.UpdateDisksErr = bosherr.Error("fake-update-disks-error")
+
+		_, err := deployer.Deploy(cloud, deploymentManifest, "fake-manifest-sha", cloudStemcell, registryConfig, fakeVMManager, mockBlobstore, skipDrain, recreate, fakeStage)
+		Expect(err).To(HaveOccurred())
+
+		Expect(fakeCheckpointRepo.Record).To(Equal(&biconfig.CheckpointRecord{
+			ManifestSHA: "fake-manifest-sha",
+			StemcellCID: "fake-stemcell-cid",
+			VMCID:       "fake-vm-cid",
+			Completed:   []string{biconfig.CheckpointVMCreated},
+		}))
+	})
+
 	It("creates a vm", func() {
-		_, err := deployer.Deploy(cloud, deploymentManifest, cloudStemcell, registryConfig, fakeVMManager, mockBlobstore, skipDrain, fakeStage)
+		_, err := deployer.Deploy(cloud, deploymentManifest, "fake-manifest-sha", cloudStemcell, registryConfig, fakeVMManager, mockBlobstore, skipDrain, recreate, fakeStage)
 		Expect(err).NotTo(HaveOccurred())
 
 		Expect(fakeVMManager.CreateInput).To(Equal(fakebivm.CreateInput{
@@ -255,7 +378,7 @@ var _ = Describe("Deployer", func() {
 		})
 
 		It("starts the SSH tunnel", func() {
-			_, err := deployer.Deploy(cloud, deploymentManifest, cloudStemcell, registryConfig, fakeVMManager, mockBlobstore, skipDrain, fakeStage)
+			_, err := deployer.Deploy(cloud, deploymentManifest, "fake-manifest-sha", cloudStemcell, registryConfig, fakeVMManager, mockBlobstore, skipDrain, recreate, fakeStage)
 			Expect(err).NotTo(HaveOccurred())
 			Expect(fakeSSHTunnel.Started).To(BeTrue())
 			Expect(fakeSSHTunnelFactory.NewSSHTunnelOptions).To(Equal(bisshtunnel.Options{
@@ -275,7 +398,7 @@ var _ = Describe("Deployer", func() {
 			})
 
 			It("returns an error", func() {
-				_, err := deployer.Deploy(cloud, deploymentManifest, cloudStemcell, registryConfig, fakeVMManager, mockBlobstore, skipDrain, fakeStage)
+				_, err := deployer.Deploy(cloud, deploymentManifest, "fake-manifest-sha", cloudStemcell, registryConfig, fakeVMManager, mockBlobstore, skipDrain, recreate, fakeStage)
 				Expect(err).To(HaveOccurred())
 				Expect(err.Error()).To(ContainSubstring("fake-ssh-tunnel-start-error"))
 			})
@@ -283,7 +406,7 @@ var _ = Describe("Deployer", func() {
 	})
 
 	It("waits for the vm", func() {
-		_, err := deployer.Deploy(cloud, deploymentManifest, cloudStemcell, registryConfig, fakeVMManager, mockBlobstore, skipDrain, fakeStage)
+		_, err := deployer.Deploy(cloud, deploymentManifest, "fake-manifest-sha", cloudStemcell, registryConfig, fakeVMManager, mockBlobstore, skipDrain, recreate, fakeStage)
 		Expect(err).NotTo(HaveOccurred())
 		Expect(fakeVM.WaitUntilReadyInputs).To(ContainElement(fakebivm.WaitUntilReadyInput{
 			Timeout: 10 * time.Minute,
@@ -292,7 +415,7 @@ var _ = Describe("Deployer", func() {
 	})
 
 	It("logs start and stop events to the eventLogger", func() {
-		_, err := deployer.Deploy(cloud, deploymentManifest, cloudStemcell, registryConfig, fakeVMManager, mockBlobstore, skipDrain, fakeStage)
+		_, err := deployer.Deploy(cloud, deploymentManifest, "fake-manifest-sha", cloudStemcell, registryConfig, fakeVMManager, mockBlobstore, skipDrain, recreate, fakeStage)
 		Expect(err).NotTo(HaveOccurred())
 
 		Expect(fakeStage.PerformCalls[1]).To(Equal(&fakebiui.PerformCall{
@@ -310,7 +433,7 @@ var _ = Describe("Deployer", func() {
 		})
 
 		It("logs start and stop events to the eventLogger", func() {
-			_, err := deployer.Deploy(cloud, deploymentManifest, cloudStemcell, registryConfig, fakeVMManager, mockBlobstore, skipDrain, fakeStage)
+			_, err := deployer.Deploy(cloud, deploymentManifest, "fake-manifest-sha", cloudStemcell, registryConfig, fakeVMManager, mockBlobstore, skipDrain, recreate, fakeStage)
 			Expect(err).To(HaveOccurred())
 			Expect(err.Error()).To(ContainSubstring("fake-wait-error"))
 
@@ -322,7 +445,7 @@ var _ = Describe("Deployer", func() {
 	})
 
 	It("updates the vm", func() {
-		_, err := deployer.Deploy(cloud, deploymentManifest, cloudStemcell, registryConfig, fakeVMManager, mockBlobstore, skipDrain, fakeStage)
+		_, err := deployer.Deploy(cloud, deploymentManifest, "fake-manifest-sha", cloudStemcell, registryConfig, fakeVMManager, mockBlobstore, skipDrain, recreate, fakeStage)
 		Expect(err).NotTo(HaveOccurred())
 
 		Expect(fakeVM.ApplyInputs).To(Equal([]fakebivm.ApplyInput{
@@ -332,14 +455,14 @@ var _ = Describe("Deployer", func() {
 	})
 
 	It("starts the agent", func() {
-		_, err := deployer.Deploy(cloud, deploymentManifest, cloudStemcell, registryConfig, fakeVMManager, mockBlobstore, skipDrain, fakeStage)
+		_, err := deployer.Deploy(cloud, deploymentManifest, "fake-manifest-sha", cloudStemcell, registryConfig, fakeVMManager, mockBlobstore, skipDrain, recreate, fakeStage)
 		Expect(err).NotTo(HaveOccurred())
 
 		Expect(fakeVM.StartCalled).To(Equal(1))
 	})
 
 	It("waits until agent reports state as running", func() {
-		_, err := deployer.Deploy(cloud, deploymentManifest, cloudStemcell, registryConfig, fakeVMManager, mockBlobstore, skipDrain, fakeStage)
+		_, err := deployer.Deploy(cloud, deploymentManifest, "fake-manifest-sha", cloudStemcell, registryConfig, fakeVMManager, mockBlobstore, skipDrain, recreate, fakeStage)
 		Expect(err).NotTo(HaveOccurred())
 
 		Expect(fakeVM.WaitToBeRunningInputs).To(ContainElement(fakebivm.WaitInput{
@@ -354,13 +477,13 @@ var _ = Describe("Deployer", func() {
 		})
 
 		It("returns an error", func() {
-			_, err := deployer.Deploy(cloud, deploymentManifest, cloudStemcell, registryConfig, fakeVMManager, mockBlobstore, skipDrain, fakeStage)
+			_, err := deployer.Deploy(cloud, deploymentManifest, "fake-manifest-sha", cloudStemcell, registryConfig, fakeVMManager, mockBlobstore, skipDrain, recreate, fakeStage)
 			Expect(err).To(HaveOccurred())
 		})
 	})
 
 	It("logs instance update ui stages", func() {
-		_, err := deployer.Deploy(cloud, deploymentManifest, cloudStemcell, registryConfig, fakeVMManager, mockBlobstore, skipDrain, fakeStage)
+		_, err := deployer.Deploy(cloud, deploymentManifest, "fake-manifest-sha", cloudStemcell, registryConfig, fakeVMManager, mockBlobstore, skipDrain, recreate, fakeStage)
 		Expect(err).NotTo(HaveOccurred())
 
 		Expect(fakeStage.PerformCalls[2:4]).To(Equal([]*fakebiui.PerformCall{
@@ -375,7 +498,7 @@ var _ = Describe("Deployer", func() {
 		})
 
 		It("fails with descriptive error", func() {
-			_, err := deployer.Deploy(cloud, deploymentManifest, cloudStemcell, registryConfig, fakeVMManager, mockBlobstore, skipDrain, fakeStage)
+			_, err := deployer.Deploy(cloud, deploymentManifest, "fake-manifest-sha", cloudStemcell, registryConfig, fakeVMManager, mockBlobstore, skipDrain, recreate, fakeStage)
 			Expect(err).To(HaveOccurred())
 			Expect(err.Error()).To(Equal("Applying the initial agent state: fake-apply-error"))
 		})
@@ -387,7 +510,7 @@ var _ = Describe("Deployer", func() {
 		})
 
 		It("logs start and stop events to the eventLogger", func() {
-			_, err := deployer.Deploy(cloud, deploymentManifest, cloudStemcell, registryConfig, fakeVMManager, mockBlobstore, skipDrain, fakeStage)
+			_, err := deployer.Deploy(cloud, deploymentManifest, "fake-manifest-sha", cloudStemcell, registryConfig, fakeVMManager, mockBlobstore, skipDrain, recreate, fakeStage)
 			Expect(err).To(HaveOccurred())
 			Expect(err.Error()).To(ContainSubstring("fake-start-error"))
 
@@ -407,7 +530,7 @@ var _ = Describe("Deployer", func() {
 		})
 
 		It("logs start and stop events to the eventLogger", func() {
-			_, err := deployer.Deploy(cloud, deploymentManifest, cloudStemcell, registryConfig, fakeVMManager, mockBlobstore, skipDrain, fakeStage)
+			_, err := deployer.Deploy(cloud, deploymentManifest, "fake-manifest-sha", cloudStemcell, registryConfig, fakeVMManager, mockBlobstore, skipDrain, recreate, fakeStage)
 			Expect(err).To(HaveOccurred())
 			Expect(err.Error()).To(ContainSubstring("fake-wait-running-error"))
 
@@ -120,7 +120,7 @@ var _ = Describe("Deployment", func() {
 	JustBeforeEach(func() {
 		// all these local factories & managers are just used to construct a Deployment based on the deployment state
 		diskManagerFactory := bidisk.NewManagerFactory(diskRepo, logger)
-		diskDeployer := bivm.NewDiskDeployer(diskManagerFactory, diskRepo, logger, false)
+		diskDeployer := bivm.NewDiskDeployer(diskManagerFactory, diskRepo, biconfig.NewCheckpointRepo(deploymentStateService), logger, false)
 
 		vmManagerFactory := bivm.NewManagerFactory(vmRepo, stemcellRepo, diskDeployer, fakeUUIDGenerator, fs, logger)
 		sshTunnelFactory := bisshtunnel.NewFactory(logger)
@@ -0,0 +1,323 @@
+package deployment
+
+import (
+	"fmt"
+
+	bosherr "github.com/cloudfoundry/bosh-utils/errors"
+	boshlog "github.com/cloudfoundry/bosh-utils/logger"
+
+	bicloud "github.com/cloudfoundry/bosh-cli/cloud"
+	biconfig "github.com/cloudfoundry/bosh-cli/config"
+	bidisk "github.com/cloudfoundry/bosh-cli/deployment/disk"
+	bivm "github.com/cloudfoundry/bosh-cli/deployment/vm"
+	biui "github.com/cloudfoundry/bosh-cli/ui"
+)
+
+const (
+	DriftMissingVM         = "missing-vm"
+	DriftUnresponsiveAgent = "unresponsive-agent"
+	DriftMissingDisk       = "missing-disk"
+	DriftOrphanedDisk      = "orphaned-disk"
+	DriftDetachedDisk      = "detached-disk"
+	DriftUnexpectedDisk    = "unexpected-disk"
+)
+
+// Drift describes difference between create-env state and the IaaS.
+type Drift struct {
+	Kind        string
+	CID         string
+	Description string
+	Repaired    bool
+}
+
+type DriftChecker interface {
+	Check() ([]Drift, error)
+
+	// Repair updates state or reattaches disks for drifts that can be repaired
+	// and marks them as repaired. Remaining drifts are returned unchanged.
+	Repair([]Drift, biui.Stage) ([]Drift, error)
+}
+
+type driftChecker struct {
+	cloud          bicloud.Cloud
+	vmManager      bivm.Manager
+	diskManager    bidisk.Manager
+	deploymentRepo biconfig.DeploymentRepo
+	vmRepo         biconfig.VMRepo
+	diskRepo       biconfig.DiskRepo
+	logger         boshlog.Logger
+	logTag         string
+}
+
+func NewDriftChecker(
+	cloud bicloud.Cloud,
+	vmManager bivm.Manager,
+	diskManager bidisk.Manager,
+	deploymentRepo biconfig.DeploymentRepo,
+	vmRepo biconfig.VMRepo,
+	diskRepo biconfig.DiskRepo,
+	logger boshlog.Logger,
+) DriftChecker {
+	return driftChecker{
+		cloud:          cloud,
+		vmManager:      vmManager,
+		diskManager:    diskManager,
+		deploymentRepo: deploymentRepo,
+		vmRepo:         vmRepo,
+		diskRepo:       diskRepo,
+		logger:         logger,
+		logTag:         "driftChecker",
+	}
+}
+
+func (c driftChecker) Check() ([]Drift, error) {
+	currentDisk, currentDiskFound, err := c.diskRepo.FindCurrent()
+	if err != nil {
+		return nil, bosherr.WrapError(err, "Finding current disk")
+	}
+
+	diskRecords, err := c.diskRepo.All()
+	if err != nil {
+		return nil, bosherr.WrapError(err, "Finding disks")
+	}
+
+	var diskDrifts []Drift
+
+	// Disks are assumed to exist when CPI cannot check for them
+	hasDiskImplemented := true
+
+	for _, diskRecord := range diskRecords {
+		isCurrent := currentDiskFound && diskRecord.ID == currentDisk.ID
+
+		found := true
+
+		if hasDiskImplemented {
+			found, err = c.cloud.HasDisk(diskRecord.CID)
+			if err != nil {
+				if !isNotImplemented(err) {
+					return nil, bosherr.WrapErrorf(err, "Checking if disk '%s' exists", diskRecord.CID)
+				}
+
+				c.logger.Warn(c.logTag, "'HasDisk' not implemented by CPI, skipping missing disks check")
+				hasDiskImplemented = false
+				found = true
+			}
+		}
+
+		if !found {
+			diskDrifts = append(diskDrifts, Drift{
+				Kind:        DriftMissingDisk,
+				CID:         diskRecord.CID,
+				Description: "Disk is recorded in state but does not exist",
+			})
+
+			if isCurrent {
+				currentDiskFound = false
+			}
+		} else if !isCurrent {
+			diskDrifts = append(diskDrifts, Drift{
+				Kind:        DriftOrphanedDisk,
+				CID:         diskRecord.CID,
+				Description: "Disk is not current disk and will be deleted by next create-env",
+			})
+		}
+	}
+
+	vmCID, vmFound, err := c.vmRepo.FindCurrent()
+	if err != nil {
+		return nil, bosherr.WrapError(err, "Finding current VM")
+	}
+
+	var vmDrifts []Drift
+
+	if vmFound {
+		vmDrifts, err = c.checkVM(vmCID, currentDisk, currentDiskFound)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return append(vmDrifts, diskDrifts...), nil
+}
+
+func (c driftChecker) checkVM(vmCID string, currentDisk biconfig.DiskRecord, currentDiskFound bool) ([]Drift, error) {
+	found, err := c.cloud.HasVM(vmCID)
+	if err != nil {
+		return nil, bosherr.WrapErrorf(err, "Checking if VM '%s' exists", vmCID)
+	}
+
+	if !found {
+		return []Drift{{
+			Kind:        DriftMissingVM,
+			CID:         vmCID,
+			Description: "VM is recorded in state but does not exist",
+		}}, nil
+	}
+
+	var drifts []Drift
+
+	vm, _, err := c.vmManager.FindCurrent()
+	if err != nil {
+		return nil, bosherr.WrapError(err, "Finding current VM")
+	}
+
+	_, err = vm.GetState()
+	if err != nil {
+		drifts = append(drifts, Drift{
+			Kind:        DriftUnresponsiveAgent,
+			CID:         vmCID,
+			Description: fmt.Sprintf("Agent did not respond: %s", err),
+		})
+	}
+
+	attachedDiskCIDs, err := c.cloud.GetDisks(vmCID)
+	if err != nil {
+		if isNotImplemented(err) {
+			c.logger.Warn(c.logTag, "'GetDisks' not implemented by CPI, skipping attached disks check")
+			return drifts, nil
+		}
+		return nil, bosherr.WrapErrorf(err, "Listing disks attached to VM '%s'", vmCID)
+	}
+
+	currentDiskAttached := false
+
+	for _, diskCID := range attachedDiskCIDs {
+		if currentDiskFound && diskCID == currentDisk.CID {
+			currentDiskAttached = true
+			continue
+		}
+
+		drifts = append(drifts, Drift{
+			Kind:        DriftUnexpectedDisk,
+			CID:         diskCID,
+			Description: fmt.Sprintf("Disk is attached to VM '%s' but is not current disk", vmCID),
+		})
+	}
+
+	if currentDiskFound && !currentDiskAttached {
+		drifts = append(drifts, Drift{
+			Kind:        DriftDetachedDisk,
+			CID:         currentDisk.CID,
+			Description: fmt.Sprintf("Current disk is not attached to VM '%s'", vmCID),
+		})
+	}
+
+	return drifts, nil
+}
+
+func isNotImplemented(err error) bool {
+	cloudErr, ok := err.(bicloud.Error)
+	return ok && cloudErr.Type() == bicloud.NotImplementedError
+}
+
+func (c driftChecker) Repair(drifts []Drift, stage biui.Stage) ([]Drift, error) {
+	agentResponsive := true
+
+	for _, drift := range drifts {
+		if drift.Kind == DriftUnresponsiveAgent {
+			agentResponsive = false
+		}
+	}
+
+	repairedDrifts := append([]Drift{}, drifts...)
+
+	for i, drift := range repairedDrifts {
+		var repairFunc func() error
+
+		switch drift.Kind {
+		case DriftMissingVM:
+			repairFunc = c.forgetVM
+		case DriftMissingDisk:
+			repairFunc = func() error { return c.forgetDisk(drift.CID) }
+		case DriftDetachedDisk:
+			// Attaching disk requires agent to mount it
+			if !agentResponsive {
+				continue
+			}
+			repairFunc = c.reattachDisks
+		default:
+			continue
+		}
+
+		err := stage.Perform(fmt.Sprintf("Repairing %s '%s'", drift.Kind, drift.CID), repairFunc)
+		if err != nil {
+			return repairedDrifts, err
+		}
+
+		repairedDrifts[i].Repaired = true
+	}
+
+	return repairedDrifts, nil
+}
+
+func (c driftChecker) forgetVM() error {
+	err := c.vmRepo.ClearCurrent()
+	if err != nil {
+		return bosherr.WrapError(err, "Clearing current VM")
+	}
+
+	// Jobs have to be applied to a new VM,
+	// so the next create-env must not consider deployment up to date
+	err = c.deploymentRepo.UpdateCurrent("")
+	if err != nil {
+		return bosherr.WrapError(err, "Clearing current manifest SHA")
+	}
+
+	return nil
+}
+
+func (c driftChecker) forgetDisk(diskCID string) error {
+	diskRecord, found, err := c.diskRepo.Find(diskCID)
+	if err != nil {
+		return bosherr.WrapErrorf(err, "Finding disk '%s'", diskCID)
+	}
+
+	if !found {
+		return nil
+	}
+
+	currentDisk, currentDiskFound, err := c.diskRepo.FindCurrent()
+	if err != nil {
+		return bosherr.WrapError(err, "Finding current disk")
+	}
+
+	err = c.diskRepo.Delete(diskRecord)
+	if err != nil {
+		return bosherr.WrapErrorf(err, "Deleting disk record '%s'", diskCID)
+	}
+
+	if currentDiskFound && currentDisk.ID == diskRecord.ID {
+		// Disk has to be created again by the next create-env
+		err = c.deploymentRepo.UpdateCurrent("")
+		if err != nil {
+			return bosherr.WrapError(err, "Clearing current manifest SHA")
+		}
+	}
+
+	return nil
+}
+
+func (c driftChecker) reattachDisks() error {
+	vm, found, err := c.vmManager.FindCurrent()
+	if err != nil {
+		return bosherr.WrapError(err, "Finding current VM")
+	}
+
+	if !found {
+		return bosherr.Error("Expected to find current VM")
+	}
+
+	disks, err := c.diskManager.FindCurrent()
+	if err != nil {
+		return bosherr.WrapError(err, "Finding current disk")
+	}
+
+	for _, disk := range disks {
+		err = vm.AttachDisk(disk)
+		if err != nil {
+			return bosherr.WrapErrorf(err, "Attaching disk '%s' to VM '%s'", disk.CID(), vm.CID())
+		}
+	}
+
+	return nil
+}
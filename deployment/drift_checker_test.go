@@ -0,0 +1,248 @@
+package deployment_test
+
+import (
+	"errors"
+
+	boshlog "github.com/cloudfoundry/bosh-utils/logger"
+	biproperty "github.com/cloudfoundry/bosh-utils/property"
+	fakesys "github.com/cloudfoundry/bosh-utils/system/fakes"
+	fakeuuid "github.com/cloudfoundry/bosh-utils/uuid/fakes"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	bicloud "github.com/cloudfoundry/bosh-cli/cloud"
+	fakebicloud "github.com/cloudfoundry/bosh-cli/cloud/fakes"
+	biconfig "github.com/cloudfoundry/bosh-cli/config"
+	. "github.com/cloudfoundry/bosh-cli/deployment"
+	bidisk "github.com/cloudfoundry/bosh-cli/deployment/disk"
+	fakebidisk "github.com/cloudfoundry/bosh-cli/deployment/disk/fakes"
+	fakebivm "github.com/cloudfoundry/bosh-cli/deployment/vm/fakes"
+	fakebiui "github.com/cloudfoundry/bosh-cli/ui/fakes"
+)
+
+var _ = Describe("DriftChecker", func() {
+	var (
+		cloud           *fakebicloud.FakeCloud
+		fakeVM          *fakebivm.FakeVM
+		fakeVMManager   *fakebivm.FakeManager
+		fakeDiskManager *fakebidisk.FakeManager
+		stateService    biconfig.DeploymentStateService
+		deploymentRepo  biconfig.DeploymentRepo
+		vmRepo          biconfig.VMRepo
+		diskRepo        biconfig.DiskRepo
+		fakeStage       *fakebiui.FakeStage
+
+		checker DriftChecker
+	)
+
+	BeforeEach(func() {
+		logger := boshlog.NewLogger(boshlog.LevelNone)
+		uuidGen := fakeuuid.NewFakeGenerator()
+
+		stateService = biconfig.NewFileSystemDeploymentStateService(
+			fakesys.NewFakeFileSystem(), uuidGen, logger, "/state.json")
+
+		err := stateService.Save(biconfig.DeploymentState{
+			DirectorID:         "director-id",
+			CurrentVMCID:       "vm-cid",
+			CurrentManifestSHA: "sha",
+			CurrentDiskID:      "disk-id",
+			Disks: []biconfig.DiskRecord{
+				{ID: "disk-id", CID: "disk-cid", Size: 1024, CloudProperties: biproperty.Map{}},
+				{ID: "old-disk-id", CID: "old-disk-cid", Size: 512, CloudProperties: biproperty.Map{}},
+			},
+		})
+		Expect(err).ToNot(HaveOccurred())
+
+		deploymentRepo = biconfig.NewDeploymentRepo(stateService)
+		vmRepo = biconfig.NewVMRepo(stateService)
+		diskRepo = biconfig.NewDiskRepo(stateService, uuidGen)
+
+		cloud = fakebicloud.NewFakeCloud()
+		cloud.HasVMFound = true
+		cloud.HasDiskFound = map[string]bool{"disk-cid": true, "old-disk-cid": true}
+		cloud.GetDisksCIDs = []string{"disk-cid"}
+
+		fakeVM = fakebivm.NewFakeVM("vm-cid")
+		fakeVMManager = fakebivm.NewFakeManager()
+		fakeVMManager.SetFindCurrentBehavior(fakeVM, true, nil)
+
+		fakeDiskManager = fakebidisk.NewFakeManager()
+		fakeStage = fakebiui.NewFakeStage()
+
+		checker = NewDriftChecker(cloud, fakeVMManager, fakeDiskManager, deploymentRepo, vmRepo, diskRepo, logger)
+	})
+
+	Describe("Check", func() {
+		It("reports only orphaned disks when IaaS matches state", func() {
+			drifts, err := checker.Check()
+			Expect(err).ToNot(HaveOccurred())
+			Expect(drifts).To(Equal([]Drift{{
+				Kind:        DriftOrphanedDisk,
+				CID:         "old-disk-cid",
+				Description: "Disk is not current disk and will be deleted by next create-env",
+			}}))
+
+			Expect(cloud.HasVMInput.VMCID).To(Equal("vm-cid"))
+			Expect(cloud.GetDisksInput.VMCID).To(Equal("vm-cid"))
+			Expect(fakeVM.GetStateCalled).To(Equal(1))
+		})
+
+		It("reports missing VM without checking agent", func() {
+			cloud.HasVMFound = false
+
+			drifts, err := checker.Check()
+			Expect(err).ToNot(HaveOccurred())
+			Expect(drifts[0]).To(Equal(Drift{
+				Kind:        DriftMissingVM,
+				CID:         "vm-cid",
+				Description: "VM is recorded in state but does not exist",
+			}))
+			Expect(fakeVM.GetStateCalled).To(Equal(0))
+		})
+
+		It("reports missing disks", func() {
+			cloud.HasDiskFound["old-disk-cid"] = false
+
+			drifts, err := checker.Check()
+			Expect(err).ToNot(HaveOccurred())
+			Expect(drifts).To(Equal([]Drift{{
+				Kind:        DriftMissingDisk,
+				CID:         "old-disk-cid",
+				Description: "Disk is recorded in state but does not exist",
+			}}))
+		})
+
+		It("reports unresponsive agent", func() {
+			fakeVM.GetStateErr = errors.New("fake-agent-err")
+
+			drifts, err := checker.Check()
+			Expect(err).ToNot(HaveOccurred())
+			Expect(drifts).To(ContainElement(Drift{
+				Kind:        DriftUnresponsiveAgent,
+				CID:         "vm-cid",
+				Description: "Agent did not respond: fake-agent-err",
+			}))
+		})
+
+		It("reports detached current disk and unexpectedly attached disks", func() {
+			cloud.GetDisksCIDs = []string{"other-disk-cid"}
+
+			drifts, err := checker.Check()
+			Expect(err).ToNot(HaveOccurred())
+			Expect(drifts).To(ContainElement(Drift{
+				Kind:        DriftUnexpectedDisk,
+				CID:         "other-disk-cid",
+				Description: "Disk is attached to VM 'vm-cid' but is not current disk",
+			}))
+			Expect(drifts).To(ContainElement(Drift{
+				Kind:        DriftDetachedDisk,
+				CID:         "disk-cid",
+				Description: "Current disk is not attached to VM 'vm-cid'",
+			}))
+		})
+
+		It("does not report detached current disk if it does not exist", func() {
+			cloud.HasDiskFound["disk-cid"] = false
+			cloud.GetDisksCIDs = []string{}
+
+			drifts, err := checker.Check()
+			Expect(err).ToNot(HaveOccurred())
+			for _, drift := range drifts {
+				Expect(drift.Kind).ToNot(Equal(DriftDetachedDisk))
+			}
+		})
+
+		It("skips attached disks check if CPI does not implement get_disks", func() {
+			cloud.GetDisksCIDs = nil
+			cloud.GetDisksErr = bicloud.NewCPIError("get_disks", bicloud.CmdError{Type: bicloud.NotImplementedError})
+
+			drifts, err := checker.Check()
+			Expect(err).ToNot(HaveOccurred())
+			Expect(drifts).To(HaveLen(1))
+		})
+
+		It("skips missing disks check if CPI does not implement has_disk", func() {
+			cloud.HasDiskFound["old-disk-cid"] = false
+			cloud.HasDiskErr = bicloud.NewCPIError("has_disk", bicloud.CmdError{Type: bicloud.NotImplementedError})
+
+			drifts, err := checker.Check()
+			Expect(err).ToNot(HaveOccurred())
+			for _, drift := range drifts {
+				Expect(drift.Kind).ToNot(Equal(DriftMissingDisk))
+			}
+		})
+
+		It("returns error if checking disk fails", func() {
+			cloud.HasDiskErr = errors.New("fake-has-disk-err")
+
+			_, err := checker.Check()
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("Checking if disk 'disk-cid' exists: fake-has-disk-err"))
+		})
+	})
+
+	Describe("Repair", func() {
+		It("forgets missing VM so that next create-env deploys again", func() {
+			drifts, err := checker.Repair([]Drift{{Kind: DriftMissingVM, CID: "vm-cid"}}, fakeStage)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(drifts[0].Repaired).To(BeTrue())
+
+			state, err := stateService.Load()
+			Expect(err).ToNot(HaveOccurred())
+			Expect(state.CurrentVMCID).To(BeEmpty())
+			Expect(state.CurrentManifestSHA).To(BeEmpty())
+
+			Expect(fakeStage.PerformCalls[0].Name).To(Equal("Repairing missing-vm 'vm-cid'"))
+		})
+
+		It("forgets missing disks", func() {
+			drifts, err := checker.Repair([]Drift{{Kind: DriftMissingDisk, CID: "disk-cid"}}, fakeStage)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(drifts[0].Repaired).To(BeTrue())
+
+			state, err := stateService.Load()
+			Expect(err).ToNot(HaveOccurred())
+			Expect(state.CurrentDiskID).To(BeEmpty())
+			Expect(state.CurrentManifestSHA).To(BeEmpty())
+			Expect(state.Disks).To(HaveLen(1))
+			Expect(state.Disks[0].CID).To(Equal("old-disk-cid"))
+		})
+
+		It("reattaches detached current disk", func() {
+			disk := fakebidisk.NewFakeDisk("disk-cid")
+			fakeDiskManager.SetFindCurrentBehavior([]bidisk.Disk{disk}, nil)
+
+			drifts, err := checker.Repair([]Drift{{Kind: DriftDetachedDisk, CID: "disk-cid"}}, fakeStage)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(drifts[0].Repaired).To(BeTrue())
+			Expect(fakeVM.AttachDiskInputs).To(Equal([]fakebivm.AttachDiskInput{{Disk: disk}}))
+		})
+
+		It("does not reattach disks when agent is unresponsive", func() {
+			drifts, err := checker.Repair([]Drift{
+				{Kind: DriftUnresponsiveAgent, CID: "vm-cid"},
+				{Kind: DriftDetachedDisk, CID: "disk-cid"},
+			}, fakeStage)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(drifts[0].Repaired).To(BeFalse())
+			Expect(drifts[1].Repaired).To(BeFalse())
+			Expect(fakeVM.AttachDiskInputs).To(BeEmpty())
+		})
+
+		It("leaves drifts that cannot be repaired", func() {
+			drifts, err := checker.Repair([]Drift{{Kind: DriftOrphanedDisk, CID: "old-disk-cid"}}, fakeStage)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(drifts[0].Repaired).To(BeFalse())
+			Expect(fakeStage.PerformCalls).To(BeEmpty())
+		})
+
+		It("returns error if repair fails", func() {
+			fakeDiskManager.SetFindCurrentBehavior(nil, errors.New("fake-find-err"))
+
+			_, err := checker.Repair([]Drift{{Kind: DriftDetachedDisk, CID: "disk-cid"}}, fakeStage)
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("fake-find-err"))
+		})
+	})
+})
@@ -19,6 +19,7 @@ import (
 type Instance interface {
 	JobName() string
 	ID() int
+	VMCID() string
 	Disks() ([]bidisk.Disk, error)
 	WaitUntilReady(biinstallmanifest.Registry, biui.Stage) error
 	UpdateDisks(bideplmanifest.Manifest, biui.Stage) ([]bidisk.Disk, error)
@@ -83,6 +84,10 @@ func (i *instance) ID() int {
 	return i.id
 }
 
+func (i *instance) VMCID() string {
+	return i.vm.CID()
+}
+
 func (i *instance) Disks() ([]bidisk.Disk, error) {
 	disks, err := i.vm.Disks()
 	if err != nil {
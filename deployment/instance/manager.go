@@ -27,6 +27,14 @@ type Manager interface {
 		registryConfig biinstallmanifest.Registry,
 		eventLoggerStage biui.Stage,
 	) (Instance, []bidisk.Disk, error)
+	CreateVM(
+		jobName string,
+		id int,
+		deploymentManifest bideplmanifest.Manifest,
+		cloudStemcell bistemcell.CloudStemcell,
+		eventLoggerStage biui.Stage,
+	) (Instance, error)
+	ForVM(jobName string, id int, vm bivm.VM) Instance
 	DeleteAll(
 		pingTimeout time.Duration,
 		pingDelay time.Duration,
@@ -101,6 +109,32 @@ func (m *manager) Create(
 	registryConfig biinstallmanifest.Registry,
 	eventLoggerStage biui.Stage,
 ) (Instance, []bidisk.Disk, error) {
+	instance, err := m.CreateVM(jobName, id, deploymentManifest, cloudStemcell, eventLoggerStage)
+	if err != nil {
+		return nil, []bidisk.Disk{}, err
+	}
+
+	if err := instance.WaitUntilReady(registryConfig, eventLoggerStage); err != nil {
+		return instance, []bidisk.Disk{}, bosherr.WrapError(err, "Waiting until instance is ready")
+	}
+
+	disks, err := instance.UpdateDisks(deploymentManifest, eventLoggerStage)
+	if err != nil {
+		return instance, disks, bosherr.WrapError(err, "Updating instance disks")
+	}
+
+	return instance, disks, err
+}
+
+// CreateVM creates VM for the instance without waiting for its agent
+// or updating its disks.
+func (m *manager) CreateVM(
+	jobName string,
+	id int,
+	deploymentManifest bideplmanifest.Manifest,
+	cloudStemcell bistemcell.CloudStemcell,
+	eventLoggerStage biui.Stage,
+) (Instance, error) {
 	var vm bivm.VM
 	stepName := fmt.Sprintf("Creating VM for instance '%s/%d' from stemcell '%s'", jobName, id, cloudStemcell.CID())
 	err := eventLoggerStage.Perform(stepName, func() error {
@@ -117,21 +151,15 @@ func (m *manager) Create(
 		return nil
 	})
 	if err != nil {
-		return nil, []bidisk.Disk{}, err
+		return nil, err
 	}
 
-	instance := m.instanceFactory.NewInstance(jobName, id, vm, m.vmManager, m.sshTunnelFactory, m.blobstore, m.logger)
-
-	if err := instance.WaitUntilReady(registryConfig, eventLoggerStage); err != nil {
-		return instance, []bidisk.Disk{}, bosherr.WrapError(err, "Waiting until instance is ready")
-	}
-
-	disks, err := instance.UpdateDisks(deploymentManifest, eventLoggerStage)
-	if err != nil {
-		return instance, disks, bosherr.WrapError(err, "Updating instance disks")
-	}
+	return m.ForVM(jobName, id, vm), nil
+}
 
-	return instance, disks, err
+// ForVM returns instance for already existing VM, e.g. when resuming deploy.
+func (m *manager) ForVM(jobName string, id int, vm bivm.VM) Instance {
+	return m.instanceFactory.NewInstance(jobName, id, vm, m.vmManager, m.sshTunnelFactory, m.blobstore, m.logger)
 }
 
 func (m *manager) DeleteAll(
@@ -8,6 +8,7 @@ import (
 	disk "github.com/cloudfoundry/bosh-cli/deployment/disk"
 	instance "github.com/cloudfoundry/bosh-cli/deployment/instance"
 	manifest "github.com/cloudfoundry/bosh-cli/deployment/manifest"
+	vm "github.com/cloudfoundry/bosh-cli/deployment/vm"
 	manifest0 "github.com/cloudfoundry/bosh-cli/installation/manifest"
 	stemcell "github.com/cloudfoundry/bosh-cli/stemcell"
 	ui "github.com/cloudfoundry/bosh-cli/ui"
@@ -153,6 +154,20 @@ func (mr *MockInstanceMockRecorder) UpdateJobs(arg0, arg1 interface{}) *gomock.C
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateJobs", reflect.TypeOf((*MockInstance)(nil).UpdateJobs), arg0, arg1)
 }
 
+// VMCID mocks base method
+func (m *MockInstance) VMCID() string {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "VMCID")
+	ret0, _ := ret[0].(string)
+	return ret0
+}
+
+// VMCID indicates an expected call of VMCID
+func (mr *MockInstanceMockRecorder) VMCID() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "VMCID", reflect.TypeOf((*MockInstance)(nil).VMCID))
+}
+
 // WaitUntilReady mocks base method
 func (m *MockInstance) WaitUntilReady(arg0 manifest0.Registry, arg1 ui.Stage) error {
 	m.ctrl.T.Helper()
@@ -206,6 +221,21 @@ func (mr *MockManagerMockRecorder) Create(arg0, arg1, arg2, arg3, arg4, arg5 int
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockManager)(nil).Create), arg0, arg1, arg2, arg3, arg4, arg5)
 }
 
+// CreateVM mocks base method
+func (m *MockManager) CreateVM(arg0 string, arg1 int, arg2 manifest.Manifest, arg3 stemcell.CloudStemcell, arg4 ui.Stage) (instance.Instance, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateVM", arg0, arg1, arg2, arg3, arg4)
+	ret0, _ := ret[0].(instance.Instance)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateVM indicates an expected call of CreateVM
+func (mr *MockManagerMockRecorder) CreateVM(arg0, arg1, arg2, arg3, arg4 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateVM", reflect.TypeOf((*MockManager)(nil).CreateVM), arg0, arg1, arg2, arg3, arg4)
+}
+
 // DeleteAll mocks base method
 func (m *MockManager) DeleteAll(arg0, arg1 time.Duration, arg2 bool, arg3 ui.Stage) error {
 	m.ctrl.T.Helper()
@@ -234,3 +264,17 @@ func (mr *MockManagerMockRecorder) FindCurrent() *gomock.Call {
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindCurrent", reflect.TypeOf((*MockManager)(nil).FindCurrent))
 }
+
+// ForVM mocks base method
+func (m *MockManager) ForVM(arg0 string, arg1 int, arg2 vm.VM) instance.Instance {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ForVM", arg0, arg1, arg2)
+	ret0, _ := ret[0].(instance.Instance)
+	return ret0
+}
+
+// ForVM indicates an expected call of ForVM
+func (mr *MockManagerMockRecorder) ForVM(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ForVM", reflect.TypeOf((*MockManager)(nil).ForVM), arg0, arg1, arg2)
+}
@@ -18,6 +18,7 @@ import (
 	. "github.com/cloudfoundry/bosh-cli/release/resource"
 	bistatejob "github.com/cloudfoundry/bosh-cli/state/job"
 	mock_state_job "github.com/cloudfoundry/bosh-cli/state/job/mocks"
+	bitemplate "github.com/cloudfoundry/bosh-cli/templatescompiler"
 	mock_template "github.com/cloudfoundry/bosh-cli/templatescompiler/mocks"
 	fakebiui "github.com/cloudfoundry/bosh-cli/ui/fakes"
 )
@@ -277,6 +278,10 @@ var _ = Describe("Builder", func() {
 				},
 			}
 
+			releaseJobLinks := map[string]bitemplate.ReleaseJobLinks{
+				"job-name": {},
+			}
+
 			jobProperties := biproperty.Map{
 				"fake-job-property": "fake-job-property-value",
 			}
@@ -284,7 +289,7 @@ var _ = Describe("Builder", func() {
 				"fake-job-property": "fake-global-property-value",
 			}
 
-			mockJobListRenderer.EXPECT().Render(releaseJobs, releaseJobProperties, jobProperties, globalProperties, "fake-deployment-name", expectedIP).Return(mockRenderedJobList, nil)
+			mockJobListRenderer.EXPECT().Render(releaseJobs, releaseJobProperties, releaseJobLinks, jobProperties, globalProperties, "fake-deployment-name", expectedIP).Return(mockRenderedJobList, nil)
 
 			mockRenderedJobList.EXPECT().DeleteSilently()
 
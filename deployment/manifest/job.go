@@ -27,6 +27,8 @@ type ReleaseJobRef struct {
 	Name       string
 	Release    string
 	Properties *biproperty.Map
+	Consumes   biproperty.Map
+	Provides   biproperty.Map
 }
 
 type JobNetwork struct {
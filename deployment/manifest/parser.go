@@ -88,6 +88,9 @@ type releaseJobRef struct {
 	// This is a pointer so we can differentiate between `properties: {}`
 	// and not specifying the key at all.
 	Properties *map[interface{}]interface{}
+
+	Consumes map[interface{}]interface{}
+	Provides map[interface{}]interface{}
 }
 
 type stemcellRef struct {
@@ -234,6 +237,24 @@ func (p *parser) parseJobManifests(rawJobs []job) ([]Job, error) {
 					ref.Properties = &properties
 				}
 
+				if rawJobRef.Consumes != nil {
+					consumes, err := biproperty.BuildMap(rawJobRef.Consumes)
+					if err != nil {
+						return []Job{}, bosherr.WrapErrorf(err, "Parsing release job consumes: %#v", rawJobRef.Consumes)
+					}
+
+					ref.Consumes = consumes
+				}
+
+				if rawJobRef.Provides != nil {
+					provides, err := biproperty.BuildMap(rawJobRef.Provides)
+					if err != nil {
+						return []Job{}, bosherr.WrapErrorf(err, "Parsing release job provides: %#v", rawJobRef.Provides)
+					}
+
+					ref.Provides = provides
+				}
+
 				releaseJobRefs[i] = ref
 			}
 			job.Templates = releaseJobRefs
@@ -550,6 +550,36 @@ instance_groups:
 			})
 		})
 
+		Context("when job is defined inside an instance_group with links", func() {
+			BeforeEach(func() {
+				contents := `
+---
+instance_groups:
+- name: jobby
+  jobs:
+  - name: job1
+    consumes:
+      db: {from: primary-db}
+      cache: nil
+    provides:
+      api: {as: public-api}
+`
+				interpolatedTemplate = bidepltpl.NewInterpolatedTemplate([]byte(contents), "fake-sha")
+			})
+
+			It("parses consumed and provided links", func() {
+				deploymentManifest, err := parser.Parse(interpolatedTemplate, manifestPath)
+				Expect(err).ToNot(HaveOccurred())
+				Expect(deploymentManifest.Jobs[0].Templates[0].Consumes).To(Equal(biproperty.Map{
+					"db":    biproperty.Map{"from": "primary-db"},
+					"cache": "nil",
+				}))
+				Expect(deploymentManifest.Jobs[0].Templates[0].Provides).To(Equal(biproperty.Map{
+					"api": biproperty.Map{"as": "public-api"},
+				}))
+			})
+		})
+
 		Context("when both instance_groups and jobs are present at root level in deployment manifest", func() {
 			BeforeEach(func() {
 				contents := `
@@ -0,0 +1,308 @@
+package deployment
+
+import (
+	"encoding/json"
+	"fmt"
+
+	bosherr "github.com/cloudfoundry/bosh-utils/errors"
+	biproperty "github.com/cloudfoundry/bosh-utils/property"
+
+	biconfig "github.com/cloudfoundry/bosh-cli/config"
+	bideplmanifest "github.com/cloudfoundry/bosh-cli/deployment/manifest"
+	biinstallmanifest "github.com/cloudfoundry/bosh-cli/installation/manifest"
+	birel "github.com/cloudfoundry/bosh-cli/release"
+	bistemcell "github.com/cloudfoundry/bosh-cli/stemcell"
+)
+
+const (
+	PlanActionInstallCPI     = "install-cpi"
+	PlanActionUploadStemcell = "upload-stemcell"
+	PlanActionCreateVM       = "create-vm"
+	PlanActionRecreateVM     = "recreate-vm"
+	PlanActionCreateDisk     = "create-disk"
+	PlanActionMigrateDisk    = "migrate-disk"
+	PlanActionDeleteDisk     = "delete-disk"
+	PlanActionCompileRelease = "compile-release"
+	PlanActionRenderJobs     = "render-jobs"
+)
+
+// Plan describes steps that create-env would perform
+// to converge deployment state to the deployment manifest.
+type Plan struct {
+	Steps []PlanStep
+}
+
+type PlanStep struct {
+	Action  string
+	Subject string
+	Reasons []string
+}
+
+func (p Plan) HasChanges() bool { return len(p.Steps) > 0 }
+
+type PlanInput struct {
+	State       biconfig.DeploymentState
+	Manifest    bideplmanifest.Manifest
+	ManifestSHA string
+	Releases    []birel.Release
+	Stemcell    bistemcell.ExtractedStemcell
+
+	InstallationManifest biinstallmanifest.Manifest
+
+	Recreate                bool
+	RecreatePersistentDisks bool
+}
+
+// NewPlan compares deployment state with desired deployment
+// without making any changes or contacting the CPI.
+func NewPlan(input PlanInput) (Plan, error) {
+	var plan Plan
+
+	state := input.State
+	stemcellManifest := input.Stemcell.Manifest()
+	stemcellRef := fmt.Sprintf("%s/%s", stemcellManifest.Name, stemcellManifest.Version)
+
+	var changeReasons []string
+
+	if len(state.CurrentManifestSHA) == 0 {
+		changeReasons = append(changeReasons, "no manifest currently deployed")
+	} else if state.CurrentManifestSHA != input.ManifestSHA {
+		changeReasons = append(changeReasons, fmt.Sprintf(
+			"manifest SHA changed from '%s' to '%s'", state.CurrentManifestSHA, input.ManifestSHA))
+	}
+
+	currentStemcell, found := findStemcellRecord(state.Stemcells, func(rec biconfig.StemcellRecord) bool {
+		return rec.ID == state.CurrentStemcellID
+	})
+	if !found {
+		changeReasons = append(changeReasons, "no stemcell currently deployed")
+	} else if currentStemcell.Name != stemcellManifest.Name || currentStemcell.Version != stemcellManifest.Version {
+		changeReasons = append(changeReasons, fmt.Sprintf(
+			"stemcell changed from '%s/%s' to '%s'", currentStemcell.Name, currentStemcell.Version, stemcellRef))
+	}
+
+	var releaseSteps []PlanStep
+
+	for _, release := range input.Releases {
+		releaseRef := fmt.Sprintf("%s/%s", release.Name(), release.Version())
+
+		reason := planReleaseChange(state.Releases, release)
+		if len(reason) > 0 {
+			changeReasons = append(changeReasons, fmt.Sprintf("release '%s' %s", releaseRef, reason))
+			releaseSteps = append(releaseSteps, PlanStep{
+				Action:  PlanActionCompileRelease,
+				Subject: releaseRef,
+				Reasons: []string{reason},
+			})
+		}
+	}
+
+	for _, rec := range state.Releases {
+		if !hasRelease(input.Releases, rec.Name) {
+			changeReasons = append(changeReasons, fmt.Sprintf("release '%s/%s' was removed", rec.Name, rec.Version))
+		}
+	}
+
+	if len(changeReasons) == 0 && !input.Recreate && !input.RecreatePersistentDisks {
+		return plan, nil
+	}
+
+	plan.Steps = append(plan.Steps, planInstallation(input))
+
+	_, uploaded := findStemcellRecord(state.Stemcells, func(rec biconfig.StemcellRecord) bool {
+		return rec.Name == stemcellManifest.Name && rec.Version == stemcellManifest.Version
+	})
+	if !uploaded {
+		plan.Steps = append(plan.Steps, PlanStep{
+			Action:  PlanActionUploadStemcell,
+			Subject: stemcellRef,
+			Reasons: []string{"stemcell has not been uploaded"},
+		})
+	}
+
+	if len(state.CurrentVMCID) == 0 {
+		plan.Steps = append(plan.Steps, PlanStep{
+			Action:  PlanActionCreateVM,
+			Subject: input.Manifest.JobName(),
+			Reasons: []string{"no VM currently exists"},
+		})
+	} else {
+		vmReasons := append([]string{}, changeReasons...)
+		if input.Recreate {
+			vmReasons = append(vmReasons, "recreate requested")
+		}
+		if len(vmReasons) == 0 {
+			vmReasons = append(vmReasons, "VM is recreated when persistent disks are recreated")
+		}
+
+		plan.Steps = append(plan.Steps, PlanStep{
+			Action:  PlanActionRecreateVM,
+			Subject: state.CurrentVMCID,
+			Reasons: vmReasons,
+		})
+	}
+
+	diskStep, err := planDisk(input)
+	if err != nil {
+		return Plan{}, err
+	}
+
+	if len(diskStep.Action) > 0 {
+		plan.Steps = append(plan.Steps, diskStep)
+	}
+
+	plan.Steps = append(plan.Steps, releaseSteps...)
+
+	renderReasons := changeReasons
+	if len(renderReasons) == 0 {
+		renderReasons = []string{"jobs are re-applied to recreated VM"}
+	}
+
+	plan.Steps = append(plan.Steps, PlanStep{
+		Action:  PlanActionRenderJobs,
+		Subject: input.Manifest.JobName(),
+		Reasons: renderReasons,
+	})
+
+	return plan, nil
+}
+
+// planInstallation describes CPI installation which precedes any CPI calls;
+// already compiled CPI packages are reused when CPI release did not change.
+func planInstallation(input PlanInput) PlanStep {
+	cpiJob := input.InstallationManifest.Template
+
+	step := PlanStep{
+		Action:  PlanActionInstallCPI,
+		Subject: fmt.Sprintf("%s/%s", cpiJob.Release, cpiJob.Name),
+	}
+
+	for _, release := range input.Releases {
+		if release.Name() == cpiJob.Release {
+			reason := planReleaseChange(input.State.Releases, release)
+			if len(reason) > 0 {
+				step.Reasons = append(step.Reasons, fmt.Sprintf(
+					"CPI release '%s/%s' %s", release.Name(), release.Version(), reason))
+			}
+		}
+	}
+
+	if len(step.Reasons) == 0 {
+		step.Reasons = []string{"CPI is required to perform planned steps (compiled CPI packages are reused)"}
+	}
+
+	return step
+}
+
+func planDisk(input PlanInput) (PlanStep, error) {
+	state := input.State
+
+	diskPool, err := input.Manifest.DiskPool(input.Manifest.JobName())
+	if err != nil {
+		return PlanStep{}, bosherr.WrapError(err, "Finding persistent disk pool")
+	}
+
+	currentDisk, found := findDiskRecord(state.Disks, state.CurrentDiskID)
+
+	if diskPool.DiskSize == 0 {
+		if found {
+			return PlanStep{
+				Action:  PlanActionDeleteDisk,
+				Subject: currentDisk.CID,
+				Reasons: []string{"persistent disk is no longer specified in the manifest"},
+			}, nil
+		}
+		return PlanStep{}, nil
+	}
+
+	if !found {
+		return PlanStep{
+			Action:  PlanActionCreateDisk,
+			Subject: fmt.Sprintf("%d MB", diskPool.DiskSize),
+			Reasons: []string{"no persistent disk currently exists"},
+		}, nil
+	}
+
+	var reasons []string
+
+	if input.RecreatePersistentDisks {
+		reasons = append(reasons, "recreate of persistent disks requested")
+	}
+
+	if currentDisk.Size != diskPool.DiskSize {
+		reasons = append(reasons, fmt.Sprintf("disk size changed from %d MB to %d MB", currentDisk.Size, diskPool.DiskSize))
+	}
+
+	changed, err := cloudPropertiesChanged(currentDisk.CloudProperties, diskPool.CloudProperties)
+	if err != nil {
+		return PlanStep{}, err
+	}
+
+	if changed {
+		reasons = append(reasons, "disk cloud_properties changed")
+	}
+
+	if len(reasons) == 0 {
+		return PlanStep{}, nil
+	}
+
+	return PlanStep{Action: PlanActionMigrateDisk, Subject: currentDisk.CID, Reasons: reasons}, nil
+}
+
+func planReleaseChange(records []biconfig.ReleaseRecord, release birel.Release) string {
+	for _, rec := range records {
+		if rec.Name == release.Name() {
+			if rec.Version == release.Version() {
+				return ""
+			}
+			return fmt.Sprintf("changed from version '%s'", rec.Version)
+		}
+	}
+
+	return "is not currently deployed"
+}
+
+func hasRelease(releases []birel.Release, name string) bool {
+	for _, release := range releases {
+		if release.Name() == name {
+			return true
+		}
+	}
+	return false
+}
+
+func findStemcellRecord(records []biconfig.StemcellRecord, matchFunc func(biconfig.StemcellRecord) bool) (biconfig.StemcellRecord, bool) {
+	for _, rec := range records {
+		if matchFunc(rec) {
+			return rec, true
+		}
+	}
+	return biconfig.StemcellRecord{}, false
+}
+
+func findDiskRecord(records []biconfig.DiskRecord, id string) (biconfig.DiskRecord, bool) {
+	if len(id) == 0 {
+		return biconfig.DiskRecord{}, false
+	}
+
+	for _, rec := range records {
+		if rec.ID == id {
+			return rec, true
+		}
+	}
+	return biconfig.DiskRecord{}, false
+}
+
+func cloudPropertiesChanged(current, desired biproperty.Map) (bool, error) {
+	currentBytes, err := json.Marshal(current)
+	if err != nil {
+		return false, bosherr.WrapError(err, "Marshalling current disk cloud properties")
+	}
+
+	desiredBytes, err := json.Marshal(desired)
+	if err != nil {
+		return false, bosherr.WrapError(err, "Marshalling desired disk cloud properties")
+	}
+
+	return string(currentBytes) != string(desiredBytes), nil
+}
@@ -0,0 +1,200 @@
+package deployment_test
+
+import (
+	biproperty "github.com/cloudfoundry/bosh-utils/property"
+	fakesys "github.com/cloudfoundry/bosh-utils/system/fakes"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	biconfig "github.com/cloudfoundry/bosh-cli/config"
+	. "github.com/cloudfoundry/bosh-cli/deployment"
+	bideplmanifest "github.com/cloudfoundry/bosh-cli/deployment/manifest"
+	biinstallmanifest "github.com/cloudfoundry/bosh-cli/installation/manifest"
+	boshrel "github.com/cloudfoundry/bosh-cli/release"
+	fakerel "github.com/cloudfoundry/bosh-cli/release/releasefakes"
+	bistemcell "github.com/cloudfoundry/bosh-cli/stemcell"
+)
+
+var _ = Describe("NewPlan", func() {
+	var (
+		input PlanInput
+	)
+
+	BeforeEach(func() {
+		release := &fakerel.FakeRelease{
+			NameStub:    func() string { return "rel" },
+			VersionStub: func() string { return "2" },
+		}
+
+		stemcell := bistemcell.NewExtractedStemcell(
+			bistemcell.Manifest{Name: "stemcell", Version: "2"},
+			"fake-extracted-path",
+			nil,
+			fakesys.NewFakeFileSystem(),
+		)
+
+		input = PlanInput{
+			State: biconfig.DeploymentState{
+				CurrentVMCID:       "vm-cid",
+				CurrentManifestSHA: "sha",
+				CurrentStemcellID:  "stemcell-id",
+				Stemcells:          []biconfig.StemcellRecord{{ID: "stemcell-id", Name: "stemcell", Version: "2"}},
+				Releases:           []biconfig.ReleaseRecord{{ID: "rel-id", Name: "rel", Version: "2"}},
+				CurrentDiskID:      "disk-id",
+				Disks: []biconfig.DiskRecord{{
+					ID:              "disk-id",
+					CID:             "disk-cid",
+					Size:            1024,
+					CloudProperties: biproperty.Map{},
+				}},
+			},
+			Manifest: bideplmanifest.Manifest{
+				Jobs: []bideplmanifest.Job{{Name: "bosh", PersistentDisk: 1024}},
+			},
+			ManifestSHA: "sha",
+			Releases:    []boshrel.Release{release},
+			Stemcell:    stemcell,
+
+			InstallationManifest: biinstallmanifest.Manifest{
+				Template: biinstallmanifest.ReleaseJobRef{Name: "cpi", Release: "rel"},
+			},
+		}
+	})
+
+	cpiReusedStep := PlanStep{
+		Action:  PlanActionInstallCPI,
+		Subject: "rel/cpi",
+		Reasons: []string{"CPI is required to perform planned steps (compiled CPI packages are reused)"},
+	}
+
+	It("returns empty plan when nothing changed", func() {
+		plan, err := NewPlan(input)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(plan.HasChanges()).To(BeFalse())
+	})
+
+	It("plans full deploy when nothing is deployed", func() {
+		input.State = biconfig.DeploymentState{}
+
+		plan, err := NewPlan(input)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(plan.Steps).To(Equal([]PlanStep{
+			{Action: PlanActionInstallCPI, Subject: "rel/cpi", Reasons: []string{"CPI release 'rel/2' is not currently deployed"}},
+			{Action: PlanActionUploadStemcell, Subject: "stemcell/2", Reasons: []string{"stemcell has not been uploaded"}},
+			{Action: PlanActionCreateVM, Subject: "bosh", Reasons: []string{"no VM currently exists"}},
+			{Action: PlanActionCreateDisk, Subject: "1024 MB", Reasons: []string{"no persistent disk currently exists"}},
+			{Action: PlanActionCompileRelease, Subject: "rel/2", Reasons: []string{"is not currently deployed"}},
+			{Action: PlanActionRenderJobs, Subject: "bosh", Reasons: []string{
+				"no manifest currently deployed",
+				"no stemcell currently deployed",
+				"release 'rel/2' is not currently deployed",
+			}},
+		}))
+	})
+
+	It("plans VM recreate and job re-render when manifest changed", func() {
+		input.ManifestSHA = "new-sha"
+
+		plan, err := NewPlan(input)
+		Expect(err).ToNot(HaveOccurred())
+
+		reasons := []string{"manifest SHA changed from 'sha' to 'new-sha'"}
+		Expect(plan.Steps).To(Equal([]PlanStep{
+			cpiReusedStep,
+			{Action: PlanActionRecreateVM, Subject: "vm-cid", Reasons: reasons},
+			{Action: PlanActionRenderJobs, Subject: "bosh", Reasons: reasons},
+		}))
+	})
+
+	It("plans stemcell upload when stemcell changed", func() {
+		input.State.Stemcells[0].Version = "1"
+
+		plan, err := NewPlan(input)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(plan.Steps[1]).To(Equal(PlanStep{
+			Action:  PlanActionUploadStemcell,
+			Subject: "stemcell/2",
+			Reasons: []string{"stemcell has not been uploaded"},
+		}))
+		Expect(plan.Steps[2].Reasons).To(Equal([]string{"stemcell changed from 'stemcell/1' to 'stemcell/2'"}))
+	})
+
+	It("plans release compilation when release version changed", func() {
+		input.State.Releases[0].Version = "1"
+
+		plan, err := NewPlan(input)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(plan.Steps).To(ContainElement(PlanStep{
+			Action:  PlanActionCompileRelease,
+			Subject: "rel/2",
+			Reasons: []string{"changed from version '1'"},
+		}))
+	})
+
+	It("plans CPI installation when CPI release version changed", func() {
+		input.State.Releases[0].Version = "1"
+
+		plan, err := NewPlan(input)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(plan.Steps[0]).To(Equal(PlanStep{
+			Action:  PlanActionInstallCPI,
+			Subject: "rel/cpi",
+			Reasons: []string{"CPI release 'rel/2' changed from version '1'"},
+		}))
+	})
+
+	It("plans disk migration when disk size or cloud properties changed", func() {
+		input.ManifestSHA = "new-sha"
+		input.Manifest.Jobs[0].PersistentDisk = 0
+		input.Manifest.Jobs[0].PersistentDiskPool = "disks"
+		input.Manifest.DiskPools = []bideplmanifest.DiskPool{{
+			Name:            "disks",
+			DiskSize:        2048,
+			CloudProperties: biproperty.Map{"type": "ssd"},
+		}}
+
+		plan, err := NewPlan(input)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(plan.Steps).To(ContainElement(PlanStep{
+			Action:  PlanActionMigrateDisk,
+			Subject: "disk-cid",
+			Reasons: []string{"disk size changed from 1024 MB to 2048 MB", "disk cloud_properties changed"},
+		}))
+	})
+
+	It("plans disk deletion when disk is removed from manifest", func() {
+		input.ManifestSHA = "new-sha"
+		input.Manifest.Jobs[0].PersistentDisk = 0
+
+		plan, err := NewPlan(input)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(plan.Steps).To(ContainElement(PlanStep{
+			Action:  PlanActionDeleteDisk,
+			Subject: "disk-cid",
+			Reasons: []string{"persistent disk is no longer specified in the manifest"},
+		}))
+	})
+
+	It("plans VM recreate and disk migration when requested", func() {
+		input.Recreate = true
+		input.RecreatePersistentDisks = true
+
+		plan, err := NewPlan(input)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(plan.Steps).To(Equal([]PlanStep{
+			cpiReusedStep,
+			{Action: PlanActionRecreateVM, Subject: "vm-cid", Reasons: []string{"recreate requested"}},
+			{Action: PlanActionMigrateDisk, Subject: "disk-cid", Reasons: []string{"recreate of persistent disks requested"}},
+			{Action: PlanActionRenderJobs, Subject: "bosh", Reasons: []string{"jobs are re-applied to recreated VM"}},
+		}))
+	})
+
+	It("returns error if disk pool cannot be found", func() {
+		input.ManifestSHA = "new-sha"
+		input.Manifest.Jobs[0].PersistentDiskPool = "missing"
+
+		_, err := NewPlan(input)
+		Expect(err).To(HaveOccurred())
+		Expect(err.Error()).To(ContainSubstring("Finding persistent disk pool"))
+	})
+})
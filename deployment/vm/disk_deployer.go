@@ -19,6 +19,7 @@ type DiskDeployer interface {
 
 type diskDeployer struct {
 	diskRepo               biconfig.DiskRepo
+	checkpointRepo         biconfig.CheckpointRepo
 	diskManagerFactory     bidisk.ManagerFactory
 	diskManager            bidisk.Manager
 	logger                 boshlog.Logger
@@ -26,10 +27,11 @@ type diskDeployer struct {
 	recreatePersistentDisk bool
 }
 
-func NewDiskDeployer(diskManagerFactory bidisk.ManagerFactory, diskRepo biconfig.DiskRepo, logger boshlog.Logger, recreatePersistentDisk bool) DiskDeployer {
+func NewDiskDeployer(diskManagerFactory bidisk.ManagerFactory, diskRepo biconfig.DiskRepo, checkpointRepo biconfig.CheckpointRepo, logger boshlog.Logger, recreatePersistentDisk bool) DiskDeployer {
 	return &diskDeployer{
 		diskManagerFactory:     diskManagerFactory,
 		diskRepo:               diskRepo,
+		checkpointRepo:         checkpointRepo,
 		logger:                 logger,
 		logTag:                 "diskDeployer",
 		recreatePersistentDisk: recreatePersistentDisk,
@@ -95,9 +97,11 @@ func (d *diskDeployer) deployExistingDisk(disk bidisk.Disk, diskPool bideplmanif
 
 		// after migration, only the new disk is part of the deployment
 		disks[0] = disk
+
+		return disks, d.completeCheckpoint(biconfig.CheckpointDiskMigrated, disk)
 	}
 
-	return disks, nil
+	return disks, d.completeCheckpoint(biconfig.CheckpointDiskAttached, disk)
 }
 
 func (d *diskDeployer) deployNewDisk(diskPool bideplmanifest.DiskPool, vm VM, stage biui.Stage) ([]bidisk.Disk, error) {
@@ -121,6 +125,11 @@ func (d *diskDeployer) deployNewDisk(diskPool bideplmanifest.DiskPool, vm VM, st
 		return disks, err
 	}
 
+	err = d.completeCheckpoint(biconfig.CheckpointDiskAttached, disk)
+	if err != nil {
+		return disks, err
+	}
+
 	return disks, nil
 }
 
@@ -215,3 +224,12 @@ func (d *diskDeployer) attachDisk(disk bidisk.Disk, vm VM, stage biui.Stage) err
 
 	return err
 }
+
+func (d *diskDeployer) completeCheckpoint(checkpoint string, disk bidisk.Disk) error {
+	err := d.checkpointRepo.CompleteDisk(checkpoint, disk.CID())
+	if err != nil {
+		return bosherr.WrapErrorf(err, "Recording '%s' checkpoint", checkpoint)
+	}
+
+	return nil
+}
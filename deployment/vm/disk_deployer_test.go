@@ -29,6 +29,7 @@ var _ = Describe("DiskDeployer", func() {
 		fakeVM                 *fakebivm.FakeVM
 		fakeDisk               *fakebidisk.FakeDisk
 		fakeDiskRepo           *fakebiconfig.FakeDiskRepo
+		fakeCheckpointRepo     *fakebiconfig.FakeCheckpointRepo
 		fakeDiskManagerFactory *fakebidisk.FakeManagerFactory
 		logger                 boshlog.Logger
 	)
@@ -46,9 +47,12 @@ var _ = Describe("DiskDeployer", func() {
 		logger = boshlog.NewLogger(boshlog.LevelNone)
 		fakeStage = fakebiui.NewFakeStage()
 		fakeDiskRepo = fakebiconfig.NewFakeDiskRepo()
+		fakeCheckpointRepo = fakebiconfig.NewFakeCheckpointRepo()
+		fakeCheckpointRepo.Record = &biconfig.CheckpointRecord{VMCID: "fake-vm-cid"}
 		diskDeployer = NewDiskDeployer(
 			fakeDiskManagerFactory,
 			fakeDiskRepo,
+			fakeCheckpointRepo,
 			logger,
 			false,
 		)
@@ -98,6 +102,14 @@ var _ = Describe("DiskDeployer", func() {
 					existingDisk.SetNeedsMigrationBehavior(false)
 				})
 
+				It("records disk attached checkpoint", func() {
+					_, err := diskDeployer.Deploy(diskPool, cloud, fakeVM, fakeStage)
+					Expect(err).ToNot(HaveOccurred())
+
+					Expect(fakeCheckpointRepo.Record.DiskCID).To(Equal("fake-existing-disk-cid"))
+					Expect(fakeCheckpointRepo.Record.Completed).To(Equal([]string{biconfig.CheckpointDiskAttached}))
+				})
+
 				It("does not log the create disk event", func() {
 					disks, err := diskDeployer.Deploy(diskPool, cloud, fakeVM, fakeStage)
 					Expect(err).ToNot(HaveOccurred())
@@ -116,6 +128,7 @@ var _ = Describe("DiskDeployer", func() {
 					diskDeployer = NewDiskDeployer(
 						fakeDiskManagerFactory,
 						fakeDiskRepo,
+						fakeCheckpointRepo,
 						logger,
 						true,
 					)
@@ -365,6 +378,14 @@ var _ = Describe("DiskDeployer", func() {
 					}))
 				})
 
+				It("records disk migrated checkpoint", func() {
+					_, err := diskDeployer.Deploy(diskPool, cloud, fakeVM, fakeStage)
+					Expect(err).NotTo(HaveOccurred())
+
+					Expect(fakeCheckpointRepo.Record.DiskCID).To(Equal("fake-secondary-disk-cid"))
+					Expect(fakeCheckpointRepo.Record.Completed).To(Equal([]string{biconfig.CheckpointDiskMigrated}))
+				})
+
 				Context("when disk creation fails", func() {
 					BeforeEach(func() {
 						fakeDiskManager.CreateErr = bosherr.Error("fake-create-disk-error")
@@ -483,6 +504,14 @@ var _ = Describe("DiskDeployer", func() {
 				}))
 			})
 
+			It("records disk attached checkpoint", func() {
+				_, err := diskDeployer.Deploy(diskPool, cloud, fakeVM, fakeStage)
+				Expect(err).NotTo(HaveOccurred())
+
+				Expect(fakeCheckpointRepo.Record.DiskCID).To(Equal("fake-new-disk-cid"))
+				Expect(fakeCheckpointRepo.Record.Completed).To(Equal([]string{biconfig.CheckpointDiskAttached}))
+			})
+
 			It("logs the create disk event", func() {
 				_, err := diskDeployer.Deploy(diskPool, cloud, fakeVM, fakeStage)
 				Expect(err).ToNot(HaveOccurred())
@@ -1,6 +1,7 @@
 package director
 
 import (
+	"context"
 	"time"
 
 	"github.com/cloudfoundry/bosh-utils/httpclient"
@@ -32,3 +33,17 @@ func (c Client) WithContext(contextId string) Client {
 
 	return Client{clientRequest, taskClientRequest}
 }
+
+// WithGoContext returns a copy of the Client which aborts requests
+// and stops waiting for tasks once ctx is done. If cancelTasks is set
+// task that is being waited for is also cancelled on the Director.
+func (c Client) WithGoContext(ctx context.Context, cancelTasks bool) Client {
+	clientRequest := c.clientRequest.WithGoContext(ctx)
+	taskClientRequest := c.taskClientRequest.WithGoContext(ctx, cancelTasks)
+
+	return Client{clientRequest, taskClientRequest}
+}
+
+func (c Client) withTaskStarted(f func(id int, state string)) Client {
+	return Client{c.clientRequest, c.taskClientRequest.withTaskStarted(f)}
+}
@@ -1,6 +1,7 @@
 package director
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"io"
@@ -16,6 +17,7 @@ import (
 type ClientRequest struct {
 	endpoint     string
 	contextId    string
+	ctx          context.Context
 	httpClient   *httpclient.HTTPClient
 	fileReporter FileReporter
 	logger       boshlog.Logger
@@ -41,6 +43,22 @@ func (r ClientRequest) WithContext(contextId string) ClientRequest {
 	return r
 }
 
+// WithGoContext returns a copy of the ClientRequest
+// which sends all requests with given context
+// so that they are aborted once it is cancelled or times out
+func (r ClientRequest) WithGoContext(ctx context.Context) ClientRequest {
+	r.ctx = ctx
+	return r
+}
+
+// GoContext returns context requests are sent with
+func (r ClientRequest) GoContext() context.Context {
+	if r.ctx == nil {
+		return context.Background()
+	}
+	return r.ctx
+}
+
 func (r ClientRequest) Get(path string, response interface{}) error {
 	respBody, _, err := r.RawGet(path, nil, nil)
 	if err != nil {
@@ -172,6 +190,9 @@ func (r ClientRequest) setContextIDHeader(f func(*http.Request)) func(*http.Requ
 		if r.contextId != "" {
 			req.Header.Set("X-Bosh-Context-Id", r.contextId)
 		}
+		if r.ctx != nil {
+			*req = *req.WithContext(r.ctx)
+		}
 	}
 }
 
@@ -2,6 +2,7 @@ package director_test
 
 import (
 	"bytes"
+	"context"
 	"crypto/tls"
 	"fmt"
 	"io"
@@ -257,6 +258,19 @@ var _ = Describe("ClientRequest", func() {
 					Expect(err).ToNot(HaveOccurred())
 				})
 			})
+
+			Context("when go context is done", func() {
+				It("does not perform request", func() {
+					ctx, cancel := context.WithCancel(context.Background())
+					cancel()
+
+					_, _, err := req.WithGoContext(ctx).RawGet("/path", nil, nil)
+					Expect(err).To(HaveOccurred())
+					Expect(err.Error()).To(ContainSubstring("context canceled"))
+
+					Expect(server.ReceivedRequests()).To(BeEmpty())
+				})
+			})
 		})
 
 		Describe("Request logging", func() {
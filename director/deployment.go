@@ -1,6 +1,7 @@
 package director
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"net/http"
@@ -49,6 +50,12 @@ type VariableResult struct {
 
 func (d DeploymentImpl) Name() string { return d.name }
 
+func (d DeploymentImpl) WithGoContext(ctx context.Context, cancelTasks bool) Deployment {
+	// returns a copy of the DeploymentImpl
+	d.client = d.client.WithGoContext(ctx, cancelTasks)
+	return &d
+}
+
 func (d *DeploymentImpl) CloudConfig() (string, error) {
 	d.fetch()
 	return d.cloudConfig, d.fetchErr
@@ -212,6 +219,52 @@ func (d DeploymentImpl) Delete(force bool) error {
 	return nil
 }
 
+func (d DeploymentImpl) UpdateAsync(manifest []byte, opts UpdateOpts) (Task, error) {
+	return d.startTask(func(d DeploymentImpl) error { return d.Update(manifest, opts) })
+}
+
+func (d DeploymentImpl) DeleteAsync(force bool) (Task, error) {
+	return d.startTask(func(d DeploymentImpl) error { return d.Delete(force) })
+}
+
+func (d DeploymentImpl) StartAsync(slug AllOrInstanceGroupOrInstanceSlug, opts StartOpts) (Task, error) {
+	return d.startTask(func(d DeploymentImpl) error { return d.Start(slug, opts) })
+}
+
+func (d DeploymentImpl) StopAsync(slug AllOrInstanceGroupOrInstanceSlug, opts StopOpts) (Task, error) {
+	return d.startTask(func(d DeploymentImpl) error { return d.Stop(slug, opts) })
+}
+
+func (d DeploymentImpl) RestartAsync(slug AllOrInstanceGroupOrInstanceSlug, opts RestartOpts) (Task, error) {
+	return d.startTask(func(d DeploymentImpl) error { return d.Restart(slug, opts) })
+}
+
+func (d DeploymentImpl) RecreateAsync(slug AllOrInstanceGroupOrInstanceSlug, opts RecreateOpts) (Task, error) {
+	return d.startTask(func(d DeploymentImpl) error { return d.Recreate(slug, opts) })
+}
+
+// startTask runs action against a copy of the deployment
+// which returns as soon as Director starts a task for it
+func (d DeploymentImpl) startTask(action func(DeploymentImpl) error) (Task, error) {
+	var task Task
+
+	startingDep := d
+	startingDep.client = d.client.withTaskStarted(func(id int, state string) {
+		task = TaskImpl{client: d.client, id: id, state: state, deploymentName: d.name}
+	})
+
+	err := action(startingDep)
+	if err != nil {
+		return nil, err
+	}
+
+	if task == nil {
+		return nil, bosherr.Error("Expected Director to start a task")
+	}
+
+	return task, nil
+}
+
 func (d DeploymentImpl) AttachDisk(slug InstanceSlug, diskCID string, diskProperties string) error {
 	values := gourl.Values{}
 	values.Add("deployment", d.Name())
@@ -1,6 +1,7 @@
 package director_test
 
 import (
+	"context"
 	"fmt"
 	"net/http"
 	"net/url"
@@ -857,7 +858,78 @@ var _ = Describe("Deployment", func() {
 		})
 	})
 
+	Describe("WithGoContext", func() {
+		It("cancels task being waited for once context is cancelled", func() {
+			ctx, cancel := context.WithCancel(context.Background())
+
+			redirectHeader := http.Header{}
+			redirectHeader.Add("Location", "/tasks/123")
+
+			server.AppendHandlers(
+				ghttp.CombineHandlers(
+					ghttp.VerifyRequest("POST", "/deployments"),
+					ghttp.RespondWith(http.StatusFound, nil, redirectHeader),
+				),
+				ghttp.CombineHandlers(
+					ghttp.VerifyRequest("GET", "/tasks/123"),
+					ghttp.RespondWith(http.StatusOK, `{"id":123, "state":"queued"}`),
+				),
+				ghttp.CombineHandlers(
+					ghttp.VerifyRequest("GET", "/tasks/123"),
+					func(_ http.ResponseWriter, _ *http.Request) { cancel() },
+					ghttp.RespondWith(http.StatusOK, `{"id":123, "state":"processing"}`),
+				),
+				ghttp.CombineHandlers(
+					ghttp.VerifyRequest("DELETE", "/task/123"),
+					ghttp.VerifyBasicAuth("username", "password"),
+					ghttp.RespondWith(http.StatusNoContent, ""),
+				),
+			)
+
+			err := deployment.WithGoContext(ctx, true).Update([]byte("manifest"), UpdateOpts{})
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("context canceled"))
+
+			Expect(server.ReceivedRequests()).To(HaveLen(4))
+		})
+	})
+
 	Describe("Update", func() {
+		It("returns started task without waiting for it when updating asynchronously", func() {
+			redirectHeader := http.Header{}
+			redirectHeader.Add("Location", "/tasks/123")
+
+			server.AppendHandlers(
+				ghttp.CombineHandlers(
+					ghttp.VerifyRequest("POST", "/deployments"),
+					ghttp.VerifyBasicAuth("username", "password"),
+					ghttp.VerifyBody([]byte("manifest")),
+					ghttp.RespondWith(http.StatusFound, nil, redirectHeader),
+				),
+				ghttp.CombineHandlers(
+					ghttp.VerifyRequest("GET", "/tasks/123"),
+					ghttp.VerifyBasicAuth("username", "password"),
+					ghttp.RespondWith(http.StatusOK, `{"id":123, "state":"queued"}`),
+				),
+			)
+
+			task, err := deployment.UpdateAsync([]byte("manifest"), UpdateOpts{})
+			Expect(err).ToNot(HaveOccurred())
+			Expect(task.ID()).To(Equal(123))
+			Expect(task.State()).To(Equal("queued"))
+			Expect(task.DeploymentName()).To(Equal("dep"))
+
+			Expect(server.ReceivedRequests()).To(HaveLen(2))
+		})
+
+		It("returns error if asynchronous update cannot be started", func() {
+			AppendBadRequest(ghttp.VerifyRequest("POST", "/deployments"), server)
+
+			_, err := deployment.UpdateAsync([]byte("manifest"), UpdateOpts{})
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("Updating deployment"))
+		})
+
 		It("succeeds updating deployment", func() {
 			ConfigureTaskResult(
 				ghttp.CombineHandlers(
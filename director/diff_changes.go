@@ -0,0 +1,180 @@
+package director
+
+import (
+	"strings"
+)
+
+type DiffChangeType string
+
+const (
+	DiffChangeAdded   DiffChangeType = "added"
+	DiffChangeRemoved DiffChangeType = "removed"
+	DiffChangeChanged DiffChangeType = "changed"
+)
+
+// DiffChange describes a single added, removed or changed manifest section.
+// Path consists of map keys and list item names, e.g.
+// ["instance_groups", "web", "jobs", "nginx", "properties", "port"].
+type DiffChange struct {
+	Type DiffChangeType `json:"type"`
+	Path []string       `json:"path"`
+
+	InstanceGroup string   `json:"instance_group,omitempty"`
+	Job           string   `json:"job,omitempty"`
+	PropertyPath  []string `json:"property_path,omitempty"`
+
+	Old []string `json:"old,omitempty"`
+	New []string `json:"new,omitempty"`
+}
+
+type diffPathSegment struct {
+	indent int
+	name   string
+}
+
+// Changes groups diff lines into changes to manifest sections.
+func (d DeploymentDiff) Changes() []DiffChange {
+	return NewDiffChanges(d.Diff)
+}
+
+// NewDiffChanges parses diff lines (text and its 'added', 'removed' or empty state)
+// returned by the Director. Consecutive lines nested under the same added or removed
+// line are grouped into a single change; removal immediately followed by an addition
+// at the same path is reported as a change.
+func NewDiffChanges(lines [][]interface{}) []DiffChange {
+	var (
+		changes      []DiffChange
+		stack        []diffPathSegment
+		currIndent   int
+		changeIndent int
+	)
+
+	// Index of the change that following nested lines belong to
+	currChange := -1
+
+	for _, line := range lines {
+		if len(line) == 0 {
+			continue
+		}
+
+		text, _ := line[0].(string)
+
+		var state string
+		if len(line) > 1 {
+			state, _ = line[1].(string)
+		}
+
+		if len(strings.TrimSpace(text)) == 0 {
+			continue
+		}
+
+		currIndent, stack = pushDiffPathSegment(stack, text)
+
+		if state != string(DiffChangeAdded) && state != string(DiffChangeRemoved) {
+			currChange = -1
+			continue
+		}
+
+		if currChange >= 0 && currIndent > changeIndent {
+			change := &changes[currChange]
+			if string(change.Type) == state || (change.Type == DiffChangeChanged && state == string(DiffChangeAdded)) {
+				appendDiffChangeLine(change, state, text)
+				continue
+			}
+		}
+
+		path := make([]string, len(stack))
+		for i, segment := range stack {
+			path[i] = segment.name
+		}
+
+		if state == string(DiffChangeAdded) && currChange >= 0 {
+			change := &changes[currChange]
+			if change.Type == DiffChangeRemoved && equalDiffPaths(change.Path, path) {
+				change.Type = DiffChangeChanged
+				appendDiffChangeLine(change, state, text)
+				changeIndent = currIndent
+				continue
+			}
+		}
+
+		changes = append(changes, newDiffChange(DiffChangeType(state), path, text))
+		currChange = len(changes) - 1
+		changeIndent = currIndent
+	}
+
+	return changes
+}
+
+// pushDiffPathSegment keeps track of the path of the given line.
+// List items are considered to be nested one level under their key
+// since Director formats them with the same indentation.
+func pushDiffPathSegment(stack []diffPathSegment, text string) (int, []diffPathSegment) {
+	trimmed := strings.TrimLeft(text, " ")
+	indent := len(text) - len(trimmed)
+
+	var name string
+
+	if strings.HasPrefix(trimmed, "- ") || trimmed == "-" {
+		indent++
+		name = strings.TrimSpace(strings.TrimPrefix(trimmed, "-"))
+
+		if strings.HasPrefix(name, "name: ") {
+			name = strings.TrimSpace(strings.TrimPrefix(name, "name: "))
+		}
+	} else if idx := strings.Index(trimmed, ":"); idx > 0 && (idx == len(trimmed)-1 || trimmed[idx+1] == ' ') {
+		name = trimmed[0:idx]
+	}
+
+	for len(stack) > 0 && stack[len(stack)-1].indent >= indent {
+		stack = stack[:len(stack)-1]
+	}
+
+	if len(name) > 0 {
+		stack = append(stack, diffPathSegment{indent: indent, name: name})
+	}
+
+	return indent, stack
+}
+
+func newDiffChange(changeType DiffChangeType, path []string, text string) DiffChange {
+	change := DiffChange{Type: changeType, Path: path}
+
+	if len(path) > 1 && path[0] == "instance_groups" {
+		change.InstanceGroup = path[1]
+
+		if len(path) > 3 && path[2] == "jobs" {
+			change.Job = path[3]
+
+			if len(path) > 5 && path[4] == "properties" {
+				change.PropertyPath = path[5:]
+			}
+		}
+	}
+
+	appendDiffChangeLine(&change, string(changeType), text)
+
+	return change
+}
+
+func appendDiffChangeLine(change *DiffChange, state string, text string) {
+	if state == string(DiffChangeRemoved) {
+		change.Old = append(change.Old, text)
+	} else {
+		change.New = append(change.New, text)
+	}
+}
+
+func equalDiffPaths(a, b []string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+
+	return true
+}
@@ -0,0 +1,104 @@
+package director_test
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	. "github.com/cloudfoundry/bosh-cli/director"
+)
+
+var _ = Describe("NewDiffChanges", func() {
+	It("returns no changes when nothing was added or removed", func() {
+		changes := NewDiffChanges([][]interface{}{
+			[]interface{}{"name: dep", ""},
+			[]interface{}{"", ""},
+		})
+		Expect(changes).To(BeEmpty())
+	})
+
+	It("reports job property changes with instance group, job and property path", func() {
+		changes := NewDiffChanges([][]interface{}{
+			[]interface{}{"instance_groups:", ""},
+			[]interface{}{"- name: web", ""},
+			[]interface{}{"  jobs:", ""},
+			[]interface{}{"  - name: nginx", ""},
+			[]interface{}{"    properties:", ""},
+			[]interface{}{"      nginx:", ""},
+			[]interface{}{"        port: 80", "removed"},
+			[]interface{}{"        port: 8080", "added"},
+		})
+
+		Expect(changes).To(Equal([]DiffChange{
+			{
+				Type:          DiffChangeChanged,
+				Path:          []string{"instance_groups", "web", "jobs", "nginx", "properties", "nginx", "port"},
+				InstanceGroup: "web",
+				Job:           "nginx",
+				PropertyPath:  []string{"nginx", "port"},
+				Old:           []string{"        port: 80"},
+				New:           []string{"        port: 8080"},
+			},
+		}))
+	})
+
+	It("groups nested lines under added and removed sections", func() {
+		changes := NewDiffChanges([][]interface{}{
+			[]interface{}{"instance_groups:", ""},
+			[]interface{}{"- name: api", "removed"},
+			[]interface{}{"  instances: 1", "removed"},
+			[]interface{}{"- name: web", ""},
+			[]interface{}{"  jobs:", ""},
+			[]interface{}{"  - name: route", "added"},
+			[]interface{}{"    release: routing", "added"},
+			[]interface{}{"  - name: nginx", ""},
+		})
+
+		Expect(changes).To(Equal([]DiffChange{
+			{
+				Type:          DiffChangeRemoved,
+				Path:          []string{"instance_groups", "api"},
+				InstanceGroup: "api",
+				Old:           []string{"- name: api", "  instances: 1"},
+			},
+			{
+				Type:          DiffChangeAdded,
+				Path:          []string{"instance_groups", "web", "jobs", "route"},
+				InstanceGroup: "web",
+				Job:           "route",
+				New:           []string{"  - name: route", "    release: routing"},
+			},
+		}))
+	})
+
+	It("reports sibling changes separately", func() {
+		changes := NewDiffChanges([][]interface{}{
+			[]interface{}{"stemcells:", ""},
+			[]interface{}{"- alias: default", ""},
+			[]interface{}{"  version: '1'", "removed"},
+			[]interface{}{"  version: '2'", "added"},
+			[]interface{}{"update:", ""},
+			[]interface{}{"  canaries: 1", "added"},
+			[]interface{}{"  max_in_flight: 2", "added"},
+		})
+
+		Expect(changes).To(HaveLen(3))
+		Expect(changes[0].Type).To(Equal(DiffChangeChanged))
+		Expect(changes[0].Path).To(Equal([]string{"stemcells", "alias: default", "version"}))
+		Expect(changes[1].Path).To(Equal([]string{"update", "canaries"}))
+		Expect(changes[2].Path).To(Equal([]string{"update", "max_in_flight"}))
+	})
+})
+
+var _ = Describe("DeploymentDiff", func() {
+	Describe("Changes", func() {
+		It("parses diff lines", func() {
+			diff := NewDeploymentDiff([][]interface{}{
+				[]interface{}{"name: dep", "added"},
+			}, nil)
+
+			Expect(diff.Changes()).To(Equal([]DiffChange{
+				{Type: DiffChangeAdded, Path: []string{"name"}, New: []string{"name: dep"}},
+			}))
+		})
+	})
+})
@@ -1,6 +1,7 @@
 package director
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	bosherr "github.com/cloudfoundry/bosh-utils/errors"
@@ -25,6 +26,10 @@ func (d DirectorImpl) WithContext(id string) Director {
 	return DirectorImpl{client: d.client.WithContext(id)}
 }
 
+func (d DirectorImpl) WithGoContext(ctx context.Context, cancelTasks bool) Director {
+	return DirectorImpl{client: d.client.WithGoContext(ctx, cancelTasks)}
+}
+
 func (c Client) OrphanedVMs() ([]OrphanedVM, error) {
 	var resps []OrphanedVMResponse
 
@@ -2,6 +2,7 @@
 package directorfakes
 
 import (
+	"context"
 	"sync"
 
 	"github.com/cloudfoundry/bosh-cli/director"
@@ -56,6 +57,19 @@ type FakeDeployment struct {
 	deleteReturnsOnCall map[int]struct {
 		result1 error
 	}
+	DeleteAsyncStub        func(bool) (director.Task, error)
+	deleteAsyncMutex       sync.RWMutex
+	deleteAsyncArgsForCall []struct {
+		arg1 bool
+	}
+	deleteAsyncReturns struct {
+		result1 director.Task
+		result2 error
+	}
+	deleteAsyncReturnsOnCall map[int]struct {
+		result1 director.Task
+		result2 error
+	}
 	DeleteSnapshotStub        func(string) error
 	deleteSnapshotMutex       sync.RWMutex
 	deleteSnapshotArgsForCall []struct {
@@ -226,6 +240,20 @@ type FakeDeployment struct {
 	recreateReturnsOnCall map[int]struct {
 		result1 error
 	}
+	RecreateAsyncStub        func(director.AllOrInstanceGroupOrInstanceSlug, director.RecreateOpts) (director.Task, error)
+	recreateAsyncMutex       sync.RWMutex
+	recreateAsyncArgsForCall []struct {
+		arg1 director.AllOrInstanceGroupOrInstanceSlug
+		arg2 director.RecreateOpts
+	}
+	recreateAsyncReturns struct {
+		result1 director.Task
+		result2 error
+	}
+	recreateAsyncReturnsOnCall map[int]struct {
+		result1 director.Task
+		result2 error
+	}
 	ReleasesStub        func() ([]director.Release, error)
 	releasesMutex       sync.RWMutex
 	releasesArgsForCall []struct {
@@ -261,6 +289,20 @@ type FakeDeployment struct {
 	restartReturnsOnCall map[int]struct {
 		result1 error
 	}
+	RestartAsyncStub        func(director.AllOrInstanceGroupOrInstanceSlug, director.RestartOpts) (director.Task, error)
+	restartAsyncMutex       sync.RWMutex
+	restartAsyncArgsForCall []struct {
+		arg1 director.AllOrInstanceGroupOrInstanceSlug
+		arg2 director.RestartOpts
+	}
+	restartAsyncReturns struct {
+		result1 director.Task
+		result2 error
+	}
+	restartAsyncReturnsOnCall map[int]struct {
+		result1 director.Task
+		result2 error
+	}
 	RunErrandStub        func(string, bool, bool, []director.InstanceGroupOrInstanceSlug) ([]director.ErrandResult, error)
 	runErrandMutex       sync.RWMutex
 	runErrandArgsForCall []struct {
@@ -277,6 +319,22 @@ type FakeDeployment struct {
 		result1 []director.ErrandResult
 		result2 error
 	}
+	RunErrandAsyncStub        func(string, bool, bool, []director.InstanceGroupOrInstanceSlug) (director.Task, error)
+	runErrandAsyncMutex       sync.RWMutex
+	runErrandAsyncArgsForCall []struct {
+		arg1 string
+		arg2 bool
+		arg3 bool
+		arg4 []director.InstanceGroupOrInstanceSlug
+	}
+	runErrandAsyncReturns struct {
+		result1 director.Task
+		result2 error
+	}
+	runErrandAsyncReturnsOnCall map[int]struct {
+		result1 director.Task
+		result2 error
+	}
 	ScanForProblemsStub        func() ([]director.Problem, error)
 	scanForProblemsMutex       sync.RWMutex
 	scanForProblemsArgsForCall []struct {
@@ -327,6 +385,20 @@ type FakeDeployment struct {
 	startReturnsOnCall map[int]struct {
 		result1 error
 	}
+	StartAsyncStub        func(director.AllOrInstanceGroupOrInstanceSlug, director.StartOpts) (director.Task, error)
+	startAsyncMutex       sync.RWMutex
+	startAsyncArgsForCall []struct {
+		arg1 director.AllOrInstanceGroupOrInstanceSlug
+		arg2 director.StartOpts
+	}
+	startAsyncReturns struct {
+		result1 director.Task
+		result2 error
+	}
+	startAsyncReturnsOnCall map[int]struct {
+		result1 director.Task
+		result2 error
+	}
 	StemcellsStub        func() ([]director.Stemcell, error)
 	stemcellsMutex       sync.RWMutex
 	stemcellsArgsForCall []struct {
@@ -351,6 +423,20 @@ type FakeDeployment struct {
 	stopReturnsOnCall map[int]struct {
 		result1 error
 	}
+	StopAsyncStub        func(director.AllOrInstanceGroupOrInstanceSlug, director.StopOpts) (director.Task, error)
+	stopAsyncMutex       sync.RWMutex
+	stopAsyncArgsForCall []struct {
+		arg1 director.AllOrInstanceGroupOrInstanceSlug
+		arg2 director.StopOpts
+	}
+	stopAsyncReturns struct {
+		result1 director.Task
+		result2 error
+	}
+	stopAsyncReturnsOnCall map[int]struct {
+		result1 director.Task
+		result2 error
+	}
 	TakeSnapshotStub        func(director.InstanceSlug) error
 	takeSnapshotMutex       sync.RWMutex
 	takeSnapshotArgsForCall []struct {
@@ -372,6 +458,18 @@ type FakeDeployment struct {
 	takeSnapshotsReturnsOnCall map[int]struct {
 		result1 error
 	}
+	TakeSnapshotsAsyncStub        func() (director.Task, error)
+	takeSnapshotsAsyncMutex       sync.RWMutex
+	takeSnapshotsAsyncArgsForCall []struct {
+	}
+	takeSnapshotsAsyncReturns struct {
+		result1 director.Task
+		result2 error
+	}
+	takeSnapshotsAsyncReturnsOnCall map[int]struct {
+		result1 director.Task
+		result2 error
+	}
 	TeamsStub        func() ([]string, error)
 	teamsMutex       sync.RWMutex
 	teamsArgsForCall []struct {
@@ -396,6 +494,20 @@ type FakeDeployment struct {
 	updateReturnsOnCall map[int]struct {
 		result1 error
 	}
+	UpdateAsyncStub        func([]byte, director.UpdateOpts) (director.Task, error)
+	updateAsyncMutex       sync.RWMutex
+	updateAsyncArgsForCall []struct {
+		arg1 []byte
+		arg2 director.UpdateOpts
+	}
+	updateAsyncReturns struct {
+		result1 director.Task
+		result2 error
+	}
+	updateAsyncReturnsOnCall map[int]struct {
+		result1 director.Task
+		result2 error
+	}
 	VMInfosStub        func() ([]director.VMInfo, error)
 	vMInfosMutex       sync.RWMutex
 	vMInfosArgsForCall []struct {
@@ -420,6 +532,18 @@ type FakeDeployment struct {
 		result1 []director.VariableResult
 		result2 error
 	}
+	WithGoContextStub        func(context.Context, bool) director.Deployment
+	withGoContextMutex       sync.RWMutex
+	withGoContextArgsForCall []struct {
+		arg1 context.Context
+		arg2 bool
+	}
+	withGoContextReturns struct {
+		result1 director.Deployment
+	}
+	withGoContextReturnsOnCall map[int]struct {
+		result1 director.Deployment
+	}
 	invocations      map[string][][]interface{}
 	invocationsMutex sync.RWMutex
 }
@@ -666,6 +790,70 @@ func (fake *FakeDeployment) DeleteReturnsOnCall(i int, result1 error) {
 	}{result1}
 }
 
+func (fake *FakeDeployment) DeleteAsync(arg1 bool) (director.Task, error) {
+	fake.deleteAsyncMutex.Lock()
+	ret, specificReturn := fake.deleteAsyncReturnsOnCall[len(fake.deleteAsyncArgsForCall)]
+	fake.deleteAsyncArgsForCall = append(fake.deleteAsyncArgsForCall, struct {
+		arg1 bool
+	}{arg1})
+	stub := fake.DeleteAsyncStub
+	fakeReturns := fake.deleteAsyncReturns
+	fake.recordInvocation("DeleteAsync", []interface{}{arg1})
+	fake.deleteAsyncMutex.Unlock()
+	if stub != nil {
+		return stub(arg1)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeDeployment) DeleteAsyncCallCount() int {
+	fake.deleteAsyncMutex.RLock()
+	defer fake.deleteAsyncMutex.RUnlock()
+	return len(fake.deleteAsyncArgsForCall)
+}
+
+func (fake *FakeDeployment) DeleteAsyncCalls(stub func(bool) (director.Task, error)) {
+	fake.deleteAsyncMutex.Lock()
+	defer fake.deleteAsyncMutex.Unlock()
+	fake.DeleteAsyncStub = stub
+}
+
+func (fake *FakeDeployment) DeleteAsyncArgsForCall(i int) bool {
+	fake.deleteAsyncMutex.RLock()
+	defer fake.deleteAsyncMutex.RUnlock()
+	argsForCall := fake.deleteAsyncArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *FakeDeployment) DeleteAsyncReturns(result1 director.Task, result2 error) {
+	fake.deleteAsyncMutex.Lock()
+	defer fake.deleteAsyncMutex.Unlock()
+	fake.DeleteAsyncStub = nil
+	fake.deleteAsyncReturns = struct {
+		result1 director.Task
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeDeployment) DeleteAsyncReturnsOnCall(i int, result1 director.Task, result2 error) {
+	fake.deleteAsyncMutex.Lock()
+	defer fake.deleteAsyncMutex.Unlock()
+	fake.DeleteAsyncStub = nil
+	if fake.deleteAsyncReturnsOnCall == nil {
+		fake.deleteAsyncReturnsOnCall = make(map[int]struct {
+			result1 director.Task
+			result2 error
+		})
+	}
+	fake.deleteAsyncReturnsOnCall[i] = struct {
+		result1 director.Task
+		result2 error
+	}{result1, result2}
+}
+
 func (fake *FakeDeployment) DeleteSnapshot(arg1 string) error {
 	fake.deleteSnapshotMutex.Lock()
 	ret, specificReturn := fake.deleteSnapshotReturnsOnCall[len(fake.deleteSnapshotArgsForCall)]
@@ -1516,6 +1704,71 @@ func (fake *FakeDeployment) RecreateReturnsOnCall(i int, result1 error) {
 	}{result1}
 }
 
+func (fake *FakeDeployment) RecreateAsync(arg1 director.AllOrInstanceGroupOrInstanceSlug, arg2 director.RecreateOpts) (director.Task, error) {
+	fake.recreateAsyncMutex.Lock()
+	ret, specificReturn := fake.recreateAsyncReturnsOnCall[len(fake.recreateAsyncArgsForCall)]
+	fake.recreateAsyncArgsForCall = append(fake.recreateAsyncArgsForCall, struct {
+		arg1 director.AllOrInstanceGroupOrInstanceSlug
+		arg2 director.RecreateOpts
+	}{arg1, arg2})
+	stub := fake.RecreateAsyncStub
+	fakeReturns := fake.recreateAsyncReturns
+	fake.recordInvocation("RecreateAsync", []interface{}{arg1, arg2})
+	fake.recreateAsyncMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeDeployment) RecreateAsyncCallCount() int {
+	fake.recreateAsyncMutex.RLock()
+	defer fake.recreateAsyncMutex.RUnlock()
+	return len(fake.recreateAsyncArgsForCall)
+}
+
+func (fake *FakeDeployment) RecreateAsyncCalls(stub func(director.AllOrInstanceGroupOrInstanceSlug, director.RecreateOpts) (director.Task, error)) {
+	fake.recreateAsyncMutex.Lock()
+	defer fake.recreateAsyncMutex.Unlock()
+	fake.RecreateAsyncStub = stub
+}
+
+func (fake *FakeDeployment) RecreateAsyncArgsForCall(i int) (director.AllOrInstanceGroupOrInstanceSlug, director.RecreateOpts) {
+	fake.recreateAsyncMutex.RLock()
+	defer fake.recreateAsyncMutex.RUnlock()
+	argsForCall := fake.recreateAsyncArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *FakeDeployment) RecreateAsyncReturns(result1 director.Task, result2 error) {
+	fake.recreateAsyncMutex.Lock()
+	defer fake.recreateAsyncMutex.Unlock()
+	fake.RecreateAsyncStub = nil
+	fake.recreateAsyncReturns = struct {
+		result1 director.Task
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeDeployment) RecreateAsyncReturnsOnCall(i int, result1 director.Task, result2 error) {
+	fake.recreateAsyncMutex.Lock()
+	defer fake.recreateAsyncMutex.Unlock()
+	fake.RecreateAsyncStub = nil
+	if fake.recreateAsyncReturnsOnCall == nil {
+		fake.recreateAsyncReturnsOnCall = make(map[int]struct {
+			result1 director.Task
+			result2 error
+		})
+	}
+	fake.recreateAsyncReturnsOnCall[i] = struct {
+		result1 director.Task
+		result2 error
+	}{result1, result2}
+}
+
 func (fake *FakeDeployment) Releases() ([]director.Release, error) {
 	fake.releasesMutex.Lock()
 	ret, specificReturn := fake.releasesReturnsOnCall[len(fake.releasesArgsForCall)]
@@ -1700,6 +1953,71 @@ func (fake *FakeDeployment) RestartReturnsOnCall(i int, result1 error) {
 	}{result1}
 }
 
+func (fake *FakeDeployment) RestartAsync(arg1 director.AllOrInstanceGroupOrInstanceSlug, arg2 director.RestartOpts) (director.Task, error) {
+	fake.restartAsyncMutex.Lock()
+	ret, specificReturn := fake.restartAsyncReturnsOnCall[len(fake.restartAsyncArgsForCall)]
+	fake.restartAsyncArgsForCall = append(fake.restartAsyncArgsForCall, struct {
+		arg1 director.AllOrInstanceGroupOrInstanceSlug
+		arg2 director.RestartOpts
+	}{arg1, arg2})
+	stub := fake.RestartAsyncStub
+	fakeReturns := fake.restartAsyncReturns
+	fake.recordInvocation("RestartAsync", []interface{}{arg1, arg2})
+	fake.restartAsyncMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeDeployment) RestartAsyncCallCount() int {
+	fake.restartAsyncMutex.RLock()
+	defer fake.restartAsyncMutex.RUnlock()
+	return len(fake.restartAsyncArgsForCall)
+}
+
+func (fake *FakeDeployment) RestartAsyncCalls(stub func(director.AllOrInstanceGroupOrInstanceSlug, director.RestartOpts) (director.Task, error)) {
+	fake.restartAsyncMutex.Lock()
+	defer fake.restartAsyncMutex.Unlock()
+	fake.RestartAsyncStub = stub
+}
+
+func (fake *FakeDeployment) RestartAsyncArgsForCall(i int) (director.AllOrInstanceGroupOrInstanceSlug, director.RestartOpts) {
+	fake.restartAsyncMutex.RLock()
+	defer fake.restartAsyncMutex.RUnlock()
+	argsForCall := fake.restartAsyncArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *FakeDeployment) RestartAsyncReturns(result1 director.Task, result2 error) {
+	fake.restartAsyncMutex.Lock()
+	defer fake.restartAsyncMutex.Unlock()
+	fake.RestartAsyncStub = nil
+	fake.restartAsyncReturns = struct {
+		result1 director.Task
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeDeployment) RestartAsyncReturnsOnCall(i int, result1 director.Task, result2 error) {
+	fake.restartAsyncMutex.Lock()
+	defer fake.restartAsyncMutex.Unlock()
+	fake.RestartAsyncStub = nil
+	if fake.restartAsyncReturnsOnCall == nil {
+		fake.restartAsyncReturnsOnCall = make(map[int]struct {
+			result1 director.Task
+			result2 error
+		})
+	}
+	fake.restartAsyncReturnsOnCall[i] = struct {
+		result1 director.Task
+		result2 error
+	}{result1, result2}
+}
+
 func (fake *FakeDeployment) RunErrand(arg1 string, arg2 bool, arg3 bool, arg4 []director.InstanceGroupOrInstanceSlug) ([]director.ErrandResult, error) {
 	var arg4Copy []director.InstanceGroupOrInstanceSlug
 	if arg4 != nil {
@@ -1772,6 +2090,78 @@ func (fake *FakeDeployment) RunErrandReturnsOnCall(i int, result1 []director.Err
 	}{result1, result2}
 }
 
+func (fake *FakeDeployment) RunErrandAsync(arg1 string, arg2 bool, arg3 bool, arg4 []director.InstanceGroupOrInstanceSlug) (director.Task, error) {
+	var arg4Copy []director.InstanceGroupOrInstanceSlug
+	if arg4 != nil {
+		arg4Copy = make([]director.InstanceGroupOrInstanceSlug, len(arg4))
+		copy(arg4Copy, arg4)
+	}
+	fake.runErrandAsyncMutex.Lock()
+	ret, specificReturn := fake.runErrandAsyncReturnsOnCall[len(fake.runErrandAsyncArgsForCall)]
+	fake.runErrandAsyncArgsForCall = append(fake.runErrandAsyncArgsForCall, struct {
+		arg1 string
+		arg2 bool
+		arg3 bool
+		arg4 []director.InstanceGroupOrInstanceSlug
+	}{arg1, arg2, arg3, arg4Copy})
+	stub := fake.RunErrandAsyncStub
+	fakeReturns := fake.runErrandAsyncReturns
+	fake.recordInvocation("RunErrandAsync", []interface{}{arg1, arg2, arg3, arg4Copy})
+	fake.runErrandAsyncMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2, arg3, arg4)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeDeployment) RunErrandAsyncCallCount() int {
+	fake.runErrandAsyncMutex.RLock()
+	defer fake.runErrandAsyncMutex.RUnlock()
+	return len(fake.runErrandAsyncArgsForCall)
+}
+
+func (fake *FakeDeployment) RunErrandAsyncCalls(stub func(string, bool, bool, []director.InstanceGroupOrInstanceSlug) (director.Task, error)) {
+	fake.runErrandAsyncMutex.Lock()
+	defer fake.runErrandAsyncMutex.Unlock()
+	fake.RunErrandAsyncStub = stub
+}
+
+func (fake *FakeDeployment) RunErrandAsyncArgsForCall(i int) (string, bool, bool, []director.InstanceGroupOrInstanceSlug) {
+	fake.runErrandAsyncMutex.RLock()
+	defer fake.runErrandAsyncMutex.RUnlock()
+	argsForCall := fake.runErrandAsyncArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3, argsForCall.arg4
+}
+
+func (fake *FakeDeployment) RunErrandAsyncReturns(result1 director.Task, result2 error) {
+	fake.runErrandAsyncMutex.Lock()
+	defer fake.runErrandAsyncMutex.Unlock()
+	fake.RunErrandAsyncStub = nil
+	fake.runErrandAsyncReturns = struct {
+		result1 director.Task
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeDeployment) RunErrandAsyncReturnsOnCall(i int, result1 director.Task, result2 error) {
+	fake.runErrandAsyncMutex.Lock()
+	defer fake.runErrandAsyncMutex.Unlock()
+	fake.RunErrandAsyncStub = nil
+	if fake.runErrandAsyncReturnsOnCall == nil {
+		fake.runErrandAsyncReturnsOnCall = make(map[int]struct {
+			result1 director.Task
+			result2 error
+		})
+	}
+	fake.runErrandAsyncReturnsOnCall[i] = struct {
+		result1 director.Task
+		result2 error
+	}{result1, result2}
+}
+
 func (fake *FakeDeployment) ScanForProblems() ([]director.Problem, error) {
 	fake.scanForProblemsMutex.Lock()
 	ret, specificReturn := fake.scanForProblemsReturnsOnCall[len(fake.scanForProblemsArgsForCall)]
@@ -2011,6 +2401,71 @@ func (fake *FakeDeployment) StartReturnsOnCall(i int, result1 error) {
 	}{result1}
 }
 
+func (fake *FakeDeployment) StartAsync(arg1 director.AllOrInstanceGroupOrInstanceSlug, arg2 director.StartOpts) (director.Task, error) {
+	fake.startAsyncMutex.Lock()
+	ret, specificReturn := fake.startAsyncReturnsOnCall[len(fake.startAsyncArgsForCall)]
+	fake.startAsyncArgsForCall = append(fake.startAsyncArgsForCall, struct {
+		arg1 director.AllOrInstanceGroupOrInstanceSlug
+		arg2 director.StartOpts
+	}{arg1, arg2})
+	stub := fake.StartAsyncStub
+	fakeReturns := fake.startAsyncReturns
+	fake.recordInvocation("StartAsync", []interface{}{arg1, arg2})
+	fake.startAsyncMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeDeployment) StartAsyncCallCount() int {
+	fake.startAsyncMutex.RLock()
+	defer fake.startAsyncMutex.RUnlock()
+	return len(fake.startAsyncArgsForCall)
+}
+
+func (fake *FakeDeployment) StartAsyncCalls(stub func(director.AllOrInstanceGroupOrInstanceSlug, director.StartOpts) (director.Task, error)) {
+	fake.startAsyncMutex.Lock()
+	defer fake.startAsyncMutex.Unlock()
+	fake.StartAsyncStub = stub
+}
+
+func (fake *FakeDeployment) StartAsyncArgsForCall(i int) (director.AllOrInstanceGroupOrInstanceSlug, director.StartOpts) {
+	fake.startAsyncMutex.RLock()
+	defer fake.startAsyncMutex.RUnlock()
+	argsForCall := fake.startAsyncArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *FakeDeployment) StartAsyncReturns(result1 director.Task, result2 error) {
+	fake.startAsyncMutex.Lock()
+	defer fake.startAsyncMutex.Unlock()
+	fake.StartAsyncStub = nil
+	fake.startAsyncReturns = struct {
+		result1 director.Task
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeDeployment) StartAsyncReturnsOnCall(i int, result1 director.Task, result2 error) {
+	fake.startAsyncMutex.Lock()
+	defer fake.startAsyncMutex.Unlock()
+	fake.StartAsyncStub = nil
+	if fake.startAsyncReturnsOnCall == nil {
+		fake.startAsyncReturnsOnCall = make(map[int]struct {
+			result1 director.Task
+			result2 error
+		})
+	}
+	fake.startAsyncReturnsOnCall[i] = struct {
+		result1 director.Task
+		result2 error
+	}{result1, result2}
+}
+
 func (fake *FakeDeployment) Stemcells() ([]director.Stemcell, error) {
 	fake.stemcellsMutex.Lock()
 	ret, specificReturn := fake.stemcellsReturnsOnCall[len(fake.stemcellsArgsForCall)]
@@ -2129,6 +2584,71 @@ func (fake *FakeDeployment) StopReturnsOnCall(i int, result1 error) {
 	}{result1}
 }
 
+func (fake *FakeDeployment) StopAsync(arg1 director.AllOrInstanceGroupOrInstanceSlug, arg2 director.StopOpts) (director.Task, error) {
+	fake.stopAsyncMutex.Lock()
+	ret, specificReturn := fake.stopAsyncReturnsOnCall[len(fake.stopAsyncArgsForCall)]
+	fake.stopAsyncArgsForCall = append(fake.stopAsyncArgsForCall, struct {
+		arg1 director.AllOrInstanceGroupOrInstanceSlug
+		arg2 director.StopOpts
+	}{arg1, arg2})
+	stub := fake.StopAsyncStub
+	fakeReturns := fake.stopAsyncReturns
+	fake.recordInvocation("StopAsync", []interface{}{arg1, arg2})
+	fake.stopAsyncMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeDeployment) StopAsyncCallCount() int {
+	fake.stopAsyncMutex.RLock()
+	defer fake.stopAsyncMutex.RUnlock()
+	return len(fake.stopAsyncArgsForCall)
+}
+
+func (fake *FakeDeployment) StopAsyncCalls(stub func(director.AllOrInstanceGroupOrInstanceSlug, director.StopOpts) (director.Task, error)) {
+	fake.stopAsyncMutex.Lock()
+	defer fake.stopAsyncMutex.Unlock()
+	fake.StopAsyncStub = stub
+}
+
+func (fake *FakeDeployment) StopAsyncArgsForCall(i int) (director.AllOrInstanceGroupOrInstanceSlug, director.StopOpts) {
+	fake.stopAsyncMutex.RLock()
+	defer fake.stopAsyncMutex.RUnlock()
+	argsForCall := fake.stopAsyncArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *FakeDeployment) StopAsyncReturns(result1 director.Task, result2 error) {
+	fake.stopAsyncMutex.Lock()
+	defer fake.stopAsyncMutex.Unlock()
+	fake.StopAsyncStub = nil
+	fake.stopAsyncReturns = struct {
+		result1 director.Task
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeDeployment) StopAsyncReturnsOnCall(i int, result1 director.Task, result2 error) {
+	fake.stopAsyncMutex.Lock()
+	defer fake.stopAsyncMutex.Unlock()
+	fake.StopAsyncStub = nil
+	if fake.stopAsyncReturnsOnCall == nil {
+		fake.stopAsyncReturnsOnCall = make(map[int]struct {
+			result1 director.Task
+			result2 error
+		})
+	}
+	fake.stopAsyncReturnsOnCall[i] = struct {
+		result1 director.Task
+		result2 error
+	}{result1, result2}
+}
+
 func (fake *FakeDeployment) TakeSnapshot(arg1 director.InstanceSlug) error {
 	fake.takeSnapshotMutex.Lock()
 	ret, specificReturn := fake.takeSnapshotReturnsOnCall[len(fake.takeSnapshotArgsForCall)]
@@ -2243,6 +2763,62 @@ func (fake *FakeDeployment) TakeSnapshotsReturnsOnCall(i int, result1 error) {
 	}{result1}
 }
 
+func (fake *FakeDeployment) TakeSnapshotsAsync() (director.Task, error) {
+	fake.takeSnapshotsAsyncMutex.Lock()
+	ret, specificReturn := fake.takeSnapshotsAsyncReturnsOnCall[len(fake.takeSnapshotsAsyncArgsForCall)]
+	fake.takeSnapshotsAsyncArgsForCall = append(fake.takeSnapshotsAsyncArgsForCall, struct {
+	}{})
+	stub := fake.TakeSnapshotsAsyncStub
+	fakeReturns := fake.takeSnapshotsAsyncReturns
+	fake.recordInvocation("TakeSnapshotsAsync", []interface{}{})
+	fake.takeSnapshotsAsyncMutex.Unlock()
+	if stub != nil {
+		return stub()
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeDeployment) TakeSnapshotsAsyncCallCount() int {
+	fake.takeSnapshotsAsyncMutex.RLock()
+	defer fake.takeSnapshotsAsyncMutex.RUnlock()
+	return len(fake.takeSnapshotsAsyncArgsForCall)
+}
+
+func (fake *FakeDeployment) TakeSnapshotsAsyncCalls(stub func() (director.Task, error)) {
+	fake.takeSnapshotsAsyncMutex.Lock()
+	defer fake.takeSnapshotsAsyncMutex.Unlock()
+	fake.TakeSnapshotsAsyncStub = stub
+}
+
+func (fake *FakeDeployment) TakeSnapshotsAsyncReturns(result1 director.Task, result2 error) {
+	fake.takeSnapshotsAsyncMutex.Lock()
+	defer fake.takeSnapshotsAsyncMutex.Unlock()
+	fake.TakeSnapshotsAsyncStub = nil
+	fake.takeSnapshotsAsyncReturns = struct {
+		result1 director.Task
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeDeployment) TakeSnapshotsAsyncReturnsOnCall(i int, result1 director.Task, result2 error) {
+	fake.takeSnapshotsAsyncMutex.Lock()
+	defer fake.takeSnapshotsAsyncMutex.Unlock()
+	fake.TakeSnapshotsAsyncStub = nil
+	if fake.takeSnapshotsAsyncReturnsOnCall == nil {
+		fake.takeSnapshotsAsyncReturnsOnCall = make(map[int]struct {
+			result1 director.Task
+			result2 error
+		})
+	}
+	fake.takeSnapshotsAsyncReturnsOnCall[i] = struct {
+		result1 director.Task
+		result2 error
+	}{result1, result2}
+}
+
 func (fake *FakeDeployment) Teams() ([]string, error) {
 	fake.teamsMutex.Lock()
 	ret, specificReturn := fake.teamsReturnsOnCall[len(fake.teamsArgsForCall)]
@@ -2366,6 +2942,76 @@ func (fake *FakeDeployment) UpdateReturnsOnCall(i int, result1 error) {
 	}{result1}
 }
 
+func (fake *FakeDeployment) UpdateAsync(arg1 []byte, arg2 director.UpdateOpts) (director.Task, error) {
+	var arg1Copy []byte
+	if arg1 != nil {
+		arg1Copy = make([]byte, len(arg1))
+		copy(arg1Copy, arg1)
+	}
+	fake.updateAsyncMutex.Lock()
+	ret, specificReturn := fake.updateAsyncReturnsOnCall[len(fake.updateAsyncArgsForCall)]
+	fake.updateAsyncArgsForCall = append(fake.updateAsyncArgsForCall, struct {
+		arg1 []byte
+		arg2 director.UpdateOpts
+	}{arg1Copy, arg2})
+	stub := fake.UpdateAsyncStub
+	fakeReturns := fake.updateAsyncReturns
+	fake.recordInvocation("UpdateAsync", []interface{}{arg1Copy, arg2})
+	fake.updateAsyncMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeDeployment) UpdateAsyncCallCount() int {
+	fake.updateAsyncMutex.RLock()
+	defer fake.updateAsyncMutex.RUnlock()
+	return len(fake.updateAsyncArgsForCall)
+}
+
+func (fake *FakeDeployment) UpdateAsyncCalls(stub func([]byte, director.UpdateOpts) (director.Task, error)) {
+	fake.updateAsyncMutex.Lock()
+	defer fake.updateAsyncMutex.Unlock()
+	fake.UpdateAsyncStub = stub
+}
+
+func (fake *FakeDeployment) UpdateAsyncArgsForCall(i int) ([]byte, director.UpdateOpts) {
+	fake.updateAsyncMutex.RLock()
+	defer fake.updateAsyncMutex.RUnlock()
+	argsForCall := fake.updateAsyncArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *FakeDeployment) UpdateAsyncReturns(result1 director.Task, result2 error) {
+	fake.updateAsyncMutex.Lock()
+	defer fake.updateAsyncMutex.Unlock()
+	fake.UpdateAsyncStub = nil
+	fake.updateAsyncReturns = struct {
+		result1 director.Task
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeDeployment) UpdateAsyncReturnsOnCall(i int, result1 director.Task, result2 error) {
+	fake.updateAsyncMutex.Lock()
+	defer fake.updateAsyncMutex.Unlock()
+	fake.UpdateAsyncStub = nil
+	if fake.updateAsyncReturnsOnCall == nil {
+		fake.updateAsyncReturnsOnCall = make(map[int]struct {
+			result1 director.Task
+			result2 error
+		})
+	}
+	fake.updateAsyncReturnsOnCall[i] = struct {
+		result1 director.Task
+		result2 error
+	}{result1, result2}
+}
+
 func (fake *FakeDeployment) VMInfos() ([]director.VMInfo, error) {
 	fake.vMInfosMutex.Lock()
 	ret, specificReturn := fake.vMInfosReturnsOnCall[len(fake.vMInfosArgsForCall)]
@@ -2478,6 +3124,68 @@ func (fake *FakeDeployment) VariablesReturnsOnCall(i int, result1 []director.Var
 	}{result1, result2}
 }
 
+func (fake *FakeDeployment) WithGoContext(arg1 context.Context, arg2 bool) director.Deployment {
+	fake.withGoContextMutex.Lock()
+	ret, specificReturn := fake.withGoContextReturnsOnCall[len(fake.withGoContextArgsForCall)]
+	fake.withGoContextArgsForCall = append(fake.withGoContextArgsForCall, struct {
+		arg1 context.Context
+		arg2 bool
+	}{arg1, arg2})
+	stub := fake.WithGoContextStub
+	fakeReturns := fake.withGoContextReturns
+	fake.recordInvocation("WithGoContext", []interface{}{arg1, arg2})
+	fake.withGoContextMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *FakeDeployment) WithGoContextCallCount() int {
+	fake.withGoContextMutex.RLock()
+	defer fake.withGoContextMutex.RUnlock()
+	return len(fake.withGoContextArgsForCall)
+}
+
+func (fake *FakeDeployment) WithGoContextCalls(stub func(context.Context, bool) director.Deployment) {
+	fake.withGoContextMutex.Lock()
+	defer fake.withGoContextMutex.Unlock()
+	fake.WithGoContextStub = stub
+}
+
+func (fake *FakeDeployment) WithGoContextArgsForCall(i int) (context.Context, bool) {
+	fake.withGoContextMutex.RLock()
+	defer fake.withGoContextMutex.RUnlock()
+	argsForCall := fake.withGoContextArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *FakeDeployment) WithGoContextReturns(result1 director.Deployment) {
+	fake.withGoContextMutex.Lock()
+	defer fake.withGoContextMutex.Unlock()
+	fake.WithGoContextStub = nil
+	fake.withGoContextReturns = struct {
+		result1 director.Deployment
+	}{result1}
+}
+
+func (fake *FakeDeployment) WithGoContextReturnsOnCall(i int, result1 director.Deployment) {
+	fake.withGoContextMutex.Lock()
+	defer fake.withGoContextMutex.Unlock()
+	fake.WithGoContextStub = nil
+	if fake.withGoContextReturnsOnCall == nil {
+		fake.withGoContextReturnsOnCall = make(map[int]struct {
+			result1 director.Deployment
+		})
+	}
+	fake.withGoContextReturnsOnCall[i] = struct {
+		result1 director.Deployment
+	}{result1}
+}
+
 func (fake *FakeDeployment) Invocations() map[string][][]interface{} {
 	fake.invocationsMutex.RLock()
 	defer fake.invocationsMutex.RUnlock()
@@ -2489,6 +3197,8 @@ func (fake *FakeDeployment) Invocations() map[string][][]interface{} {
 	defer fake.cloudConfigMutex.RUnlock()
 	fake.deleteMutex.RLock()
 	defer fake.deleteMutex.RUnlock()
+	fake.deleteAsyncMutex.RLock()
+	defer fake.deleteAsyncMutex.RUnlock()
 	fake.deleteSnapshotMutex.RLock()
 	defer fake.deleteSnapshotMutex.RUnlock()
 	fake.deleteSnapshotsMutex.RLock()
@@ -2517,14 +3227,20 @@ func (fake *FakeDeployment) Invocations() map[string][][]interface{} {
 	defer fake.nameMutex.RUnlock()
 	fake.recreateMutex.RLock()
 	defer fake.recreateMutex.RUnlock()
+	fake.recreateAsyncMutex.RLock()
+	defer fake.recreateAsyncMutex.RUnlock()
 	fake.releasesMutex.RLock()
 	defer fake.releasesMutex.RUnlock()
 	fake.resolveProblemsMutex.RLock()
 	defer fake.resolveProblemsMutex.RUnlock()
 	fake.restartMutex.RLock()
 	defer fake.restartMutex.RUnlock()
+	fake.restartAsyncMutex.RLock()
+	defer fake.restartAsyncMutex.RUnlock()
 	fake.runErrandMutex.RLock()
 	defer fake.runErrandMutex.RUnlock()
+	fake.runErrandAsyncMutex.RLock()
+	defer fake.runErrandAsyncMutex.RUnlock()
 	fake.scanForProblemsMutex.RLock()
 	defer fake.scanForProblemsMutex.RUnlock()
 	fake.setUpSSHMutex.RLock()
@@ -2533,22 +3249,32 @@ func (fake *FakeDeployment) Invocations() map[string][][]interface{} {
 	defer fake.snapshotsMutex.RUnlock()
 	fake.startMutex.RLock()
 	defer fake.startMutex.RUnlock()
+	fake.startAsyncMutex.RLock()
+	defer fake.startAsyncMutex.RUnlock()
 	fake.stemcellsMutex.RLock()
 	defer fake.stemcellsMutex.RUnlock()
 	fake.stopMutex.RLock()
 	defer fake.stopMutex.RUnlock()
+	fake.stopAsyncMutex.RLock()
+	defer fake.stopAsyncMutex.RUnlock()
 	fake.takeSnapshotMutex.RLock()
 	defer fake.takeSnapshotMutex.RUnlock()
 	fake.takeSnapshotsMutex.RLock()
 	defer fake.takeSnapshotsMutex.RUnlock()
+	fake.takeSnapshotsAsyncMutex.RLock()
+	defer fake.takeSnapshotsAsyncMutex.RUnlock()
 	fake.teamsMutex.RLock()
 	defer fake.teamsMutex.RUnlock()
 	fake.updateMutex.RLock()
 	defer fake.updateMutex.RUnlock()
+	fake.updateAsyncMutex.RLock()
+	defer fake.updateAsyncMutex.RUnlock()
 	fake.vMInfosMutex.RLock()
 	defer fake.vMInfosMutex.RUnlock()
 	fake.variablesMutex.RLock()
 	defer fake.variablesMutex.RUnlock()
+	fake.withGoContextMutex.RLock()
+	defer fake.withGoContextMutex.RUnlock()
 	copiedInvocations := map[string][][]interface{}{}
 	for key, value := range fake.invocations {
 		copiedInvocations[key] = value
@@ -2,6 +2,7 @@
 package directorfakes
 
 import (
+	"context"
 	"io"
 	"sync"
 
@@ -704,6 +705,18 @@ type FakeDirector struct {
 	withContextReturnsOnCall map[int]struct {
 		result1 director.Director
 	}
+	WithGoContextStub        func(context.Context, bool) director.Director
+	withGoContextMutex       sync.RWMutex
+	withGoContextArgsForCall []struct {
+		arg1 context.Context
+		arg2 bool
+	}
+	withGoContextReturns struct {
+		result1 director.Director
+	}
+	withGoContextReturnsOnCall map[int]struct {
+		result1 director.Director
+	}
 	invocations      map[string][][]interface{}
 	invocationsMutex sync.RWMutex
 }
@@ -4104,6 +4117,68 @@ func (fake *FakeDirector) WithContextReturnsOnCall(i int, result1 director.Direc
 	}{result1}
 }
 
+func (fake *FakeDirector) WithGoContext(arg1 context.Context, arg2 bool) director.Director {
+	fake.withGoContextMutex.Lock()
+	ret, specificReturn := fake.withGoContextReturnsOnCall[len(fake.withGoContextArgsForCall)]
+	fake.withGoContextArgsForCall = append(fake.withGoContextArgsForCall, struct {
+		arg1 context.Context
+		arg2 bool
+	}{arg1, arg2})
+	stub := fake.WithGoContextStub
+	fakeReturns := fake.withGoContextReturns
+	fake.recordInvocation("WithGoContext", []interface{}{arg1, arg2})
+	fake.withGoContextMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *FakeDirector) WithGoContextCallCount() int {
+	fake.withGoContextMutex.RLock()
+	defer fake.withGoContextMutex.RUnlock()
+	return len(fake.withGoContextArgsForCall)
+}
+
+func (fake *FakeDirector) WithGoContextCalls(stub func(context.Context, bool) director.Director) {
+	fake.withGoContextMutex.Lock()
+	defer fake.withGoContextMutex.Unlock()
+	fake.WithGoContextStub = stub
+}
+
+func (fake *FakeDirector) WithGoContextArgsForCall(i int) (context.Context, bool) {
+	fake.withGoContextMutex.RLock()
+	defer fake.withGoContextMutex.RUnlock()
+	argsForCall := fake.withGoContextArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *FakeDirector) WithGoContextReturns(result1 director.Director) {
+	fake.withGoContextMutex.Lock()
+	defer fake.withGoContextMutex.Unlock()
+	fake.WithGoContextStub = nil
+	fake.withGoContextReturns = struct {
+		result1 director.Director
+	}{result1}
+}
+
+func (fake *FakeDirector) WithGoContextReturnsOnCall(i int, result1 director.Director) {
+	fake.withGoContextMutex.Lock()
+	defer fake.withGoContextMutex.Unlock()
+	fake.WithGoContextStub = nil
+	if fake.withGoContextReturnsOnCall == nil {
+		fake.withGoContextReturnsOnCall = make(map[int]struct {
+			result1 director.Director
+		})
+	}
+	fake.withGoContextReturnsOnCall[i] = struct {
+		result1 director.Director
+	}{result1}
+}
+
 func (fake *FakeDirector) Invocations() map[string][][]interface{} {
 	fake.invocationsMutex.RLock()
 	defer fake.invocationsMutex.RUnlock()
@@ -4215,6 +4290,8 @@ func (fake *FakeDirector) Invocations() map[string][][]interface{} {
 	defer fake.uploadStemcellURLMutex.RUnlock()
 	fake.withContextMutex.RLock()
 	defer fake.withContextMutex.RUnlock()
+	fake.withGoContextMutex.RLock()
+	defer fake.withGoContextMutex.RUnlock()
 	copiedInvocations := map[string][][]interface{}{}
 	for key, value := range fake.invocations {
 		copiedInvocations[key] = value
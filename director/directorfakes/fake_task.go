@@ -143,6 +143,15 @@ type FakeTask struct {
 	resultOutputReturnsOnCall map[int]struct {
 		result1 error
 	}
+	WaitStub        func() error
+	waitMutex       sync.RWMutex
+	waitArgsForCall []struct{}
+	waitReturns     struct {
+		result1 error
+	}
+	waitReturnsOnCall map[int]struct {
+		result1 error
+	}
 	CancelStub        func() error
 	cancelMutex       sync.RWMutex
 	cancelArgsForCall []struct{}
@@ -748,6 +757,46 @@ func (fake *FakeTask) ResultOutputReturnsOnCall(i int, result1 error) {
 	}{result1}
 }
 
+func (fake *FakeTask) Wait() error {
+	fake.waitMutex.Lock()
+	ret, specificReturn := fake.waitReturnsOnCall[len(fake.waitArgsForCall)]
+	fake.waitArgsForCall = append(fake.waitArgsForCall, struct{}{})
+	fake.recordInvocation("Wait", []interface{}{})
+	fake.waitMutex.Unlock()
+	if fake.WaitStub != nil {
+		return fake.WaitStub()
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fake.waitReturns.result1
+}
+
+func (fake *FakeTask) WaitCallCount() int {
+	fake.waitMutex.RLock()
+	defer fake.waitMutex.RUnlock()
+	return len(fake.waitArgsForCall)
+}
+
+func (fake *FakeTask) WaitReturns(result1 error) {
+	fake.WaitStub = nil
+	fake.waitReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeTask) WaitReturnsOnCall(i int, result1 error) {
+	fake.WaitStub = nil
+	if fake.waitReturnsOnCall == nil {
+		fake.waitReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.waitReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
 func (fake *FakeTask) Cancel() error {
 	fake.cancelMutex.Lock()
 	ret, specificReturn := fake.cancelReturnsOnCall[len(fake.cancelArgsForCall)]
@@ -819,6 +868,8 @@ func (fake *FakeTask) Invocations() map[string][][]interface{} {
 	defer fake.debugOutputMutex.RUnlock()
 	fake.resultOutputMutex.RLock()
 	defer fake.resultOutputMutex.RUnlock()
+	fake.waitMutex.RLock()
+	defer fake.waitMutex.RUnlock()
 	fake.cancelMutex.RLock()
 	defer fake.cancelMutex.RUnlock()
 	return fake.invocations
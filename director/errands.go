@@ -76,6 +76,13 @@ func (d DeploymentImpl) RunErrand(name string, keepAlive bool, whenChanged bool,
 	return result, nil
 }
 
+func (d DeploymentImpl) RunErrandAsync(name string, keepAlive bool, whenChanged bool, slugs []InstanceGroupOrInstanceSlug) (Task, error) {
+	return d.startTask(func(d DeploymentImpl) error {
+		_, err := d.client.RunErrand(d.name, name, keepAlive, whenChanged, slugs)
+		return err
+	})
+}
+
 func (c Client) Errands(deploymentName string) ([]Errand, error) {
 	var errands []Errand
 
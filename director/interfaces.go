@@ -1,6 +1,7 @@
 package director
 
 import (
+	"context"
 	"io"
 	"os"
 	"time"
@@ -18,6 +19,11 @@ import (
 type Director interface {
 	IsAuthenticated() (bool, error)
 	WithContext(id string) Director
+
+	// WithGoContext returns Director that aborts requests and task waits
+	// once ctx is done, optionally cancelling waited for tasks on the Director.
+	// Deployments found via returned Director share the same context.
+	WithGoContext(ctx context.Context, cancelTasks bool) Director
 	Info() (Info, error)
 
 	Locks() ([]Lock, error)
@@ -133,6 +139,7 @@ type FileReporter interface {
 
 type Deployment interface {
 	Name() string
+	WithGoContext(ctx context.Context, cancelTasks bool) Deployment
 	Manifest() (string, error)
 	CloudConfig() (string, error)
 	Diff([]byte, bool) (DeploymentDiff, error)
@@ -179,6 +186,16 @@ type Deployment interface {
 	Update(manifest []byte, opts UpdateOpts) error
 	Delete(force bool) error
 
+	// Async variants start Director task and return it without waiting for it to finish
+	UpdateAsync(manifest []byte, opts UpdateOpts) (Task, error)
+	DeleteAsync(force bool) (Task, error)
+	StartAsync(slug AllOrInstanceGroupOrInstanceSlug, opts StartOpts) (Task, error)
+	StopAsync(slug AllOrInstanceGroupOrInstanceSlug, opts StopOpts) (Task, error)
+	RestartAsync(slug AllOrInstanceGroupOrInstanceSlug, opts RestartOpts) (Task, error)
+	RecreateAsync(slug AllOrInstanceGroupOrInstanceSlug, opts RecreateOpts) (Task, error)
+	RunErrandAsync(string, bool, bool, []InstanceGroupOrInstanceSlug) (Task, error)
+	TakeSnapshotsAsync() (Task, error)
+
 	AttachDisk(slug InstanceSlug, diskCID string, diskProperties string) error
 }
 
@@ -290,6 +307,8 @@ type Task interface {
 	DebugOutput(TaskReporter) error
 	ResultOutput(TaskReporter) error
 
+	// Wait blocks until task is finished and returns error unless it succeeded
+	Wait() error
 	Cancel() error
 }
 
@@ -0,0 +1,391 @@
+package lint
+
+import (
+	"fmt"
+	"net"
+	"sort"
+	"strings"
+
+	bosherr "github.com/cloudfoundry/bosh-utils/errors"
+	"gopkg.in/yaml.v2"
+
+	boshrel "github.com/cloudfoundry/bosh-cli/release"
+	boshjob "github.com/cloudfoundry/bosh-cli/release/job"
+)
+
+// Problem describes a single issue found in a deployment manifest.
+// Path is a go-patch style pointer to the offending manifest section,
+// e.g. '/instance_groups/name=web/jobs/name=nginx'.
+type Problem struct {
+	Path    string `json:"path"`
+	Message string `json:"message"`
+}
+
+type Linter struct {
+	releaseReader boshrel.Reader
+}
+
+func NewLinter(releaseReader boshrel.Reader) Linter {
+	return Linter{releaseReader: releaseReader}
+}
+
+var knownTopLevelKeys = map[string]struct{}{
+	"name":            {},
+	"director_uuid":   {},
+	"releases":        {},
+	"stemcells":       {},
+	"instance_groups": {},
+	"update":          {},
+	"variables":       {},
+	"features":        {},
+	"tags":            {},
+	"addons":          {},
+	"properties":      {},
+}
+
+type manifest struct {
+	Releases       []named         `yaml:"releases"`
+	Stemcells      []stemcell      `yaml:"stemcells"`
+	InstanceGroups []instanceGroup `yaml:"instance_groups"`
+}
+
+type named struct {
+	Name string `yaml:"name"`
+}
+
+type stemcell struct {
+	Alias string `yaml:"alias"`
+}
+
+type instanceGroup struct {
+	Name               string    `yaml:"name"`
+	AZs                []string  `yaml:"azs"`
+	VMType             string    `yaml:"vm_type"`
+	VMExtensions       []string  `yaml:"vm_extensions"`
+	PersistentDiskType string    `yaml:"persistent_disk_type"`
+	Stemcell           string    `yaml:"stemcell"`
+	Networks           []network `yaml:"networks"`
+	Jobs               []job     `yaml:"jobs"`
+}
+
+type network struct {
+	Name      string   `yaml:"name"`
+	StaticIPs []string `yaml:"static_ips"`
+}
+
+type job struct {
+	Name       string                      `yaml:"name"`
+	Release    string                      `yaml:"release"`
+	Properties map[interface{}]interface{} `yaml:"properties"`
+}
+
+type cloudConfig struct {
+	AZs          []named `yaml:"azs"`
+	VMTypes      []named `yaml:"vm_types"`
+	VMExtensions []named `yaml:"vm_extensions"`
+	DiskTypes    []named `yaml:"disk_types"`
+	Networks     []named `yaml:"networks"`
+}
+
+// Lint validates deployment manifest without contacting the Director.
+// Cloud config checks are skipped if cloud config is empty; jobs and
+// their properties are only checked for releases found in given release tarballs.
+func (l Linter) Lint(manifestBytes, cloudConfigBytes []byte, releasePaths []string) ([]Problem, error) {
+	var (
+		topLevel yaml.MapSlice
+		man      manifest
+		problems []Problem
+	)
+
+	err := yaml.Unmarshal(manifestBytes, &topLevel)
+	if err != nil {
+		return nil, bosherr.WrapError(err, "Parsing manifest")
+	}
+
+	err = yaml.Unmarshal(manifestBytes, &man)
+	if err != nil {
+		return nil, bosherr.WrapError(err, "Parsing manifest")
+	}
+
+	for _, item := range topLevel {
+		key := fmt.Sprintf("%v", item.Key)
+		if _, found := knownTopLevelKeys[key]; !found {
+			problems = append(problems, Problem{Path: "/" + key, Message: fmt.Sprintf("Unknown top-level key '%s'", key)})
+		}
+	}
+
+	problems = append(problems, l.lintReferences(man)...)
+
+	if len(cloudConfigBytes) > 0 {
+		var cc cloudConfig
+
+		err = yaml.Unmarshal(cloudConfigBytes, &cc)
+		if err != nil {
+			return nil, bosherr.WrapError(err, "Parsing cloud config")
+		}
+
+		problems = append(problems, l.lintCloudConfig(man, cc)...)
+	}
+
+	problems = append(problems, l.lintStaticIPs(man)...)
+
+	jobs, err := l.readReleaseJobs(releasePaths)
+	if err != nil {
+		return nil, err
+	}
+
+	problems = append(problems, l.lintJobs(man, jobs)...)
+
+	return problems, nil
+}
+
+func (l Linter) lintReferences(man manifest) []Problem {
+	var problems []Problem
+
+	releases := map[string]struct{}{}
+	for _, rel := range man.Releases {
+		releases[rel.Name] = struct{}{}
+	}
+
+	stemcells := map[string]struct{}{}
+	for _, stemcell := range man.Stemcells {
+		stemcells[stemcell.Alias] = struct{}{}
+	}
+
+	for _, ig := range man.InstanceGroups {
+		igPath := "/instance_groups/name=" + ig.Name
+
+		if len(ig.Stemcell) > 0 {
+			if _, found := stemcells[ig.Stemcell]; !found {
+				problems = append(problems, Problem{
+					Path:    igPath + "/stemcell",
+					Message: fmt.Sprintf("Stemcell '%s' is not declared in stemcells", ig.Stemcell),
+				})
+			}
+		}
+
+		for _, j := range ig.Jobs {
+			if _, found := releases[j.Release]; !found {
+				problems = append(problems, Problem{
+					Path:    igPath + "/jobs/name=" + j.Name + "/release",
+					Message: fmt.Sprintf("Release '%s' is not declared in releases", j.Release),
+				})
+			}
+		}
+	}
+
+	return problems
+}
+
+func (l Linter) lintCloudConfig(man manifest, cc cloudConfig) []Problem {
+	var problems []Problem
+
+	azs := namesSet(cc.AZs)
+	vmTypes := namesSet(cc.VMTypes)
+	vmExtensions := namesSet(cc.VMExtensions)
+	diskTypes := namesSet(cc.DiskTypes)
+	networks := namesSet(cc.Networks)
+
+	missing := func(path, kind, name string) {
+		problems = append(problems, Problem{
+			Path:    path,
+			Message: fmt.Sprintf("%s '%s' is not found in cloud config", kind, name),
+		})
+	}
+
+	for _, ig := range man.InstanceGroups {
+		igPath := "/instance_groups/name=" + ig.Name
+
+		for _, az := range ig.AZs {
+			if _, found := azs[az]; !found {
+				missing(igPath+"/azs", "AZ", az)
+			}
+		}
+
+		if len(ig.VMType) > 0 {
+			if _, found := vmTypes[ig.VMType]; !found {
+				missing(igPath+"/vm_type", "VM type", ig.VMType)
+			}
+		}
+
+		for _, ext := range ig.VMExtensions {
+			if _, found := vmExtensions[ext]; !found {
+				missing(igPath+"/vm_extensions", "VM extension", ext)
+			}
+		}
+
+		if len(ig.PersistentDiskType) > 0 {
+			if _, found := diskTypes[ig.PersistentDiskType]; !found {
+				missing(igPath+"/persistent_disk_type", "Disk type", ig.PersistentDiskType)
+			}
+		}
+
+		for _, net := range ig.Networks {
+			if _, found := networks[net.Name]; !found {
+				missing(igPath+"/networks/name="+net.Name, "Network", net.Name)
+			}
+		}
+	}
+
+	return problems
+}
+
+func (l Linter) lintStaticIPs(man manifest) []Problem {
+	var problems []Problem
+
+	// Static IPs are keyed by network since the same IP may be used on different networks
+	seen := map[string]string{}
+
+	for _, ig := range man.InstanceGroups {
+		for _, net := range ig.Networks {
+			path := "/instance_groups/name=" + ig.Name + "/networks/name=" + net.Name + "/static_ips"
+
+			for _, ipOrRange := range net.StaticIPs {
+				ips, err := expandStaticIPs(ipOrRange)
+				if err != nil {
+					problems = append(problems, Problem{Path: path, Message: err.Error()})
+					continue
+				}
+
+				for _, ip := range ips {
+					key := net.Name + "/" + ip
+
+					if igName, found := seen[key]; found {
+						problems = append(problems, Problem{
+							Path:    path,
+							Message: fmt.Sprintf("Static IP '%s' is already used by instance group '%s'", ip, igName),
+						})
+						continue
+					}
+
+					seen[key] = ig.Name
+				}
+			}
+		}
+	}
+
+	return problems
+}
+
+func (l Linter) readReleaseJobs(releasePaths []string) (map[string]map[string]boshjob.Job, error) {
+	jobs := map[string]map[string]boshjob.Job{}
+
+	for _, path := range releasePaths {
+		release, err := l.releaseReader.Read(path)
+		if err != nil {
+			return nil, bosherr.WrapErrorf(err, "Reading release '%s'", path)
+		}
+
+		// Job specs are already loaded hence extracted files are no longer needed
+		err = release.CleanUp()
+		if err != nil {
+			return nil, bosherr.WrapErrorf(err, "Cleaning up release '%s'", path)
+		}
+
+		relJobs := map[string]boshjob.Job{}
+		for _, j := range release.Jobs() {
+			relJobs[j.Name()] = *j
+		}
+
+		jobs[release.Name()] = relJobs
+	}
+
+	return jobs, nil
+}
+
+func (l Linter) lintJobs(man manifest, jobs map[string]map[string]boshjob.Job) []Problem {
+	var problems []Problem
+
+	for _, ig := range man.InstanceGroups {
+		for _, j := range ig.Jobs {
+			relJobs, found := jobs[j.Release]
+			if !found {
+				continue
+			}
+
+			jobPath := "/instance_groups/name=" + ig.Name + "/jobs/name=" + j.Name
+
+			relJob, found := relJobs[j.Name]
+			if !found {
+				problems = append(problems, Problem{
+					Path:    jobPath,
+					Message: fmt.Sprintf("Job '%s' is not found in release '%s'", j.Name, j.Release),
+				})
+				continue
+			}
+
+			for _, name := range undeclaredProperties(j.Properties, relJob.Properties) {
+				problems = append(problems, Problem{
+					Path:    jobPath + "/properties/" + strings.Replace(name, ".", "/", -1),
+					Message: fmt.Sprintf("Property '%s' is not declared in job '%s' spec", name, j.Name),
+				})
+			}
+		}
+	}
+
+	return problems
+}
+
+// undeclaredProperties returns sorted dot separated names of properties which
+// are neither declared in the job spec nor nested under a declared property.
+func undeclaredProperties(props map[interface{}]interface{}, defs map[string]boshjob.PropertyDefinition) []string {
+	var names []string
+
+	boshjob.WalkProperties(props, defs, func(name string, _ interface{}, kind boshjob.PropertyKind) {
+		if kind != boshjob.DeclaredProperty {
+			names = append(names, name)
+		}
+	})
+
+	sort.Strings(names)
+
+	return names
+}
+
+// expandStaticIPs supports single IPs and 'first-last' IPv4 ranges.
+func expandStaticIPs(ipOrRange string) ([]string, error) {
+	pieces := strings.Split(ipOrRange, "-")
+
+	first := net.ParseIP(strings.TrimSpace(pieces[0]))
+	if first == nil || len(pieces) > 2 {
+		return nil, bosherr.Errorf("Invalid static IP '%s'", ipOrRange)
+	}
+
+	if len(pieces) == 1 {
+		return []string{first.String()}, nil
+	}
+
+	last := net.ParseIP(strings.TrimSpace(pieces[1]))
+	if last == nil || first.To4() == nil || last.To4() == nil {
+		return nil, bosherr.Errorf("Invalid static IP range '%s'", ipOrRange)
+	}
+
+	var ips []string
+
+	for ip := ipToUint32(first); ip <= ipToUint32(last); ip++ {
+		ips = append(ips, uint32ToIP(ip).String())
+
+		if ip == ipToUint32(last) {
+			break
+		}
+	}
+
+	return ips, nil
+}
+
+func ipToUint32(ip net.IP) uint32 {
+	ip = ip.To4()
+	return uint32(ip[0])<<24 | uint32(ip[1])<<16 | uint32(ip[2])<<8 | uint32(ip[3])
+}
+
+func uint32ToIP(n uint32) net.IP {
+	return net.IPv4(byte(n>>24), byte(n>>16), byte(n>>8), byte(n))
+}
+
+func namesSet(items []named) map[string]struct{} {
+	names := map[string]struct{}{}
+	for _, item := range items {
+		names[item.Name] = struct{}{}
+	}
+	return names
+}
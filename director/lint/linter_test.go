@@ -0,0 +1,257 @@
+package lint_test
+
+import (
+	"errors"
+	"path/filepath"
+
+	boshcrypto "github.com/cloudfoundry/bosh-utils/crypto"
+	boshfu "github.com/cloudfoundry/bosh-utils/fileutil"
+	boshlog "github.com/cloudfoundry/bosh-utils/logger"
+	boshsys "github.com/cloudfoundry/bosh-utils/system"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	bicrypto "github.com/cloudfoundry/bosh-cli/crypto"
+	. "github.com/cloudfoundry/bosh-cli/director/lint"
+	boshrel "github.com/cloudfoundry/bosh-cli/release"
+	boshjob "github.com/cloudfoundry/bosh-cli/release/job"
+	fakerel "github.com/cloudfoundry/bosh-cli/release/releasefakes"
+	boshres "github.com/cloudfoundry/bosh-cli/release/resource"
+)
+
+var _ = Describe("Linter", func() {
+	var (
+		releaseReader *fakerel.FakeReader
+		linter        Linter
+	)
+
+	BeforeEach(func() {
+		releaseReader = &fakerel.FakeReader{}
+		linter = NewLinter(releaseReader)
+	})
+
+	Describe("Lint", func() {
+		It("returns no problems for valid manifest", func() {
+			manifest := []byte(`
+name: dep
+releases:
+- name: rel
+stemcells:
+- alias: default
+instance_groups:
+- name: web
+  stemcell: default
+  jobs:
+  - name: nginx
+    release: rel
+`)
+
+			problems, err := linter.Lint(manifest, nil, nil)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(problems).To(BeEmpty())
+		})
+
+		It("reports unknown top-level keys", func() {
+			problems, err := linter.Lint([]byte("name: dep\ninstance_group: []\n"), nil, nil)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(problems).To(Equal([]Problem{
+				{Path: "/instance_group", Message: "Unknown top-level key 'instance_group'"},
+			}))
+		})
+
+		It("reports undeclared releases and stemcells", func() {
+			manifest := []byte(`
+releases:
+- name: rel
+stemcells:
+- alias: default
+instance_groups:
+- name: web
+  stemcell: other
+  jobs:
+  - name: nginx
+    release: other-rel
+`)
+
+			problems, err := linter.Lint(manifest, nil, nil)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(problems).To(Equal([]Problem{
+				{Path: "/instance_groups/name=web/stemcell", Message: "Stemcell 'other' is not declared in stemcells"},
+				{Path: "/instance_groups/name=web/jobs/name=nginx/release", Message: "Release 'other-rel' is not declared in releases"},
+			}))
+		})
+
+		It("reports references missing from cloud config", func() {
+			manifest := []byte(`
+instance_groups:
+- name: web
+  azs: [z1, z2]
+  vm_type: small
+  vm_extensions: [lb]
+  persistent_disk_type: large
+  networks:
+  - name: private
+  - name: public
+`)
+			cloudConfig := []byte(`
+azs:
+- name: z1
+vm_types:
+- name: default
+disk_types:
+- name: large
+networks:
+- name: private
+`)
+
+			problems, err := linter.Lint(manifest, cloudConfig, nil)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(problems).To(Equal([]Problem{
+				{Path: "/instance_groups/name=web/azs", Message: "AZ 'z2' is not found in cloud config"},
+				{Path: "/instance_groups/name=web/vm_type", Message: "VM type 'small' is not found in cloud config"},
+				{Path: "/instance_groups/name=web/vm_extensions", Message: "VM extension 'lb' is not found in cloud config"},
+				{Path: "/instance_groups/name=web/networks/name=public", Message: "Network 'public' is not found in cloud config"},
+			}))
+		})
+
+		It("reports duplicate static IPs on the same network", func() {
+			manifest := []byte(`
+instance_groups:
+- name: web
+  networks:
+  - name: private
+    static_ips: [10.0.0.1-10.0.0.3]
+  - name: public
+    static_ips: [10.0.0.5]
+- name: api
+  networks:
+  - name: private
+    static_ips: [10.0.0.3, 10.0.0.5]
+- name: worker
+  networks:
+  - name: private
+    static_ips: [not-ip]
+`)
+
+			problems, err := linter.Lint(manifest, nil, nil)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(problems).To(Equal([]Problem{
+				{Path: "/instance_groups/name=api/networks/name=private/static_ips", Message: "Static IP '10.0.0.3' is already used by instance group 'web'"},
+				{Path: "/instance_groups/name=worker/networks/name=private/static_ips", Message: "Invalid static IP 'not-ip'"},
+			}))
+		})
+
+		Context("when release tarballs are provided", func() {
+			var (
+				release *fakerel.FakeRelease
+			)
+
+			BeforeEach(func() {
+				job := boshjob.NewJob(boshres.NewResource("nginx", "fp", nil))
+				job.Properties = map[string]boshjob.PropertyDefinition{
+					"nginx.port":   {},
+					"nginx.config": {},
+				}
+
+				release = &fakerel.FakeRelease{}
+				release.NameReturns("rel")
+				release.JobsReturns([]*boshjob.Job{job})
+
+				releaseReader.ReadReturns(release, nil)
+			})
+
+			It("reports jobs missing from release and undeclared properties", func() {
+				manifest := []byte(`
+releases:
+- name: rel
+instance_groups:
+- name: web
+  jobs:
+  - name: nginx
+    release: rel
+    properties:
+      nginx:
+        port: 80
+        config:
+          any: value
+        workers: 2
+      other: true
+  - name: route
+    release: rel
+`)
+
+				problems, err := linter.Lint(manifest, nil, []string{"/rel.tgz"})
+				Expect(err).ToNot(HaveOccurred())
+				Expect(problems).To(Equal([]Problem{
+					{Path: "/instance_groups/name=web/jobs/name=nginx/properties/nginx/workers", Message: "Property 'nginx.workers' is not declared in job 'nginx' spec"},
+					{Path: "/instance_groups/name=web/jobs/name=nginx/properties/other", Message: "Property 'other' is not declared in job 'nginx' spec"},
+					{Path: "/instance_groups/name=web/jobs/name=route", Message: "Job 'route' is not found in release 'rel'"},
+				}))
+
+				Expect(releaseReader.ReadArgsForCall(0)).To(Equal("/rel.tgz"))
+				Expect(release.CleanUpCallCount()).To(Equal(1))
+			})
+
+			It("returns an error if reading release fails", func() {
+				releaseReader.ReadReturns(nil, errors.New("fake-err"))
+
+				_, err := linter.Lint([]byte("name: dep"), nil, []string{"/rel.tgz"})
+				Expect(err).To(HaveOccurred())
+				Expect(err.Error()).To(ContainSubstring("Reading release '/rel.tgz': fake-err"))
+			})
+
+			It("returns an error if cleaning up release fails", func() {
+				release.CleanUpReturns(errors.New("fake-err"))
+
+				_, err := linter.Lint([]byte("name: dep"), nil, []string{"/rel.tgz"})
+				Expect(err).To(HaveOccurred())
+				Expect(err.Error()).To(ContainSubstring("Cleaning up release '/rel.tgz': fake-err"))
+			})
+		})
+
+		Context("when release tarball is read from disk", func() {
+			BeforeEach(func() {
+				logger := boshlog.NewLogger(boshlog.LevelNone)
+				fs := boshsys.NewOsFileSystem(logger)
+				cmdRunner := boshsys.NewExecCmdRunner(logger)
+				compressor := boshfu.NewTarballCompressor(cmdRunner, fs)
+				digestCalculator := bicrypto.NewDigestCalculator(fs, []boshcrypto.Algorithm{boshcrypto.DigestAlgorithmSHA1})
+
+				relProv := boshrel.NewProvider(cmdRunner, compressor, digestCalculator, fs, logger)
+				linter = NewLinter(relProv.NewExtractingArchiveReader())
+			})
+
+			It("checks properties against job specs from the tarball", func() {
+				manifest := []byte(`
+releases:
+- name: lint-release
+instance_groups:
+- name: web
+  jobs:
+  - name: nginx
+    release: lint-release
+    properties:
+      port: 8080
+      tls:
+        cert: fake-cert
+        key: fake-key
+`)
+
+				releasePath, err := filepath.Abs(filepath.Join("assets", "lint-release.tgz"))
+				Expect(err).ToNot(HaveOccurred())
+
+				problems, err := linter.Lint(manifest, nil, []string{releasePath})
+				Expect(err).ToNot(HaveOccurred())
+				Expect(problems).To(Equal([]Problem{
+					{Path: "/instance_groups/name=web/jobs/name=nginx/properties/tls/key", Message: "Property 'tls.key' is not declared in job 'nginx' spec"},
+				}))
+			})
+		})
+
+		It("returns an error if manifest cannot be parsed", func() {
+			_, err := linter.Lint([]byte("- name"), nil, nil)
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("Parsing manifest"))
+		})
+	})
+})
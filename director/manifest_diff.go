@@ -0,0 +1,343 @@
+package director
+
+import (
+	"reflect"
+	"regexp"
+	"strings"
+
+	bosherr "github.com/cloudfoundry/bosh-utils/errors"
+	"gopkg.in/yaml.v2"
+)
+
+const manifestDiffRedacted = "<redacted>"
+
+var manifestDiffVariableRegexp = regexp.MustCompile(`\(\(([-/\.\w\pL]+)\)\)`)
+
+// ManifestDiffInput represents one side of a local manifest diff.
+// Uninterpolated manifest (with ops applied but without variables)
+// is used to determine which values came from variables;
+// if it is empty no values are redacted.
+type ManifestDiffInput struct {
+	Manifest       []byte
+	Uninterpolated []byte
+}
+
+type manifestDiffNode struct {
+	value interface{}
+	raw   interface{}
+}
+
+type manifestDiff struct {
+	redact bool
+}
+
+// DiffManifests compares two manifests without contacting the Director.
+// Lists of hashes which all have 'name' key (instance groups, jobs, etc.)
+// are matched by name instead of position. Returned diff lines use the same
+// format as the Director so they can be printed or parsed the same way.
+func DiffManifests(from, to ManifestDiffInput, redact bool) (DeploymentDiff, error) {
+	fromNode, err := newManifestDiffNode(from)
+	if err != nil {
+		return DeploymentDiff{}, bosherr.WrapErrorf(err, "Parsing first manifest")
+	}
+
+	toNode, err := newManifestDiffNode(to)
+	if err != nil {
+		return DeploymentDiff{}, bosherr.WrapErrorf(err, "Parsing second manifest")
+	}
+
+	lines := manifestDiff{redact: redact}.diffMaps("", fromNode, toNode)
+
+	return NewDeploymentDiff(lines, nil), nil
+}
+
+func newManifestDiffNode(input ManifestDiffInput) (manifestDiffNode, error) {
+	var value, raw yaml.MapSlice
+
+	err := yaml.Unmarshal(input.Manifest, &value)
+	if err != nil {
+		return manifestDiffNode{}, bosherr.WrapError(err, "Unmarshalling manifest")
+	}
+
+	if len(input.Uninterpolated) > 0 {
+		err = yaml.Unmarshal(input.Uninterpolated, &raw)
+		if err != nil {
+			return manifestDiffNode{}, bosherr.WrapError(err, "Unmarshalling uninterpolated manifest")
+		}
+	}
+
+	return manifestDiffNode{value: value, raw: raw}, nil
+}
+
+func (d manifestDiff) diffMaps(indent string, from, to manifestDiffNode) [][]interface{} {
+	fromMap, _ := from.value.(yaml.MapSlice)
+	toMap, _ := to.value.(yaml.MapSlice)
+
+	var lines [][]interface{}
+
+	for _, item := range toMap {
+		toItem := manifestDiffNode{value: item.Value, raw: lookupManifestDiffKey(to.raw, item.Key)}
+
+		fromValue, found := lookupManifestDiffValue(fromMap, item.Key)
+		if !found {
+			lines = append(lines, d.keyLines(indent, item.Key, toItem, "added")...)
+			continue
+		}
+
+		fromItem := manifestDiffNode{value: fromValue, raw: lookupManifestDiffKey(from.raw, item.Key)}
+
+		lines = append(lines, d.diffKey(indent, item.Key, fromItem, toItem)...)
+	}
+
+	for _, item := range fromMap {
+		if _, found := lookupManifestDiffValue(toMap, item.Key); !found {
+			fromItem := manifestDiffNode{value: item.Value, raw: lookupManifestDiffKey(from.raw, item.Key)}
+			lines = append(lines, d.keyLines(indent, item.Key, fromItem, "removed")...)
+		}
+	}
+
+	return lines
+}
+
+func (d manifestDiff) diffKey(indent string, key interface{}, from, to manifestDiffNode) [][]interface{} {
+	_, fromIsMap := from.value.(yaml.MapSlice)
+	_, toIsMap := to.value.(yaml.MapSlice)
+
+	if fromIsMap && toIsMap {
+		nested := d.diffMaps(indent+"  ", from, to)
+		if len(nested) == 0 {
+			return nil
+		}
+
+		return append([][]interface{}{d.keyHeader(indent, key)}, nested...)
+	}
+
+	if isNamedManifestDiffList(from.value) && isNamedManifestDiffList(to.value) {
+		nested := d.diffNamedLists(indent, from, to)
+		if len(nested) == 0 {
+			return nil
+		}
+
+		return append([][]interface{}{d.keyHeader(indent, key)}, nested...)
+	}
+
+	// Interpolated values are compared so that changed credentials are still shown
+	if reflect.DeepEqual(from.value, to.value) {
+		return nil
+	}
+
+	lines := d.keyLines(indent, key, from, "removed")
+	return append(lines, d.keyLines(indent, key, to, "added")...)
+}
+
+// diffNamedLists formats list items the same way as the Director:
+// item's dash is placed at the indentation of the list key.
+func (d manifestDiff) diffNamedLists(indent string, from, to manifestDiffNode) [][]interface{} {
+	fromList := from.value.([]interface{})
+	toList := to.value.([]interface{})
+
+	var lines [][]interface{}
+
+	for i, toValue := range toList {
+		name := manifestDiffItemName(toValue)
+		toItem := manifestDiffNode{value: toValue, raw: lookupManifestDiffIndex(to.raw, i)}
+
+		j, found := findManifestDiffNamedItem(fromList, name)
+		if !found {
+			lines = append(lines, d.itemLines(indent, toItem, "added")...)
+			continue
+		}
+
+		fromItem := manifestDiffNode{value: fromList[j], raw: lookupManifestDiffIndex(from.raw, j)}
+
+		nested := d.diffMaps(indent+"  ", fromItem, toItem)
+		if len(nested) > 0 {
+			header := []interface{}{indent + "- name: " + d.formatScalar(name), ""}
+			lines = append(lines, header)
+			lines = append(lines, nested...)
+		}
+	}
+
+	for i, fromValue := range fromList {
+		if _, found := findManifestDiffNamedItem(toList, manifestDiffItemName(fromValue)); !found {
+			fromItem := manifestDiffNode{value: fromValue, raw: lookupManifestDiffIndex(from.raw, i)}
+			lines = append(lines, d.itemLines(indent, fromItem, "removed")...)
+		}
+	}
+
+	return lines
+}
+
+func (d manifestDiff) keyHeader(indent string, key interface{}) []interface{} {
+	return []interface{}{indent + d.formatScalar(key) + ":", ""}
+}
+
+func (d manifestDiff) keyLines(indent string, key interface{}, node manifestDiffNode, state string) [][]interface{} {
+	return d.yamlLines(indent, yaml.MapSlice{{Key: key, Value: d.redacted(node)}}, state)
+}
+
+func (d manifestDiff) itemLines(indent string, node manifestDiffNode, state string) [][]interface{} {
+	value := d.redacted(node)
+
+	if item, ok := value.(yaml.MapSlice); ok {
+		value = nameFirstManifestDiffMap(item)
+	}
+
+	return d.yamlLines(indent, []interface{}{value}, state)
+}
+
+func (d manifestDiff) yamlLines(indent string, value interface{}, state string) [][]interface{} {
+	bytes, err := yaml.Marshal(value)
+	if err != nil {
+		// Values come from unmarshalled YAML hence are always marshallable
+		panic(bosherr.WrapError(err, "Marshalling manifest diff value"))
+	}
+
+	var lines [][]interface{}
+
+	for _, line := range strings.Split(strings.TrimSuffix(string(bytes), "\n"), "\n") {
+		lines = append(lines, []interface{}{indent + line, state})
+	}
+
+	return lines
+}
+
+func (d manifestDiff) formatScalar(value interface{}) string {
+	bytes, err := yaml.Marshal(value)
+	if err != nil {
+		panic(bosherr.WrapError(err, "Marshalling manifest diff value"))
+	}
+
+	return strings.TrimSuffix(string(bytes), "\n")
+}
+
+func (d manifestDiff) redacted(node manifestDiffNode) interface{} {
+	if !d.redact {
+		return node.value
+	}
+
+	return redactManifestDiffValue(node.value, node.raw)
+}
+
+// redactManifestDiffValue replaces values that were interpolated from variables.
+func redactManifestDiffValue(value, raw interface{}) interface{} {
+	switch typedRaw := raw.(type) {
+	case string:
+		if manifestDiffVariableRegexp.MatchString(typedRaw) {
+			return manifestDiffRedacted
+		}
+
+	case yaml.MapSlice:
+		typedValue, ok := value.(yaml.MapSlice)
+		if !ok {
+			return value
+		}
+
+		result := yaml.MapSlice{}
+
+		for _, item := range typedValue {
+			rawValue, _ := lookupManifestDiffValue(typedRaw, item.Key)
+			result = append(result, yaml.MapItem{Key: item.Key, Value: redactManifestDiffValue(item.Value, rawValue)})
+		}
+
+		return result
+
+	case []interface{}:
+		typedValue, ok := value.([]interface{})
+		if !ok {
+			return value
+		}
+
+		result := []interface{}{}
+
+		for i, item := range typedValue {
+			result = append(result, redactManifestDiffValue(item, lookupManifestDiffIndex(typedRaw, i)))
+		}
+
+		return result
+	}
+
+	return value
+}
+
+func isNamedManifestDiffList(value interface{}) bool {
+	list, ok := value.([]interface{})
+	if !ok || len(list) == 0 {
+		return false
+	}
+
+	for _, item := range list {
+		if _, found := manifestDiffItemNameLookup(item); !found {
+			return false
+		}
+	}
+
+	return true
+}
+
+func manifestDiffItemName(item interface{}) interface{} {
+	name, _ := manifestDiffItemNameLookup(item)
+	return name
+}
+
+func manifestDiffItemNameLookup(item interface{}) (interface{}, bool) {
+	typedItem, ok := item.(yaml.MapSlice)
+	if !ok {
+		return nil, false
+	}
+
+	return lookupManifestDiffValue(typedItem, "name")
+}
+
+func findManifestDiffNamedItem(list []interface{}, name interface{}) (int, bool) {
+	if len(list) == 0 || !isNamedManifestDiffList(list) {
+		return 0, false
+	}
+
+	for i, item := range list {
+		if reflect.DeepEqual(manifestDiffItemName(item), name) {
+			return i, true
+		}
+	}
+
+	return 0, false
+}
+
+func nameFirstManifestDiffMap(item yaml.MapSlice) yaml.MapSlice {
+	result := yaml.MapSlice{}
+
+	for _, mapItem := range item {
+		if mapItem.Key == "name" {
+			result = append(yaml.MapSlice{mapItem}, result...)
+		} else {
+			result = append(result, mapItem)
+		}
+	}
+
+	return result
+}
+
+func lookupManifestDiffValue(m yaml.MapSlice, key interface{}) (interface{}, bool) {
+	for _, item := range m {
+		if reflect.DeepEqual(item.Key, key) {
+			return item.Value, true
+		}
+	}
+
+	return nil, false
+}
+
+func lookupManifestDiffKey(raw interface{}, key interface{}) interface{} {
+	m, _ := raw.(yaml.MapSlice)
+	value, _ := lookupManifestDiffValue(m, key)
+	return value
+}
+
+func lookupManifestDiffIndex(raw interface{}, i int) interface{} {
+	list, ok := raw.([]interface{})
+	if !ok || i >= len(list) {
+		return nil
+	}
+
+	return list[i]
+}
@@ -0,0 +1,153 @@
+package director_test
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	. "github.com/cloudfoundry/bosh-cli/director"
+)
+
+var _ = Describe("DiffManifests", func() {
+	It("returns no lines for equal manifests", func() {
+		manifest := []byte("name: dep\ninstance_groups:\n- name: web\n  instances: 1\n")
+
+		diff, err := DiffManifests(ManifestDiffInput{Manifest: manifest}, ManifestDiffInput{Manifest: manifest}, true)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(diff.Diff).To(BeEmpty())
+	})
+
+	It("matches instance groups and jobs by name regardless of order", func() {
+		from := []byte(`
+name: dep
+instance_groups:
+- name: api
+  instances: 1
+- name: web
+  instances: 1
+  jobs:
+  - name: nginx
+    properties:
+      port: 80
+  - name: route
+`)
+		to := []byte(`
+name: dep
+instance_groups:
+- name: web
+  instances: 1
+  jobs:
+  - name: route
+  - name: nginx
+    properties:
+      port: 8080
+- name: api
+  instances: 2
+`)
+
+		diff, err := DiffManifests(ManifestDiffInput{Manifest: from}, ManifestDiffInput{Manifest: to}, true)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(diff.Diff).To(Equal([][]interface{}{
+			{"instance_groups:", ""},
+			{"- name: web", ""},
+			{"  jobs:", ""},
+			{"  - name: nginx", ""},
+			{"    properties:", ""},
+			{"      port: 80", "removed"},
+			{"      port: 8080", "added"},
+			{"- name: api", ""},
+			{"  instances: 1", "removed"},
+			{"  instances: 2", "added"},
+		}))
+
+		changes := diff.Changes()
+		Expect(changes).To(HaveLen(2))
+		Expect(changes[0].InstanceGroup).To(Equal("web"))
+		Expect(changes[0].Job).To(Equal("nginx"))
+		Expect(changes[0].PropertyPath).To(Equal([]string{"port"}))
+	})
+
+	It("shows added and removed sections", func() {
+		from := []byte(`
+instance_groups:
+- name: web
+  azs: [z1]
+- name: api
+  azs: [z1]
+update:
+  canaries: 1
+`)
+		to := []byte(`
+instance_groups:
+- name: web
+  azs: [z1, z2]
+- azs: [z1]
+  name: worker
+features:
+  use_dns_addresses: true
+`)
+
+		diff, err := DiffManifests(ManifestDiffInput{Manifest: from}, ManifestDiffInput{Manifest: to}, true)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(diff.Diff).To(Equal([][]interface{}{
+			{"instance_groups:", ""},
+			{"- name: web", ""},
+			{"  azs:", "removed"},
+			{"  - z1", "removed"},
+			{"  azs:", "added"},
+			{"  - z1", "added"},
+			{"  - z2", "added"},
+			{"- name: worker", "added"},
+			{"  azs:", "added"},
+			{"  - z1", "added"},
+			{"- name: api", "removed"},
+			{"  azs:", "removed"},
+			{"  - z1", "removed"},
+			{"features:", "added"},
+			{"  use_dns_addresses: true", "added"},
+			{"update:", "removed"},
+			{"  canaries: 1", "removed"},
+		}))
+	})
+
+	Context("when uninterpolated manifests are provided", func() {
+		var (
+			from, to ManifestDiffInput
+		)
+
+		BeforeEach(func() {
+			from = ManifestDiffInput{
+				Manifest:       []byte("properties:\n  password: secret1\n  port: 80\n"),
+				Uninterpolated: []byte("properties:\n  password: ((password))\n  port: 80\n"),
+			}
+			to = ManifestDiffInput{
+				Manifest:       []byte("properties:\n  password: secret2\n  port: 8080\n"),
+				Uninterpolated: []byte("properties:\n  password: ((password))\n  port: 8080\n"),
+			}
+		})
+
+		It("redacts values coming from variables", func() {
+			diff, err := DiffManifests(from, to, true)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(diff.Diff).To(Equal([][]interface{}{
+				{"properties:", ""},
+				{"  password: <redacted>", "removed"},
+				{"  password: <redacted>", "added"},
+				{"  port: 80", "removed"},
+				{"  port: 8080", "added"},
+			}))
+		})
+
+		It("does not redact values if redaction is disabled", func() {
+			diff, err := DiffManifests(from, to, false)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(diff.Diff).To(ContainElement([]interface{}{"  password: secret1", "removed"}))
+			Expect(diff.Diff).To(ContainElement([]interface{}{"  password: secret2", "added"}))
+		})
+	})
+
+	It("returns an error if manifest cannot be parsed", func() {
+		_, err := DiffManifests(ManifestDiffInput{Manifest: []byte("-a: b\n- c")}, ManifestDiffInput{}, true)
+		Expect(err).To(HaveOccurred())
+		Expect(err.Error()).To(ContainSubstring("Parsing first manifest"))
+	})
+})
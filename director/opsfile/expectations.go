@@ -0,0 +1,124 @@
+package opsfile
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+
+	bosherr "github.com/cloudfoundry/bosh-utils/errors"
+	"github.com/cppforlife/go-patch/patch"
+	"gopkg.in/yaml.v2"
+)
+
+/*
+- path: /instance_groups/name=web/instances
+  value: 2
+- path: /instance_groups/name=web/jobs/name=nginx
+- path: /instance_groups/name=api
+  absent: true
+*/
+
+// Expectation expects a value to be found at the path; if neither
+// value nor absent is specified only presence of the path is checked.
+type Expectation struct {
+	Path   patch.Pointer
+	Value  interface{}
+	Absent bool
+
+	hasValue bool
+}
+
+type ExpectationResult struct {
+	Expectation Expectation
+	Error       error
+}
+
+func (r ExpectationResult) Passed() bool { return r.Error == nil }
+
+type expectationDefinition struct {
+	Path   string       `yaml:"path"`
+	Value  *interface{} `yaml:"value"`
+	Absent bool         `yaml:"absent"`
+}
+
+func NewExpectationsFromBytes(bytes []byte) ([]Expectation, error) {
+	var defs []expectationDefinition
+
+	err := yaml.Unmarshal(bytes, &defs)
+	if err != nil {
+		return nil, bosherr.WrapError(err, "Unmarshalling expectations")
+	}
+
+	var expectations []Expectation
+
+	for i, def := range defs {
+		path, err := patch.NewPointerFromString(def.Path)
+		if err != nil {
+			return nil, bosherr.WrapErrorf(err, "Expectation [%d]: Parsing path", i)
+		}
+
+		if def.Absent && def.Value != nil {
+			return nil, bosherr.Errorf("Expectation [%d]: Expected either value or absent to be specified", i)
+		}
+
+		expectation := Expectation{Path: path, Absent: def.Absent}
+
+		if def.Value != nil {
+			expectation.Value = *def.Value
+			expectation.hasValue = true
+		}
+
+		expectations = append(expectations, expectation)
+	}
+
+	return expectations, nil
+}
+
+// Check runs all expectations against the document and reports each result.
+func Check(doc interface{}, expectations []Expectation) []ExpectationResult {
+	var results []ExpectationResult
+
+	for _, expectation := range expectations {
+		results = append(results, ExpectationResult{Expectation: expectation, Error: expectation.check(doc)})
+	}
+
+	return results
+}
+
+func (e Expectation) check(doc interface{}) error {
+	found, err := patch.FindOp{Path: e.Path}.Apply(doc)
+
+	if e.Absent {
+		if err == nil {
+			return bosherr.Errorf("Expected to not find '%s'", e.Path)
+		}
+
+		// Parent must exist to make sure that path is not misspelled
+		tokens := e.Path.Tokens()
+		if len(tokens) > 1 {
+			_, err = patch.FindOp{Path: patch.NewPointer(tokens[:len(tokens)-1])}.Apply(doc)
+			return err
+		}
+
+		return nil
+	}
+
+	if err != nil {
+		return err
+	}
+
+	if e.hasValue && !reflect.DeepEqual(found, e.Value) {
+		return bosherr.Errorf("Expected value '%s' but found '%s'", formatValue(e.Value), formatValue(found))
+	}
+
+	return nil
+}
+
+func formatValue(value interface{}) string {
+	bytes, err := yaml.Marshal(value)
+	if err != nil {
+		return fmt.Sprintf("%v", value)
+	}
+
+	return strings.TrimSuffix(string(bytes), "\n")
+}
@@ -0,0 +1,93 @@
+package opsfile_test
+
+import (
+	"github.com/cppforlife/go-patch/patch"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"gopkg.in/yaml.v2"
+
+	. "github.com/cloudfoundry/bosh-cli/director/opsfile"
+)
+
+var _ = Describe("NewExpectationsFromBytes", func() {
+	It("parses expectations", func() {
+		expectations, err := NewExpectationsFromBytes([]byte(`
+- path: /name
+- path: /instance_groups/name=web/instances
+  value: 2
+- path: /instance_groups/name=api
+  absent: true
+`))
+		Expect(err).ToNot(HaveOccurred())
+		Expect(expectations).To(HaveLen(3))
+		Expect(expectations[0].Path).To(Equal(patch.MustNewPointerFromString("/name")))
+		Expect(expectations[1].Value).To(Equal(2))
+		Expect(expectations[2].Absent).To(BeTrue())
+	})
+
+	It("returns an error if path is invalid", func() {
+		_, err := NewExpectationsFromBytes([]byte("- path: name"))
+		Expect(err).To(HaveOccurred())
+		Expect(err.Error()).To(ContainSubstring("Expectation [0]: Parsing path"))
+	})
+
+	It("returns an error if both value and absent are specified", func() {
+		_, err := NewExpectationsFromBytes([]byte("- {path: /name, value: dep, absent: true}"))
+		Expect(err).To(HaveOccurred())
+		Expect(err.Error()).To(ContainSubstring("Expectation [0]: Expected either value or absent to be specified"))
+	})
+})
+
+var _ = Describe("Check", func() {
+	var (
+		doc interface{}
+	)
+
+	BeforeEach(func() {
+		err := yaml.Unmarshal([]byte(`
+name: dep
+instance_groups:
+- name: web
+  instances: 2
+`), &doc)
+		Expect(err).ToNot(HaveOccurred())
+	})
+
+	It("reports results of each expectation", func() {
+		expectations, err := NewExpectationsFromBytes([]byte(`
+- path: /name
+- path: /instance_groups/name=web/instances
+  value: 2
+- path: /instance_groups/name=web/instances
+  value: 3
+- path: /instance_groups/name=api
+  absent: true
+- path: /instance_groups/name=web
+  absent: true
+- path: /update
+- path: /instance_group/name=api
+  absent: true
+`))
+		Expect(err).ToNot(HaveOccurred())
+
+		results := Check(doc, expectations)
+		Expect(results).To(HaveLen(7))
+
+		Expect(results[0].Passed()).To(BeTrue())
+		Expect(results[1].Passed()).To(BeTrue())
+
+		Expect(results[2].Passed()).To(BeFalse())
+		Expect(results[2].Error.Error()).To(Equal("Expected value '3' but found '2'"))
+
+		Expect(results[3].Passed()).To(BeTrue())
+
+		Expect(results[4].Passed()).To(BeFalse())
+		Expect(results[4].Error.Error()).To(ContainSubstring("Expected to not find '/instance_groups/name=web'"))
+
+		Expect(results[5].Passed()).To(BeFalse())
+		Expect(results[5].Error.Error()).To(ContainSubstring("Expected to find a map key 'update'"))
+
+		Expect(results[6].Passed()).To(BeFalse())
+		Expect(results[6].Error.Error()).To(ContainSubstring("Expected to find a map key 'instance_group'"))
+	})
+})
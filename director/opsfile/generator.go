@@ -0,0 +1,180 @@
+package opsfile
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+
+	bosherr "github.com/cloudfoundry/bosh-utils/errors"
+	"github.com/cppforlife/go-patch/patch"
+	"gopkg.in/yaml.v2"
+)
+
+// Generate returns an ops file that turns the first manifest into the second one.
+// Unlike patch.Diff, items of lists of hashes that all have 'name' key
+// are addressed by name (e.g. '/instance_groups/name=web') so that
+// generated ops keep working when list order changes.
+func Generate(fromBytes, toBytes []byte) ([]byte, error) {
+	var from, to interface{}
+
+	err := yaml.Unmarshal(fromBytes, &from)
+	if err != nil {
+		return nil, bosherr.WrapError(err, "Unmarshalling first manifest")
+	}
+
+	err = yaml.Unmarshal(toBytes, &to)
+	if err != nil {
+		return nil, bosherr.WrapError(err, "Unmarshalling second manifest")
+	}
+
+	ops := calculateOps(from, to, []patch.Token{patch.RootToken{}})
+
+	opDefs, err := patch.NewOpDefinitionsFromOps(ops)
+	if err != nil {
+		return nil, bosherr.WrapError(err, "Building ops definitions")
+	}
+
+	if len(opDefs) == 0 {
+		return []byte("[]\n"), nil
+	}
+
+	bytes, err := yaml.Marshal(opDefs)
+	if err != nil {
+		return nil, bosherr.WrapError(err, "Marshalling ops")
+	}
+
+	return bytes, nil
+}
+
+func calculateOps(from, to interface{}, tokens []patch.Token) patch.Ops {
+	switch typedFrom := from.(type) {
+	case map[interface{}]interface{}:
+		if typedTo, ok := to.(map[interface{}]interface{}); ok {
+			return calculateMapOps(typedFrom, typedTo, tokens)
+		}
+
+	case []interface{}:
+		if typedTo, ok := to.([]interface{}); ok && isNamedList(typedFrom) && isNamedList(typedTo) {
+			return calculateNamedListOps(typedFrom, typedTo, tokens)
+		}
+	}
+
+	if reflect.DeepEqual(from, to) {
+		return nil
+	}
+
+	return patch.Ops{patch.ReplaceOp{Path: patch.NewPointer(tokens), Value: to}}
+}
+
+func calculateMapOps(from, to map[interface{}]interface{}, tokens []patch.Token) patch.Ops {
+	var ops patch.Ops
+
+	for _, key := range sortedKeys(from, to) {
+		fromVal, inFrom := from[key]
+		toVal, inTo := to[key]
+
+		switch {
+		case inFrom && inTo:
+			ops = append(ops, calculateOps(fromVal, toVal, withToken(tokens, patch.KeyToken{Key: fmt.Sprintf("%v", key)}))...)
+		case inFrom:
+			ops = append(ops, patch.RemoveOp{Path: patch.NewPointer(withToken(tokens, patch.KeyToken{Key: fmt.Sprintf("%v", key)}))})
+		default:
+			path := patch.NewPointer(withToken(tokens, patch.KeyToken{Key: fmt.Sprintf("%v", key), Optional: true}))
+			ops = append(ops, patch.ReplaceOp{Path: path, Value: toVal})
+		}
+	}
+
+	return ops
+}
+
+func calculateNamedListOps(from, to []interface{}, tokens []patch.Token) patch.Ops {
+	var ops patch.Ops
+
+	for _, fromItem := range from {
+		name := itemName(fromItem)
+		if _, found := findNamedItem(to, name); !found {
+			path := patch.NewPointer(withToken(tokens, patch.MatchingIndexToken{Key: "name", Value: name}))
+			ops = append(ops, patch.RemoveOp{Path: path})
+		}
+	}
+
+	for _, toItem := range to {
+		name := itemName(toItem)
+
+		fromItem, found := findNamedItem(from, name)
+		if !found {
+			ops = append(ops, patch.ReplaceOp{Path: patch.NewPointer(withToken(tokens, patch.AfterLastIndexToken{})), Value: toItem})
+			continue
+		}
+
+		ops = append(ops, calculateOps(fromItem, toItem, withToken(tokens, patch.MatchingIndexToken{Key: "name", Value: name}))...)
+	}
+
+	return ops
+}
+
+func isNamedList(list []interface{}) bool {
+	if len(list) == 0 {
+		return false
+	}
+
+	names := map[string]struct{}{}
+
+	for _, item := range list {
+		typedItem, ok := item.(map[interface{}]interface{})
+		if !ok {
+			return false
+		}
+
+		name, ok := typedItem["name"].(string)
+		if !ok {
+			return false
+		}
+
+		// Items with duplicate names cannot be addressed by name
+		if _, found := names[name]; found {
+			return false
+		}
+
+		names[name] = struct{}{}
+	}
+
+	return true
+}
+
+func itemName(item interface{}) string {
+	return item.(map[interface{}]interface{})["name"].(string)
+}
+
+func findNamedItem(list []interface{}, name string) (interface{}, bool) {
+	for _, item := range list {
+		if itemName(item) == name {
+			return item, true
+		}
+	}
+	return nil, false
+}
+
+func sortedKeys(from, to map[interface{}]interface{}) []interface{} {
+	var keys []interface{}
+
+	for key := range from {
+		keys = append(keys, key)
+	}
+
+	for key := range to {
+		if _, found := from[key]; !found {
+			keys = append(keys, key)
+		}
+	}
+
+	sort.Slice(keys, func(i, j int) bool {
+		return fmt.Sprintf("%v", keys[i]) < fmt.Sprintf("%v", keys[j])
+	})
+
+	return keys
+}
+
+func withToken(tokens []patch.Token, token patch.Token) []patch.Token {
+	return append(append([]patch.Token{}, tokens...), token)
+}
@@ -0,0 +1,99 @@
+package opsfile_test
+
+import (
+	"github.com/cppforlife/go-patch/patch"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"gopkg.in/yaml.v2"
+
+	. "github.com/cloudfoundry/bosh-cli/director/opsfile"
+)
+
+var _ = Describe("Generate", func() {
+	It("generates ops addressing named list items by name", func() {
+		from := []byte(`
+name: dep
+instance_groups:
+- name: api
+  instances: 1
+- name: web
+  instances: 1
+  jobs:
+  - name: nginx
+    properties: {port: 80}
+update:
+  canaries: 1
+`)
+		to := []byte(`
+name: dep
+instance_groups:
+- name: web
+  instances: 2
+  jobs:
+  - name: nginx
+    properties: {port: 80, tls: true}
+- name: worker
+  instances: 1
+features:
+  use_dns_addresses: true
+`)
+
+		bytes, err := Generate(from, to)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(string(bytes)).To(Equal(`- type: replace
+  path: /features?
+  value:
+    use_dns_addresses: true
+- type: remove
+  path: /instance_groups/name=api
+- type: replace
+  path: /instance_groups/name=web/instances
+  value: 2
+- type: replace
+  path: /instance_groups/name=web/jobs/name=nginx/properties/tls?
+  value: true
+- type: replace
+  path: /instance_groups/-
+  value:
+    instances: 1
+    name: worker
+- type: remove
+  path: /update
+`))
+
+		var opDefs []patch.OpDefinition
+		Expect(yaml.Unmarshal(bytes, &opDefs)).To(Succeed())
+
+		ops, err := patch.NewOpsFromDefinitions(opDefs)
+		Expect(err).ToNot(HaveOccurred())
+
+		var fromDoc, toDoc interface{}
+		Expect(yaml.Unmarshal(from, &fromDoc)).To(Succeed())
+		Expect(yaml.Unmarshal(to, &toDoc)).To(Succeed())
+
+		result, err := ops.Apply(fromDoc)
+		Expect(err).ToNot(HaveOccurred())
+
+		// Named list items are matched by name, hence order may differ
+		Expect(result.(map[interface{}]interface{})["features"]).To(Equal(toDoc.(map[interface{}]interface{})["features"]))
+		Expect(result.(map[interface{}]interface{})["instance_groups"]).To(ConsistOf(toDoc.(map[interface{}]interface{})["instance_groups"]))
+	})
+
+	It("replaces lists which cannot be matched by name", func() {
+		bytes, err := Generate([]byte("azs: [z1]"), []byte("azs: [z1, z2]"))
+		Expect(err).ToNot(HaveOccurred())
+		Expect(string(bytes)).To(Equal("- type: replace\n  path: /azs\n  value:\n  - z1\n  - z2\n"))
+	})
+
+	It("returns empty ops if manifests are the same", func() {
+		bytes, err := Generate([]byte("name: dep"), []byte("name: dep"))
+		Expect(err).ToNot(HaveOccurred())
+		Expect(string(bytes)).To(Equal("[]\n"))
+	})
+
+	It("returns an error if manifest cannot be parsed", func() {
+		_, err := Generate([]byte("name: dep"), []byte("-a: b\n- c"))
+		Expect(err).To(HaveOccurred())
+		Expect(err.Error()).To(ContainSubstring("Unmarshalling second manifest"))
+	})
+})
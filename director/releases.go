@@ -107,12 +107,23 @@ type Job struct {
 
 	LinksConsumed []Link `json:"consumes"`
 	LinksProvided []Link `json:"provides"`
+
+	Properties map[string]JobProperty `json:"properties"`
 }
 
 type Link struct {
-	Name     string `json:"name"`
-	Type     string `json:"type"`
-	Optional bool   `json:"optional" yaml:",omitempty"`
+	Name       string   `json:"name"`
+	Type       string   `json:"type"`
+	Optional   bool     `json:"optional" yaml:",omitempty"`
+	Properties []string `json:"properties" yaml:",omitempty"`
+}
+
+type JobProperty struct {
+	Description string      `json:"description"`
+	Type        string      `json:"type"`
+	Default     interface{} `json:"default"`
+	Example     interface{} `json:"example"`
+	Env         string      `json:"env"`
 }
 
 type Package struct {
@@ -100,6 +100,10 @@ func (d DeploymentImpl) TakeSnapshots() error {
 	return d.client.TakeSnapshots(d.name)
 }
 
+func (d DeploymentImpl) TakeSnapshotsAsync() (Task, error) {
+	return d.startTask(func(d DeploymentImpl) error { return d.TakeSnapshots() })
+}
+
 func (d DeploymentImpl) DeleteSnapshots() error {
 	return d.client.DeleteSnapshots(d.name)
 }
@@ -1,6 +1,7 @@
 package director
 
 import (
+	"context"
 	"fmt"
 	"net/http"
 	"time"
@@ -12,6 +13,13 @@ type TaskClientRequest struct {
 	clientRequest         ClientRequest
 	taskReporter          TaskReporter
 	taskCheckStepDuration time.Duration
+
+	// cancelTasks indicates whether task that is being waited for
+	// should be cancelled on the Director when context is done
+	cancelTasks bool
+
+	// taskStarted is called with started task instead of waiting for it when set
+	taskStarted func(id int, state string)
 }
 
 func NewTaskClientRequest(
@@ -26,6 +34,21 @@ func NewTaskClientRequest(
 	}
 }
 
+// WithGoContext returns a copy of the TaskClientRequest
+// which stops waiting for tasks once ctx is done
+func (r TaskClientRequest) WithGoContext(ctx context.Context, cancelTasks bool) TaskClientRequest {
+	r.clientRequest = r.clientRequest.WithGoContext(ctx)
+	r.cancelTasks = cancelTasks
+	return r
+}
+
+// withTaskStarted returns a copy of the TaskClientRequest
+// which does not wait for started tasks but reports them to f
+func (r TaskClientRequest) withTaskStarted(f func(id int, state string)) TaskClientRequest {
+	r.taskStarted = f
+	return r
+}
+
 type taskShortResp struct {
 	ID    int    // 165
 	State string // e.g. "queued", "processing", "done", "error", "cancelled"
@@ -100,18 +123,21 @@ func (r TaskClientRequest) WaitForCompletion(id int, type_ string, taskReporter
 	for {
 		err := r.clientRequest.Get(taskPath, &taskResp)
 		if err != nil {
-			return bosherr.WrapError(err, "Getting task state")
+			return r.abandonTask(id, bosherr.WrapError(err, "Getting task state"))
 		}
 
 		// retrieve output *after* getting state to make sure
 		// it's complete in case of task being finished
 		outputOffset, err = r.reportOutputChunk(taskResp.ID, outputOffset, type_, taskReporter)
 		if err != nil {
-			return bosherr.WrapError(err, "Getting task output")
+			return r.abandonTask(id, bosherr.WrapError(err, "Getting task output"))
 		}
 
 		if taskResp.IsRunning() {
-			time.Sleep(r.taskCheckStepDuration)
+			err = r.waitForNextCheck()
+			if err != nil {
+				return r.abandonTask(id, err)
+			}
 			continue
 		}
 
@@ -125,7 +151,51 @@ func (r TaskClientRequest) WaitForCompletion(id int, type_ string, taskReporter
 	}
 }
 
+func (r TaskClientRequest) waitForNextCheck() error {
+	ctx := r.clientRequest.GoContext()
+
+	timer := time.NewTimer(r.taskCheckStepDuration)
+	defer timer.Stop()
+
+	select {
+	case <-timer.C:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// abandonTask optionally cancels task on the Director
+// if waiting for it was interrupted by done context.
+func (r TaskClientRequest) abandonTask(id int, err error) error {
+	ctxErr := r.clientRequest.GoContext().Err()
+	if ctxErr == nil {
+		return err
+	}
+
+	err = bosherr.WrapErrorf(ctxErr, "Waiting for task '%d'", id)
+
+	if !r.cancelTasks {
+		return err
+	}
+
+	// Original context is already done hence cancel request must not use it
+	path := fmt.Sprintf("/task/%d", id)
+
+	_, _, cancelErr := r.clientRequest.WithGoContext(context.Background()).RawDelete(path)
+	if cancelErr != nil {
+		return bosherr.NewMultiError(err, bosherr.WrapErrorf(cancelErr, "Cancelling task '%d'", id))
+	}
+
+	return err
+}
+
 func (r TaskClientRequest) waitForResult(taskResp taskShortResp) ([]byte, error) {
+	if r.taskStarted != nil {
+		r.taskStarted(taskResp.ID, taskResp.State)
+		return nil, nil
+	}
+
 	err := r.WaitForCompletion(taskResp.ID, "event", r.taskReporter)
 	if err != nil {
 		return nil, err
@@ -1,6 +1,7 @@
 package director_test
 
 import (
+	"context"
 	"crypto/tls"
 	"net/http"
 	"time"
@@ -435,6 +436,79 @@ var _ = Describe("TaskClientRequest", func() {
 			Expect(taskReporter.TaskFinishedCallCount()).To(Equal(1))
 		})
 
+		Context("when go context is cancelled while waiting", func() {
+			var (
+				ctx    context.Context
+				cancel context.CancelFunc
+			)
+
+			BeforeEach(func() {
+				ctx, cancel = context.WithCancel(context.Background())
+
+				server.AppendHandlers(
+					ghttp.CombineHandlers(
+						ghttp.VerifyRequest("GET", "/tasks/123"),
+						ghttp.RespondWith(http.StatusOK, `{"id":123, "state":"processing"}`),
+					),
+					ghttp.CombineHandlers(
+						ghttp.VerifyRequest("GET", "/tasks/123/output", "type=event"),
+						ghttp.RespondWith(http.StatusOK, ""),
+					),
+					ghttp.CombineHandlers(
+						ghttp.VerifyRequest("GET", "/tasks/123"),
+						func(_ http.ResponseWriter, _ *http.Request) { cancel() },
+						ghttp.RespondWith(http.StatusOK, `{"id":123, "state":"processing"}`),
+					),
+				)
+			})
+
+			It("stops waiting and returns an error", func() {
+				req = req.WithGoContext(ctx, false)
+
+				err := act()
+				Expect(err).To(HaveOccurred())
+				Expect(err.Error()).To(ContainSubstring("Waiting for task '123': context canceled"))
+
+				Expect(server.ReceivedRequests()).To(HaveLen(3))
+
+				Expect(taskReporter.TaskStartedCallCount()).To(Equal(1))
+				Expect(taskReporter.TaskFinishedCallCount()).To(Equal(1))
+			})
+
+			It("cancels task if requested", func() {
+				req = req.WithGoContext(ctx, true)
+
+				server.AppendHandlers(
+					ghttp.CombineHandlers(
+						ghttp.VerifyRequest("DELETE", "/task/123"),
+						ghttp.RespondWith(http.StatusNoContent, ""),
+					),
+				)
+
+				err := act()
+				Expect(err).To(HaveOccurred())
+				Expect(err.Error()).To(ContainSubstring("Waiting for task '123': context canceled"))
+
+				Expect(server.ReceivedRequests()).To(HaveLen(4))
+			})
+
+			It("returns an error if cancelling task fails", func() {
+				req = req.WithGoContext(ctx, true)
+
+				server.AppendHandlers(
+					ghttp.CombineHandlers(
+						ghttp.VerifyRequest("DELETE", "/task/123"),
+						ghttp.RespondWith(http.StatusBadRequest, ""),
+					),
+				)
+
+				err := act()
+				Expect(err).To(HaveOccurred())
+				Expect(err.Error()).To(ContainSubstring("Waiting for task '123': context canceled"))
+				Expect(err.Error()).To(ContainSubstring("Cancelling task '123'"))
+			})
+		})
+
 		It("returns an error if getting task output fails", func() {
 			server.AppendHandlers(
 				ghttp.CombineHandlers(
@@ -45,6 +45,10 @@ func (t TaskImpl) Result() string      { return t.result }
 
 func (t TaskImpl) Cancel() error { return t.client.CancelTask(t.id) }
 
+func (t TaskImpl) Wait() error {
+	return t.client.taskClientRequest.WaitForCompletion(t.id, "event", NewNoopTaskReporter())
+}
+
 type TaskResp struct {
 	ID int // 165
 
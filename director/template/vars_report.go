@@ -0,0 +1,227 @@
+package template
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/cppforlife/go-patch/patch"
+	"gopkg.in/yaml.v2"
+)
+
+const VarsReportSourceGenerated = "generated"
+
+// VarsSource is a named set of variables (e.g. all variables given via --var).
+// Generating sources are able to create missing variables that have a type.
+type VarsSource struct {
+	Name       string
+	Variables  Variables
+	Generating bool
+}
+
+type VarsReport struct {
+	References  []VarReference
+	Definitions []VarDefinitionReport
+	Unused      []UnusedVar
+}
+
+// VarReference describes a single ((var)) occurrence in a template.
+// Source is empty when variable cannot be resolved.
+type VarReference struct {
+	Name   string `json:"name"`
+	Path   string `json:"path"`
+	Found  bool   `json:"found"`
+	Source string `json:"source,omitempty"`
+}
+
+// VarDefinitionReport describes a variable from 'variables' section;
+// CA is set for certificates that are signed by another variable.
+type VarDefinitionReport struct {
+	Name string `json:"name"`
+	Type string `json:"type"`
+	CA   string `json:"ca,omitempty"`
+}
+
+type UnusedVar struct {
+	Name   string `json:"name"`
+	Source string `json:"source"`
+}
+
+// VarsReport lists variables referenced by the template after applying op
+// without interpolating them, hence no variables are generated.
+func (t Template) VarsReport(sources []VarsSource, op patch.Op) (VarsReport, error) {
+	var (
+		obj    interface{}
+		report VarsReport
+	)
+
+	err := yaml.Unmarshal(t.bytes, &obj)
+	if err != nil {
+		return report, err
+	}
+
+	if op != nil {
+		obj, err = op.Apply(obj)
+		if err != nil {
+			return report, err
+		}
+	}
+
+	var defs varDefinitions
+
+	if _, isMap := obj.(map[interface{}]interface{}); isMap {
+		defsBytes, err := yaml.Marshal(obj)
+		if err != nil {
+			return report, err
+		}
+
+		err = yaml.Unmarshal(defsBytes, &defs)
+		if err != nil {
+			return report, err
+		}
+	}
+
+	for _, def := range defs.Definitions {
+		defReport := VarDefinitionReport{Name: def.Name, Type: def.Type}
+
+		if opts, ok := def.Options.(map[interface{}]interface{}); ok {
+			if ca, ok := opts["ca"].(string); ok {
+				defReport.CA = ca
+			}
+		}
+
+		report.Definitions = append(report.Definitions, defReport)
+	}
+
+	used := map[string]struct{}{}
+
+	err = t.collectVarReferences(obj, []patch.Token{patch.RootToken{}}, func(name string, path patch.Pointer) error {
+		// Only top level name is looked up for references such as ((cert.ca))
+		baseName := strings.Split(name, ".")[0]
+		used[baseName] = struct{}{}
+
+		source, found, err := t.findVarSource(baseName, defs.Find(baseName), sources)
+		if err != nil {
+			return err
+		}
+
+		report.References = append(report.References, VarReference{
+			Name:   name,
+			Path:   path.String(),
+			Found:  found,
+			Source: source,
+		})
+
+		return nil
+	})
+	if err != nil {
+		return report, err
+	}
+
+	for _, source := range sources {
+		sourceDefs, err := source.Variables.List()
+		if err != nil {
+			return report, err
+		}
+
+		for _, def := range sourceDefs {
+			if _, found := used[def.Name]; !found {
+				report.Unused = append(report.Unused, UnusedVar{Name: def.Name, Source: source.Name})
+			}
+		}
+	}
+
+	sort.SliceStable(report.Unused, func(i, j int) bool {
+		return report.Unused[i].Name < report.Unused[j].Name
+	})
+
+	return report, nil
+}
+
+func (t Template) findVarSource(name string, def VariableDefinition, sources []VarsSource) (string, bool, error) {
+	for _, source := range sources {
+		// Type is not provided to avoid generating variables
+		_, found, err := source.Variables.Get(VariableDefinition{Name: name})
+		if err != nil {
+			return "", false, err
+		}
+
+		if found {
+			return source.Name, true, nil
+		}
+	}
+
+	if len(def.Type) > 0 {
+		for _, source := range sources {
+			if source.Generating {
+				return VarsReportSourceGenerated, true, nil
+			}
+		}
+	}
+
+	return "", false, nil
+}
+
+func (t Template) collectVarReferences(node interface{}, tokens []patch.Token, f func(string, patch.Pointer) error) error {
+	switch typedNode := node.(type) {
+	case map[interface{}]interface{}:
+		var keys []string
+
+		for k := range typedNode {
+			keys = append(keys, fmt.Sprintf("%v", k))
+		}
+
+		sort.Strings(keys)
+
+		for _, key := range keys {
+			keyTokens := append(append([]patch.Token{}, tokens...), patch.KeyToken{Key: key})
+
+			for _, name := range (interpolator{}).extractVarNames(key) {
+				err := f(name, patch.NewPointer(keyTokens))
+				if err != nil {
+					return err
+				}
+			}
+
+			err := t.collectVarReferences(t.mapValue(typedNode, key), keyTokens, f)
+			if err != nil {
+				return err
+			}
+		}
+
+	case []interface{}:
+		for idx, item := range typedNode {
+			var token patch.Token = patch.IndexToken{Index: idx}
+
+			if typedItem, ok := item.(map[interface{}]interface{}); ok {
+				if name, ok := typedItem["name"].(string); ok {
+					token = patch.MatchingIndexToken{Key: "name", Value: name}
+				}
+			}
+
+			err := t.collectVarReferences(item, append(append([]patch.Token{}, tokens...), token), f)
+			if err != nil {
+				return err
+			}
+		}
+
+	case string:
+		for _, name := range (interpolator{}).extractVarNames(typedNode) {
+			err := f(name, patch.NewPointer(tokens))
+			if err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+func (t Template) mapValue(m map[interface{}]interface{}, key string) interface{} {
+	for k, v := range m {
+		if fmt.Sprintf("%v", k) == key {
+			return v
+		}
+	}
+	return nil
+}
@@ -0,0 +1,154 @@
+package template_test
+
+import (
+	"errors"
+
+	"github.com/cppforlife/go-patch/patch"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	. "github.com/cloudfoundry/bosh-cli/director/template"
+)
+
+var _ = Describe("Template", func() {
+	Describe("VarsReport", func() {
+		var (
+			sources []VarsSource
+		)
+
+		BeforeEach(func() {
+			sources = []VarsSource{
+				{Name: "--var", Variables: StaticVariables{"password": "secret", "extra": "val"}},
+				{Name: "--vars-file", Variables: StaticVariables{"password": "other", "ca": "ca-val"}},
+			}
+		})
+
+		It("reports each variable reference with its path and source", func() {
+			tpl := NewTemplate([]byte(`
+name: dep
+instance_groups:
+- name: web
+  jobs:
+  - name: nginx
+    properties:
+      password: ((password))
+      tls: ((ca.certificate))
+  networks:
+  - static_ips: [((ip))]
+`))
+
+			report, err := tpl.VarsReport(sources, nil)
+			Expect(err).ToNot(HaveOccurred())
+
+			Expect(report.References).To(Equal([]VarReference{
+				{
+					Name:   "password",
+					Path:   "/instance_groups/name=web/jobs/name=nginx/properties/password",
+					Found:  true,
+					Source: "--var",
+				},
+				{
+					Name:   "ca.certificate",
+					Path:   "/instance_groups/name=web/jobs/name=nginx/properties/tls",
+					Found:  true,
+					Source: "--vars-file",
+				},
+				{
+					Name: "ip",
+					Path: "/instance_groups/name=web/networks/0/static_ips/0",
+				},
+			}))
+
+			Expect(report.Unused).To(Equal([]UnusedVar{{Name: "extra", Source: "--var"}}))
+		})
+
+		It("applies operations before collecting references", func() {
+			tpl := NewTemplate([]byte("name: dep\n"))
+
+			op := patch.ReplaceOp{Path: patch.MustNewPointerFromString("/password?"), Value: "((password))"}
+
+			report, err := tpl.VarsReport(sources, op)
+			Expect(err).ToNot(HaveOccurred())
+
+			Expect(report.References).To(Equal([]VarReference{
+				{Name: "password", Path: "/password", Found: true, Source: "--var"},
+			}))
+		})
+
+		It("reports variables definitions and their CAs", func() {
+			tpl := NewTemplate([]byte(`
+variables:
+- name: ca
+  type: certificate
+  options: {is_ca: true}
+- name: cert
+  type: certificate
+  options: {ca: ca}
+- name: password
+  type: password
+`))
+
+			report, err := tpl.VarsReport(sources, nil)
+			Expect(err).ToNot(HaveOccurred())
+
+			Expect(report.Definitions).To(Equal([]VarDefinitionReport{
+				{Name: "ca", Type: "certificate"},
+				{Name: "cert", Type: "certificate", CA: "ca"},
+				{Name: "password", Type: "password"},
+			}))
+
+			Expect(report.References).To(BeEmpty())
+		})
+
+		Context("when variable is defined but not found", func() {
+			var (
+				tpl   Template
+				store *FakeVariables
+			)
+
+			BeforeEach(func() {
+				tpl = NewTemplate([]byte("cert: ((cert))\nvariables:\n- name: cert\n  type: certificate\n"))
+				store = &FakeVariables{}
+			})
+
+			It("reports it as generated if a generating source is given without asking to generate it", func() {
+				sources = append(sources, VarsSource{Name: "vars-store", Variables: store, Generating: true})
+
+				report, err := tpl.VarsReport(sources, nil)
+				Expect(err).ToNot(HaveOccurred())
+
+				Expect(report.References).To(Equal([]VarReference{
+					{Name: "cert", Path: "/cert", Found: true, Source: "generated"},
+				}))
+
+				Expect(store.GetVarDef).To(Equal(VariableDefinition{Name: "cert"}))
+			})
+
+			It("reports it as missing if no source is generating", func() {
+				sources = append(sources, VarsSource{Name: "other", Variables: store})
+
+				report, err := tpl.VarsReport(sources, nil)
+				Expect(err).ToNot(HaveOccurred())
+
+				Expect(report.References).To(Equal([]VarReference{
+					{Name: "cert", Path: "/cert"},
+				}))
+			})
+		})
+
+		It("returns an error if looking up variable fails", func() {
+			sources = []VarsSource{{Name: "vars-store", Variables: &FakeVariables{GetErr: errors.New("fake-err")}}}
+
+			_, err := NewTemplate([]byte("key: ((key))")).VarsReport(sources, nil)
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(Equal("fake-err"))
+		})
+
+		It("returns an error if operation cannot be applied", func() {
+			op := patch.ReplaceOp{Path: patch.MustNewPointerFromString("/missing/key"), Value: "val"}
+
+			_, err := NewTemplate([]byte("key: val")).VarsReport(sources, op)
+			Expect(err).To(HaveOccurred())
+		})
+	})
+})
@@ -46,8 +46,9 @@ var _ = Describe("JobRenderer", func() {
 		releaseJob  bireljob.Job
 		releaseJobs []bireljob.Job
 
-		manifest  biinstallmanifest.Manifest
-		fakeStage *fakebiui.FakeStage
+		manifest        biinstallmanifest.Manifest
+		releaseJobLinks map[string]bitemplate.ReleaseJobLinks
+		fakeStage       *fakebiui.FakeStage
 
 		renderedJobList bitemplate.RenderedJobList
 	)
@@ -66,14 +67,23 @@ var _ = Describe("JobRenderer", func() {
 		manifest = biinstallmanifest.Manifest{
 			Name: "fake-installation-name",
 			Template: biinstallmanifest.ReleaseJobRef{
-				Name:    "fake-cpi-job-name",
-				Release: "fake-cpi-release-name",
+				Name:     "fake-cpi-job-name",
+				Release:  "fake-cpi-release-name",
+				Consumes: biproperty.Map{"db": biproperty.Map{"from": "fake-db-link"}},
+				Provides: biproperty.Map{"cpi": biproperty.Map{"as": "fake-cpi-link"}},
 			},
 			Properties: biproperty.Map{
 				"fake-installation-property": "fake-installation-property-value",
 			},
 		}
 
+		releaseJobLinks = map[string]bitemplate.ReleaseJobLinks{
+			"fake-cpi-job-name": {
+				Consumes: biproperty.Map{"db": biproperty.Map{"from": "fake-db-link"}},
+				Provides: biproperty.Map{"cpi": biproperty.Map{"as": "fake-cpi-link"}},
+			},
+		}
+
 		pkg1 := birelpkg.NewPackage(NewResource("pkg1-name", "pkg1-fp", nil), nil)
 		pkg2 := birelpkg.NewPackage(NewResource("pkg2-name", "pkg2-fp", nil), []string{"pkg1-name"})
 		pkg2.AttachDependencies([]*birelpkg.Package{pkg1})
@@ -106,7 +116,7 @@ var _ = Describe("JobRenderer", func() {
 		renderedJobList = bitemplate.NewRenderedJobList()
 		renderedJobList.Add(bitemplate.NewRenderedJob(releaseJob, "/fake-rendered-job-cpi", fs, logger))
 
-		mockJobListRenderer.EXPECT().Render(releaseJobs, releaseJobProperties, jobProperties, globalProperties, deploymentName, address).Return(renderedJobList, nil).AnyTimes()
+		mockJobListRenderer.EXPECT().Render(releaseJobs, releaseJobProperties, releaseJobLinks, jobProperties, globalProperties, deploymentName, address).Return(renderedJobList, nil).AnyTimes()
 
 		fakeCompressor.CompressFilesInDirTarballPath = "/fake-rendered-job-tarball-cpi.tgz"
 		multiDigest := boshcrypto.MustParseMultipleDigest("fakerenderedjobtarballsha1cpi")
@@ -18,8 +18,10 @@ type Certificate struct {
 }
 
 type ReleaseJobRef struct {
-	Name    string
-	Release string
+	Name     string
+	Release  string
+	Consumes biproperty.Map
+	Provides biproperty.Map
 }
 
 type Registry struct {
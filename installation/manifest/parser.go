@@ -47,8 +47,10 @@ func (i installation) HasSSHTunnel() bool {
 }
 
 type template struct {
-	Name    string
-	Release string
+	Name     string
+	Release  string
+	Consumes map[interface{}]interface{}
+	Provides map[interface{}]interface{}
 }
 
 func NewParser(fs boshsys.FileSystem, uuidGenerator boshuuid.Generator, logger boshlog.Logger, validator Validator) Parser {
@@ -127,6 +129,22 @@ func (p *parser) Parse(path string, vars boshtpl.Variables, op patch.Op, release
 	}
 	installationManifest.Properties = properties
 
+	if comboManifest.CloudProvider.Template.Consumes != nil {
+		consumes, err := biproperty.BuildMap(comboManifest.CloudProvider.Template.Consumes)
+		if err != nil {
+			return Manifest{}, bosherr.WrapErrorf(err, "Parsing cloud_provider template consumes: %#v", comboManifest.CloudProvider.Template.Consumes)
+		}
+		installationManifest.Template.Consumes = consumes
+	}
+
+	if comboManifest.CloudProvider.Template.Provides != nil {
+		provides, err := biproperty.BuildMap(comboManifest.CloudProvider.Template.Provides)
+		if err != nil {
+			return Manifest{}, bosherr.WrapErrorf(err, "Parsing cloud_provider template provides: %#v", comboManifest.CloudProvider.Template.Provides)
+		}
+		installationManifest.Template.Provides = provides
+	}
+
 	if comboManifest.CloudProvider.HasSSHTunnel() {
 		password, err := p.uuidGenerator.Generate()
 		if err != nil {
@@ -413,12 +413,14 @@ cloud_provider:
 				deploymentRecord := bidepl.NewRecord(deploymentRepo, releaseRepo, stemcellRepo)
 				stemcellManagerFactory = bistemcell.NewManagerFactory(stemcellRepo)
 				diskManagerFactory = bidisk.NewManagerFactory(diskRepo, logger)
-				diskDeployer = bivm.NewDiskDeployer(diskManagerFactory, diskRepo, logger, false)
+				checkpointRepo := biconfig.NewCheckpointRepo(deploymentStateService)
+				diskDeployer = bivm.NewDiskDeployer(diskManagerFactory, diskRepo, checkpointRepo, logger, false)
 				vmManagerFactory = bivm.NewManagerFactory(vmRepo, stemcellRepo, diskDeployer, fakeAgentIDGenerator, fs, logger)
 				deployer := bidepl.NewDeployer(
 					vmManagerFactory,
 					instanceManagerFactory,
 					deploymentFactory,
+					checkpointRepo,
 					logger,
 				)
 				tarballCache := bitarball.NewCache("fake-base-path", fs, logger)
@@ -721,6 +723,11 @@ cloud_provider:
 
 			gomock.InOrder(
 				mockCloud.EXPECT().Info().Return(bicloud.CpiInfo{ApiVersion: cpiApiVersion}, nil),
+
+				// interrupted migration prevents resuming on old vm
+				mockCloud.EXPECT().HasVM(oldVMCID).Return(true, nil),
+				mockAgentClient.EXPECT().ListDisk().Return([]string{oldDiskCID, "fake-disk-cid-2"}, nil),
+
 				mockCloud.EXPECT().HasVM(oldVMCID).Return(true, nil),
 
 				// shutdown old vm
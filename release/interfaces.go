@@ -31,6 +31,14 @@ type Writer interface {
 	Write(Release, []string) (string, error)
 }
 
+//counterfeiter:generate . ArchiveVerifier
+
+type ArchiveVerifier interface {
+	// Verify returns reasons why an archive could not have been
+	// produced reproducibly. No reasons are returned otherwise.
+	Verify(string) ([]string, error)
+}
+
 //counterfeiter:generate . Release
 
 type Release interface {
@@ -5,7 +5,6 @@ import (
 
 	bosherr "github.com/cloudfoundry/bosh-utils/errors"
 	boshcmd "github.com/cloudfoundry/bosh-utils/fileutil"
-	biproperty "github.com/cloudfoundry/bosh-utils/property"
 	boshsys "github.com/cloudfoundry/bosh-utils/system"
 
 	boshjobman "github.com/cloudfoundry/bosh-cli/release/job/manifest"
@@ -60,25 +59,10 @@ func (r ArchiveReaderImpl) Read(ref boshman.JobRef, path string) (*Job, error) {
 		return nil, err
 	}
 
-	job.Templates = manifest.Templates
-	job.PackageNames = manifest.Packages
-
-	properties := make(map[string]PropertyDefinition, len(manifest.Properties))
-
-	for propertyName, rawPropertyDef := range manifest.Properties {
-		defaultValue, err := biproperty.Build(rawPropertyDef.Default)
-		if err != nil {
-			errMsg := "Parsing job '%s' property '%s' default: %#v"
-			return nil, bosherr.WrapErrorf(err, errMsg, job.Name(), propertyName, rawPropertyDef.Default)
-		}
-
-		properties[propertyName] = PropertyDefinition{
-			Description: rawPropertyDef.Description,
-			Default:     defaultValue,
-		}
+	err = job.loadManifest(manifest)
+	if err != nil {
+		return nil, err
 	}
 
-	job.Properties = properties
-
 	return job, nil
 }
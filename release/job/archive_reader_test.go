@@ -46,10 +46,17 @@ var _ = Describe("ArchiveReaderImpl", func() {
 name: name
 templates: {src: dst}
 packages: [pkg]
+consumes:
+- {name: db, type: database, optional: true}
+provides:
+- {name: api, type: http, properties: [prop]}
 properties:
   prop:
     description: prop-desc
+    type: string
     default: prop-default
+    example: prop-example
+    env: PROP
 `)
 
 			job, err := reader.Read(ref, "archive-path")
@@ -62,10 +69,19 @@ properties:
 
 			Expect(job.Templates).To(Equal(map[string]string{"src": "dst"}))
 			Expect(job.PackageNames).To(Equal([]string{"pkg"}))
+			Expect(job.Consumes).To(Equal([]LinkDefinition{
+				{Name: "db", Type: "database", Optional: true},
+			}))
+			Expect(job.Provides).To(Equal([]LinkDefinition{
+				{Name: "api", Type: "http", Properties: []string{"prop"}},
+			}))
 			Expect(job.Properties).To(Equal(map[string]PropertyDefinition{
 				"prop": PropertyDefinition{
 					Description: "prop-desc",
+					Type:        "string",
 					Default:     biproperty.Property("prop-default"),
+					Example:     biproperty.Property("prop-example"),
+					Env:         "PROP",
 				},
 			}))
 
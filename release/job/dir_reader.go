@@ -37,8 +37,11 @@ func (r DirReaderImpl) Read(path string) (*Job, error) {
 	}
 
 	job := NewJob(NewResource(manifest.Name, fp, archive))
-	job.PackageNames = manifest.Packages
-	// Does not read all manifest values...
+
+	err = job.loadManifest(manifest)
+	if err != nil {
+		return nil, err
+	}
 
 	return job, nil
 }
@@ -80,3 +83,23 @@ func (r DirReaderImpl) collectFiles(path string) (boshjobman.Manifest, []File, e
 
 	return manifest, files, nil
 }
+
+// ReadSource returns job that refers to its source directory as if it was extracted,
+// so that its templates can be rendered without building the job.
+// Source directory is not removed when job is cleaned up.
+func (r DirReaderImpl) ReadSource(path string) (*Job, error) {
+	manifest, _, err := r.collectFiles(path)
+	if err != nil {
+		return nil, bosherr.WrapErrorf(err, "Collecting job files")
+	}
+
+	job := NewJob(NewResource(manifest.Name, "", nil))
+	job.extractedPath = path
+
+	err = job.loadManifest(manifest)
+	if err != nil {
+		return nil, err
+	}
+
+	return job, nil
+}
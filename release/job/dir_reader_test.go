@@ -55,7 +55,11 @@ properties:
 			archive.FingerprintReturns("fp", nil)
 
 			expectedJob := NewJob(NewResource("my-job", "fp", archive))
-			expectedJob.PackageNames = []string{"pkg"} // only expect pkg names
+			expectedJob.Templates = map[string]string{"src": "dst"}
+			expectedJob.PackageNames = []string{"pkg"}
+			expectedJob.Properties = map[string]PropertyDefinition{
+				"prop": PropertyDefinition{Description: "prop-desc", Default: "prop-default"},
+			}
 
 			job, err := reader.Read(filepath.Join("/", "my-job"))
 			Expect(err).NotTo(HaveOccurred())
@@ -77,9 +81,12 @@ properties:
 
 			archive.FingerprintReturns("fp", nil)
 
+			expectedJob := NewJob(NewResource("my-job", "fp", archive))
+			expectedJob.Properties = map[string]PropertyDefinition{}
+
 			job, err := reader.Read(filepath.Join("/", "my-job"))
 			Expect(err).NotTo(HaveOccurred())
-			Expect(job).To(Equal(NewJob(NewResource("my-job", "fp", archive))))
+			Expect(job).To(Equal(expectedJob))
 
 			Expect(collectedFiles).To(Equal([]File{
 				File{Path: filepath.Join("/", "my-job", "spec"), DirPath: filepath.Join("/", "my-job"), RelativePath: "job.MF"},
@@ -126,4 +133,47 @@ properties:
 			Expect(err.Error()).To(ContainSubstring("Job directory 'my-job-name' does not match job name 'other-job-name' in spec"))
 		})
 	})
+
+	Describe("ReadSource", func() {
+		It("returns a job with templates and properties that refers to job directory", func() {
+			fs.WriteFileString(filepath.Join("/", "my-job", "spec"), `---
+name: my-job
+templates: {src: dst}
+packages: [pkg]
+properties:
+  prop:
+    description: prop-desc
+    default: prop-default
+`)
+
+			job, err := reader.ReadSource(filepath.Join("/", "my-job"))
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(job.Name()).To(Equal("my-job"))
+			Expect(job.ExtractedPath()).To(Equal(filepath.Join("/", "my-job")))
+			Expect(job.Templates).To(Equal(map[string]string{"src": "dst"}))
+			Expect(job.PackageNames).To(Equal([]string{"pkg"}))
+			Expect(job.Properties).To(Equal(map[string]PropertyDefinition{
+				"prop": PropertyDefinition{Description: "prop-desc", Default: "prop-default"},
+			}))
+		})
+
+		It("does not remove job directory when job is cleaned up", func() {
+			fs.WriteFileString(filepath.Join("/", "my-job", "spec"), "---\nname: my-job")
+
+			job, err := reader.ReadSource(filepath.Join("/", "my-job"))
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(job.CleanUp()).To(Succeed())
+			Expect(fs.FileExists(filepath.Join("/", "my-job", "spec"))).To(BeTrue())
+		})
+
+		It("returns error if spec file is not valid", func() {
+			fs.WriteFileString(filepath.Join("/", "my-job", "spec"), `-`)
+
+			_, err := reader.ReadSource(filepath.Join("/", "my-job"))
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("Collecting job files"))
+		})
+	})
 })
@@ -6,6 +6,7 @@ import (
 	boshsys "github.com/cloudfoundry/bosh-utils/system"
 
 	"github.com/cloudfoundry/bosh-cli/crypto"
+	boshjobman "github.com/cloudfoundry/bosh-cli/release/job/manifest"
 	boshpkg "github.com/cloudfoundry/bosh-cli/release/pkg"
 	. "github.com/cloudfoundry/bosh-cli/release/resource"
 	crypto2 "github.com/cloudfoundry/bosh-utils/crypto"
@@ -23,15 +24,29 @@ type Job struct {
 	Templates    map[string]string
 	PackageNames []string
 	Packages     []boshpkg.Compilable
+	Consumes     []LinkDefinition
+	Provides     []LinkDefinition
 	Properties   map[string]PropertyDefinition
 
 	extractedPath string
 	fs            boshsys.FileSystem
 }
 
+type LinkDefinition struct {
+	Name     string
+	Type     string
+	Optional bool
+
+	// Names of job properties that are exposed via provided link
+	Properties []string
+}
+
 type PropertyDefinition struct {
 	Description string
+	Type        string
 	Default     biproperty.Property
+	Example     biproperty.Property
+	Env         string
 }
 
 func NewJob(resource Resource) *Job {
@@ -79,6 +94,8 @@ func (j *Job) RehashWithCalculator(calculator crypto.DigestCalculator, archiveFi
 		Templates:    j.Templates,
 		PackageNames: j.PackageNames,
 		Packages:     j.Packages,
+		Consumes:     j.Consumes,
+		Provides:     j.Provides,
 		Properties:   j.Properties,
 
 		extractedPath: j.extractedPath,
@@ -115,3 +132,54 @@ func (j Job) CleanUp() error {
 	}
 	return nil
 }
+
+// loadManifest populates job details (templates, packages, links and properties) from its spec.
+func (j *Job) loadManifest(manifest boshjobman.Manifest) error {
+	j.Templates = manifest.Templates
+	j.PackageNames = manifest.Packages
+	j.Consumes = j.linkDefinitions(manifest.Consumes)
+	j.Provides = j.linkDefinitions(manifest.Provides)
+
+	properties := make(map[string]PropertyDefinition, len(manifest.Properties))
+
+	for propertyName, rawPropertyDef := range manifest.Properties {
+		defaultValue, err := biproperty.Build(rawPropertyDef.Default)
+		if err != nil {
+			errMsg := "Parsing job '%s' property '%s' default: %#v"
+			return bosherr.WrapErrorf(err, errMsg, j.Name(), propertyName, rawPropertyDef.Default)
+		}
+
+		exampleValue, err := biproperty.Build(rawPropertyDef.Example)
+		if err != nil {
+			errMsg := "Parsing job '%s' property '%s' example: %#v"
+			return bosherr.WrapErrorf(err, errMsg, j.Name(), propertyName, rawPropertyDef.Example)
+		}
+
+		properties[propertyName] = PropertyDefinition{
+			Description: rawPropertyDef.Description,
+			Type:        rawPropertyDef.Type,
+			Default:     defaultValue,
+			Example:     exampleValue,
+			Env:         rawPropertyDef.Env,
+		}
+	}
+
+	j.Properties = properties
+
+	return nil
+}
+
+func (j *Job) linkDefinitions(rawLinkDefs []boshjobman.LinkDefinition) []LinkDefinition {
+	var links []LinkDefinition
+
+	for _, rawLinkDef := range rawLinkDefs {
+		links = append(links, LinkDefinition{
+			Name:       rawLinkDef.Name,
+			Type:       rawLinkDef.Type,
+			Optional:   rawLinkDef.Optional,
+			Properties: rawLinkDef.Properties,
+		})
+	}
+
+	return links
+}
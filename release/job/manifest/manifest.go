@@ -10,12 +10,26 @@ type Manifest struct {
 	Name       string                        `yaml:"name"`
 	Templates  map[string]string             `yaml:"templates"`
 	Packages   []string                      `yaml:"packages"`
+	Consumes   []LinkDefinition              `yaml:"consumes"`
+	Provides   []LinkDefinition              `yaml:"provides"`
 	Properties map[string]PropertyDefinition `yaml:"properties"`
 }
 
+type LinkDefinition struct {
+	Name     string `yaml:"name"`
+	Type     string `yaml:"type"`
+	Optional bool   `yaml:"optional"`
+
+	// Only specified for provided links
+	Properties []string `yaml:"properties"`
+}
+
 type PropertyDefinition struct {
 	Description string      `yaml:"description"`
+	Type        string      `yaml:"type"`
 	Default     interface{} `yaml:"default"`
+	Example     interface{} `yaml:"example"`
+	Env         string      `yaml:"env"`
 }
 
 func NewManifestFromPath(path string, fs boshsys.FileSystem) (Manifest, error) {
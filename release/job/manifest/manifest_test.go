@@ -63,6 +63,59 @@ properties:
 		}))
 	})
 
+	It("parses links and property metadata", func() {
+		contents := `---
+name: name
+
+consumes:
+- name: db
+  type: database
+- name: backup
+  type: blobstore
+  optional: true
+
+provides:
+- name: api
+  type: http
+  properties: [port, tls.enabled]
+
+properties:
+  port:
+    description: Port to listen on
+    type: integer
+    default: 8080
+    example: 443
+    env: PORT
+`
+
+		fs.WriteFileString("/path", contents)
+
+		manifest, err := NewManifestFromPath("/path", fs)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(manifest).To(Equal(Manifest{
+			Name: "name",
+
+			Consumes: []LinkDefinition{
+				{Name: "db", Type: "database"},
+				{Name: "backup", Type: "blobstore", Optional: true},
+			},
+
+			Provides: []LinkDefinition{
+				{Name: "api", Type: "http", Properties: []string{"port", "tls.enabled"}},
+			},
+
+			Properties: map[string]PropertyDefinition{
+				"port": PropertyDefinition{
+					Description: "Port to listen on",
+					Type:        "integer",
+					Default:     8080,
+					Example:     443,
+					Env:         "PORT",
+				},
+			},
+		}))
+	})
+
 	It("returns error if manifest is not valid yaml", func() {
 		fs.WriteFileString("/path", "-")
 
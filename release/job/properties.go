@@ -0,0 +1,66 @@
+package job
+
+import (
+	"fmt"
+	"strings"
+)
+
+// PropertyKind tells how a manifest property relates to properties declared in a job spec.
+type PropertyKind int
+
+const (
+	// DeclaredProperty is declared in the job spec
+	DeclaredProperty PropertyKind = iota
+
+	// NonHashProperty prefixes declared properties (e.g. 'tls' for 'tls.cert') but is not a hash
+	NonHashProperty
+
+	// UndeclaredProperty is neither declared nor nested under a declared property
+	UndeclaredProperty
+)
+
+// WalkProperties calls fn with dot separated names of given manifest properties.
+// Hashes are only descended into if their names prefix declared properties.
+func WalkProperties(
+	props map[interface{}]interface{},
+	defs map[string]PropertyDefinition,
+	fn func(name string, value interface{}, kind PropertyKind),
+) {
+	walkProperties("", props, defs, fn)
+}
+
+func walkProperties(
+	prefix string,
+	props map[interface{}]interface{},
+	defs map[string]PropertyDefinition,
+	fn func(string, interface{}, PropertyKind),
+) {
+	for key, value := range props {
+		name := prefix + fmt.Sprintf("%v", key)
+
+		if _, found := defs[name]; found {
+			fn(name, value, DeclaredProperty)
+			continue
+		}
+
+		if !hasPropertyWithPrefix(name+".", defs) {
+			fn(name, value, UndeclaredProperty)
+			continue
+		}
+
+		if nested, ok := value.(map[interface{}]interface{}); ok {
+			walkProperties(name+".", nested, defs, fn)
+		} else {
+			fn(name, value, NonHashProperty)
+		}
+	}
+}
+
+func hasPropertyWithPrefix(prefix string, defs map[string]PropertyDefinition) bool {
+	for name := range defs {
+		if strings.HasPrefix(name, prefix) {
+			return true
+		}
+	}
+	return false
+}
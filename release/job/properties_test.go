@@ -0,0 +1,67 @@
+package job_test
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	. "github.com/cloudfoundry/bosh-cli/release/job"
+)
+
+var _ = Describe("WalkProperties", func() {
+	type visit struct {
+		Value interface{}
+		Kind  PropertyKind
+	}
+
+	walk := func(props map[interface{}]interface{}) map[string]visit {
+		defs := map[string]PropertyDefinition{
+			"port":     {Type: "integer"},
+			"tls.cert": {},
+			"tls.key":  {},
+			"env":      {Type: "hash"},
+		}
+
+		visits := map[string]visit{}
+
+		WalkProperties(props, defs, func(name string, value interface{}, kind PropertyKind) {
+			visits[name] = visit{value, kind}
+		})
+
+		return visits
+	}
+
+	It("reports declared properties without descending into them", func() {
+		Expect(walk(map[interface{}]interface{}{
+			"port": 80,
+			"env":  map[interface{}]interface{}{"any": "value"},
+		})).To(Equal(map[string]visit{
+			"port": {80, DeclaredProperty},
+			"env":  {map[interface{}]interface{}{"any": "value"}, DeclaredProperty},
+		}))
+	})
+
+	It("descends into hashes that prefix declared properties", func() {
+		Expect(walk(map[interface{}]interface{}{
+			"tls": map[interface{}]interface{}{"cert": "fake-cert", "ca": "fake-ca"},
+		})).To(Equal(map[string]visit{
+			"tls.cert": {"fake-cert", DeclaredProperty},
+			"tls.ca":   {"fake-ca", UndeclaredProperty},
+		}))
+	})
+
+	It("reports non-hash values that prefix declared properties", func() {
+		Expect(walk(map[interface{}]interface{}{
+			"tls": "((tls))",
+		})).To(Equal(map[string]visit{
+			"tls": {"((tls))", NonHashProperty},
+		}))
+	})
+
+	It("reports undeclared properties without descending into them", func() {
+		Expect(walk(map[interface{}]interface{}{
+			"other": map[interface{}]interface{}{"port": 80},
+		})).To(Equal(map[string]visit{
+			"other": {map[interface{}]interface{}{"port": 80}, UndeclaredProperty},
+		}))
+	})
+})
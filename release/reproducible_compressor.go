@@ -0,0 +1,312 @@
+package release
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"crypto/sha256"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	bosherr "github.com/cloudfoundry/bosh-utils/errors"
+	boshcmd "github.com/cloudfoundry/bosh-utils/fileutil"
+	boshsys "github.com/cloudfoundry/bosh-utils/system"
+)
+
+// ReproducibleCompressor creates tarballs whose bytes only depend on file names,
+// contents and executable bits: entries are sorted, owned by root with normalized modes
+// and modification time, and gzip headers do not carry file name or timestamp.
+type ReproducibleCompressor struct {
+	compressor boshcmd.Compressor
+	fs         boshsys.FileSystem
+	modTime    time.Time
+}
+
+var _ boshcmd.Compressor = ReproducibleCompressor{}
+var _ ArchiveVerifier = ReproducibleCompressor{}
+
+func NewReproducibleCompressor(compressor boshcmd.Compressor, fs boshsys.FileSystem, modTime time.Time) ReproducibleCompressor {
+	return ReproducibleCompressor{compressor: compressor, fs: fs, modTime: modTime.UTC().Truncate(time.Second)}
+}
+
+func (c ReproducibleCompressor) CompressFilesInDir(dir string) (string, error) {
+	return c.CompressSpecificFilesInDir(dir, []string{"."})
+}
+
+func (c ReproducibleCompressor) CompressSpecificFilesInDir(dir string, files []string) (string, error) {
+	tarball, err := c.fs.TempFile("bosh-release-ReproducibleCompressor")
+	if err != nil {
+		return "", bosherr.WrapError(err, "Creating temporary file for tarball")
+	}
+
+	defer tarball.Close()
+
+	gzipWriter, err := gzip.NewWriterLevel(tarball, gzip.DefaultCompression)
+	if err != nil {
+		return "", bosherr.WrapError(err, "Creating gzip writer")
+	}
+
+	tarWriter := tar.NewWriter(gzipWriter)
+
+	for _, file := range files {
+		err := c.fs.Walk(filepath.Join(dir, file), func(path string, info os.FileInfo, err error) error {
+			if err != nil {
+				return err
+			}
+
+			relPath, err := filepath.Rel(dir, path)
+			if err != nil {
+				return err
+			}
+
+			return c.writeEntry(tarWriter, path, c.entryName(file, relPath), info)
+		})
+		if err != nil {
+			return "", bosherr.WrapErrorf(err, "Adding '%s' to tarball", file)
+		}
+	}
+
+	err = tarWriter.Close()
+	if err != nil {
+		return "", bosherr.WrapError(err, "Closing tar writer")
+	}
+
+	err = gzipWriter.Close()
+	if err != nil {
+		return "", bosherr.WrapError(err, "Closing gzip writer")
+	}
+
+	return tarball.Name(), nil
+}
+
+func (c ReproducibleCompressor) DecompressFileToDir(path string, dir string, options boshcmd.CompressorOptions) error {
+	return c.compressor.DecompressFileToDir(path, dir, options)
+}
+
+func (c ReproducibleCompressor) CleanUp(path string) error {
+	return c.fs.RemoveAll(path)
+}
+
+// entryName keeps entry names the same as 'tar czf -C dir files...' would produce them
+func (c ReproducibleCompressor) entryName(file, relPath string) string {
+	relPath = filepath.ToSlash(relPath)
+
+	if file != "." {
+		return relPath
+	}
+
+	if relPath == "." {
+		return "./"
+	}
+
+	return "./" + relPath
+}
+
+func (c ReproducibleCompressor) writeEntry(tarWriter *tar.Writer, path, name string, info os.FileInfo) error {
+	header := &tar.Header{
+		Name:    name,
+		ModTime: c.modTime,
+		Mode:    0644,
+	}
+
+	switch {
+	case info.IsDir():
+		header.Typeflag = tar.TypeDir
+		header.Mode = 0755
+		if !strings.HasSuffix(header.Name, "/") {
+			header.Name += "/"
+		}
+
+	case info.Mode()&os.ModeSymlink != 0:
+		target, err := c.fs.Readlink(path)
+		if err != nil {
+			return bosherr.WrapErrorf(err, "Reading symlink '%s'", path)
+		}
+
+		header.Typeflag = tar.TypeSymlink
+		header.Linkname = target
+		header.Mode = 0777
+
+	case info.Mode().IsRegular():
+		header.Typeflag = tar.TypeReg
+		header.Size = info.Size()
+		if info.Mode()&0111 != 0 {
+			header.Mode = 0755
+		}
+
+	default:
+		return bosherr.Errorf("Expected '%s' to be a regular file, directory or symlink", path)
+	}
+
+	err := tarWriter.WriteHeader(header)
+	if err != nil {
+		return bosherr.WrapErrorf(err, "Writing tar header for '%s'", name)
+	}
+
+	if header.Typeflag != tar.TypeReg {
+		return nil
+	}
+
+	file, err := c.fs.OpenFile(path, os.O_RDONLY, 0)
+	if err != nil {
+		return bosherr.WrapErrorf(err, "Opening '%s'", path)
+	}
+
+	defer file.Close()
+
+	_, err = io.Copy(tarWriter, file)
+	if err != nil {
+		return bosherr.WrapErrorf(err, "Writing '%s' into tarball", name)
+	}
+
+	return nil
+}
+
+// Verify checks that a tarball (and every *.tgz archive nested in it) could have been
+// produced by ReproducibleCompressor. Returned problems are prefixed with archive path.
+func (c ReproducibleCompressor) Verify(path string) ([]string, error) {
+	file, err := c.fs.OpenFile(path, os.O_RDONLY, 0)
+	if err != nil {
+		return nil, bosherr.WrapErrorf(err, "Opening tarball '%s'", path)
+	}
+
+	defer file.Close()
+
+	return c.verifyArchive(filepath.Base(path), file)
+}
+
+// verifyArchive streams archive through decompression and recompression
+// so that tarballs (including nested ones) are never fully loaded into memory.
+func (c ReproducibleCompressor) verifyArchive(name string, archive io.Reader) ([]string, error) {
+	var problems, entryProblems []string
+
+	report := func(msg string, args ...interface{}) {
+		problems = append(problems, name+": "+fmt.Sprintf(msg, args...))
+	}
+
+	reportEntry := func(msg string, args ...interface{}) {
+		entryProblems = append(entryProblems, name+": "+fmt.Sprintf(msg, args...))
+	}
+
+	archiveDigest := sha256.New()
+
+	gzipReader, err := gzip.NewReader(io.TeeReader(archive, archiveDigest))
+	if err != nil {
+		return nil, bosherr.WrapErrorf(err, "Reading gzip header of '%s'", name)
+	}
+
+	if !gzipReader.ModTime.IsZero() || len(gzipReader.Name) > 0 {
+		report("gzip header contains file name or modification time")
+	}
+
+	recompressedDigest := sha256.New()
+
+	gzipWriter, err := gzip.NewWriterLevel(recompressedDigest, gzip.DefaultCompression)
+	if err != nil {
+		return nil, bosherr.WrapError(err, "Creating gzip writer")
+	}
+
+	tarStream := io.TeeReader(gzipReader, gzipWriter)
+	tarReader := tar.NewReader(tarStream)
+
+	var modTime time.Time
+	var prevName string
+
+	for {
+		header, err := tarReader.Next()
+		if err == io.EOF {
+			break
+		} else if err != nil {
+			return nil, bosherr.WrapErrorf(err, "Reading tar entries of '%s'", name)
+		}
+
+		if modTime.IsZero() {
+			modTime = header.ModTime
+		} else if !header.ModTime.Equal(modTime) {
+			reportEntry("entry '%s' has modification time '%s' instead of '%s'", header.Name, header.ModTime.UTC(), modTime.UTC())
+		}
+
+		if header.Uid != 0 || header.Gid != 0 || len(header.Uname) > 0 || len(header.Gname) > 0 {
+			reportEntry("entry '%s' has owner '%d:%d' ('%s:%s') instead of '0:0'", header.Name, header.Uid, header.Gid, header.Uname, header.Gname)
+		}
+
+		if expectedMode := c.expectedMode(header); header.Mode != expectedMode {
+			reportEntry("entry '%s' has mode '%o' instead of '%o'", header.Name, header.Mode, expectedMode)
+		}
+
+		if c.isUnsorted(prevName, header.Name) {
+			reportEntry("entry '%s' is not sorted after '%s'", header.Name, prevName)
+		}
+
+		prevName = header.Name
+
+		if header.Typeflag == tar.TypeReg && strings.HasSuffix(header.Name, ".tgz") {
+			nestedProblems, err := c.verifyArchive(name+"/"+strings.TrimPrefix(header.Name, "./"), tarReader)
+			if err != nil {
+				return nil, err
+			}
+
+			entryProblems = append(entryProblems, nestedProblems...)
+		}
+	}
+
+	// Padding after the last tar entry is part of the compressed stream as well
+	_, err = io.Copy(ioutil.Discard, tarStream)
+	if err != nil {
+		return nil, bosherr.WrapErrorf(err, "Decompressing '%s'", name)
+	}
+
+	err = gzipWriter.Close()
+	if err != nil {
+		return nil, bosherr.WrapErrorf(err, "Recompressing '%s'", name)
+	}
+
+	if !bytes.Equal(recompressedDigest.Sum(nil), archiveDigest.Sum(nil)) {
+		report("gzip stream was not produced with default compression settings")
+	}
+
+	return append(problems, entryProblems...), nil
+}
+
+func (c ReproducibleCompressor) expectedMode(header *tar.Header) int64 {
+	switch header.Typeflag {
+	case tar.TypeDir:
+		return 0755
+	case tar.TypeSymlink:
+		return 0777
+	default:
+		if header.Mode&0111 != 0 {
+			return 0755
+		}
+		return 0644
+	}
+}
+
+// isUnsorted checks that entries within the same directory follow lexical order;
+// top level entries of a release tarball keep their order (e.g. release.MF goes first).
+func (c ReproducibleCompressor) isUnsorted(prevName, name string) bool {
+	prevDir, prevBase := c.splitEntryName(prevName)
+	dir, base := c.splitEntryName(name)
+
+	if len(prevName) == 0 || prevDir != dir || dir == "" {
+		return false
+	}
+
+	return base < prevBase
+}
+
+func (c ReproducibleCompressor) splitEntryName(name string) (string, string) {
+	name = strings.TrimSuffix(name, "/")
+
+	idx := strings.LastIndex(name, "/")
+	if idx == -1 {
+		return "", name
+	}
+
+	return name[:idx], name[idx+1:]
+}
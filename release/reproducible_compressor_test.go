@@ -0,0 +1,268 @@
+package release_test
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"errors"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"time"
+
+	boshcmd "github.com/cloudfoundry/bosh-utils/fileutil"
+	fakefu "github.com/cloudfoundry/bosh-utils/fileutil/fakes"
+	boshlog "github.com/cloudfoundry/bosh-utils/logger"
+	boshsys "github.com/cloudfoundry/bosh-utils/system"
+	fakesys "github.com/cloudfoundry/bosh-utils/system/fakes"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	. "github.com/cloudfoundry/bosh-cli/release"
+)
+
+var _ = Describe("ReproducibleCompressor", func() {
+	var (
+		fs         boshsys.FileSystem
+		compressor ReproducibleCompressor
+		modTime    time.Time
+		dir        string
+	)
+
+	BeforeEach(func() {
+		fs = boshsys.NewOsFileSystem(boshlog.NewLogger(boshlog.LevelNone))
+		modTime = time.Unix(1500000000, 0).UTC()
+		compressor = NewReproducibleCompressor(fakefu.NewFakeCompressor(), fs, modTime)
+
+		var err error
+		dir, err = fs.TempDir("reproducible-compressor")
+		Expect(err).ToNot(HaveOccurred())
+
+		Expect(fs.MkdirAll(filepath.Join(dir, "templates"), 0700)).To(Succeed())
+		Expect(fs.WriteFileString(filepath.Join(dir, "job.MF"), "name: web")).To(Succeed())
+		Expect(fs.WriteFileString(filepath.Join(dir, "monit"), "check process web")).To(Succeed())
+		Expect(fs.WriteFileString(filepath.Join(dir, "templates", "ctl.erb"), "#!/bin/bash")).To(Succeed())
+		Expect(fs.Chmod(filepath.Join(dir, "templates", "ctl.erb"), 0700)).To(Succeed())
+		Expect(fs.Chmod(filepath.Join(dir, "monit"), 0600)).To(Succeed())
+		Expect(fs.Symlink("ctl.erb", filepath.Join(dir, "templates", "link"))).To(Succeed())
+	})
+
+	AfterEach(func() {
+		Expect(fs.RemoveAll(dir)).To(Succeed())
+	})
+
+	readEntries := func(path string) []*tar.Header {
+		archiveBytes, err := fs.ReadFile(path)
+		Expect(err).ToNot(HaveOccurred())
+
+		gzipReader, err := gzip.NewReader(bytes.NewReader(archiveBytes))
+		Expect(err).ToNot(HaveOccurred())
+
+		var headers []*tar.Header
+
+		tarReader := tar.NewReader(gzipReader)
+		for {
+			header, err := tarReader.Next()
+			if err != nil {
+				break
+			}
+			headers = append(headers, header)
+		}
+
+		return headers
+	}
+
+	Describe("CompressFilesInDir", func() {
+		It("creates tarball with sorted entries and normalized metadata", func() {
+			path, err := compressor.CompressFilesInDir(dir)
+			Expect(err).ToNot(HaveOccurred())
+
+			defer compressor.CleanUp(path)
+
+			var summary [][]interface{}
+
+			for _, header := range readEntries(path) {
+				Expect(header.ModTime.Equal(modTime)).To(BeTrue())
+				Expect(header.Uid).To(Equal(0))
+				Expect(header.Gid).To(Equal(0))
+				Expect(header.Uname).To(BeEmpty())
+				Expect(header.Gname).To(BeEmpty())
+
+				summary = append(summary, []interface{}{header.Name, header.Mode, header.Linkname})
+			}
+
+			Expect(summary).To(Equal([][]interface{}{
+				{"./", int64(0755), ""},
+				{"./job.MF", int64(0644), ""},
+				{"./monit", int64(0644), ""},
+				{"./templates/", int64(0755), ""},
+				{"./templates/ctl.erb", int64(0755), ""},
+				{"./templates/link", int64(0777), "ctl.erb"},
+			}))
+		})
+
+		It("creates identical tarballs regardless of file modification times", func() {
+			firstPath, err := compressor.CompressFilesInDir(dir)
+			Expect(err).ToNot(HaveOccurred())
+
+			defer compressor.CleanUp(firstPath)
+
+			Expect(os.Chtimes(filepath.Join(dir, "job.MF"), time.Now(), time.Now().Add(time.Hour))).To(Succeed())
+
+			secondPath, err := compressor.CompressFilesInDir(dir)
+			Expect(err).ToNot(HaveOccurred())
+
+			defer compressor.CleanUp(secondPath)
+
+			firstBytes, err := fs.ReadFile(firstPath)
+			Expect(err).ToNot(HaveOccurred())
+
+			secondBytes, err := fs.ReadFile(secondPath)
+			Expect(err).ToNot(HaveOccurred())
+
+			Expect(firstBytes).To(Equal(secondBytes))
+		})
+	})
+
+	Describe("CompressSpecificFilesInDir", func() {
+		It("includes given files in given order", func() {
+			path, err := compressor.CompressSpecificFilesInDir(dir, []string{"monit", "templates", "job.MF"})
+			Expect(err).ToNot(HaveOccurred())
+
+			defer compressor.CleanUp(path)
+
+			var names []string
+			for _, header := range readEntries(path) {
+				names = append(names, header.Name)
+			}
+
+			Expect(names).To(Equal([]string{"monit", "templates/", "templates/ctl.erb", "templates/link", "job.MF"}))
+		})
+	})
+
+	Describe("DecompressFileToDir", func() {
+		It("delegates to underlying compressor", func() {
+			underlying := fakefu.NewFakeCompressor()
+			compressor = NewReproducibleCompressor(underlying, fs, modTime)
+
+			err := compressor.DecompressFileToDir("/archive.tgz", "/dst", boshcmd.CompressorOptions{StripComponents: 1})
+			Expect(err).ToNot(HaveOccurred())
+
+			Expect(underlying.DecompressFileToDirTarballPaths).To(Equal([]string{"/archive.tgz"}))
+			Expect(underlying.DecompressFileToDirDirs).To(Equal([]string{"/dst"}))
+			Expect(underlying.DecompressFileToDirOptions).To(Equal([]boshcmd.CompressorOptions{{StripComponents: 1}}))
+		})
+	})
+
+	Describe("Verify", func() {
+		It("returns no problems for tarball with nested archives created reproducibly", func() {
+			jobPath, err := compressor.CompressFilesInDir(dir)
+			Expect(err).ToNot(HaveOccurred())
+
+			defer compressor.CleanUp(jobPath)
+
+			releaseDir, err := fs.TempDir("reproducible-release")
+			Expect(err).ToNot(HaveOccurred())
+
+			defer fs.RemoveAll(releaseDir)
+
+			Expect(fs.WriteFileString(filepath.Join(releaseDir, "release.MF"), "name: rel")).To(Succeed())
+			Expect(fs.MkdirAll(filepath.Join(releaseDir, "jobs"), 0755)).To(Succeed())
+			Expect(fs.CopyFile(jobPath, filepath.Join(releaseDir, "jobs", "web.tgz"))).To(Succeed())
+
+			releasePath, err := compressor.CompressSpecificFilesInDir(releaseDir, []string{"release.MF", "jobs"})
+			Expect(err).ToNot(HaveOccurred())
+
+			defer compressor.CleanUp(releasePath)
+
+			problems, err := compressor.Verify(releasePath)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(problems).To(BeEmpty())
+		})
+
+		It("returns problems for tarball with unnormalized metadata", func() {
+			tarBuf := &bytes.Buffer{}
+			tarWriter := tar.NewWriter(tarBuf)
+
+			writeFile := func(header *tar.Header) {
+				header.Typeflag = tar.TypeReg
+				Expect(tarWriter.WriteHeader(header)).To(Succeed())
+			}
+
+			writeFile(&tar.Header{Name: "./b", Mode: 0644, ModTime: modTime})
+			writeFile(&tar.Header{Name: "./a", Mode: 0600, ModTime: modTime.Add(time.Hour), Uid: 1000, Uname: "user"})
+			Expect(tarWriter.Close()).To(Succeed())
+
+			gzipBuf := &bytes.Buffer{}
+			gzipWriter := gzip.NewWriter(gzipBuf)
+			gzipWriter.Name = "job.tar"
+			_, err := gzipWriter.Write(tarBuf.Bytes())
+			Expect(err).ToNot(HaveOccurred())
+			Expect(gzipWriter.Close()).To(Succeed())
+
+			path := filepath.Join(dir, "job.tgz")
+			Expect(ioutil.WriteFile(path, gzipBuf.Bytes(), 0644)).To(Succeed())
+
+			problems, err := compressor.Verify(path)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(problems).To(Equal([]string{
+				"job.tgz: gzip header contains file name or modification time",
+				"job.tgz: gzip stream was not produced with default compression settings",
+				"job.tgz: entry './a' has modification time '2017-07-14 03:40:00 +0000 UTC' instead of '2017-07-14 02:40:00 +0000 UTC'",
+				"job.tgz: entry './a' has owner '1000:0' ('user:') instead of '0:0'",
+				"job.tgz: entry './a' has mode '600' instead of '644'",
+				"job.tgz: entry './a' is not sorted after './b'",
+			}))
+		})
+
+		It("returns problems for nested archives prefixed with their path", func() {
+			releaseDir, err := fs.TempDir("reproducible-release")
+			Expect(err).ToNot(HaveOccurred())
+
+			defer fs.RemoveAll(releaseDir)
+
+			jobFile, err := os.Create(filepath.Join(releaseDir, "web.tgz"))
+			Expect(err).ToNot(HaveOccurred())
+
+			gzipWriter := gzip.NewWriter(jobFile)
+			gzipWriter.ModTime = modTime
+			tarWriter := tar.NewWriter(gzipWriter)
+			Expect(tarWriter.WriteHeader(&tar.Header{Name: "./job.MF", Typeflag: tar.TypeReg, Mode: 0644, ModTime: modTime, Size: 9})).To(Succeed())
+			_, err = tarWriter.Write([]byte("name: web"))
+			Expect(err).ToNot(HaveOccurred())
+			Expect(tarWriter.Close()).To(Succeed())
+			Expect(gzipWriter.Close()).To(Succeed())
+			Expect(jobFile.Close()).To(Succeed())
+
+			releasePath, err := compressor.CompressSpecificFilesInDir(releaseDir, []string{"web.tgz"})
+			Expect(err).ToNot(HaveOccurred())
+
+			defer compressor.CleanUp(releasePath)
+
+			problems, err := compressor.Verify(releasePath)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(problems).To(Equal([]string{
+				filepath.Base(releasePath) + "/web.tgz: gzip header contains file name or modification time",
+				filepath.Base(releasePath) + "/web.tgz: gzip stream was not produced with default compression settings",
+			}))
+		})
+
+		It("returns error if tarball cannot be opened", func() {
+			fakeFS := fakesys.NewFakeFileSystem()
+			fakeFS.OpenFileErr = errors.New("fake-err")
+
+			_, err := NewReproducibleCompressor(nil, fakeFS, modTime).Verify("/release.tgz")
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("Opening tarball '/release.tgz': fake-err"))
+		})
+
+		It("returns error if tarball is not gzipped", func() {
+			fakeFS := fakesys.NewFakeFileSystem()
+			fakeFS.WriteFileString("/release.tgz", "not-gzip")
+
+			_, err := NewReproducibleCompressor(nil, fakeFS, modTime).Verify("/release.tgz")
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("Reading gzip header of 'release.tgz'"))
+		})
+	})
+})
@@ -2,7 +2,9 @@ package releasedir
 
 import (
 	"path/filepath"
+	"strconv"
 	"strings"
+	"time"
 
 	bosherr "github.com/cloudfoundry/bosh-utils/errors"
 	boshsys "github.com/cloudfoundry/bosh-utils/system"
@@ -71,6 +73,25 @@ func (r FSGitRepo) LastCommitSHA() (string, error) {
 	return strings.TrimSpace(stdout), nil
 }
 
+func (r FSGitRepo) LastCommitTime() (time.Time, error) {
+	cmd := boshsys.Command{
+		Name:       "git",
+		Args:       []string{"log", "-1", "--format=%ct"},
+		WorkingDir: r.dirPath,
+	}
+	stdout, _, _, err := r.runner.RunComplexCommand(cmd)
+	if err != nil {
+		return time.Time{}, bosherr.WrapErrorf(err, "Checking last commit time")
+	}
+
+	secs, err := strconv.ParseInt(strings.TrimSpace(stdout), 10, 64)
+	if err != nil {
+		return time.Time{}, bosherr.WrapErrorf(err, "Parsing last commit time '%s'", strings.TrimSpace(stdout))
+	}
+
+	return time.Unix(secs, 0).UTC(), nil
+}
+
 func (r FSGitRepo) MustNotBeDirty(force bool) (bool, error) {
 	cmd := boshsys.Command{
 		Name:       "git",
@@ -2,6 +2,7 @@ package releasedir_test
 
 import (
 	"errors"
+	"time"
 
 	boshsys "github.com/cloudfoundry/bosh-utils/system"
 	fakesys "github.com/cloudfoundry/bosh-utils/system/fakes"
@@ -15,7 +16,7 @@ var _ = Describe("GitRepo", func() {
 	var (
 		cmdRunner *fakesys.FakeCmdRunner
 		fs        *fakesys.FakeFileSystem
-		gitRepo   GitRepo
+		gitRepo   FSGitRepo
 	)
 
 	BeforeEach(func() {
@@ -120,6 +121,41 @@ releases/**/*.tgz
 		})
 	})
 
+	Describe("LastCommitTime", func() {
+		cmd := "git log -1 --format=%ct"
+
+		It("returns last commit time", func() {
+			cmdRunner.AddCmdResult(cmd, fakesys.FakeCmdResult{
+				Stdout: "1500000000\n",
+			})
+			commitTime, err := gitRepo.LastCommitTime()
+			Expect(err).ToNot(HaveOccurred())
+			Expect(commitTime).To(Equal(time.Unix(1500000000, 0).UTC()))
+
+			Expect(cmdRunner.RunComplexCommands).To(Equal([]boshsys.Command{{
+				Name:       "git",
+				Args:       []string{"log", "-1", "--format=%ct"},
+				WorkingDir: "/dir",
+			}}))
+		})
+
+		It("returns error if cannot check last commit time", func() {
+			cmdRunner.AddCmdResult(cmd, fakesys.FakeCmdResult{
+				Error: errors.New("fake-err"),
+			})
+			_, err := gitRepo.LastCommitTime()
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("fake-err"))
+		})
+
+		It("returns error if last commit time cannot be parsed", func() {
+			cmdRunner.AddCmdResult(cmd, fakesys.FakeCmdResult{Stdout: "not-time\n"})
+			_, err := gitRepo.LastCommitTime()
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("Parsing last commit time 'not-time'"))
+		})
+	})
+
 	Describe("MustNotBeDirty", func() {
 		cmd := "git status --short"
 
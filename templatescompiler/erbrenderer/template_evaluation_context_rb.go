@@ -21,7 +21,25 @@ class Hash
   end
 end
 
+module PropertyLookup
+  private
+
+  def lookup_property(collection, name)
+    keys = name.split(".")
+    ref = collection
+
+    keys.each do |key|
+      ref = ref[key]
+      return nil if ref.nil?
+    end
+
+    ref
+  end
+end
+
 class TemplateEvaluationContext
+  include PropertyLookup
+
   attr_reader :name, :index
   attr_reader :properties, :raw_properties
   attr_reader :spec
@@ -43,6 +61,7 @@ class TemplateEvaluationContext
 
     @properties = openstruct(properties)
     @raw_properties = properties
+    @links = spec['links'] || {}
     @spec = openstruct(spec)
   end
 
@@ -73,8 +92,19 @@ class TemplateEvaluationContext
     InactiveElseBlock.new
   end
 
+  def link(name)
+    link_spec = @links[name]
+    raise UnknownLink.new(name) if link_spec.nil?
+
+    create_evaluation_link(link_spec)
+  end
+
   def if_link(name)
-    false
+    link_spec = @links[name]
+    return ActiveElseBlock.new(self) if link_spec.nil?
+
+    yield create_evaluation_link(link_spec)
+    InactiveElseBlock.new
   end
 
   private
@@ -110,16 +140,19 @@ class TemplateEvaluationContext
     end
   end
 
-  def lookup_property(collection, name)
-    keys = name.split(".")
-    ref = collection
-
-    keys.each do |key|
-      ref = ref[key]
-      return nil if ref.nil?
+  def create_evaluation_link(link_spec)
+    instances = (link_spec['instances'] || []).map do |instance|
+      EvaluationLinkInstance.new(
+        instance['name'],
+        instance['index'],
+        instance['id'],
+        instance['az'],
+        instance['address'],
+        instance['bootstrap']
+      )
     end
 
-    ref
+    EvaluationLink.new(instances, link_spec['properties'] || {}, link_spec['address'])
   end
 
   class UnknownProperty < StandardError
@@ -131,6 +164,60 @@ class TemplateEvaluationContext
     end
   end
 
+  class UnknownLink < StandardError
+    def initialize(name)
+      super("Can't find link '#{name}'")
+    end
+  end
+
+  class EvaluationLinkInstance
+    attr_reader :name, :index, :id, :az, :address, :bootstrap
+
+    def initialize(name, index, id, az, address, bootstrap)
+      @name = name
+      @index = index
+      @id = id
+      @az = az
+      @address = address
+      @bootstrap = bootstrap
+    end
+  end
+
+  class EvaluationLink
+    include PropertyLookup
+
+    attr_reader :instances, :properties, :address
+
+    def initialize(instances, properties, address)
+      @instances = instances
+      @properties = properties
+      @address = address
+    end
+
+    def p(*args)
+      names = Array(args[0])
+
+      names.each do |name|
+        result = lookup_property(@properties, name)
+        return result unless result.nil?
+      end
+
+      return args[1] if args.length == 2
+      raise UnknownProperty.new(names)
+    end
+
+    def if_p(*names)
+      values = names.map do |name|
+        value = lookup_property(@properties, name)
+        return ActiveElseBlock.new(self) if value.nil?
+        value
+      end
+
+      yield *values
+      InactiveElseBlock.new
+    end
+  end
+
   class ActiveElseBlock
     def initialize(template)
       @context = template
@@ -21,6 +21,9 @@ type jobEvaluationContext struct {
 	uuidGen              boshuuid.Generator
 	logger               boshlog.Logger
 	logTag               string
+
+	instance *InstanceSpec
+	links    biproperty.Map
 }
 
 // RootContext is exposed as an open struct in ERB templates.
@@ -42,6 +45,8 @@ type RootContext struct {
 	ClusterProperties biproperty.Map  `json:"cluster_properties"` // values from instance group (deployment job) properties
 	JobProperties     *biproperty.Map `json:"job_properties"`     // values from release job (aka template) properties
 	DefaultProperties biproperty.Map  `json:"default_properties"` // values from release's job's spec
+
+	Links biproperty.Map `json:"links,omitempty"` // resolved links consumed by release job
 }
 
 type jobContext struct {
@@ -54,9 +59,28 @@ type networkContext struct {
 	Gateway string `json:"gateway"`
 }
 
+// InstanceSpec describes instance that job is rendered for.
+// Zero values fall back to values used by create-env.
+type InstanceSpec struct {
+	Deployment string                 `yaml:"deployment"`
+	ID         string                 `yaml:"id"`
+	Index      int                    `yaml:"index"`
+	AZ         string                 `yaml:"az"`
+	Bootstrap  *bool                  `yaml:"bootstrap"`
+	Address    string                 `yaml:"address"`
+	Networks   map[string]NetworkSpec `yaml:"networks"`
+}
+
+type NetworkSpec struct {
+	IP      string `yaml:"ip"`
+	Netmask string `yaml:"netmask"`
+	Gateway string `yaml:"gateway"`
+}
+
 func NewJobEvaluationContext(
 	releaseJob bireljob.Job,
 	releaseJobProperties *biproperty.Map,
+	links biproperty.Map,
 	jobProperties biproperty.Map,
 	globalProperties biproperty.Map,
 	deploymentName string,
@@ -74,6 +98,32 @@ func NewJobEvaluationContext(
 		uuidGen:              uuidGen,
 		logTag:               "jobEvaluationContext",
 		logger:               logger,
+		links:                links,
+	}
+}
+
+// NewInstanceJobEvaluationContext is used to render job outside of create-env
+// (e.g. to test its templates); instance details and links are given explicitly.
+func NewInstanceJobEvaluationContext(
+	releaseJob bireljob.Job,
+	properties biproperty.Map,
+	instance InstanceSpec,
+	links biproperty.Map,
+	uuidGen boshuuid.Generator,
+	logger boshlog.Logger,
+) bierbrenderer.TemplateEvaluationContext {
+	return jobEvaluationContext{
+		releaseJob:           releaseJob,
+		releaseJobProperties: &properties,
+		jobProperties:        biproperty.Map{},
+		globalProperties:     biproperty.Map{},
+		deploymentName:       instance.Deployment,
+		address:              instance.Address,
+		uuidGen:              uuidGen,
+		logTag:               "jobEvaluationContext",
+		logger:               logger,
+		instance:             &instance,
+		links:                links,
 	}
 }
 
@@ -92,15 +142,22 @@ func (ec jobEvaluationContext) MarshalJSON() ([]byte, error) {
 		ClusterProperties: ec.jobProperties,
 		JobProperties:     ec.releaseJobProperties,
 		DefaultProperties: defaultProperties,
+		Links:             ec.links,
 	}
 
 	if len(ec.address) > 0 {
 		context.Address = ec.address
 	}
 
-	context.ID, err = ec.uuidGen.Generate()
-	if err != nil {
-		return []byte{}, bosherr.WrapErrorf(err, "Setting job eval context's ID to UUID: %#v", context)
+	if ec.instance != nil {
+		ec.applyInstance(&context)
+	}
+
+	if len(context.ID) == 0 {
+		context.ID, err = ec.uuidGen.Generate()
+		if err != nil {
+			return []byte{}, bosherr.WrapErrorf(err, "Setting job eval context's ID to UUID: %#v", context)
+		}
 	}
 
 	ec.logger.Debug(ec.logTag, "Marshalling context %#v", context)
@@ -113,6 +170,31 @@ func (ec jobEvaluationContext) MarshalJSON() ([]byte, error) {
 	return jsonBytes, nil
 }
 
+func (ec jobEvaluationContext) applyInstance(context *RootContext) {
+	context.ID = ec.instance.ID
+	context.Index = ec.instance.Index
+
+	if len(ec.instance.AZ) > 0 {
+		context.AZ = ec.instance.AZ
+	}
+
+	if ec.instance.Bootstrap != nil {
+		context.Bootstrap = *ec.instance.Bootstrap
+	}
+
+	if len(ec.instance.Networks) > 0 {
+		context.NetworkContexts = map[string]networkContext{}
+
+		for name, network := range ec.instance.Networks {
+			context.NetworkContexts[name] = networkContext{
+				IP:      network.IP,
+				Netmask: network.Netmask,
+				Gateway: network.Gateway,
+			}
+		}
+	}
+}
+
 func (ec jobEvaluationContext) propertyDefaults(properties map[string]bireljob.PropertyDefinition) biproperty.Map {
 	result := biproperty.Map{}
 	for propertyKey, property := range properties {
@@ -25,6 +25,7 @@ var _ = Describe("JobEvaluationContext", func() {
 	var (
 		releaseJob              *boshreljob.Job
 		jobProperties           *biproperty.Map
+		links                   biproperty.Map
 		instanceGroupProperties biproperty.Map
 		deploymentProperties    biproperty.Map
 		erbRenderer             erbrenderer.ERBRenderer
@@ -49,6 +50,7 @@ var _ = Describe("JobEvaluationContext", func() {
 
 		uuidGen = fakeuuid.NewFakeGenerator()
 		jobProperties = nil
+		links = nil
 	})
 
 	JustBeforeEach(func() {
@@ -57,6 +59,7 @@ var _ = Describe("JobEvaluationContext", func() {
 		jobEvaluationContext = NewJobEvaluationContext(
 			*releaseJob,
 			jobProperties,
+			links,
 			instanceGroupProperties,
 			deploymentProperties,
 			"fake-deployment-name",
@@ -94,6 +97,21 @@ var _ = Describe("JobEvaluationContext", func() {
 		Expect(generatedContext.ID).To(Equal("fake-uuid"))
 	})
 
+	Context("when links are resolved", func() {
+		BeforeEach(func() {
+			links = biproperty.Map{
+				"db": biproperty.Map{"properties": biproperty.Map{"port": 5432.0}},
+			}
+		})
+
+		It("it has links available in the spec", func() {
+			generatedContext := act()
+			Expect(generatedContext.Links).To(Equal(biproperty.Map{
+				"db": map[string]interface{}{"properties": map[string]interface{}{"port": 5432.0}},
+			}))
+		})
+	})
+
 	It("it has az available in the spec", func() {
 		generatedContext := act()
 		Expect(generatedContext.AZ).To(Equal("unknown"))
@@ -135,6 +153,7 @@ var _ = Describe("JobEvaluationContext", func() {
 		jobEvaluationContext := NewJobEvaluationContext(
 			*releaseJob,
 			jobProperties,
+			links,
 			instanceGroupProperties,
 			deploymentProperties,
 			"fake-deployment-name",
@@ -264,3 +283,86 @@ var _ = Describe("JobEvaluationContext", func() {
 		})
 	})
 })
+
+var _ = Describe("NewInstanceJobEvaluationContext", func() {
+	var (
+		releaseJob *boshreljob.Job
+		instance   InstanceSpec
+		uuidGen    *fakeuuid.FakeGenerator
+	)
+
+	BeforeEach(func() {
+		releaseJob = boshreljob.NewJob(NewResource("fake-job-name", "", nil))
+		releaseJob.Properties = map[string]boshreljob.PropertyDefinition{
+			"property1": boshreljob.PropertyDefinition{Default: "spec-default"},
+		}
+
+		instance = InstanceSpec{}
+
+		uuidGen = fakeuuid.NewFakeGenerator()
+		uuidGen.GeneratedUUID = "fake-uuid"
+	})
+
+	act := func(links biproperty.Map) RootContext {
+		logger := boshlog.NewLogger(boshlog.LevelNone)
+
+		context := NewInstanceJobEvaluationContext(
+			*releaseJob, biproperty.Map{"property1": "value"}, instance, links, uuidGen, logger)
+
+		generatedJSON, err := context.MarshalJSON()
+		Expect(err).ToNot(HaveOccurred())
+
+		generatedContext := RootContext{}
+
+		err = json.Unmarshal(generatedJSON, &generatedContext)
+		Expect(err).ToNot(HaveOccurred())
+
+		return generatedContext
+	}
+
+	It("uses create-env defaults when instance details are not given", func() {
+		context := act(nil)
+
+		Expect(context.ID).To(Equal("fake-uuid"))
+		Expect(context.Index).To(Equal(0))
+		Expect(context.AZ).To(Equal("unknown"))
+		Expect(context.Bootstrap).To(BeTrue())
+		Expect(context.Links).To(BeNil())
+		Expect(*context.JobProperties).To(Equal(biproperty.Map{"property1": "value"}))
+		Expect(context.DefaultProperties).To(Equal(biproperty.Map{"property1": "spec-default"}))
+	})
+
+	It("uses given instance details and links", func() {
+		bootstrap := false
+
+		instance = InstanceSpec{
+			Deployment: "fake-deployment-name",
+			ID:         "fake-id",
+			Index:      2,
+			AZ:         "z1",
+			Bootstrap:  &bootstrap,
+			Address:    "fake-address",
+			Networks: map[string]NetworkSpec{
+				"private": {IP: "10.0.0.2", Netmask: "255.255.255.0", Gateway: "10.0.0.1"},
+			},
+		}
+
+		context := act(biproperty.Map{
+			"db": map[string]interface{}{"address": "db-address"},
+		})
+
+		Expect(context.ID).To(Equal("fake-id"))
+		Expect(context.Index).To(Equal(2))
+		Expect(context.AZ).To(Equal("z1"))
+		Expect(context.Bootstrap).To(BeFalse())
+		Expect(context.Deployment).To(Equal("fake-deployment-name"))
+		Expect(context.Address).To(Equal("fake-address"))
+		Expect(context.Links).To(Equal(biproperty.Map{
+			"db": map[string]interface{}{"address": "db-address"},
+		}))
+
+		networksJSON, err := json.Marshal(context.NetworkContexts)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(networksJSON).To(MatchJSON(`{"private": {"ip": "10.0.0.2", "netmask": "255.255.255.0", "gateway": "10.0.0.1"}}`))
+	})
+})
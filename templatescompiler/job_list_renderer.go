@@ -11,6 +11,7 @@ type JobListRenderer interface {
 	Render(
 		releaseJobs []bireljob.Job,
 		releaseJobProperties map[string]*biproperty.Map,
+		releaseJobLinks map[string]ReleaseJobLinks,
 		jobProperties biproperty.Map,
 		globalProperties biproperty.Map,
 		deploymentName string,
@@ -38,6 +39,7 @@ func NewJobListRenderer(
 func (r *jobListRenderer) Render(
 	releaseJobs []bireljob.Job,
 	releaseJobProperties map[string]*biproperty.Map,
+	releaseJobLinks map[string]ReleaseJobLinks,
 	jobProperties biproperty.Map,
 	globalProperties biproperty.Map,
 	deploymentName string,
@@ -46,9 +48,22 @@ func (r *jobListRenderer) Render(
 	r.logger.Debug(r.logTag, "Rendering job list: deploymentName='%s' jobProperties=%#v globalProperties=%#v", deploymentName, jobProperties, globalProperties)
 	renderedJobList := NewRenderedJobList()
 
+	links, err := linksResolver{
+		releaseJobs:          releaseJobs,
+		releaseJobProperties: releaseJobProperties,
+		releaseJobLinks:      releaseJobLinks,
+		jobProperties:        jobProperties,
+		globalProperties:     globalProperties,
+		deploymentName:       deploymentName,
+		address:              address,
+	}.Resolve()
+	if err != nil {
+		return renderedJobList, bosherr.WrapError(err, "Resolving links")
+	}
+
 	// render all the jobs' templates
 	for _, releaseJob := range releaseJobs {
-		renderedJob, err := r.jobRenderer.Render(releaseJob, releaseJobProperties[releaseJob.Name()], jobProperties, globalProperties, deploymentName, address)
+		renderedJob, err := r.jobRenderer.Render(releaseJob, releaseJobProperties[releaseJob.Name()], links[releaseJob.Name()], jobProperties, globalProperties, deploymentName, address)
 		if err != nil {
 			defer renderedJobList.DeleteSilently()
 			return renderedJobList, bosherr.WrapErrorf(err, "Rendering templates for job '%s/%s'", releaseJob.Name(), releaseJob.Fingerprint())
@@ -32,6 +32,8 @@ var _ = Describe("JobListRenderer", func() {
 
 		releaseJobs          []boshreljob.Job
 		releaseJobProperties map[string]*biproperty.Map
+		releaseJobLinks      map[string]ReleaseJobLinks
+		expectedLinks        []biproperty.Map
 		jobProperties        biproperty.Map
 		globalProperties     biproperty.Map
 		deploymentName       string
@@ -61,6 +63,9 @@ var _ = Describe("JobListRenderer", func() {
 			"fake-release-job-name-1": &biproperty.Map{},
 		}
 
+		releaseJobLinks = map[string]ReleaseJobLinks{}
+		expectedLinks = []biproperty.Map{{}, {}}
+
 		jobProperties = biproperty.Map{
 			"fake-key": "fake-job-value",
 		}
@@ -80,14 +85,14 @@ var _ = Describe("JobListRenderer", func() {
 		jobListRenderer = NewJobListRenderer(mockJobRenderer, logger)
 	})
 
-	JustBeforeEach(func() {
-		mockJobRenderer.EXPECT().Render(releaseJobs[0], releaseJobProperties[releaseJobs[0].Name()], jobProperties, globalProperties, deploymentName, address).Return(renderedJobs[0], nil)
-		expectRender1 = mockJobRenderer.EXPECT().Render(releaseJobs[1], releaseJobProperties[releaseJobs[1].Name()], jobProperties, globalProperties, deploymentName, address).Return(renderedJobs[1], nil)
-	})
-
 	Describe("Render", func() {
+		JustBeforeEach(func() {
+			mockJobRenderer.EXPECT().Render(releaseJobs[0], releaseJobProperties[releaseJobs[0].Name()], expectedLinks[0], jobProperties, globalProperties, deploymentName, address).Return(renderedJobs[0], nil)
+			expectRender1 = mockJobRenderer.EXPECT().Render(releaseJobs[1], releaseJobProperties[releaseJobs[1].Name()], expectedLinks[1], jobProperties, globalProperties, deploymentName, address).Return(renderedJobs[1], nil)
+		})
+
 		It("returns a new RenderedJobList with all the RenderedJobs", func() {
-			renderedJobList, err := jobListRenderer.Render(releaseJobs, releaseJobProperties, jobProperties, globalProperties, deploymentName, address)
+			renderedJobList, err := jobListRenderer.Render(releaseJobs, releaseJobProperties, releaseJobLinks, jobProperties, globalProperties, deploymentName, address)
 			Expect(err).ToNot(HaveOccurred())
 			Expect(renderedJobList.All()).To(Equal([]RenderedJob{
 				renderedJobs[0],
@@ -95,6 +100,89 @@ var _ = Describe("JobListRenderer", func() {
 			}))
 		})
 
+		Context("when jobs provide and consume links", func() {
+			BeforeEach(func() {
+				releaseJobs[0].Provides = []boshreljob.LinkDefinition{
+					{Name: "conn", Type: "database", Properties: []string{"db.port", "db.user"}},
+				}
+				releaseJobs[0].Properties = map[string]boshreljob.PropertyDefinition{
+					"db.port": {Default: 5432},
+					"db.user": {Default: "admin"},
+				}
+				releaseJobProperties["fake-release-job-name-0"] = &biproperty.Map{
+					"db": biproperty.Map{"port": 3306},
+				}
+
+				releaseJobs[1].Consumes = []boshreljob.LinkDefinition{
+					{Name: "db", Type: "database"},
+					{Name: "cache", Type: "redis", Optional: true},
+				}
+
+				expectedLinks[1] = biproperty.Map{
+					"db": biproperty.Map{
+						"address": "1.2.3.4",
+						"properties": biproperty.Map{
+							"db": biproperty.Map{"port": 3306, "user": "admin"},
+						},
+						"instances": []interface{}{
+							biproperty.Map{
+								"name":      "fake-deployment-name",
+								"index":     0,
+								"az":        "unknown",
+								"address":   "1.2.3.4",
+								"bootstrap": true,
+							},
+						},
+					},
+				}
+			})
+
+			It("renders consuming jobs with links provided by colocated jobs", func() {
+				_, err := jobListRenderer.Render(releaseJobs, releaseJobProperties, releaseJobLinks, jobProperties, globalProperties, deploymentName, address)
+				Expect(err).ToNot(HaveOccurred())
+			})
+
+			Context("when link is consumed from provider alias", func() {
+				BeforeEach(func() {
+					releaseJobLinks["fake-release-job-name-0"] = ReleaseJobLinks{
+						Provides: biproperty.Map{"conn": biproperty.Map{"as": "primary-db"}},
+					}
+					releaseJobLinks["fake-release-job-name-1"] = ReleaseJobLinks{
+						Consumes: biproperty.Map{"db": biproperty.Map{"from": "primary-db"}},
+					}
+				})
+
+				It("renders consuming jobs with aliased link", func() {
+					_, err := jobListRenderer.Render(releaseJobs, releaseJobProperties, releaseJobLinks, jobProperties, globalProperties, deploymentName, address)
+					Expect(err).ToNot(HaveOccurred())
+				})
+			})
+
+			Context("when link is specified manually in manifest", func() {
+				BeforeEach(func() {
+					releaseJobLinks["fake-release-job-name-1"] = ReleaseJobLinks{
+						Consumes: biproperty.Map{
+							"db":    biproperty.Map{"address": "db.internal", "properties": biproperty.Map{"port": 1}},
+							"cache": "nil",
+						},
+					}
+
+					expectedLinks[1] = biproperty.Map{
+						"db": biproperty.Map{
+							"address":    "db.internal",
+							"properties": biproperty.Map{"port": 1},
+							"instances":  []interface{}{},
+						},
+					}
+				})
+
+				It("renders consuming jobs with manual link", func() {
+					_, err := jobListRenderer.Render(releaseJobs, releaseJobProperties, releaseJobLinks, jobProperties, globalProperties, deploymentName, address)
+					Expect(err).ToNot(HaveOccurred())
+				})
+			})
+		})
+
 		Context("when rendering a job fails", func() {
 			JustBeforeEach(func() {
 				expectRender1.Return(nil, bosherr.Error("fake-render-error"))
@@ -103,11 +191,48 @@ var _ = Describe("JobListRenderer", func() {
 			It("returns an error and cleans up any sucessfully rendered jobs", func() {
 				renderedJobs[0].EXPECT().DeleteSilently()
 
-				_, err := jobListRenderer.Render(releaseJobs, releaseJobProperties, jobProperties, globalProperties, deploymentName, address)
+				_, err := jobListRenderer.Render(releaseJobs, releaseJobProperties, releaseJobLinks, jobProperties, globalProperties, deploymentName, address)
 				Expect(err).To(HaveOccurred())
 				Expect(err.Error()).To(ContainSubstring("fake-render-error"))
 			})
 		})
 	})
 
+	Describe("Render with unresolvable links", func() {
+		// jobs are not rendered when links cannot be resolved
+		BeforeEach(func() {
+			releaseJobs[1].Consumes = []boshreljob.LinkDefinition{{Name: "db", Type: "database"}}
+		})
+
+		It("returns an error if required link has no provider", func() {
+			_, err := jobListRenderer.Render(releaseJobs, releaseJobProperties, releaseJobLinks, jobProperties, globalProperties, deploymentName, address)
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("Expected to find provider of type 'database' for link 'db' consumed by job 'fake-release-job-name-1'"))
+		})
+
+		It("returns an error if there are multiple providers of the same type", func() {
+			releaseJobs[0].Provides = []boshreljob.LinkDefinition{{Name: "db1", Type: "database"}, {Name: "db2", Type: "database"}}
+
+			_, err := jobListRenderer.Render(releaseJobs, releaseJobProperties, releaseJobLinks, jobProperties, globalProperties, deploymentName, address)
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("Expected single provider of type 'database' for link 'db' consumed by job 'fake-release-job-name-1', but found: fake-release-job-name-0.db1, fake-release-job-name-0.db2"))
+		})
+
+		It("returns an error if link is consumed from provider of a different type", func() {
+			releaseJobs[0].Provides = []boshreljob.LinkDefinition{{Name: "cache", Type: "redis"}}
+			releaseJobLinks["fake-release-job-name-1"] = ReleaseJobLinks{Consumes: biproperty.Map{"db": biproperty.Map{"from": "cache"}}}
+
+			_, err := jobListRenderer.Render(releaseJobs, releaseJobProperties, releaseJobLinks, jobProperties, globalProperties, deploymentName, address)
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("to be of type 'database'"))
+		})
+
+		It("returns an error if provided link property is not defined in spec", func() {
+			releaseJobs[0].Provides = []boshreljob.LinkDefinition{{Name: "db", Type: "database", Properties: []string{"port"}}}
+
+			_, err := jobListRenderer.Render(releaseJobs, releaseJobProperties, releaseJobLinks, jobProperties, globalProperties, deploymentName, address)
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("Expected link 'db' property 'port' to be defined in job 'fake-release-job-name-0' spec"))
+		})
+	})
 })
@@ -14,7 +14,13 @@ import (
 )
 
 type JobRenderer interface {
-	Render(releaseJob bireljob.Job, releaseJobProperties *biproperty.Map, jobProperties biproperty.Map, globalProperties biproperty.Map, deploymentName string, address string) (RenderedJob, error)
+	Render(releaseJob bireljob.Job, releaseJobProperties *biproperty.Map, links biproperty.Map, jobProperties biproperty.Map, globalProperties biproperty.Map, deploymentName string, address string) (RenderedJob, error)
+}
+
+// InstanceJobRenderer renders job templates and monit file into given directory
+// so that release authors can inspect them without deploying.
+type InstanceJobRenderer interface {
+	RenderInstance(releaseJob bireljob.Job, properties biproperty.Map, instance InstanceSpec, links biproperty.Map, destinationPath string) error
 }
 
 type jobRenderer struct {
@@ -40,8 +46,23 @@ func NewJobRenderer(
 	}
 }
 
-func (r *jobRenderer) Render(releaseJob bireljob.Job, releaseJobProperties *biproperty.Map, jobProperties biproperty.Map, globalProperties biproperty.Map, deploymentName string, address string) (RenderedJob, error) {
-	context := NewJobEvaluationContext(releaseJob, releaseJobProperties, jobProperties, globalProperties, deploymentName, address, r.uuidGen, r.logger)
+func NewInstanceJobRenderer(
+	erbRenderer bierbrenderer.ERBRenderer,
+	fs boshsys.FileSystem,
+	uuidGen boshuuid.Generator,
+	logger boshlog.Logger,
+) InstanceJobRenderer {
+	return &jobRenderer{
+		erbRenderer: erbRenderer,
+		fs:          fs,
+		uuidGen:     uuidGen,
+		logger:      logger,
+		logTag:      "jobRenderer",
+	}
+}
+
+func (r *jobRenderer) Render(releaseJob bireljob.Job, releaseJobProperties *biproperty.Map, links biproperty.Map, jobProperties biproperty.Map, globalProperties biproperty.Map, deploymentName string, address string) (RenderedJob, error) {
+	context := NewJobEvaluationContext(releaseJob, releaseJobProperties, links, jobProperties, globalProperties, deploymentName, address, r.uuidGen, r.logger)
 
 	sourcePath := releaseJob.ExtractedPath()
 
@@ -52,6 +73,22 @@ func (r *jobRenderer) Render(releaseJob bireljob.Job, releaseJobProperties *bipr
 
 	renderedJob := NewRenderedJob(releaseJob, destinationPath, r.fs, r.logger)
 
+	err = r.renderJob(sourcePath, destinationPath, releaseJob, context)
+	if err != nil {
+		defer renderedJob.DeleteSilently()
+		return nil, err
+	}
+
+	return renderedJob, nil
+}
+
+func (r *jobRenderer) RenderInstance(releaseJob bireljob.Job, properties biproperty.Map, instance InstanceSpec, links biproperty.Map, destinationPath string) error {
+	context := NewInstanceJobEvaluationContext(releaseJob, properties, instance, links, r.uuidGen, r.logger)
+
+	return r.renderJob(releaseJob.ExtractedPath(), destinationPath, releaseJob, context)
+}
+
+func (r *jobRenderer) renderJob(sourcePath, destinationPath string, releaseJob bireljob.Job, context bierbrenderer.TemplateEvaluationContext) error {
 	for src, dst := range releaseJob.Templates {
 		err := r.renderFile(
 			filepath.Join(sourcePath, "templates", src),
@@ -59,22 +96,20 @@ func (r *jobRenderer) Render(releaseJob bireljob.Job, releaseJobProperties *bipr
 			context,
 		)
 		if err != nil {
-			defer renderedJob.DeleteSilently()
-			return nil, bosherr.WrapErrorf(err, "Rendering template src: %s, dst: %s", src, dst)
+			return bosherr.WrapErrorf(err, "Rendering template src: %s, dst: %s", src, dst)
 		}
 	}
 
-	err = r.renderFile(
+	err := r.renderFile(
 		filepath.Join(sourcePath, "monit"),
 		filepath.Join(destinationPath, "monit"),
 		context,
 	)
 	if err != nil {
-		defer renderedJob.DeleteSilently()
-		return nil, bosherr.WrapError(err, "Rendering monit file")
+		return bosherr.WrapError(err, "Rendering monit file")
 	}
 
-	return renderedJob, nil
+	return nil
 }
 
 func (r *jobRenderer) renderFile(sourcePath, destinationPath string, context bierbrenderer.TemplateEvaluationContext) error {
@@ -56,7 +56,7 @@ var _ = Describe("JobRenderer", func() {
 
 		logger := boshlog.NewLogger(boshlog.LevelNone)
 
-		context = NewJobEvaluationContext(*job, &releaseJobProperties, jobProperties, globalProperties, "fake-deployment-name", "1.2.3.4", nil, logger)
+		context = NewJobEvaluationContext(*job, &releaseJobProperties, nil, jobProperties, globalProperties, "fake-deployment-name", "1.2.3.4", nil, logger)
 
 		fakeERBRenderer = fakebirender.NewFakeERBRender()
 
@@ -87,7 +87,7 @@ var _ = Describe("JobRenderer", func() {
 
 	Describe("Render", func() {
 		It("renders job templates", func() {
-			renderedjob, err := jobRenderer.Render(*job, &releaseJobProperties, jobProperties, globalProperties, "fake-deployment-name", "1.2.3.4")
+			renderedjob, err := jobRenderer.Render(*job, &releaseJobProperties, nil, jobProperties, globalProperties, "fake-deployment-name", "1.2.3.4")
 			Expect(err).ToNot(HaveOccurred())
 
 			Expect(fakeERBRenderer.RenderInputs).To(Equal([]fakebirender.RenderInput{
@@ -115,10 +115,79 @@ var _ = Describe("JobRenderer", func() {
 			})
 
 			It("returns an error", func() {
-				_, err := jobRenderer.Render(*job, &releaseJobProperties, jobProperties, globalProperties, "fake-deployment-name", "1.2.3.4")
+				_, err := jobRenderer.Render(*job, &releaseJobProperties, nil, jobProperties, globalProperties, "fake-deployment-name", "1.2.3.4")
 				Expect(err).To(HaveOccurred())
 				Expect(err.Error()).To(ContainSubstring("fake-template-render-error"))
 			})
 		})
 	})
+
+	Describe("RenderInstance", func() {
+		var (
+			instanceJobRenderer InstanceJobRenderer
+			properties          biproperty.Map
+			instance            InstanceSpec
+			links               biproperty.Map
+			instanceContext     bierbrenderer.TemplateEvaluationContext
+		)
+
+		BeforeEach(func() {
+			logger := boshlog.NewLogger(boshlog.LevelNone)
+
+			properties = biproperty.Map{"fake-property-key": "fake-property-value"}
+			instance = InstanceSpec{ID: "fake-id", Deployment: "fake-deployment-name"}
+			links = biproperty.Map{"fake-link": biproperty.Map{"address": "fake-address"}}
+
+			instanceContext = NewInstanceJobEvaluationContext(*job, properties, instance, links, nil, logger)
+			instanceJobRenderer = NewInstanceJobRenderer(fakeERBRenderer, fs, nil, logger)
+
+			fakeERBRenderer.SetRenderBehavior(
+				filepath.Join(srcPath, "templates/director.yml.erb"),
+				filepath.Join("/output", "config/director.yml"),
+				instanceContext,
+				nil,
+			)
+
+			fakeERBRenderer.SetRenderBehavior(
+				filepath.Join(srcPath, "monit"),
+				filepath.Join("/output", "monit"),
+				instanceContext,
+				nil,
+			)
+		})
+
+		It("renders job templates into given directory", func() {
+			err := instanceJobRenderer.RenderInstance(*job, properties, instance, links, "/output")
+			Expect(err).ToNot(HaveOccurred())
+
+			Expect(fakeERBRenderer.RenderInputs).To(Equal([]fakebirender.RenderInput{
+				{
+					SrcPath: filepath.Join(srcPath, "templates/director.yml.erb"),
+					DstPath: filepath.Join("/output", "config/director.yml"),
+					Context: instanceContext,
+				},
+				{
+					SrcPath: filepath.Join(srcPath, "monit"),
+					DstPath: filepath.Join("/output", "monit"),
+					Context: instanceContext,
+				},
+			}))
+
+			Expect(fs.FileExists(filepath.Join("/output", "config"))).To(BeTrue())
+		})
+
+		It("returns an error if rendering fails", func() {
+			fakeERBRenderer.SetRenderBehavior(
+				filepath.Join(srcPath, "monit"),
+				filepath.Join("/output", "monit"),
+				instanceContext,
+				bosherr.Error("fake-monit-render-error"),
+			)
+
+			err := instanceJobRenderer.RenderInstance(*job, properties, instance, links, "/output")
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("Rendering monit file"))
+			Expect(err.Error()).To(ContainSubstring("fake-monit-render-error"))
+		})
+	})
 })
@@ -0,0 +1,277 @@
+package templatescompiler
+
+import (
+	"strings"
+
+	bireljob "github.com/cloudfoundry/bosh-cli/release/job"
+	bosherr "github.com/cloudfoundry/bosh-utils/errors"
+	biproperty "github.com/cloudfoundry/bosh-utils/property"
+)
+
+// ReleaseJobLinks holds link configuration of a release job from a manifest.
+// Link name mapped to nil value (or 'nil' string) explicitly disables that link.
+type ReleaseJobLinks struct {
+	Consumes biproperty.Map
+	Provides biproperty.Map
+}
+
+type linkProvider struct {
+	jobName string
+	alias   string
+	link    bireljob.LinkDefinition
+	value   biproperty.Map
+}
+
+// linksResolver resolves links between release jobs colocated on a single instance
+// since create-env does not have any other instances to link to.
+type linksResolver struct {
+	releaseJobs          []bireljob.Job
+	releaseJobProperties map[string]*biproperty.Map
+	releaseJobLinks      map[string]ReleaseJobLinks
+	jobProperties        biproperty.Map
+	globalProperties     biproperty.Map
+	deploymentName       string
+	address              string
+}
+
+func (r linksResolver) Resolve() (map[string]biproperty.Map, error) {
+	providers, err := r.providers()
+	if err != nil {
+		return nil, err
+	}
+
+	result := map[string]biproperty.Map{}
+
+	for _, releaseJob := range r.releaseJobs {
+		links := biproperty.Map{}
+		consumes := r.releaseJobLinks[releaseJob.Name()].Consumes
+
+		for _, link := range releaseJob.Consumes {
+			config, configured := consumes[link.Name]
+
+			if configured && r.isDisabled(config) {
+				continue
+			}
+
+			configMap, _ := config.(biproperty.Map)
+
+			value, found, err := r.consume(releaseJob, link, configMap, providers)
+			if err != nil {
+				return nil, err
+			}
+
+			if found {
+				links[link.Name] = value
+			}
+		}
+
+		result[releaseJob.Name()] = links
+	}
+
+	return result, nil
+}
+
+func (r linksResolver) consume(releaseJob bireljob.Job, link bireljob.LinkDefinition, config biproperty.Map, providers []linkProvider) (biproperty.Map, bool, error) {
+	if r.isManualLink(config) {
+		return r.manualLink(config), true, nil
+	}
+
+	var matches []linkProvider
+
+	if from, ok := config["from"].(string); ok {
+		for _, provider := range providers {
+			if provider.alias == from {
+				if provider.link.Type != link.Type {
+					errMsg := "Expected link '%s' provided by job '%s' to be of type '%s' to be consumed as '%s' by job '%s', but was '%s'"
+					return nil, false, bosherr.Errorf(errMsg, from, provider.jobName, link.Type, link.Name, releaseJob.Name(), provider.link.Type)
+				}
+				matches = append(matches, provider)
+			}
+		}
+
+		if len(matches) == 0 {
+			errMsg := "Expected to find link '%s' to be consumed as '%s' by job '%s'"
+			return nil, false, bosherr.Errorf(errMsg, from, link.Name, releaseJob.Name())
+		}
+	} else {
+		for _, provider := range providers {
+			if provider.link.Type == link.Type {
+				matches = append(matches, provider)
+			}
+		}
+	}
+
+	switch {
+	case len(matches) == 1:
+		return matches[0].value, true, nil
+
+	case len(matches) > 1:
+		var names []string
+		for _, provider := range matches {
+			names = append(names, provider.jobName+"."+provider.alias)
+		}
+		errMsg := "Expected single provider of type '%s' for link '%s' consumed by job '%s', but found: %s"
+		return nil, false, bosherr.Errorf(errMsg, link.Type, link.Name, releaseJob.Name(), strings.Join(names, ", "))
+
+	case link.Optional:
+		return nil, false, nil
+
+	default:
+		errMsg := "Expected to find provider of type '%s' for link '%s' consumed by job '%s'"
+		return nil, false, bosherr.Errorf(errMsg, link.Type, link.Name, releaseJob.Name())
+	}
+}
+
+func (r linksResolver) providers() ([]linkProvider, error) {
+	var providers []linkProvider
+
+	for _, releaseJob := range r.releaseJobs {
+		provides := r.releaseJobLinks[releaseJob.Name()].Provides
+
+		for _, link := range releaseJob.Provides {
+			config, configured := provides[link.Name]
+
+			if configured && r.isDisabled(config) {
+				continue
+			}
+
+			alias := link.Name
+
+			if configMap, ok := config.(biproperty.Map); ok {
+				if as, ok := configMap["as"].(string); ok && len(as) > 0 {
+					alias = as
+				}
+			}
+
+			properties, err := r.linkProperties(releaseJob, link)
+			if err != nil {
+				return nil, err
+			}
+
+			providers = append(providers, linkProvider{
+				jobName: releaseJob.Name(),
+				alias:   alias,
+				link:    link,
+				value:   r.link(properties),
+			})
+		}
+	}
+
+	return providers, nil
+}
+
+// linkProperties picks provided link properties in the same order of precedence
+// as templates do: release job properties, or instance group and global properties, then defaults.
+func (r linksResolver) linkProperties(releaseJob bireljob.Job, link bireljob.LinkDefinition) (biproperty.Map, error) {
+	properties := biproperty.Map{}
+
+	var sources []biproperty.Map
+
+	if releaseJobProperties := r.releaseJobProperties[releaseJob.Name()]; releaseJobProperties != nil {
+		sources = []biproperty.Map{*releaseJobProperties}
+	} else {
+		sources = []biproperty.Map{r.jobProperties, r.globalProperties}
+	}
+
+	for _, name := range link.Properties {
+		definition, found := releaseJob.Properties[name]
+		if !found {
+			errMsg := "Expected link '%s' property '%s' to be defined in job '%s' spec"
+			return nil, bosherr.Errorf(errMsg, link.Name, name, releaseJob.Name())
+		}
+
+		value := definition.Default
+
+		for _, source := range sources {
+			if sourceValue, found := r.lookupProperty(source, name); found {
+				value = sourceValue
+				break
+			}
+		}
+
+		r.setProperty(properties, name, value)
+	}
+
+	return properties, nil
+}
+
+func (r linksResolver) link(properties biproperty.Map) biproperty.Map {
+	return biproperty.Map{
+		"address":    r.address,
+		"properties": properties,
+		"instances": []interface{}{
+			biproperty.Map{
+				"name":      r.deploymentName,
+				"index":     0,
+				"az":        "unknown",
+				"address":   r.address,
+				"bootstrap": true,
+			},
+		},
+	}
+}
+
+func (r linksResolver) isDisabled(config biproperty.Property) bool {
+	return config == nil || config == "nil"
+}
+
+func (r linksResolver) isManualLink(config biproperty.Map) bool {
+	for _, key := range []string{"instances", "properties", "address"} {
+		if _, found := config[key]; found {
+			return true
+		}
+	}
+	return false
+}
+
+func (r linksResolver) manualLink(config biproperty.Map) biproperty.Map {
+	link := biproperty.Map{
+		"address":    config["address"],
+		"properties": config["properties"],
+		"instances":  config["instances"],
+	}
+
+	if link["properties"] == nil {
+		link["properties"] = biproperty.Map{}
+	}
+
+	if link["instances"] == nil {
+		link["instances"] = []interface{}{}
+	}
+
+	return link
+}
+
+func (r linksResolver) lookupProperty(properties biproperty.Map, name string) (biproperty.Property, bool) {
+	var current biproperty.Property = properties
+
+	for _, key := range strings.Split(name, ".") {
+		currentMap, ok := current.(biproperty.Map)
+		if !ok {
+			return nil, false
+		}
+
+		current, ok = currentMap[key]
+		if !ok || current == nil {
+			return nil, false
+		}
+	}
+
+	return current, true
+}
+
+func (r linksResolver) setProperty(properties biproperty.Map, name string, value biproperty.Property) {
+	keys := strings.Split(name, ".")
+	current := properties
+
+	for _, key := range keys[:len(keys)-1] {
+		next, ok := current[key].(biproperty.Map)
+		if !ok {
+			next = biproperty.Map{}
+			current[key] = next
+		}
+		current = next
+	}
+
+	current[keys[len(keys)-1]] = value
+}
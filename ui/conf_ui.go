@@ -1,6 +1,8 @@
 package ui
 
 import (
+	"io"
+
 	boshlog "github.com/cloudfoundry/bosh-utils/logger"
 
 	. "github.com/cloudfoundry/bosh-cli/ui/table"
@@ -8,6 +10,8 @@ import (
 
 type ConfUI struct {
 	parent      UI
+	base        UI
+	events      io.Writer
 	isTTY       bool
 	logger      boshlog.Logger
 	showColumns []Header
@@ -21,6 +25,7 @@ func NewConfUI(logger boshlog.Logger) *ConfUI {
 
 	return &ConfUI{
 		parent: ui,
+		base:   writerUI,
 		isTTY:  writerUI.IsTTY(),
 		logger: logger,
 	}
@@ -29,6 +34,7 @@ func NewConfUI(logger boshlog.Logger) *ConfUI {
 func NewWrappingConfUI(parent UI, logger boshlog.Logger) *ConfUI {
 	return &ConfUI{
 		parent: parent,
+		base:   parent,
 		isTTY:  true,
 		logger: logger,
 	}
@@ -48,6 +54,21 @@ func (ui *ConfUI) EnableJSON() {
 	ui.parent = NewJSONUI(ui.parent, ui.logger)
 }
 
+// EnableEventStream reserves output for newline-delimited JSON events
+// written to EventWriter; other output is suppressed except for errors.
+// It replaces previously enabled output formatting, hence
+// EnableNonInteractive must be called afterwards.
+func (ui *ConfUI) EnableEventStream() {
+	stream := NewEventStreamUI(ui.base)
+	ui.parent = stream
+	ui.events = stream
+}
+
+// EventWriter returns nil unless event stream was enabled.
+func (ui *ConfUI) EventWriter() io.Writer {
+	return ui.events
+}
+
 func (ui *ConfUI) ShowColumns(columns []Header) {
 	ui.showColumns = columns
 }
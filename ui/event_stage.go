@@ -0,0 +1,149 @@
+package ui
+
+import (
+	"encoding/json"
+	"io"
+	"sync"
+	"time"
+
+	"code.cloudfoundry.org/clock"
+)
+
+const (
+	StageEventStarted  = "started"
+	StageEventFinished = "finished"
+	StageEventFailed   = "failed"
+	StageEventSkipped  = "skipped"
+)
+
+// StageEvent is emitted as a single JSON line for every stage state change.
+type StageEvent struct {
+	Event string   `json:"event"`
+	Stage []string `json:"stage"`
+
+	Time       time.Time  `json:"time"`
+	StartedAt  *time.Time `json:"started_at,omitempty"`
+	FinishedAt *time.Time `json:"finished_at,omitempty"`
+
+	DurationSeconds *float64 `json:"duration_seconds,omitempty"`
+
+	SkipReason string `json:"skip_reason,omitempty"`
+	Error      string `json:"error,omitempty"`
+}
+
+type eventStage struct {
+	stage       Stage
+	path        []string
+	writer      *stageEventWriter
+	timeService clock.Clock
+}
+
+type stageEventWriter struct {
+	writer io.Writer
+	mutex  sync.Mutex
+}
+
+// NewEventStage returns Stage that decorates given stage and additionally
+// writes newline-delimited JSON events (see StageEvent) to writer.
+func NewEventStage(stage Stage, writer io.Writer, timeService clock.Clock) Stage {
+	return &eventStage{
+		stage:       stage,
+		writer:      &stageEventWriter{writer: writer},
+		timeService: timeService,
+	}
+}
+
+func (s *eventStage) Perform(name string, closure func() error) error {
+	path := s.subPath(name)
+	startTime := s.started(path)
+
+	var closureErr error
+
+	err := s.stage.Perform(name, func() error {
+		closureErr = closure()
+		return closureErr
+	})
+
+	s.finished(path, startTime, closureErr)
+
+	return err
+}
+
+func (s *eventStage) PerformComplex(name string, closure func(Stage) error) error {
+	path := s.subPath(name)
+	startTime := s.started(path)
+
+	var closureErr error
+
+	err := s.stage.PerformComplex(name, func(subStage Stage) error {
+		closureErr = closure(&eventStage{
+			stage:       subStage,
+			path:        path,
+			writer:      s.writer,
+			timeService: s.timeService,
+		})
+		return closureErr
+	})
+
+	s.finished(path, startTime, closureErr)
+
+	return err
+}
+
+func (s *eventStage) subPath(name string) []string {
+	path := make([]string, len(s.path), len(s.path)+1)
+	copy(path, s.path)
+	return append(path, name)
+}
+
+func (s *eventStage) started(path []string) time.Time {
+	startTime := s.timeService.Now()
+
+	s.writer.Write(StageEvent{
+		Event:     StageEventStarted,
+		Stage:     path,
+		Time:      startTime,
+		StartedAt: &startTime,
+	})
+
+	return startTime
+}
+
+func (s *eventStage) finished(path []string, startTime time.Time, err error) {
+	finishTime := s.timeService.Now()
+	duration := finishTime.Sub(startTime).Seconds()
+
+	event := StageEvent{
+		Event:           StageEventFinished,
+		Stage:           path,
+		Time:            finishTime,
+		StartedAt:       &startTime,
+		FinishedAt:      &finishTime,
+		DurationSeconds: &duration,
+	}
+
+	if err != nil {
+		if skipErr, ok := err.(SkipStageError); ok {
+			event.Event = StageEventSkipped
+			event.SkipReason = skipErr.SkipMessage()
+		} else {
+			event.Event = StageEventFailed
+			event.Error = err.Error()
+		}
+	}
+
+	s.writer.Write(event)
+}
+
+func (w *stageEventWriter) Write(event StageEvent) {
+	bytes, err := json.Marshal(event)
+	if err != nil {
+		return
+	}
+
+	w.mutex.Lock()
+	defer w.mutex.Unlock()
+
+	// Writing events is best effort and should never fail the stage itself
+	_, _ = w.writer.Write(append(bytes, '\n'))
+}
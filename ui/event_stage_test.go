@@ -0,0 +1,127 @@
+package ui_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"strings"
+	"time"
+
+	. "github.com/cloudfoundry/bosh-cli/ui"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	"code.cloudfoundry.org/clock/fakeclock"
+	bosherr "github.com/cloudfoundry/bosh-utils/errors"
+	boshlog "github.com/cloudfoundry/bosh-utils/logger"
+)
+
+var _ = Describe("EventStage", func() {
+	var (
+		fakeTimeService *fakeclock.FakeClock
+		uiOut, eventOut *bytes.Buffer
+		startTime       time.Time
+
+		stage Stage
+	)
+
+	BeforeEach(func() {
+		uiOut = bytes.NewBufferString("")
+		eventOut = bytes.NewBufferString("")
+
+		logger := boshlog.NewLogger(boshlog.LevelNone)
+		ui := NewWriterUI(uiOut, bytes.NewBufferString(""), logger)
+
+		startTime = time.Date(2020, time.January, 1, 0, 0, 0, 0, time.UTC)
+		fakeTimeService = fakeclock.NewFakeClock(startTime)
+
+		stage = NewEventStage(NewStage(ui, fakeTimeService, logger), eventOut, fakeTimeService)
+	})
+
+	readEvents := func() []StageEvent {
+		var events []StageEvent
+
+		for _, line := range strings.Split(strings.TrimSpace(eventOut.String()), "\n") {
+			var event StageEvent
+			Expect(json.Unmarshal([]byte(line), &event)).To(Succeed())
+			events = append(events, event)
+		}
+
+		return events
+	}
+
+	It("writes started and finished events with duration while keeping human output", func() {
+		err := stage.Perform("Simple stage", func() error {
+			fakeTimeService.Increment(time.Minute)
+			return nil
+		})
+		Expect(err).ToNot(HaveOccurred())
+
+		Expect(uiOut.String()).To(Equal("Simple stage... Finished (00:01:00)\n"))
+
+		events := readEvents()
+		Expect(events).To(HaveLen(2))
+
+		Expect(events[0].Event).To(Equal(StageEventStarted))
+		Expect(events[0].Stage).To(Equal([]string{"Simple stage"}))
+		Expect(events[0].Time).To(Equal(startTime))
+		Expect(events[0].FinishedAt).To(BeNil())
+		Expect(events[0].DurationSeconds).To(BeNil())
+
+		Expect(events[1].Event).To(Equal(StageEventFinished))
+		Expect(events[1].Stage).To(Equal([]string{"Simple stage"}))
+		Expect(*events[1].StartedAt).To(Equal(startTime))
+		Expect(*events[1].FinishedAt).To(Equal(startTime.Add(time.Minute)))
+		Expect(*events[1].DurationSeconds).To(Equal(60.0))
+	})
+
+	It("writes failed event with error", func() {
+		stageErr := bosherr.Error("fake-stage-error")
+
+		err := stage.Perform("Simple stage", func() error { return stageErr })
+		Expect(err).To(Equal(stageErr))
+
+		events := readEvents()
+		Expect(events).To(HaveLen(2))
+		Expect(events[1].Event).To(Equal(StageEventFailed))
+		Expect(events[1].Error).To(Equal("fake-stage-error"))
+	})
+
+	It("writes skipped event with skip reason", func() {
+		err := stage.Perform("Simple stage", func() error {
+			return NewSkipStageError(errors.New("fake-cause"), "fake-skip-message")
+		})
+		Expect(err).ToNot(HaveOccurred())
+
+		events := readEvents()
+		Expect(events).To(HaveLen(2))
+		Expect(events[1].Event).To(Equal(StageEventSkipped))
+		Expect(events[1].SkipReason).To(Equal("fake-skip-message"))
+		Expect(events[1].Error).To(BeEmpty())
+	})
+
+	It("includes parent stage names for nested stages", func() {
+		err := stage.PerformComplex("Complex stage", func(subStage Stage) error {
+			return subStage.Perform("Sub stage", func() error { return nil })
+		})
+		Expect(err).ToNot(HaveOccurred())
+
+		events := readEvents()
+		Expect(events).To(HaveLen(4))
+
+		Expect(events[0].Event).To(Equal(StageEventStarted))
+		Expect(events[0].Stage).To(Equal([]string{"Complex stage"}))
+
+		Expect(events[1].Event).To(Equal(StageEventStarted))
+		Expect(events[1].Stage).To(Equal([]string{"Complex stage", "Sub stage"}))
+
+		Expect(events[2].Event).To(Equal(StageEventFinished))
+		Expect(events[2].Stage).To(Equal([]string{"Complex stage", "Sub stage"}))
+
+		Expect(events[3].Event).To(Equal(StageEventFinished))
+		Expect(events[3].Stage).To(Equal([]string{"Complex stage"}))
+
+		Expect(uiOut.String()).To(ContainSubstring("Started Complex stage"))
+		Expect(uiOut.String()).To(ContainSubstring("Sub stage... Finished"))
+	})
+})
@@ -0,0 +1,65 @@
+package ui
+
+import (
+	"strings"
+
+	bosherr "github.com/cloudfoundry/bosh-utils/errors"
+
+	. "github.com/cloudfoundry/bosh-cli/ui/table"
+)
+
+// EventStreamUI reserves parent UI output for newline-delimited JSON events
+// written via Write. Human readable output is suppressed so that the stream
+// can be parsed; errors are still shown since they are written to stderr.
+type EventStreamUI struct {
+	parent UI
+}
+
+func NewEventStreamUI(parent UI) *EventStreamUI {
+	return &EventStreamUI{parent: parent}
+}
+
+func (ui *EventStreamUI) Write(bytes []byte) (int, error) {
+	ui.parent.PrintBlock(bytes)
+	return len(bytes), nil
+}
+
+func (ui *EventStreamUI) ErrorLinef(pattern string, args ...interface{}) {
+	ui.parent.ErrorLinef(pattern, args...)
+}
+
+func (ui *EventStreamUI) PrintErrorBlock(block string) {
+	ui.parent.ErrorLinef("%s", strings.TrimSuffix(block, "\n"))
+}
+
+func (ui *EventStreamUI) PrintLinef(pattern string, args ...interface{}) {}
+func (ui *EventStreamUI) BeginLinef(pattern string, args ...interface{}) {}
+func (ui *EventStreamUI) EndLinef(pattern string, args ...interface{})   {}
+func (ui *EventStreamUI) PrintBlock(block []byte)                        {}
+
+func (ui *EventStreamUI) PrintTable(table Table)                                {}
+func (ui *EventStreamUI) PrintTableFiltered(table Table, filterHeader []Header) {}
+
+func (ui *EventStreamUI) AskForText(label string) (string, error) {
+	panic(bosherr.NewUserError("Cannot ask for input while streaming events"))
+}
+
+func (ui *EventStreamUI) AskForChoice(label string, options []string) (int, error) {
+	panic(bosherr.NewUserError("Cannot ask for a choice while streaming events"))
+}
+
+func (ui *EventStreamUI) AskForPassword(label string) (string, error) {
+	panic(bosherr.NewUserError("Cannot ask for password while streaming events"))
+}
+
+func (ui *EventStreamUI) AskForConfirmation() error {
+	return bosherr.Error("Cannot ask for confirmation while streaming events (use '--non-interactive')")
+}
+
+func (ui *EventStreamUI) IsInteractive() bool {
+	return false
+}
+
+func (ui *EventStreamUI) Flush() {
+	ui.parent.Flush()
+}
@@ -0,0 +1,57 @@
+package ui_test
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	. "github.com/cloudfoundry/bosh-cli/ui"
+	fakeui "github.com/cloudfoundry/bosh-cli/ui/fakes"
+	. "github.com/cloudfoundry/bosh-cli/ui/table"
+)
+
+var _ = Describe("EventStreamUI", func() {
+	var (
+		parentUI *fakeui.FakeUI
+		ui       *EventStreamUI
+	)
+
+	BeforeEach(func() {
+		parentUI = &fakeui.FakeUI{}
+		ui = NewEventStreamUI(parentUI)
+	})
+
+	It("writes events to the parent UI", func() {
+		_, err := ui.Write([]byte("{\"event\":\"started\"}\n"))
+		Expect(err).ToNot(HaveOccurred())
+		Expect(parentUI.Blocks).To(Equal([]string{"{\"event\":\"started\"}\n"}))
+	})
+
+	It("suppresses human readable output", func() {
+		ui.PrintLinef("fake-line")
+		ui.BeginLinef("fake-start")
+		ui.EndLinef("fake-end")
+		ui.PrintBlock([]byte("fake-block"))
+		ui.PrintTable(Table{Content: "fake-table"})
+		ui.PrintTableFiltered(Table{Content: "fake-table"}, []Header{})
+
+		Expect(parentUI.Said).To(BeEmpty())
+		Expect(parentUI.Blocks).To(BeEmpty())
+		Expect(parentUI.Tables).To(BeEmpty())
+	})
+
+	It("shows errors", func() {
+		ui.ErrorLinef("fake-error-line")
+		ui.PrintErrorBlock("fake-error-block\n")
+		Expect(parentUI.Errors).To(Equal([]string{"fake-error-line", "fake-error-block"}))
+	})
+
+	It("does not ask for confirmation", func() {
+		err := ui.AskForConfirmation()
+		Expect(err).To(HaveOccurred())
+		Expect(err.Error()).To(ContainSubstring("--non-interactive"))
+	})
+
+	It("is not interactive", func() {
+		Expect(ui.IsInteractive()).To(BeFalse())
+	})
+})
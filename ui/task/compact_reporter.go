@@ -0,0 +1,101 @@
+package task
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+	"sync"
+
+	boshui "github.com/cloudfoundry/bosh-cli/ui"
+)
+
+// CompactReporter prints a single line per stage step of each task
+// which keeps output of multiple concurrently followed tasks readable.
+type CompactReporter struct {
+	ui boshui.UI
+
+	outputRest map[int]string
+	sync.Mutex
+}
+
+func NewCompactReporter(ui boshui.UI) *CompactReporter {
+	return &CompactReporter{
+		ui:         ui,
+		outputRest: map[int]string{},
+	}
+}
+
+func (r *CompactReporter) TaskStarted(id int) {
+	r.Lock()
+	defer r.Unlock()
+
+	r.ui.PrintLinef("Task %d | Started", id)
+}
+
+func (r *CompactReporter) TaskFinished(id int, state string) {
+	r.Lock()
+	defer r.Unlock()
+
+	delete(r.outputRest, id)
+
+	r.ui.PrintLinef("Task %d | %s", id, strings.Title(state))
+}
+
+func (r *CompactReporter) TaskOutputChunk(id int, chunk []byte) {
+	r.Lock()
+	defer r.Unlock()
+
+	r.outputRest[id] += string(chunk)
+
+	for {
+		idx := strings.Index(r.outputRest[id], "\n")
+		if idx == -1 {
+			break
+		}
+		if len(r.outputRest[id][0:idx]) > 0 {
+			r.showEvent(id, r.outputRest[id][0:idx])
+		}
+		r.outputRest[id] = r.outputRest[id][idx+1:]
+	}
+}
+
+func (r *CompactReporter) showEvent(id int, str string) {
+	var event Event
+
+	err := json.Unmarshal([]byte(str), &event)
+	if err != nil {
+		// Unlike full reporter ignore unknown output to keep following other tasks
+		return
+	}
+
+	prefix := fmt.Sprintf("Task %d | %s | ", id, event.TimeAsHoursStr())
+
+	desc := event.Stage
+
+	if len(event.Tags) > 0 {
+		desc += " " + strings.Join(event.Tags, ", ")
+	}
+
+	switch {
+	case event.Type == EventTypeDeprecation:
+		r.ui.ErrorLinef("%sDeprecation: %s", prefix, event.Message)
+
+	case event.Type == EventTypeWarning:
+		r.ui.ErrorLinef("%sWarning: %s", prefix, event.Message)
+
+	case event.State == EventStateStarted && event.Total > 0:
+		r.ui.PrintLinef("%s%s (%d/%d): %s", prefix, desc, event.Index, event.Total, event.Task)
+
+	case event.State == EventStateStarted:
+		r.ui.PrintLinef("%s%s: %s", prefix, desc, event.Task)
+
+	case event.State == EventStateFailed:
+		r.ui.ErrorLinef("%s%s: %s failed: %s", prefix, desc, event.Task, event.Data.Error)
+
+	case event.Error != nil:
+		r.ui.ErrorLinef("%sError: %s", prefix, event.Error.Message)
+
+	default:
+		// Skip progress and finished events
+	}
+}
@@ -0,0 +1,62 @@
+package task_test
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	fakeui "github.com/cloudfoundry/bosh-cli/ui/fakes"
+	boshuit "github.com/cloudfoundry/bosh-cli/ui/task"
+)
+
+var _ = Describe("CompactReporter", func() {
+	var (
+		ui       *fakeui.FakeUI
+		reporter *boshuit.CompactReporter
+	)
+
+	BeforeEach(func() {
+		ui = &fakeui.FakeUI{}
+		reporter = boshuit.NewCompactReporter(ui)
+	})
+
+	It("prints task beginning and ending", func() {
+		reporter.TaskStarted(123)
+		reporter.TaskFinished(123, "done")
+		Expect(ui.Said).To(Equal([]string{"Task 123 | Started", "Task 123 | Done"}))
+	})
+
+	It("prints started steps of interleaved tasks prefixed with task ID", func() {
+		reporter.TaskStarted(1)
+		reporter.TaskStarted(2)
+		reporter.TaskOutputChunk(1, []byte(`{"time":1443889300,"stage":"Updating instance","tags":["api"],"total":2,"task":"api/0","index":1,"state":"started","progress":0}`))
+		reporter.TaskOutputChunk(2, []byte(`{"time":1443889301,"stage":"Preparing deployment","tags":[],"total":1,"task":"Binding deployment","index":1,"state":"started","progress":0}`+"\n"))
+		reporter.TaskOutputChunk(1, []byte("\n"+`{"time":1443889302,"stage":"Updating instance","tags":["api"],"total":2,"task":"api/0","index":1,"state":"finished","progress":100}`+"\n"))
+		reporter.TaskOutputChunk(2, []byte(`{"time":1443889303,"stage":"Deleting","task":"vm-cid","state":"started"}`+"\n"))
+
+		Expect(ui.Said).To(Equal([]string{
+			"Task 1 | Started",
+			"Task 2 | Started",
+			"Task 2 | 16:21:41 | Preparing deployment (1/1): Binding deployment",
+			"Task 1 | 16:21:40 | Updating instance api (1/2): api/0",
+			"Task 2 | 16:21:43 | Deleting: vm-cid",
+		}))
+	})
+
+	It("prints failures, warnings and errors to error output", func() {
+		reporter.TaskOutputChunk(1, []byte(`{"time":1443889300,"stage":"Updating instance","tags":["api"],"total":1,"task":"api/0","index":1,"state":"failed","data":{"error":"fake-err"}}`+"\n"))
+		reporter.TaskOutputChunk(1, []byte(`{"time":1443889300,"type":"warning","message":"fake-warning"}`+"\n"))
+		reporter.TaskOutputChunk(1, []byte(`{"time":1443889300,"error":{"code":100,"message":"fake-task-err"}}`+"\n"))
+
+		Expect(ui.Errors).To(Equal([]string{
+			"Task 1 | 16:21:40 | Updating instance api: api/0 failed: fake-err",
+			"Task 1 | 16:21:40 | Warning: fake-warning",
+			"Task 1 | 16:21:40 | Error: fake-task-err",
+		}))
+	})
+
+	It("ignores output that is not an event", func() {
+		reporter.TaskOutputChunk(1, []byte("not-json\n"))
+		Expect(ui.Said).To(BeEmpty())
+		Expect(ui.Errors).To(BeEmpty())
+	})
+})
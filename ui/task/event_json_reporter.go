@@ -0,0 +1,155 @@
+package task
+
+import (
+	"encoding/json"
+	"io"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	TaskEventStarted     = "task_started"
+	TaskEventFinished    = "task_finished"
+	TaskEventStage       = "stage"
+	TaskEventWarning     = "warning"
+	TaskEventDeprecation = "deprecation"
+	TaskEventError       = "error"
+	TaskEventOutput      = "output"
+)
+
+// TaskEvent is a normalized director task event written as a single JSON line.
+type TaskEvent struct {
+	Event  string     `json:"event"`
+	TaskID int        `json:"task_id"`
+	Time   *time.Time `json:"time,omitempty"`
+
+	Stage string   `json:"stage,omitempty"`
+	Tags  []string `json:"tags,omitempty"`
+	Task  string   `json:"task,omitempty"`
+
+	Index    int `json:"index,omitempty"`
+	Total    int `json:"total,omitempty"`
+	Progress int `json:"progress,omitempty"`
+
+	// State is a stage step state (e.g. 'started')
+	// or a final task state (e.g. 'done') for task_finished events
+	State  string `json:"state,omitempty"`
+	Status string `json:"status,omitempty"`
+
+	Message   string `json:"message,omitempty"`
+	Error     string `json:"error,omitempty"`
+	ErrorCode int    `json:"error_code,omitempty"`
+}
+
+// EventJSONReporter re-emits director task events as newline-delimited JSON
+// (see TaskEvent) so that they can be consumed without parsing human output.
+type EventJSONReporter struct {
+	writer io.Writer
+
+	outputRest map[int]string
+	sync.Mutex
+}
+
+func NewEventJSONReporter(writer io.Writer) *EventJSONReporter {
+	return &EventJSONReporter{
+		writer:     writer,
+		outputRest: map[int]string{},
+	}
+}
+
+func (r *EventJSONReporter) TaskStarted(id int) {
+	r.Lock()
+	defer r.Unlock()
+
+	r.write(TaskEvent{Event: TaskEventStarted, TaskID: id})
+}
+
+func (r *EventJSONReporter) TaskFinished(id int, state string) {
+	r.Lock()
+	defer r.Unlock()
+
+	if len(r.outputRest[id]) > 0 {
+		r.showEvent(id, r.outputRest[id])
+	}
+
+	delete(r.outputRest, id)
+
+	r.write(TaskEvent{Event: TaskEventFinished, TaskID: id, State: state})
+}
+
+func (r *EventJSONReporter) TaskOutputChunk(id int, chunk []byte) {
+	r.Lock()
+	defer r.Unlock()
+
+	r.outputRest[id] += string(chunk)
+
+	for {
+		idx := strings.Index(r.outputRest[id], "\n")
+		if idx == -1 {
+			break
+		}
+		if len(r.outputRest[id][0:idx]) > 0 {
+			r.showEvent(id, r.outputRest[id][0:idx])
+		}
+		r.outputRest[id] = r.outputRest[id][idx+1:]
+	}
+}
+
+func (r *EventJSONReporter) showEvent(id int, str string) {
+	var event Event
+
+	err := json.Unmarshal([]byte(str), &event)
+	if err != nil {
+		// Keep unknown output instead of dropping it
+		r.write(TaskEvent{Event: TaskEventOutput, TaskID: id, Message: str})
+		return
+	}
+
+	eventTime := event.Time()
+
+	taskEvent := TaskEvent{
+		Event:  TaskEventStage,
+		TaskID: id,
+		Time:   &eventTime,
+
+		Stage: event.Stage,
+		Tags:  event.Tags,
+		Task:  event.Task,
+
+		Index:    event.Index,
+		Total:    event.Total,
+		Progress: event.Progress,
+
+		State:  event.State,
+		Status: event.Data.Status,
+
+		Message: event.Message,
+		Error:   event.Data.Error,
+	}
+
+	switch {
+	case event.Type == EventTypeDeprecation:
+		taskEvent.Event = TaskEventDeprecation
+
+	case event.Type == EventTypeWarning:
+		taskEvent.Event = TaskEventWarning
+
+	case event.Error != nil:
+		taskEvent.Event = TaskEventError
+		taskEvent.Error = event.Error.Message
+		taskEvent.ErrorCode = event.Error.Code
+	}
+
+	r.write(taskEvent)
+}
+
+func (r *EventJSONReporter) write(event TaskEvent) {
+	bytes, err := json.Marshal(event)
+	if err != nil {
+		return
+	}
+
+	// Writing events is best effort and should never fail tracking of the task
+	_, _ = r.writer.Write(append(bytes, '\n'))
+}
@@ -0,0 +1,118 @@
+package task_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+	"time"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	boshuit "github.com/cloudfoundry/bosh-cli/ui/task"
+)
+
+var _ = Describe("EventJSONReporter", func() {
+	var (
+		out      *bytes.Buffer
+		reporter *boshuit.EventJSONReporter
+	)
+
+	BeforeEach(func() {
+		out = bytes.NewBufferString("")
+		reporter = boshuit.NewEventJSONReporter(out)
+	})
+
+	readEvents := func() []boshuit.TaskEvent {
+		var events []boshuit.TaskEvent
+
+		for _, line := range strings.Split(strings.TrimSpace(out.String()), "\n") {
+			var event boshuit.TaskEvent
+			Expect(json.Unmarshal([]byte(line), &event)).To(Succeed())
+			events = append(events, event)
+		}
+
+		return events
+	}
+
+	eventTime := time.Unix(1443889300, 0).UTC()
+
+	It("writes task beginning and ending", func() {
+		reporter.TaskStarted(123)
+		reporter.TaskFinished(123, "done")
+
+		Expect(out.String()).To(Equal(
+			`{"event":"task_started","task_id":123}` + "\n" +
+				`{"event":"task_finished","task_id":123,"state":"done"}` + "\n"))
+	})
+
+	It("writes normalized stage events split across chunks", func() {
+		reporter.TaskOutputChunk(1, []byte(`{"time":1443889300,"stage":"Updating instance","tags":["api"],"total":2,"task":"api/0",`))
+		reporter.TaskOutputChunk(1, []byte(`"index":1,"state":"started","progress":0}`+"\n"))
+		reporter.TaskOutputChunk(1, []byte(`{"time":1443889300,"stage":"Updating instance","tags":["api"],"total":2,"task":"api/0","index":1,"state":"in_progress","progress":50,"data":{"status":"installing packages"}}`+"\n"))
+
+		Expect(readEvents()).To(Equal([]boshuit.TaskEvent{
+			{
+				Event:  "stage",
+				TaskID: 1,
+				Time:   &eventTime,
+				Stage:  "Updating instance",
+				Tags:   []string{"api"},
+				Task:   "api/0",
+				Index:  1,
+				Total:  2,
+				State:  "started",
+			},
+			{
+				Event:    "stage",
+				TaskID:   1,
+				Time:     &eventTime,
+				Stage:    "Updating instance",
+				Tags:     []string{"api"},
+				Task:     "api/0",
+				Index:    1,
+				Total:    2,
+				Progress: 50,
+				State:    "in_progress",
+				Status:   "installing packages",
+			},
+		}))
+	})
+
+	It("writes failures, warnings, deprecations and errors", func() {
+		reporter.TaskOutputChunk(1, []byte(`{"time":1443889300,"stage":"Updating instance","tags":["api"],"total":1,"task":"api/0","index":1,"state":"failed","data":{"error":"fake-err"}}`+"\n"))
+		reporter.TaskOutputChunk(1, []byte(`{"time":1443889300,"type":"warning","message":"fake-warning"}`+"\n"))
+		reporter.TaskOutputChunk(1, []byte(`{"time":1443889300,"type":"deprecation","message":"fake-deprecation"}`+"\n"))
+		reporter.TaskOutputChunk(1, []byte(`{"time":1443889300,"error":{"code":100,"message":"fake-task-err"}}`+"\n"))
+
+		Expect(readEvents()).To(Equal([]boshuit.TaskEvent{
+			{
+				Event:  "stage",
+				TaskID: 1,
+				Time:   &eventTime,
+				Stage:  "Updating instance",
+				Tags:   []string{"api"},
+				Task:   "api/0",
+				Index:  1,
+				Total:  1,
+				State:  "failed",
+				Error:  "fake-err",
+			},
+			{Event: "warning", TaskID: 1, Time: &eventTime, Message: "fake-warning"},
+			{Event: "deprecation", TaskID: 1, Time: &eventTime, Message: "fake-deprecation"},
+			{Event: "error", TaskID: 1, Time: &eventTime, Error: "fake-task-err", ErrorCode: 100},
+		}))
+	})
+
+	It("writes output that is not an event as is", func() {
+		reporter.TaskOutputChunk(1, []byte("not-json\n"))
+		reporter.TaskOutputChunk(1, []byte("rest"))
+		reporter.TaskFinished(1, "error")
+
+		Expect(readEvents()).To(Equal([]boshuit.TaskEvent{
+			{Event: "output", TaskID: 1, Message: "not-json"},
+			{Event: "output", TaskID: 1, Message: "rest"},
+			{Event: "task_finished", TaskID: 1, State: "error"},
+		}))
+	})
+})